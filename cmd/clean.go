@@ -3,17 +3,23 @@ package cmd
 import (
 	"bufio"
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 	"os/exec"
-	"path"
+	path2 "path"
 	"path/filepath"
 	"runtime"
 	"strings"
 
+	"github.com/bruin-data/bruin/pkg/config"
 	"github.com/bruin-data/bruin/pkg/git"
+	"github.com/bruin-data/bruin/pkg/orphans"
+	"github.com/bruin-data/bruin/pkg/path"
+	"github.com/bruin-data/bruin/pkg/pipeline"
 	"github.com/bruin-data/bruin/pkg/telemetry"
 	"github.com/bruin-data/bruin/pkg/user"
+	"github.com/manifoldco/promptui"
 	"github.com/pkg/errors"
 	"github.com/spf13/afero"
 	"github.com/urfave/cli/v3"
@@ -31,6 +37,9 @@ func CleanCmd() *cli.Command {
 				Usage:   "clean uv caches",
 			},
 		},
+		Commands: []*cli.Command{
+			CleanOrphansCmd(),
+		},
 		Action: func(ctx context.Context, c *cli.Command) error {
 			inputPath := c.Args().Get(0)
 			if inputPath == "" {
@@ -79,7 +88,7 @@ func (r *CleanCommand) Run(inputPath string, cleanUvCache bool) error {
 		return cli.Exit("", 1)
 	}
 
-	logsFolder := path.Join(repoRoot.Path, LogsFolder)
+	logsFolder := path2.Join(repoRoot.Path, LogsFolder)
 
 	contents, err := filepath.Glob(logsFolder + "/*.log")
 	if err != nil {
@@ -157,3 +166,223 @@ func (r *CleanCommand) confirmUvCacheClean() bool {
 	response = strings.TrimSpace(strings.ToLower(response))
 	return response == "y" || response == "yes"
 }
+
+func CleanOrphansCmd() *cli.Command {
+	return &cli.Command{
+		Name:      "orphans",
+		Usage:     "find the tables and views in the schemas of the pipeline that no asset produces anymore, and optionally drop them",
+		ArgsUsage: "[path to pipeline]",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "environment",
+				Aliases: []string{"e", "env"},
+				Usage:   "the environment to look for orphans in",
+			},
+			&cli.StringSliceFlag{
+				Name:  "allow",
+				Usage: "a pattern of `schema.table` or `database.schema.table` names that are never reported, e.g. 'raw.backup_*', can be given multiple times",
+			},
+			&cli.BoolFlag{
+				Name:  "apply",
+				Usage: "drop the orphaned tables and views",
+			},
+			&cli.BoolFlag{
+				Name:    "force",
+				Aliases: []string{"f"},
+				Usage:   "drop without asking for confirmation",
+			},
+			&cli.StringFlag{
+				Name:    "output",
+				Aliases: []string{"o"},
+				Value:   outputFormatPlain,
+				Usage:   "the output type, possible values are: plain, json",
+			},
+			&cli.StringFlag{
+				Name:    "config-file",
+				Sources: cli.EnvVars("BRUIN_CONFIG_FILE"),
+				Usage:   "the path to the .bruin.yml file",
+			},
+		},
+		Action: func(ctx context.Context, c *cli.Command) error {
+			defer RecoverFromPanic()
+
+			inputPath := c.Args().Get(0)
+			if inputPath == "" {
+				inputPath = "."
+			}
+			output := strings.ToLower(c.String("output"))
+
+			pipelinePath, err := path.GetPipelineRootFromTask(inputPath, PipelineDefinitionFiles)
+			if err != nil {
+				printError(err, output, "Failed to find the pipeline at "+inputPath)
+				return cli.Exit("", 1)
+			}
+
+			foundPipeline, err := DefaultPipelineBuilder.CreatePipelineFromPath(ctx, pipelinePath, pipeline.WithMutate())
+			if err != nil {
+				printError(err, output, "Failed to build the pipeline at "+pipelinePath)
+				return cli.Exit("", 1)
+			}
+
+			repoRoot, err := git.FindRepoFromPath(pipelinePath)
+			if err != nil {
+				printError(err, output, "Failed to find the git repository root")
+				return cli.Exit("", 1)
+			}
+
+			configFilePath := c.String("config-file")
+			if configFilePath == "" {
+				configFilePath = path2.Join(repoRoot.Path, ".bruin.yml")
+			}
+
+			otherPipelines, err := buildOtherPipelines(ctx, repoRoot.Path, pipelinePath)
+			if err != nil {
+				printError(err, output, "Failed to build the other pipelines of the repository")
+				return cli.Exit("", 1)
+			}
+
+			cm, err := config.LoadOrCreate(afero.NewOsFs(), configFilePath)
+			if err != nil {
+				printError(err, output, "Failed to load the config file at "+configFilePath)
+				return cli.Exit("", 1)
+			}
+			if env := c.String("environment"); env != "" {
+				if err := cm.SelectEnvironment(env); err != nil {
+					printError(err, output, "")
+					return cli.Exit("", 1)
+				}
+			}
+
+			ctx = context.WithValue(ctx, config.ConfigFilePathContextKey, configFilePath)
+			ctx = context.WithValue(ctx, config.EnvironmentNameContextKey, cm.SelectedEnvironmentName)
+			manager, errs := connectionManagerFromConfig(ctx, cm, makeLogger(false))
+			if len(errs) > 0 {
+				printError(errs[0], output, "Failed to create the connection manager")
+				return cli.Exit("", 1)
+			}
+
+			connections := cm.SelectedEnvironment.Connections
+			finder := orphans.NewFinder(manager, connections.DatabaseName, connections.IdentifierQuotes)
+			report, err := finder.Find(ctx, foundPipeline, otherPipelines, cm.SelectedEnvironment.SchemaPrefix, c.StringSlice("allow"))
+			if err != nil {
+				printError(err, output, "Failed to look for orphaned tables")
+				return cli.Exit("", 1)
+			}
+
+			if !c.Bool("apply") {
+				return printOrphansReport(output, report, nil)
+			}
+
+			if len(report.Orphans) > 0 && !c.Bool("force") {
+				if output != "json" {
+					_ = printOrphansReport(output, report, nil)
+				}
+				prompt := promptui.Prompt{
+					Label:     fmt.Sprintf("Drop %d tables and views in the environment '%s'", len(report.Orphans), cm.SelectedEnvironmentName),
+					IsConfirm: true,
+					Stdin:     os.Stdin,
+				}
+				if _, err := prompt.Run(); err != nil {
+					fmt.Println("The operation is cancelled.")
+					return cli.Exit("", 1)
+				}
+			}
+
+			dropErrors := make(map[string]string)
+			for _, object := range report.Orphans {
+				if err := finder.Drop(ctx, object); err != nil {
+					dropErrors[object.QualifiedName()] = err.Error()
+				}
+			}
+
+			return printOrphansReport(output, report, dropErrors)
+		},
+		Before: telemetry.BeforeCommand,
+		After:  telemetry.AfterCommand,
+	}
+}
+
+// buildOtherPipelines builds every pipeline of the repository except the given one, so that the
+// tables they produce in shared schemas are not taken for orphans. A pipeline that fails to build
+// fails the command, since its tables could not be told apart otherwise.
+func buildOtherPipelines(ctx context.Context, repoPath, pipelinePath string) ([]*pipeline.Pipeline, error) {
+	pipelinePath, err := filepath.Abs(pipelinePath)
+	if err != nil {
+		return nil, err
+	}
+	pipelinePaths, err := path.GetPipelinePaths(repoPath, PipelineDefinitionFiles)
+	if err != nil {
+		return nil, err
+	}
+
+	pipelines := make([]*pipeline.Pipeline, 0, len(pipelinePaths))
+	for _, otherPath := range pipelinePaths {
+		if absPath, err := filepath.Abs(otherPath); err == nil && absPath == pipelinePath {
+			continue
+		}
+		p, err := DefaultPipelineBuilder.CreatePipelineFromPath(ctx, otherPath, pipeline.WithMutate())
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to build the pipeline at '%s'", otherPath)
+		}
+		pipelines = append(pipelines, p)
+	}
+
+	return pipelines, nil
+}
+
+// printOrphansReport prints the orphans, and the outcome of dropping them when dropErrors is not nil.
+func printOrphansReport(output string, report *orphans.Report, dropErrors map[string]string) error {
+	dropped := dropErrors != nil
+
+	if output == "json" {
+		type jsonOrphan struct {
+			orphans.Object
+			Dropped bool   `json:"dropped,omitempty"`
+			Error   string `json:"error,omitempty"`
+		}
+		result := struct {
+			Orphans []jsonOrphan `json:"orphans"`
+			Skipped []string     `json:"skipped_connections,omitempty"`
+		}{Orphans: make([]jsonOrphan, 0, len(report.Orphans)), Skipped: report.Skipped}
+		for _, object := range report.Orphans {
+			dropErr := dropErrors[object.QualifiedName()]
+			result.Orphans = append(result.Orphans, jsonOrphan{Object: object, Dropped: dropped && dropErr == "", Error: dropErr})
+		}
+
+		jsonData, err := json.Marshal(result)
+		if err != nil {
+			printErrorJSON(err)
+			return cli.Exit("", 1)
+		}
+		fmt.Println(string(jsonData))
+	} else {
+		for _, connName := range report.Skipped {
+			warningPrinter.Printf("Connection '%s' does not support listing tables, its schemas are skipped.\n", connName)
+		}
+
+		if len(report.Orphans) == 0 {
+			infoPrinter.Println("No orphaned tables or views found.")
+			return nil
+		}
+
+		if !dropped {
+			infoPrinter.Printf("Found %d orphaned tables and views:\n", len(report.Orphans))
+		}
+		for _, object := range report.Orphans {
+			switch {
+			case !dropped:
+				fmt.Printf("  %s (%s)\n", object.QualifiedName(), object.Connection)
+			case dropErrors[object.QualifiedName()] != "":
+				errorPrinter.Printf("  Failed to drop %s: %s\n", object.QualifiedName(), dropErrors[object.QualifiedName()])
+			default:
+				successPrinter.Printf("  Dropped %s\n", object.QualifiedName())
+			}
+		}
+	}
+
+	if len(dropErrors) > 0 {
+		return cli.Exit("", 1)
+	}
+
+	return nil
+}
@@ -0,0 +1,174 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	"github.com/bruin-data/bruin/pkg/odcs"
+	"github.com/bruin-data/bruin/pkg/path"
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/bruin-data/bruin/pkg/telemetry"
+	"github.com/spf13/afero"
+	"github.com/urfave/cli/v3"
+)
+
+func Contract() *cli.Command {
+	return &cli.Command{
+		Name:  "contract",
+		Usage: "export assets as data contracts and create or update assets from data contracts in the Open Data Contract Standard (ODCS)",
+		Commands: []*cli.Command{
+			ContractExport(),
+			ContractImport(),
+		},
+	}
+}
+
+func ContractExport() *cli.Command {
+	return &cli.Command{
+		Name:      "export",
+		Usage:     "export the columns, checks, owner, description and SLAs of an asset as an ODCS data contract",
+		ArgsUsage: "[path to the asset definition]",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "output",
+				Aliases: []string{"o"},
+				Usage:   "the file to write the contract to, the contract is printed to the standard output by default",
+			},
+		},
+		Action: func(ctx context.Context, c *cli.Command) error {
+			assetPath := c.Args().Get(0)
+			if assetPath == "" {
+				errorPrinter.Println("Please give the path to the asset to export the contract of")
+				return cli.Exit("", 1)
+			}
+
+			return exportContract(ctx, assetPath, c.String("output"))
+		},
+		Before: telemetry.BeforeCommand,
+		After:  telemetry.AfterCommand,
+	}
+}
+
+func exportContract(ctx context.Context, assetPath, output string) error {
+	pipelinePath, err := path.GetPipelineRootFromTask(assetPath, PipelineDefinitionFiles)
+	if err != nil {
+		errorPrinter.Printf("Failed to find the pipeline of the asset '%s': %v\n", assetPath, err)
+		return cli.Exit("", 1)
+	}
+
+	p, err := DefaultPipelineBuilder.CreatePipelineFromPath(ctx, pipelinePath, pipeline.WithMutate())
+	if err != nil {
+		errorPrinter.Printf("Failed to build the pipeline at '%s': %v\n", pipelinePath, err)
+		return cli.Exit("", 1)
+	}
+
+	asset := p.GetAssetByPath(assetPath)
+	if asset == nil {
+		errorPrinter.Printf("Failed to find the asset defined in '%s'\n", assetPath)
+		return cli.Exit("", 1)
+	}
+
+	content, err := odcs.Marshal(odcs.Export(asset, p))
+	if err != nil {
+		errorPrinter.Printf("Failed to export the contract of asset '%s': %v\n", asset.Name, err)
+		return cli.Exit("", 1)
+	}
+
+	if output == "" {
+		_, err = os.Stdout.Write(content)
+		return err
+	}
+	if err := os.WriteFile(output, content, 0o600); err != nil {
+		errorPrinter.Printf("Failed to write the contract to '%s': %v\n", output, err)
+		return cli.Exit("", 1)
+	}
+	infoPrinter.Printf("Exported the contract of asset '%s' to '%s'\n", asset.Name, output)
+	return nil
+}
+
+func ContractImport() *cli.Command {
+	return &cli.Command{
+		Name:      "import",
+		Usage:     "create or update the assets of a pipeline from the tables of an ODCS data contract",
+		ArgsUsage: "[path to the contract]",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "pipeline",
+				Aliases: []string{"p"},
+				Value:   ".",
+				Usage:   "the path to the pipeline to create or update the assets in",
+			},
+			&cli.StringFlag{
+				Name:  "type",
+				Usage: "the type of the assets to create, e.g. bq.source, it is inferred from the server of the contract by default",
+			},
+		},
+		Action: func(ctx context.Context, c *cli.Command) error {
+			contractPath := c.Args().Get(0)
+			if contractPath == "" {
+				errorPrinter.Println("Please give the path to the contract to import")
+				return cli.Exit("", 1)
+			}
+
+			return importContract(ctx, afero.NewOsFs(), contractPath, c.String("pipeline"), odcs.ImportOptions{
+				AssetType: pipeline.AssetType(c.String("type")),
+			})
+		},
+		Before: telemetry.BeforeCommand,
+		After:  telemetry.AfterCommand,
+	}
+}
+
+func importContract(ctx context.Context, fs afero.Fs, contractPath, inputPath string, opts odcs.ImportOptions) error {
+	content, err := afero.ReadFile(fs, contractPath)
+	if err != nil {
+		errorPrinter.Printf("Failed to read the contract '%s': %v\n", contractPath, err)
+		return cli.Exit("", 1)
+	}
+	contract, err := odcs.Parse(content)
+	if err != nil {
+		errorPrinter.Printf("Failed to read the contract '%s': %v\n", contractPath, err)
+		return cli.Exit("", 1)
+	}
+
+	pipelinePath, err := path.GetPipelineRootFromTask(inputPath, PipelineDefinitionFiles)
+	if err != nil {
+		errorPrinter.Printf("Failed to find a pipeline in '%s': %v\n", inputPath, err)
+		return cli.Exit("", 1)
+	}
+	p, err := DefaultPipelineBuilder.CreatePipelineFromPath(ctx, pipelinePath, pipeline.WithMutate())
+	if err != nil {
+		errorPrinter.Printf("Failed to build the pipeline at '%s': %v\n", pipelinePath, err)
+		return cli.Exit("", 1)
+	}
+
+	result, err := odcs.Import(contract, p, opts)
+	if err != nil {
+		errorPrinter.Printf("Failed to import the contract '%s': %v\n", contractPath, err)
+		return cli.Exit("", 1)
+	}
+
+	for _, warning := range result.Warnings {
+		warningPrinter.Printf("Warning: %s\n", warning)
+	}
+	for _, imported := range result.Assets {
+		if imported.Created {
+			if err := fs.MkdirAll(filepath.Dir(imported.Asset.ExecutableFile.Path), 0o755); err != nil {
+				errorPrinter.Printf("Failed to create the folder of asset '%s': %v\n", imported.Asset.Name, err)
+				return cli.Exit("", 1)
+			}
+		}
+		if err := imported.Asset.Persist(fs, p); err != nil {
+			errorPrinter.Printf("Failed to write asset '%s': %v\n", imported.Asset.Name, err)
+			return cli.Exit("", 1)
+		}
+
+		action := "Updated"
+		if imported.Created {
+			action = "Created"
+		}
+		infoPrinter.Printf("%s asset '%s' at '%s'\n", action, imported.Asset.Name, imported.Asset.ExecutableFile.Path)
+	}
+	return nil
+}
@@ -7,9 +7,15 @@ import (
 	"os/exec"
 	"path/filepath"
 	"runtime"
+	"strconv"
 	"strings"
 
+	"github.com/bruin-data/bruin/pkg/ansisql"
+	"github.com/bruin-data/bruin/pkg/config"
 	"github.com/bruin-data/bruin/pkg/docsgen"
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/bruin-data/bruin/pkg/query"
+	"github.com/bruin-data/bruin/pkg/sqlparser"
 	"github.com/bruin-data/bruin/pkg/telemetry"
 	"github.com/pkg/errors"
 	"github.com/urfave/cli/v3"
@@ -40,6 +46,24 @@ func Docs() *cli.Command {
 				Name:  "exclude-code",
 				Usage: "Omit asset source code from the generated documentation",
 			},
+			&cli.BoolFlag{
+				Name:  "operational",
+				Usage: "Include the recent runs, check outcomes and column lineage of the assets",
+			},
+			&cli.IntFlag{
+				Name:  "run-history",
+				Value: 20,
+				Usage: "Number of most recent runs per pipeline to include with --operational",
+			},
+			&cli.BoolFlag{
+				Name:  "row-counts",
+				Usage: "Query the row counts of the tables the assets produce, requires --operational and access to the connections",
+			},
+			&cli.StringFlag{
+				Name:    "environment",
+				Aliases: []string{"env"},
+				Usage:   "Environment to use for the connections when querying row counts",
+			},
 			&cli.BoolFlag{
 				Name:  "open",
 				Usage: "Open the generated documentation file in your default web browser",
@@ -47,6 +71,31 @@ func Docs() *cli.Command {
 		},
 		Action: func(ctx context.Context, c *cli.Command) error {
 			inputPath := c.Args().Get(0)
+			if c.Bool("row-counts") && !c.Bool("operational") {
+				return errors.New("--row-counts can only be used together with --operational")
+			}
+
+			var operational *docsgen.OperationalOptions
+			if c.Bool("operational") {
+				operational = &docsgen.OperationalOptions{
+					RunHistory: c.Int("run-history"),
+					ColumnLineage: func(_ context.Context, p *pipeline.Pipeline) error {
+						return extractColumnLineage(filepath.Dir(p.DefinitionFile.Path), p)
+					},
+				}
+				if c.Bool("row-counts") {
+					connectionPath := inputPath
+					if connectionPath == "" {
+						connectionPath = "."
+					}
+					cm, manager, err := resolveEnvironmentConnections(ctx, connectionPath, c.String("environment"))
+					if err != nil {
+						return errors.Wrap(err, "failed to load the connections for the row counts")
+					}
+					operational.RowCounter = &docsRowCounter{connections: manager, schemaPrefix: cm.SelectedEnvironment.SchemaPrefix}
+				}
+			}
+
 			result, err := docsgen.Generate(ctx, DefaultPipelineBuilder, docsgen.Options{
 				InputPath:               inputPath,
 				OutputPath:              c.String("output"),
@@ -54,6 +103,8 @@ func Docs() *cli.Command {
 				Variant:                 c.String("variant"),
 				ExcludeCode:             c.Bool("exclude-code"),
 				PipelineDefinitionFiles: PipelineDefinitionFiles,
+				Operational:             operational,
+				GlossaryReader:          DefaultGlossaryReader,
 			})
 			if err != nil {
 				return err
@@ -74,6 +125,80 @@ func Docs() *cli.Command {
 	}
 }
 
+// docsRowCounter counts the rows of the asset tables through the connections of the
+// selected environment. The tables of a developer environment are read from their prefixed
+// schemas, the same way `bruin run` builds them.
+type docsRowCounter struct {
+	connections  config.ConnectionGetter
+	schemaPrefix string
+}
+
+func (d *docsRowCounter) CountRows(ctx context.Context, p *pipeline.Pipeline, asset *pipeline.Asset) (int64, error) {
+	connName, err := p.GetConnectionNameForAsset(asset)
+	if err != nil {
+		return 0, err
+	}
+	querier, ok := d.connections.GetConnection(connName).(schemaQuerier)
+	if !ok {
+		return 0, errors.Errorf("connection '%s' is missing or cannot run queries", connName)
+	}
+
+	table := d.quoteTableName(asset, connName, pipeline.PrefixSchemaName(asset.Name, d.schemaPrefix))
+	result, err := querier.SelectWithSchema(ctx, &query.Query{Query: "SELECT COUNT(*) FROM " + table})
+	if err != nil {
+		return 0, err
+	}
+	if len(result.Rows) != 1 || len(result.Rows[0]) != 1 {
+		return 0, errors.New("the row count query did not return a single value")
+	}
+	return rowCountValue(result.Rows[0][0])
+}
+
+// quoteTableName quotes the table name for the platform of the asset, falling back to the type of
+// its connection for the assets that are not SQL, e.g. Python assets that materialize a table.
+func (d *docsRowCounter) quoteTableName(asset *pipeline.Asset, connName, table string) string {
+	dialect, err := sqlparser.AssetTypeToDialect(asset.Type)
+	if err != nil {
+		if details, ok := d.connections.(config.ConnectionDetailsGetter); ok {
+			dialect = sqlparser.ConnectionTypeToDialect(details.GetConnectionType(connName))
+		}
+	}
+
+	switch dialect {
+	case "bigquery", "mysql", "databricks", "spark", "clickhouse", "doris", "starrocks":
+		return ansisql.QuoteIdentifierWithBackticks(table)
+	case "tsql", "fabric":
+		return ansisql.QuoteIdentifierWithBrackets(table)
+	default:
+		// only the names that need it are quoted, a quoted name is case-sensitive and would not
+		// match the folded names of the platforms like Snowflake and Postgres
+		return ansisql.QuoteIdentifierWithDoubleQuotesWhenNeeded(table)
+	}
+}
+
+func rowCountValue(value any) (int64, error) {
+	switch v := value.(type) {
+	case int64:
+		return v, nil
+	case int:
+		return int64(v), nil
+	case int32:
+		return int64(v), nil
+	case uint64:
+		return strconv.ParseInt(strconv.FormatUint(v, 10), 10, 64)
+	case float64:
+		return int64(v), nil
+	case string:
+		return strconv.ParseInt(v, 10, 64)
+	case []byte:
+		return strconv.ParseInt(string(v), 10, 64)
+	case fmt.Stringer:
+		return strconv.ParseInt(v.String(), 10, 64)
+	default:
+		return 0, errors.Errorf("unexpected row count value of type %T", value)
+	}
+}
+
 func fileURL(path string) string {
 	absolutePath, err := filepath.Abs(path)
 	if err != nil {
@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/bruin-data/bruin/pkg/query"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// docsConnections is a connection manager with a single connection of the given type.
+type docsConnections struct {
+	conn           any
+	connectionType string
+}
+
+func (d docsConnections) GetConnection(string) any        { return d.conn }
+func (d docsConnections) GetConnectionDetails(string) any { return nil }
+func (d docsConnections) GetConnectionType(string) string { return d.connectionType }
+
+func TestDocsRowCounter_CountRows(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		asset          *pipeline.Asset
+		connectionType string
+		schemaPrefix   string
+		wantQuery      string
+	}{
+		{
+			name:      "ordinary names are not quoted",
+			asset:     &pipeline.Asset{Name: "analytics.users", Type: pipeline.AssetTypeSnowflakeQuery, Connection: "conn"},
+			wantQuery: "SELECT COUNT(*) FROM analytics.users",
+		},
+		{
+			name:         "the schema prefix of the developer environment is applied",
+			asset:        &pipeline.Asset{Name: "analytics.users", Type: pipeline.AssetTypeSnowflakeQuery, Connection: "conn"},
+			schemaPrefix: "dev_",
+			wantQuery:    "SELECT COUNT(*) FROM dev_analytics.users",
+		},
+		{
+			name:         "tables without a schema are not prefixed",
+			asset:        &pipeline.Asset{Name: "users", Type: pipeline.AssetTypePostgresQuery, Connection: "conn"},
+			schemaPrefix: "dev_",
+			wantQuery:    "SELECT COUNT(*) FROM users",
+		},
+		{
+			name:      "reserved names are quoted",
+			asset:     &pipeline.Asset{Name: "sales.order", Type: pipeline.AssetTypePostgresQuery, Connection: "conn"},
+			wantQuery: `SELECT COUNT(*) FROM sales."order"`,
+		},
+		{
+			name:         "bigquery names are quoted with backticks",
+			asset:        &pipeline.Asset{Name: "my-project.analytics.users", Type: pipeline.AssetTypeBigqueryQuery, Connection: "conn"},
+			schemaPrefix: "dev_",
+			wantQuery:    "SELECT COUNT(*) FROM `my-project`.`dev_analytics`.`users`",
+		},
+		{
+			name:           "python assets are quoted for the platform of their connection",
+			asset:          &pipeline.Asset{Name: "sales.order", Type: pipeline.AssetTypePython, Connection: "conn"},
+			connectionType: "mssql",
+			wantQuery:      "SELECT COUNT(*) FROM [sales].[order]",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			conn := new(mockConnection)
+			conn.On("SelectWithSchema", mock.Anything, &query.Query{Query: tt.wantQuery}).
+				Return(&query.QueryResult{Rows: [][]interface{}{{int64(42)}}}, nil)
+
+			counter := &docsRowCounter{
+				connections:  docsConnections{conn: conn, connectionType: tt.connectionType},
+				schemaPrefix: tt.schemaPrefix,
+			}
+			count, err := counter.CountRows(t.Context(), &pipeline.Pipeline{}, tt.asset)
+			require.NoError(t, err)
+			assert.Equal(t, int64(42), count)
+			conn.AssertExpectations(t)
+		})
+	}
+}
@@ -8,7 +8,10 @@ import (
 	"strings"
 
 	"github.com/bruin-data/bruin/pkg/config"
+	"github.com/bruin-data/bruin/pkg/envclone"
 	"github.com/bruin-data/bruin/pkg/git"
+	"github.com/bruin-data/bruin/pkg/path"
+	"github.com/bruin-data/bruin/pkg/pipeline"
 	"github.com/pkg/errors"
 	"github.com/spf13/afero"
 	"github.com/urfave/cli/v3"
@@ -430,8 +433,9 @@ func (r *EnvironmentDeleteCommand) Run(name string, force bool, output, configFi
 
 func CloneEnvironment(isDebug *bool) *cli.Command {
 	return &cli.Command{
-		Name:  "clone",
-		Usage: "clone an existing environment",
+		Name:      "clone",
+		Usage:     "clone an existing environment",
+		ArgsUsage: "[source] [target]",
 		Flags: []cli.Flag{
 			&cli.StringFlag{
 				Name:    "source",
@@ -439,16 +443,28 @@ func CloneEnvironment(isDebug *bool) *cli.Command {
 				Usage:   "the name of the environment to clone from (defaults to default environment)",
 			},
 			&cli.StringFlag{
-				Name:     "target",
-				Aliases:  []string{"t"},
-				Usage:    "the name of the new environment",
-				Required: true,
+				Name:    "target",
+				Aliases: []string{"t"},
+				Usage:   "the name of the new environment",
 			},
 			&cli.StringFlag{
 				Name:    "schema-prefix",
 				Aliases: []string{"p"},
 				Usage:   "schema prefix for the cloned environment (optional)",
 			},
+			&cli.BoolFlag{
+				Name:  "with-data",
+				Usage: "seed the prefixed schemas of the target environment with the tables of the source environment",
+			},
+			&cli.StringFlag{
+				Name:  "select",
+				Usage: "select the assets whose tables are cloned with --with-data, using the same selector syntax as 'bruin run --selector'",
+			},
+			&cli.StringFlag{
+				Name:  "pipeline",
+				Usage: "the path to the pipeline whose assets are cloned with --with-data",
+				Value: ".",
+			},
 			&cli.StringFlag{
 				Name:    "output",
 				Aliases: []string{"o"},
@@ -475,14 +491,48 @@ func CloneEnvironment(isDebug *bool) *cli.Command {
 				configFilePath = path2.Join(repoRoot.Path, ".bruin.yml")
 			}
 
-			return r.Run(c.String("source"), c.String("target"), c.String("schema-prefix"), strings.ToLower(c.String("output")), configFilePath)
+			sourceName := c.String("source")
+			targetName := c.String("target")
+			if c.Args().Len() > 2 {
+				printError(errors.New("at most two arguments are allowed: the source and the target environment"), strings.ToLower(c.String("output")), "")
+				return cli.Exit("", 1)
+			}
+			if c.Args().Len() == 2 {
+				sourceName = c.Args().Get(0)
+				targetName = c.Args().Get(1)
+			} else if c.Args().Len() == 1 {
+				targetName = c.Args().Get(0)
+			}
+			if targetName == "" {
+				printError(errors.New("the target environment is required, either as an argument or with --target"), strings.ToLower(c.String("output")), "")
+				return cli.Exit("", 1)
+			}
+
+			var dataOpts *cloneDataOptions
+			if c.Bool("with-data") {
+				dataOpts = &cloneDataOptions{
+					pipelinePath: c.String("pipeline"),
+					selector:     c.String("select"),
+				}
+			} else if c.IsSet("select") {
+				printError(errors.New("--select can only be used together with --with-data"), strings.ToLower(c.String("output")), "")
+				return cli.Exit("", 1)
+			}
+
+			return r.Run(ctx, sourceName, targetName, c.String("schema-prefix"), strings.ToLower(c.String("output")), configFilePath, dataOpts)
 		},
 	}
 }
 
 type EnvironmentCloneCommand struct{}
 
-func (r *EnvironmentCloneCommand) Run(sourceName, targetName, schemaPrefix, output, configFilePath string) error {
+// cloneDataOptions select the assets whose tables are copied into the cloned environment.
+type cloneDataOptions struct {
+	pipelinePath string
+	selector     string
+}
+
+func (r *EnvironmentCloneCommand) Run(ctx context.Context, sourceName, targetName, schemaPrefix, output, configFilePath string, dataOpts *cloneDataOptions) error {
 	defer RecoverFromPanic()
 
 	cm, err := config.LoadOrCreate(afero.NewOsFs(), configFilePath)
@@ -502,22 +552,40 @@ func (r *EnvironmentCloneCommand) Run(sourceName, targetName, schemaPrefix, outp
 		return cli.Exit("", 1)
 	}
 
-	// Validate target environment doesn't exist
-	if cm.EnvironmentExists(targetName) {
+	// An existing target environment is only re-seeded with data, its configuration is kept as-is.
+	targetExists := cm.EnvironmentExists(targetName)
+	if targetExists && dataOpts == nil {
 		printError(fmt.Errorf("target environment '%s' already exists", targetName), output, "")
 		return cli.Exit("", 1)
 	}
 
-	// Clone the environment
-	if err := cm.CloneEnvironment(sourceName, targetName, schemaPrefix); err != nil {
-		printError(err, output, "failed to clone environment")
-		return cli.Exit("", 1)
+	if !targetExists {
+		if err := cm.CloneEnvironment(sourceName, targetName, schemaPrefix); err != nil {
+			printError(err, output, "failed to clone environment")
+			return cli.Exit("", 1)
+		}
+
+		if err := cm.Persist(); err != nil {
+			printError(err, output, "failed to persist config")
+			return cli.Exit("", 1)
+		}
 	}
 
-	// Persist changes
-	if err := cm.Persist(); err != nil {
-		printError(err, output, "failed to persist config")
-		return cli.Exit("", 1)
+	var dataResults []envclone.Result
+	if dataOpts != nil {
+		var err error
+		dataResults, err = cloneEnvironmentData(ctx, cm, sourceName, targetName, configFilePath, dataOpts)
+		if err != nil {
+			printError(err, output, "failed to clone the data of the environment")
+			return cli.Exit("", 1)
+		}
+	}
+
+	failed := 0
+	for _, result := range dataResults {
+		if result.Error != "" {
+			failed++
+		}
 	}
 
 	if output == "json" {
@@ -529,19 +597,104 @@ func (r *EnvironmentCloneCommand) Run(sourceName, targetName, schemaPrefix, outp
 		if schemaPrefix != "" {
 			result["schema_prefix"] = schemaPrefix
 		}
+		if dataOpts != nil {
+			result["tables"] = dataResults
+		}
+		if failed > 0 {
+			result["message"] = fmt.Sprintf("Environment cloned, but %d of %d tables failed to clone", failed, len(dataResults))
+		}
 		js, err := json.MarshalIndent(result, "", "  ")
 		if err != nil {
 			printError(err, output, "failed to marshal JSON")
 			return cli.Exit("", 1)
 		}
 		fmt.Println(string(js))
+		if failed > 0 {
+			return cli.Exit("", 1)
+		}
 		return nil
 	}
 
+	for _, result := range dataResults {
+		switch {
+		case result.Error != "":
+			errorPrinter.Printf("  %s: %s\n", result.Asset, result.Error)
+		case result.Skipped != "":
+			infoPrinter.Printf("  %s: skipped, %s\n", result.Asset, result.Skipped)
+		default:
+			infoPrinter.Printf("  %s -> %s (%s)\n", result.Source, result.Target, result.Method)
+		}
+	}
+
+	if failed > 0 {
+		printError(fmt.Errorf("%d of %d tables failed to clone", failed, len(dataResults)), output, "")
+		return cli.Exit("", 1)
+	}
+
 	message := fmt.Sprintf("Successfully cloned environment '%s' to '%s'", sourceName, targetName)
 	if schemaPrefix != "" {
 		message += fmt.Sprintf(" with schema prefix '%s'", schemaPrefix)
 	}
+	if dataOpts != nil {
+		message += fmt.Sprintf(", including the data of %d tables", len(dataResults))
+	}
 	printSuccessForOutput(output, message)
 	return nil
 }
+
+// cloneEnvironmentData copies the tables of the selected assets from the schemas of the source
+// environment into the prefixed schemas of the target environment.
+func cloneEnvironmentData(ctx context.Context, cm *config.Config, sourceName, targetName, configFilePath string, opts *cloneDataOptions) ([]envclone.Result, error) {
+	sourcePrefix := cm.Environments[sourceName].SchemaPrefix
+	targetPrefix := cm.Environments[targetName].SchemaPrefix
+	if targetPrefix == "" || targetPrefix == sourcePrefix {
+		return nil, errors.Errorf("--with-data requires the target environment to have a schema prefix different from the one of '%s', use --schema-prefix to set one", sourceName)
+	}
+
+	pipelinePath, err := path.GetPipelineRootFromTask(opts.pipelinePath, PipelineDefinitionFiles)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to find the pipeline at '%s'", opts.pipelinePath)
+	}
+
+	foundPipeline, err := DefaultPipelineBuilder.CreatePipelineFromPath(ctx, pipelinePath, pipeline.WithMutate())
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to build the pipeline at '%s'", pipelinePath)
+	}
+
+	assets := foundPipeline.Assets
+	if opts.selector != "" {
+		assets, err = pipeline.ResolveSelectorAssets(opts.selector, foundPipeline)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	ctx = context.WithValue(ctx, config.ConfigFilePathContextKey, configFilePath)
+	sourceManager, err := environmentConnectionManager(ctx, cm, sourceName)
+	if err != nil {
+		return nil, err
+	}
+
+	// The tables are written with the connections of the target environment, and the source
+	// tables are read through them too.
+	targetManager, err := environmentConnectionManager(ctx, cm, targetName)
+	if err != nil {
+		return nil, err
+	}
+
+	return envclone.NewCloner(sourceManager, targetManager).Clone(ctx, foundPipeline, assets, sourcePrefix, targetPrefix), nil
+}
+
+func environmentConnectionManager(ctx context.Context, cm *config.Config, environmentName string) (config.ConnectionAndDetailsGetter, error) {
+	if err := cm.SelectEnvironment(environmentName); err != nil {
+		return nil, err
+	}
+
+	ctx = context.WithValue(ctx, config.EnvironmentNameContextKey, environmentName)
+	manager, errs := connectionManagerFromConfig(ctx, cm, makeLogger(false))
+	if len(errs) > 0 {
+		return nil, errors.Wrapf(errs[0], "failed to create the connection manager of the '%s' environment", environmentName)
+	}
+
+	return manager, nil
+}
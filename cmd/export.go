@@ -0,0 +1,200 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/bruin-data/bruin/pkg/catalog"
+	"github.com/bruin-data/bruin/pkg/path"
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/bruin-data/bruin/pkg/telemetry"
+	"github.com/pkg/errors"
+	"github.com/urfave/cli/v3"
+)
+
+func Export() *cli.Command {
+	return &cli.Command{
+		Name:  "export",
+		Usage: "export the metadata of the assets to other tools",
+		Commands: []*cli.Command{
+			ExportCatalog(),
+		},
+	}
+}
+
+func ExportCatalog() *cli.Command {
+	return &cli.Command{
+		Name:      "catalog",
+		Usage:     "export descriptions, owners, tags, glossary terms, lineage and checks to a data catalog",
+		ArgsUsage: "[path to a repo or a pipeline]",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "format",
+				Aliases:  []string{"f"},
+				Usage:    "the catalog format, possible values are: " + strings.Join(catalog.Formats, ", "),
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:    "output",
+				Aliases: []string{"o"},
+				Value:   "catalog",
+				Usage:   "the directory to write the catalog files to",
+			},
+			&cli.StringFlag{
+				Name:  "database",
+				Usage: "the database, or BigQuery project, of the assets whose names only consist of a schema and a table",
+			},
+			&cli.StringFlag{
+				Name:  "origin",
+				Value: "PROD",
+				Usage: "the DataHub environment of the datasets",
+			},
+			&cli.StringSliceFlag{
+				Name:  "service",
+				Usage: "the OpenMetadata database service of a platform as platform=name, e.g. bigquery=warehouse, the platform name is used by default",
+			},
+			&cli.StringFlag{
+				Name:  "glossary-name",
+				Value: "Bruin",
+				Usage: "the OpenMetadata glossary and classification to create the glossary terms and tags in",
+			},
+			&cli.BoolFlag{
+				Name:  "no-column-lineage",
+				Usage: "skip extracting the column lineage of the SQL assets",
+			},
+			&cli.StringFlag{
+				Name:  "openmetadata-server",
+				Usage: "the OpenMetadata server to load the exported entities into, e.g. http://localhost:8585/api",
+			},
+			&cli.StringFlag{
+				Name:    "openmetadata-token",
+				Usage:   "the JWT token of the OpenMetadata bot or user to load the entities with",
+				Sources: cli.EnvVars("OPENMETADATA_TOKEN"),
+			},
+		},
+		Action: func(ctx context.Context, c *cli.Command) error {
+			inputPath := c.Args().Get(0)
+			if inputPath == "" {
+				inputPath = "."
+			}
+
+			services, err := parseCatalogServices(c.StringSlice("service"))
+			if err != nil {
+				errorPrinter.Println(err.Error())
+				return cli.Exit("", 1)
+			}
+
+			server := c.String("openmetadata-server")
+			if server != "" && c.String("format") != catalog.FormatOpenMetadata {
+				errorPrinter.Printf("--openmetadata-server can only be used with the %s format\n", catalog.FormatOpenMetadata)
+				return cli.Exit("", 1)
+			}
+
+			var loader *catalog.OpenMetadataLoader
+			if server != "" {
+				loader = catalog.NewOpenMetadataLoader(server, c.String("openmetadata-token"))
+			}
+
+			return exportCatalog(ctx, inputPath, c.String("format"), c.String("output"), !c.Bool("no-column-lineage"), loader, catalog.Options{
+				Database:     c.String("database"),
+				Origin:       c.String("origin"),
+				Services:     services,
+				GlossaryName: c.String("glossary-name"),
+			})
+		},
+		Before: telemetry.BeforeCommand,
+		After:  telemetry.AfterCommand,
+	}
+}
+
+func parseCatalogServices(values []string) (map[string]string, error) {
+	services := make(map[string]string, len(values))
+	for _, value := range values {
+		platform, name, ok := strings.Cut(value, "=")
+		if !ok || platform == "" || name == "" {
+			return nil, errors.Errorf("invalid service '%s', it needs to be given as platform=name, e.g. bigquery=warehouse", value)
+		}
+		services[platform] = name
+	}
+	return services, nil
+}
+
+func exportCatalog(ctx context.Context, inputPath, format, outputDir string, columnLineage bool, loader *catalog.OpenMetadataLoader, opts catalog.Options) error {
+	if !slices.Contains(catalog.Formats, format) {
+		errorPrinter.Printf("Invalid catalog format '%s', possible values are: %s\n", format, strings.Join(catalog.Formats, ", "))
+		return cli.Exit("", 1)
+	}
+
+	pipelinePaths := make([]string, 0)
+	if pipelinePath, err := path.GetPipelineRootFromTask(inputPath, PipelineDefinitionFiles); err == nil {
+		pipelinePaths = append(pipelinePaths, pipelinePath)
+	} else {
+		pipelinePaths, err = path.GetPipelinePaths(inputPath, PipelineDefinitionFiles)
+		if err != nil || len(pipelinePaths) == 0 {
+			errorPrinter.Printf("Failed to find any pipelines in '%s'\n", inputPath)
+			return cli.Exit("", 1)
+		}
+	}
+
+	pipelines := make([]*pipeline.Pipeline, 0, len(pipelinePaths))
+	for _, pipelinePath := range pipelinePaths {
+		p, err := DefaultPipelineBuilder.CreatePipelineFromPath(ctx, pipelinePath, pipeline.WithMutate())
+		if err != nil {
+			errorPrinter.Printf("Failed to build the pipeline at '%s': %v\n", pipelinePath, err)
+			return cli.Exit("", 1)
+		}
+		if columnLineage {
+			if err := extractColumnLineage(pipelinePath, p); err != nil {
+				errorPrinter.Printf("Failed to extract the column lineage of pipeline '%s': %v\n", p.Name, err)
+				return cli.Exit("", 1)
+			}
+		}
+		pipelines = append(pipelines, p)
+	}
+
+	glossary, err := DefaultGlossaryReader.GetGlossary(pipelinePaths[0])
+	if err != nil {
+		warningPrinter.Printf("Failed to read the glossary, the glossary terms are left out: %v\n", err)
+	} else if len(glossary.Entities) > 0 {
+		opts.Glossary = glossary
+	}
+
+	result, err := catalog.Export(format, pipelines, opts)
+	if err != nil {
+		errorPrinter.Printf("Failed to export the catalog: %v\n", err)
+		return cli.Exit("", 1)
+	}
+
+	if err := os.MkdirAll(outputDir, 0o755); err != nil {
+		errorPrinter.Printf("Failed to create the output directory '%s': %v\n", outputDir, err)
+		return cli.Exit("", 1)
+	}
+	for _, file := range result.Files {
+		if err := os.WriteFile(filepath.Join(outputDir, file.Name), file.Content, 0o600); err != nil {
+			errorPrinter.Printf("Failed to write '%s': %v\n", file.Name, err)
+			return cli.Exit("", 1)
+		}
+	}
+
+	for _, warning := range result.Warnings {
+		warningPrinter.Printf("Warning: %s\n", warning)
+	}
+	infoPrinter.Printf("Exported the metadata of %d pipelines in the %s format to '%s'\n", len(pipelines), format, outputDir)
+
+	if loader == nil {
+		return nil
+	}
+	warnings, err := loader.Load(ctx, result.Files)
+	for _, warning := range warnings {
+		warningPrinter.Printf("Warning: %s\n", warning)
+	}
+	if err != nil {
+		errorPrinter.Printf("Failed to load the catalog into OpenMetadata: %v\n", err)
+		return cli.Exit("", 1)
+	}
+	infoPrinter.Println("Loaded the exported entities into OpenMetadata")
+	return nil
+}
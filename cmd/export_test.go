@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCatalogServices(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		values  []string
+		want    map[string]string
+		wantErr string
+	}{
+		{
+			name:   "no services",
+			values: nil,
+			want:   map[string]string{},
+		},
+		{
+			name:   "services per platform",
+			values: []string{"bigquery=warehouse", "snowflake=analytics"},
+			want:   map[string]string{"bigquery": "warehouse", "snowflake": "analytics"},
+		},
+		{
+			name:    "missing name",
+			values:  []string{"bigquery"},
+			wantErr: "invalid service 'bigquery', it needs to be given as platform=name, e.g. bigquery=warehouse",
+		},
+		{
+			name:    "empty platform",
+			values:  []string{"=warehouse"},
+			wantErr: "invalid service '=warehouse', it needs to be given as platform=name, e.g. bigquery=warehouse",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := parseCatalogServices(tt.values)
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
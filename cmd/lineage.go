@@ -4,9 +4,15 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
 
+	"github.com/bruin-data/bruin/pkg/git"
+	lineagepackage "github.com/bruin-data/bruin/pkg/lineage"
 	"github.com/bruin-data/bruin/pkg/path"
 	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/bruin-data/bruin/pkg/sqlparser"
 	"github.com/pkg/errors"
 	"github.com/urfave/cli/v3"
 )
@@ -14,7 +20,7 @@ import (
 func Lineage() *cli.Command {
 	return &cli.Command{
 		Name:      "lineage",
-		Usage:     "dump the lineage for a given asset",
+		Usage:     "dump the lineage for a given asset or column",
 		ArgsUsage: "[path to the asset definition]",
 		Flags: []cli.Flag{
 			&cli.BoolFlag{
@@ -24,18 +30,38 @@ func Lineage() *cli.Command {
 			&cli.StringFlag{
 				Name:    "output",
 				Aliases: []string{"o"},
-				Usage:   "the output type, possible values are: plain, json",
+				Usage:   "the output type, possible values are: plain, json, mermaid (only with --column)",
+			},
+			&cli.StringFlag{
+				Name:  "column",
+				Usage: "trace a column instead of the asset, in the form of <asset name>.<column name>, or only the column name of the given asset",
+			},
+			&cli.BoolFlag{
+				Name:  "downstream",
+				Usage: "list every downstream column derived from the column given with --column",
+			},
+			&cli.BoolFlag{
+				Name:  "impact",
+				Usage: "report the assets, columns, checks and BI assets that break if the column given with --column is dropped or renamed",
 			},
 			&cli.StringFlag{
 				Name:  "variant",
 				Usage: "variant name to materialize for variant pipelines",
 			},
 		},
+		Commands: []*cli.Command{
+			LineageGraph(),
+		},
 		Action: func(ctx context.Context, c *cli.Command) error {
 			r := LineageCommand{
-				builder:      DefaultPipelineBuilder,
-				infoPrinter:  infoPrinter,
-				errorPrinter: errorPrinter,
+				builder:       DefaultPipelineBuilder,
+				infoPrinter:   infoPrinter,
+				errorPrinter:  errorPrinter,
+				columnLineage: extractColumnLineage,
+			}
+
+			if column := c.String("column"); column != "" {
+				return r.RunColumn(ctx, c.Args().Get(0), column, c.Bool("downstream"), c.Bool("impact"), c.String("output"), c.String("variant"))
 			}
 
 			return r.Run(ctx, c.Args().Get(0), c.Bool("full"), c.String("output"), c.String("variant"))
@@ -43,6 +69,59 @@ func Lineage() *cli.Command {
 	}
 }
 
+func LineageGraph() *cli.Command {
+	return &cli.Command{
+		Name:      "graph",
+		Usage:     "export the lineage of a whole pipeline as a graph",
+		ArgsUsage: "[path to the pipeline]",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "format",
+				Aliases: []string{"f"},
+				Usage:   "the graph format, possible values are: " + strings.Join(lineagepackage.GraphFormats, ", "),
+				Value:   "dot",
+			},
+			&cli.BoolFlag{
+				Name:  "columns",
+				Usage: "add the columns of the assets and the column-level edges between them",
+			},
+			&cli.BoolFlag{
+				Name:  "uris",
+				Usage: "add the URI dependencies of the assets, resolved against the other pipelines in the repository",
+			},
+			&cli.StringFlag{
+				Name:  "selector",
+				Usage: "only export the assets matching the selector expression, e.g. 'tag:finance' or '+orders'",
+			},
+			&cli.IntFlag{
+				Name:  "depth",
+				Usage: "add the assets up to this many hops upstream and downstream of the selected assets, requires --selector",
+			},
+			&cli.StringFlag{
+				Name:  "variant",
+				Usage: "variant name to materialize for variant pipelines",
+			},
+		},
+		Action: func(ctx context.Context, c *cli.Command) error {
+			r := LineageCommand{
+				builder:       DefaultPipelineBuilder,
+				infoPrinter:   infoPrinter,
+				errorPrinter:  errorPrinter,
+				columnLineage: extractColumnLineage,
+			}
+
+			return r.RunGraph(ctx, c.Args().Get(0), LineageGraphOptions{
+				Format:   c.String("format"),
+				Columns:  c.Bool("columns"),
+				URIs:     c.Bool("uris"),
+				Selector: c.String("selector"),
+				Depth:    c.Int("depth"),
+				Variant:  c.String("variant"),
+			})
+		},
+	}
+}
+
 type printer interface {
 	Println(a ...interface{}) (n int, err error)
 	Printf(format string, a ...interface{}) (n int, err error)
@@ -57,6 +136,9 @@ type LineageCommand struct {
 	builder      lineagePipelineCreator
 	infoPrinter  printer
 	errorPrinter printer
+
+	// columnLineage fills the column-level upstreams of the assets in the pipeline.
+	columnLineage func(assetPath string, foundPipeline *pipeline.Pipeline) error
 }
 
 func (r *LineageCommand) Run(ctx context.Context, assetPath string, fullLineage bool, output, variantName string) error {
@@ -204,3 +286,294 @@ func (r *LineageCommand) printLineageSummary(p *pipeline.Pipeline, assets []*pip
 		r.infoPrinter.Printf("\nTotal: %d\n", len(assets)+len(*additional))
 	}
 }
+
+// RunColumn traces a single column through the pipeline: either the columns derived from it, or the
+// full report of what would break if it was dropped or renamed.
+func (r *LineageCommand) RunColumn(ctx context.Context, inputPath, column string, downstream, impact bool, output, variantName string) error {
+	if !downstream && !impact {
+		r.errorPrinter.Println("Please use --column together with --downstream or --impact.")
+		return cli.Exit("", 1)
+	}
+	if output != "" && output != "plain" && output != "json" && output != "mermaid" {
+		r.errorPrinter.Printf("Invalid output type '%s', possible values are: plain, json, mermaid\n", output)
+		return cli.Exit("", 1)
+	}
+	if inputPath == "" {
+		inputPath = "."
+	}
+
+	pipelinePath, err := path.GetPipelineRootFromTask(inputPath, PipelineDefinitionFiles)
+	if err != nil {
+		r.errorPrinter.Printf("Failed to find the pipeline from the path: '%s'\n", inputPath)
+		return cli.Exit("", 1)
+	}
+
+	opts := []pipeline.CreatePipelineOption{}
+	if variantName != "" {
+		opts = append(opts, pipeline.WithVariant(variantName))
+	}
+	foundPipeline, err := r.builder.CreatePipelineFromPath(ctx, pipelinePath, opts...)
+	if err != nil {
+		printError(err, output, "Failed to build pipeline")
+		return cli.Exit("", 1)
+	}
+
+	ref, err := resolveColumnRef(foundPipeline, inputPath, column)
+	if err != nil {
+		printError(err, output, "Failed to find the column")
+		return cli.Exit("", 1)
+	}
+
+	if err := r.columnLineage(inputPath, foundPipeline); err != nil {
+		printError(err, output, "Failed to extract the column lineage")
+		return cli.Exit("", 1)
+	}
+
+	report, err := lineagepackage.AnalyzeColumnImpact(foundPipeline, ref)
+	if err != nil {
+		printError(err, output, "Failed to analyze the column lineage")
+		return cli.Exit("", 1)
+	}
+
+	if impact {
+		return r.printColumnImpact(report, output)
+	}
+	return r.printDownstreamColumns(report.Column, report.Derived, output)
+}
+
+// resolveColumnRef finds the column given as <asset name>.<column name>, asset names may contain dots
+// themselves so the last part is always the column name. A column name alone refers to the asset
+// the path points to.
+func resolveColumnRef(foundPipeline *pipeline.Pipeline, inputPath, column string) (lineagepackage.ColumnRef, error) {
+	separator := strings.LastIndex(column, ".")
+	if separator > 0 {
+		return lineagepackage.ColumnRef{Asset: column[:separator], Column: column[separator+1:]}, nil
+	}
+
+	asset := foundPipeline.GetAssetByPath(inputPath)
+	if asset == nil {
+		return lineagepackage.ColumnRef{}, errors.Errorf("the column '%s' must be given as <asset name>.<column name> unless the path points to an asset", column)
+	}
+	return lineagepackage.ColumnRef{Asset: asset.Name, Column: column}, nil
+}
+
+func (r *LineageCommand) printDownstreamColumns(column lineagepackage.ColumnRef, derived []*lineagepackage.DerivedColumn, output string) error {
+	switch output {
+	case "json":
+		jsonVersion, err := json.Marshal(struct {
+			Column     lineagepackage.ColumnRef        `json:"column"`
+			Downstream []*lineagepackage.DerivedColumn `json:"downstream"`
+		}{Column: column, Downstream: derived})
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal the column lineage to json")
+		}
+		fmt.Println(string(jsonVersion))
+		return nil
+	case "mermaid":
+		fmt.Print(lineagepackage.MermaidDownstream(column, derived))
+		return nil
+	}
+
+	r.infoPrinter.Printf("\nColumn Lineage: '%s'", column)
+	r.infoPrinter.Print("\n\n")
+	r.infoPrinter.Println("Downstream Columns")
+	r.infoPrinter.Println("========================")
+	if len(derived) == 0 {
+		r.infoPrinter.Println("Column has no downstream columns.")
+		return nil
+	}
+	for _, d := range derived {
+		r.infoPrinter.Printf("- %s %s\n", d.ColumnRef, faint(fmt.Sprintf("(from %s)", d.From)))
+	}
+	r.infoPrinter.Printf("\nTotal: %d\n", len(derived))
+	return nil
+}
+
+func (r *LineageCommand) printColumnImpact(report *lineagepackage.ColumnImpact, output string) error {
+	switch output {
+	case "json":
+		jsonVersion, err := json.Marshal(report)
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal the column impact to json")
+		}
+		fmt.Println(string(jsonVersion))
+		return nil
+	case "mermaid":
+		fmt.Print(report.Mermaid())
+		return nil
+	}
+
+	r.infoPrinter.Printf("\nImpact of dropping or renaming '%s'", report.Column)
+
+	r.printImpactSection("Affected Assets", "No assets are affected.", len(report.Assets), func() {
+		for _, asset := range report.Assets {
+			r.infoPrinter.Printf("- %s %s\n", asset.Name, faint(fmt.Sprintf("(columns: %s)", strings.Join(asset.Columns, ", "))))
+		}
+	})
+	r.printImpactSection("Affected Checks", "No checks are affected.", len(report.Checks), func() {
+		for _, check := range report.Checks {
+			kind := "column check"
+			if check.Custom {
+				kind = "custom check"
+			}
+			r.infoPrinter.Printf("- %s.%s: %s %s\n", check.Asset, check.Column, check.Name, faint(fmt.Sprintf("(%s)", kind)))
+		}
+	})
+	r.printImpactSection("Affected BI Assets", "No BI assets are affected.", len(report.BIAssets), func() {
+		for _, asset := range report.BIAssets {
+			r.infoPrinter.Printf("- %s %s\n", asset.Name, faint(fmt.Sprintf("(%s, via %s)", asset.Type, asset.Via)))
+		}
+	})
+
+	return nil
+}
+
+func (r *LineageCommand) printImpactSection(title, absenceMessage string, count int, printItems func()) {
+	r.infoPrinter.Print("\n\n")
+	r.infoPrinter.Println(title)
+	r.infoPrinter.Println("========================")
+	if count == 0 {
+		r.infoPrinter.Println(absenceMessage)
+		return
+	}
+	printItems()
+	r.infoPrinter.Printf("\nTotal: %d\n", count)
+}
+
+// extractColumnLineage parses the queries of the assets and fills their column-level upstreams.
+// Assets whose lineage cannot be parsed are skipped, the rest of the pipeline is still traced.
+func extractColumnLineage(assetPath string, foundPipeline *pipeline.Pipeline) error {
+	sqlParser, err := sqlparser.NewSQLParser(false)
+	if err != nil {
+		return errors.Wrap(err, "failed to create the SQL parser")
+	}
+	if err := sqlParser.Start(); err != nil {
+		return errors.Wrap(err, "failed to start the SQL parser")
+	}
+	defer sqlParser.Close()
+
+	processedAssets := make(map[string]bool)
+	extractor := lineagepackage.NewLineageExtractor(sqlParser).
+		WithAssetDatabases(buildAssetDatabaseMap(assetPath, foundPipeline))
+	for _, asset := range foundPipeline.Assets {
+		extractor.ColumnLineage(foundPipeline, asset, processedAssets)
+	}
+
+	return nil
+}
+
+type LineageGraphOptions struct {
+	Format   string
+	Columns  bool
+	URIs     bool
+	Selector string
+	Depth    int
+	Variant  string
+}
+
+// RunGraph writes the lineage graph of the pipeline the path belongs to in the requested format.
+func (r *LineageCommand) RunGraph(ctx context.Context, inputPath string, opts LineageGraphOptions) error {
+	if opts.Format == "" {
+		opts.Format = "dot"
+	}
+	if !slices.Contains(lineagepackage.GraphFormats, opts.Format) {
+		r.errorPrinter.Printf("Invalid graph format '%s', possible values are: %s\n", opts.Format, strings.Join(lineagepackage.GraphFormats, ", "))
+		return cli.Exit("", 1)
+	}
+	if opts.Depth < 0 {
+		r.errorPrinter.Println("The depth cannot be negative.")
+		return cli.Exit("", 1)
+	}
+	if opts.Depth > 0 && opts.Selector == "" {
+		r.errorPrinter.Println("Please use --depth together with --selector.")
+		return cli.Exit("", 1)
+	}
+	if inputPath == "" {
+		inputPath = "."
+	}
+
+	pipelinePath, err := path.GetPipelineRootFromTask(inputPath, PipelineDefinitionFiles)
+	if err != nil {
+		r.errorPrinter.Printf("Failed to find the pipeline from the path: '%s'\n", inputPath)
+		return cli.Exit("", 1)
+	}
+
+	createOpts := []pipeline.CreatePipelineOption{}
+	if opts.Variant != "" {
+		createOpts = append(createOpts, pipeline.WithVariant(opts.Variant))
+	}
+	foundPipeline, err := r.builder.CreatePipelineFromPath(ctx, pipelinePath, createOpts...)
+	if err != nil {
+		r.errorPrinter.Printf("Failed to build pipeline: %v\n", err)
+		return cli.Exit("", 1)
+	}
+
+	graphOpts := lineagepackage.GraphOptions{Columns: opts.Columns, URIs: opts.URIs}
+	if opts.Selector != "" {
+		selected, err := pipeline.ResolveSelectorAssets(opts.Selector, foundPipeline)
+		if err != nil {
+			r.errorPrinter.Printf("Failed to resolve the selector: %v\n", err)
+			return cli.Exit("", 1)
+		}
+		graphOpts.Assets = lineagepackage.ExpandAssets(foundPipeline, selected, opts.Depth)
+	}
+
+	if opts.Columns {
+		if err := r.columnLineage(pipelinePath, foundPipeline); err != nil {
+			r.errorPrinter.Printf("Failed to extract the column lineage: %v\n", err)
+			return cli.Exit("", 1)
+		}
+	}
+
+	if opts.URIs {
+		graphOpts.OtherPipelines, err = r.otherPipelines(ctx, pipelinePath, createOpts)
+		if err != nil {
+			r.errorPrinter.Printf("Failed to build the other pipelines: %v\n", err)
+			return cli.Exit("", 1)
+		}
+	}
+
+	rendered, err := lineagepackage.BuildGraph(foundPipeline, graphOpts).Render(opts.Format)
+	if err != nil {
+		r.errorPrinter.Printf("Failed to render the lineage graph: %v\n", err)
+		return cli.Exit("", 1)
+	}
+
+	fmt.Print(rendered)
+	return nil
+}
+
+// otherPipelines builds the rest of the pipelines in the repository, so that the URI dependencies
+// can be resolved to the assets that produce or consume them.
+func (r *LineageCommand) otherPipelines(ctx context.Context, pipelinePath string, opts []pipeline.CreatePipelineOption) ([]*pipeline.Pipeline, error) {
+	searchRoot := filepath.Dir(pipelinePath)
+	if repo, err := git.FindRepoFromPath(pipelinePath); err == nil {
+		searchRoot = repo.Path
+	}
+
+	pipelinePaths, err := path.GetPipelinePaths(searchRoot, PipelineDefinitionFiles)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to find the pipelines")
+	}
+
+	absolutePipelinePath, err := filepath.Abs(pipelinePath)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to resolve the pipeline path")
+	}
+
+	pipelines := make([]*pipeline.Pipeline, 0, len(pipelinePaths))
+	for _, otherPath := range pipelinePaths {
+		absoluteOtherPath, err := filepath.Abs(otherPath)
+		if err != nil || absoluteOtherPath == absolutePipelinePath {
+			continue
+		}
+
+		other, err := r.builder.CreatePipelineFromPath(ctx, otherPath, opts...)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to build the pipeline at '%s'", otherPath)
+		}
+		pipelines = append(pipelines, other)
+	}
+
+	return pipelines, nil
+}
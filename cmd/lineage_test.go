@@ -147,3 +147,159 @@ Asset has no downstream dependencies.
 		})
 	}
 }
+
+func TestLineageCommand_RunColumn(t *testing.T) {
+	t.Parallel()
+
+	// the SQL parser is not needed to trace columns, the upstreams are filled the way the lineage
+	// extractor would do it.
+	columnLineage := func(assetPath string, p *pipeline.Pipeline) error {
+		source := p.GetAssetByName("dashboard.hello_bq")
+		derived := p.GetAssetByName("hello_python")
+		derived.Columns = append(derived.Columns, pipeline.Column{
+			Name:      "one_copy",
+			Upstreams: []*pipeline.UpstreamColumn{{Table: source.Name, Column: "one"}},
+		})
+		return nil
+	}
+
+	tests := []struct {
+		name       string
+		column     string
+		downstream bool
+		impact     bool
+		want       string
+		wantErr    assert.ErrorAssertionFunc
+	}{
+		{
+			name:    "neither downstream nor impact",
+			column:  "one",
+			wantErr: assert.Error,
+		},
+		{
+			name:    "missing column",
+			column:  "dashboard.hello_bq.missing",
+			impact:  true,
+			wantErr: assert.Error,
+		},
+		{
+			name:       "downstream columns of a column of the given asset",
+			column:     "one",
+			downstream: true,
+			want: `
+Column Lineage: 'dashboard.hello_bq.one'
+
+Downstream Columns
+========================
+- hello_python.one_copy (from dashboard.hello_bq.one)
+
+Total: 1
+`,
+			wantErr: assert.NoError,
+		},
+		{
+			name:   "impact of a fully qualified column",
+			column: "dashboard.hello_bq.one",
+			impact: true,
+			want: `
+Impact of dropping or renaming 'dashboard.hello_bq.one'
+
+Affected Assets
+========================
+- hello_python (columns: one_copy)
+
+Total: 1
+
+
+Affected Checks
+========================
+- dashboard.hello_bq.one: unique (column check)
+- dashboard.hello_bq.one: not_null (column check)
+- dashboard.hello_bq.one: positive (column check)
+- dashboard.hello_bq.one: accepted_values (column check)
+
+Total: 4
+
+
+Affected BI Assets
+========================
+No BI assets are affected.
+`,
+			wantErr: assert.NoError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := bytes.NewBuffer(nil)
+			mp := &mockPrinter{buf: buf}
+
+			fs := afero.NewOsFs()
+			r := &LineageCommand{
+				builder:       pipeline.NewBuilder(builderConfig, pipeline.CreateTaskFromYamlDefinition(fs), pipeline.CreateTaskFromFileComments(fs), fs, nil, nil),
+				infoPrinter:   mp,
+				errorPrinter:  mp,
+				columnLineage: columnLineage,
+			}
+
+			res := r.RunColumn(t.Context(), path.AbsPathForTests(t, "./testdata/lineage/assets/hello_bq.sql"), tt.column, tt.downstream, tt.impact, "plain", "")
+			tt.wantErr(t, res)
+			if tt.want != "" {
+				assert.Equal(t, tt.want, buf.String())
+			}
+		})
+	}
+}
+
+func TestLineageCommand_RunGraph(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		opts    LineageGraphOptions
+		want    string
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:    "unknown format",
+			opts:    LineageGraphOptions{Format: "png"},
+			want:    "Invalid graph format 'png', possible values are: dot, mermaid, graphml, json\n",
+			wantErr: assert.Error,
+		},
+		{
+			name:    "depth without a selector",
+			opts:    LineageGraphOptions{Depth: 2},
+			want:    "Please use --depth together with --selector.\n",
+			wantErr: assert.Error,
+		},
+		{
+			name:    "selector without matches",
+			opts:    LineageGraphOptions{Selector: "tag:missing"},
+			want:    "Failed to resolve the selector: selector \"tag:missing\" matched no assets\n",
+			wantErr: assert.Error,
+		},
+		{
+			name:    "whole pipeline",
+			opts:    LineageGraphOptions{Format: "json"},
+			wantErr: assert.NoError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := bytes.NewBuffer(nil)
+			mp := &mockPrinter{buf: buf}
+
+			fs := afero.NewOsFs()
+			r := &LineageCommand{
+				builder:      pipeline.NewBuilder(builderConfig, pipeline.CreateTaskFromYamlDefinition(fs), pipeline.CreateTaskFromFileComments(fs), fs, nil, nil),
+				infoPrinter:  mp,
+				errorPrinter: mp,
+			}
+
+			res := r.RunGraph(t.Context(), path.AbsPathForTests(t, "./testdata/lineage"), tt.opts)
+			tt.wantErr(t, res)
+			assert.Equal(t, tt.want, buf.String())
+		})
+	}
+}
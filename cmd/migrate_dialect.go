@@ -0,0 +1,193 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/bruin-data/bruin/pkg/path"
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/bruin-data/bruin/pkg/sqlparser"
+	"github.com/bruin-data/bruin/pkg/transpile"
+	"github.com/pkg/errors"
+	"github.com/spf13/afero"
+	"github.com/urfave/cli/v3"
+)
+
+func MigrateDialect() *cli.Command {
+	return &cli.Command{
+		Name:      "migrate-dialect",
+		Usage:     "translate SQL assets to the dialect of another platform",
+		ArgsUsage: "[path to a pipeline, a folder of assets or a single asset]",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "to",
+				Usage:    "the platform to translate the assets to, as the prefix of its SQL asset type, e.g. sf, bq, pg",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:  "from",
+				Usage: "only translate the assets of this platform, e.g. rs",
+			},
+			&cli.BoolFlag{
+				Name:  "dry-run",
+				Usage: "report what would be translated without changing the asset files",
+			},
+			&cli.StringFlag{
+				Name:    "output",
+				Aliases: []string{"o"},
+				Usage:   "the output type, possible values are: plain, json",
+			},
+		},
+		Action: func(ctx context.Context, c *cli.Command) error {
+			inputPath := c.Args().Get(0)
+			if inputPath == "" {
+				inputPath = "."
+			}
+
+			return migrateDialect(inputPath, c.String("to"), c.String("from"), c.Bool("dry-run"), c.String("output"))
+		},
+	}
+}
+
+type dialectMigrationSummary struct {
+	Asset       string             `json:"asset"`
+	Path        string             `json:"path"`
+	From        pipeline.AssetType `json:"from"`
+	To          pipeline.AssetType `json:"to"`
+	Unsupported []string           `json:"unsupported"`
+	Error       string             `json:"error,omitempty"`
+}
+
+func migrateDialect(inputPath, to, from string, dryRun bool, output string) error {
+	target, err := transpile.TargetAssetType(to)
+	if err != nil {
+		printErrorForOutput(output, err)
+		return cli.Exit("", 1)
+	}
+	var source pipeline.AssetType
+	if from != "" {
+		source, err = transpile.TargetAssetType(from)
+		if err != nil {
+			printErrorForOutput(output, err)
+			return cli.Exit("", 1)
+		}
+	}
+
+	assetPaths := []string{inputPath}
+	if !isPathReferencingAsset(inputPath) {
+		assetPaths = path.GetAllPossibleAssetPaths(inputPath, assetsDirectoryNames, pipeline.SupportedFileSuffixes)
+	}
+
+	assets := make([]*pipeline.Asset, 0, len(assetPaths))
+	for _, assetPath := range assetPaths {
+		asset, err := DefaultPipelineBuilder.CreateAssetFromFile(assetPath, nil)
+		if err != nil || asset == nil {
+			continue
+		}
+		if !transpile.IsTranslatable(asset) || asset.Type == target || (source != "" && asset.Type != source) {
+			continue
+		}
+		assets = append(assets, asset)
+	}
+
+	if len(assets) == 0 {
+		if output == "json" {
+			fmt.Println("[]")
+			return nil
+		}
+		infoPrinter.Printf("No SQL assets to translate to '%s' were found in '%s'.\n", target, inputPath)
+		return nil
+	}
+
+	sqlParser, err := sqlparser.NewSQLParser(false)
+	if err != nil {
+		printErrorForOutput(output, errors.Wrap(err, "failed to create the SQL parser"))
+		return cli.Exit("", 1)
+	}
+	if err := sqlParser.Start(); err != nil {
+		printErrorForOutput(output, errors.Wrap(err, "failed to start the SQL parser"))
+		return cli.Exit("", 1)
+	}
+	defer sqlParser.Close()
+
+	fs := afero.NewOsFs()
+	summaries := make([]*dialectMigrationSummary, 0, len(assets))
+	failed := 0
+	for _, asset := range assets {
+		summary := &dialectMigrationSummary{
+			Asset:       asset.Name,
+			Path:        asset.ExecutableFile.Path,
+			From:        asset.Type,
+			To:          target,
+			Unsupported: make([]string, 0),
+		}
+		summaries = append(summaries, summary)
+
+		err := migrateAssetDialect(fs, sqlParser, asset, target, dryRun, summary)
+		if err != nil {
+			summary.Error = err.Error()
+			failed++
+		}
+	}
+
+	if output == "json" {
+		js, err := json.Marshal(summaries)
+		if err != nil {
+			printErrorJSON(err)
+			return cli.Exit("", 1)
+		}
+		fmt.Println(string(js))
+	} else {
+		printDialectMigration(summaries, failed, dryRun)
+	}
+
+	if failed > 0 {
+		return cli.Exit("", 1)
+	}
+	return nil
+}
+
+func migrateAssetDialect(fs afero.Fs, sqlParser *sqlparser.SQLParser, asset *pipeline.Asset, target pipeline.AssetType, dryRun bool, summary *dialectMigrationSummary) error {
+	// the query of YAML-defined assets lives in a separate file that persisting the asset would
+	// overwrite with an embedded definition.
+	if asset.DefinitionFile.Type == pipeline.YamlTask {
+		return errors.New("assets defined in YAML files are not supported yet, the asset needs to be translated manually")
+	}
+
+	result, err := transpile.MigrateAsset(sqlParser, asset, target)
+	if err != nil {
+		return err
+	}
+	summary.Unsupported = result.Unsupported
+
+	if dryRun {
+		return nil
+	}
+	return errors.Wrap(asset.Persist(fs), "failed to save the translated asset")
+}
+
+func printDialectMigration(summaries []*dialectMigrationSummary, failed int, dryRun bool) {
+	verb := "Translated"
+	if dryRun {
+		verb = "Would translate"
+	}
+
+	for _, summary := range summaries {
+		if summary.Error != "" {
+			errorPrinter.Printf("Failed to translate '%s' %s: %s\n", summary.Asset, faint(fmt.Sprintf("(%s)", summary.Path)), summary.Error)
+			continue
+		}
+
+		infoPrinter.Printf("%s '%s' from %s to %s %s\n", verb, summary.Asset, summary.From, summary.To, faint(fmt.Sprintf("(%s)", summary.Path)))
+		for _, message := range summary.Unsupported {
+			warningPrinter.Printf("  - %s\n", message)
+		}
+	}
+
+	infoPrinter.Printf("\n%s %d of %d assets", verb, len(summaries)-failed, len(summaries))
+	if failed > 0 {
+		infoPrinter.Printf(", %d failed", failed)
+	}
+	infoPrinter.Println(".")
+}
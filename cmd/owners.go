@@ -0,0 +1,220 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/bruin-data/bruin/pkg/git"
+	"github.com/bruin-data/bruin/pkg/owners"
+	"github.com/bruin-data/bruin/pkg/path"
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/bruin-data/bruin/pkg/telemetry"
+	"github.com/spf13/afero"
+	"github.com/urfave/cli/v3"
+)
+
+func Owners() *cli.Command {
+	return &cli.Command{
+		Name:      "owners",
+		Usage:     "report the assets without owners, the ownership per domain and the critical assets per owner",
+		ArgsUsage: "[path to a repo or a pipeline]",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "output",
+				Aliases: []string{"o"},
+				Value:   "plain",
+				Usage:   "the output type, possible values are: plain, json",
+			},
+		},
+		Commands: []*cli.Command{
+			OwnersCodeowners(),
+		},
+		Action: func(ctx context.Context, c *cli.Command) error {
+			repoPath, pipelines, err := loadOwnedPipelines(ctx, c.Args().Get(0))
+			if err != nil {
+				printErrorForOutput(c.String("output"), err)
+				return cli.Exit("", 1)
+			}
+
+			report := owners.BuildReport(repoPath, pipelines)
+			if c.String("output") == "json" {
+				js, err := json.MarshalIndent(report, "", "  ")
+				if err != nil {
+					printErrorJSON(err)
+					return cli.Exit("", 1)
+				}
+				fmt.Println(string(js))
+				return nil
+			}
+
+			printOwnersReport(report)
+			return nil
+		},
+		Before: telemetry.BeforeCommand,
+		After:  telemetry.AfterCommand,
+	}
+}
+
+func OwnersCodeowners() *cli.Command {
+	return &cli.Command{
+		Name:      "codeowners",
+		Usage:     "generate or validate the CODEOWNERS entries of the asset files from the owners of the assets",
+		ArgsUsage: "[path to a repo or a pipeline]",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "file",
+				Value: owners.CodeownersPath,
+				Usage: "the path of the CODEOWNERS file, relative to the root of the repository",
+			},
+			&cli.BoolFlag{
+				Name:  "check",
+				Usage: "only validate that the CODEOWNERS file is up to date, and fail if it is not",
+			},
+		},
+		Action: func(ctx context.Context, c *cli.Command) error {
+			repoPath, pipelines, err := loadOwnedPipelines(ctx, c.Args().Get(0))
+			if err != nil {
+				errorPrinter.Println(err.Error())
+				return cli.Exit("", 1)
+			}
+
+			config, err := owners.ReadConfig(afero.NewOsFs(), repoPath)
+			if err != nil {
+				errorPrinter.Printf("Failed to read the ownership configuration: %v\n", err)
+				return cli.Exit("", 1)
+			}
+
+			result := owners.BuildCodeowners(repoPath, pipelines, config)
+			for _, warning := range result.Warnings {
+				warningPrinter.Printf("Warning: %s\n", warning)
+			}
+
+			file := filepath.Join(repoPath, c.String("file"))
+			existing, err := os.ReadFile(file)
+			if err != nil && !os.IsNotExist(err) {
+				errorPrinter.Printf("Failed to read '%s': %v\n", file, err)
+				return cli.Exit("", 1)
+			}
+
+			if c.Bool("check") {
+				missing, stale := owners.DiffCodeowners(string(existing), result.Entries)
+				if len(missing) == 0 && len(stale) == 0 {
+					successPrinter.Printf("'%s' is up to date with the owners of the assets\n", c.String("file"))
+					return nil
+				}
+				errorPrinter.Printf("'%s' is out of date, run `bruin owners codeowners` to update it\n", c.String("file"))
+				for _, entry := range missing {
+					errorPrinter.Printf("  missing: %s\n", entry)
+				}
+				for _, entry := range stale {
+					errorPrinter.Printf("  stale:   %s\n", entry)
+				}
+				return cli.Exit("", 1)
+			}
+
+			if err := os.MkdirAll(filepath.Dir(file), 0o755); err != nil {
+				errorPrinter.Printf("Failed to create the folder of '%s': %v\n", file, err)
+				return cli.Exit("", 1)
+			}
+			if err := os.WriteFile(file, []byte(owners.UpdateCodeowners(string(existing), result.Entries)), 0o600); err != nil {
+				errorPrinter.Printf("Failed to write '%s': %v\n", file, err)
+				return cli.Exit("", 1)
+			}
+			infoPrinter.Printf("Wrote the code owners of %d asset files to '%s'\n", len(result.Entries), c.String("file"))
+			return nil
+		},
+		Before: telemetry.BeforeCommand,
+		After:  telemetry.AfterCommand,
+	}
+}
+
+// loadOwnedPipelines builds the pipeline the path is in, or every pipeline under the path, and
+// returns them with the root of their repository.
+func loadOwnedPipelines(ctx context.Context, inputPath string) (string, []*pipeline.Pipeline, error) {
+	if inputPath == "" {
+		inputPath = "."
+	}
+	inputPath, err := filepath.Abs(inputPath)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to resolve the path '%s': %w", inputPath, err)
+	}
+
+	repo, err := git.FindRepoFromPath(inputPath)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to find the repository of '%s': %w", inputPath, err)
+	}
+
+	pipelinePaths := make([]string, 0)
+	if pipelinePath, err := path.GetPipelineRootFromTask(inputPath, PipelineDefinitionFiles); err == nil {
+		pipelinePaths = append(pipelinePaths, pipelinePath)
+	} else {
+		pipelinePaths, err = path.GetPipelinePaths(inputPath, PipelineDefinitionFiles)
+		if err != nil || len(pipelinePaths) == 0 {
+			return "", nil, fmt.Errorf("failed to find any pipelines in '%s'", inputPath)
+		}
+	}
+
+	pipelines := make([]*pipeline.Pipeline, 0, len(pipelinePaths))
+	for _, pipelinePath := range pipelinePaths {
+		p, err := DefaultPipelineBuilder.CreatePipelineFromPath(ctx, pipelinePath, pipeline.WithMutate())
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to build the pipeline at '%s': %w", pipelinePath, err)
+		}
+		pipelines = append(pipelines, p)
+	}
+	return repo.Path, pipelines, nil
+}
+
+func printOwnersReport(report *owners.Report) {
+	owned := report.Assets - len(report.Unowned)
+	infoPrinter.Printf("%d of %d assets have an owner\n", owned, report.Assets)
+
+	if len(report.Unowned) > 0 {
+		fmt.Println()
+		infoPrinter.Println("Assets without an owner:")
+		for _, asset := range report.Unowned {
+			tier := "      "
+			if asset.Tier > 0 {
+				tier = fmt.Sprintf("tier %d", asset.Tier)
+			}
+			warningPrinter.Printf("  %s  %s ", tier, asset.Name)
+			fmt.Printf("(%s, %s)\n", asset.Pipeline, asset.Path)
+		}
+	}
+
+	if len(report.Domains) > 0 {
+		fmt.Println()
+		infoPrinter.Println("Ownership by domain:")
+		for _, domain := range report.Domains {
+			name := domain.Domain
+			if name == "" {
+				name = "(no domain)"
+			}
+			fmt.Printf("  %s: %d assets, %d without an owner\n", name, domain.Assets, domain.Unowned)
+			for _, owner := range domain.Owners {
+				fmt.Printf("    %s: %d\n", owner.Owner, owner.Assets)
+			}
+		}
+	}
+
+	fmt.Println()
+	infoPrinter.Printf("Critical assets (tier 1-%d) per owner:\n", owners.CriticalMaxTier)
+	if len(report.Critical) == 0 && report.UnownedCritical == 0 {
+		fmt.Println("  no asset has a critical tier")
+		return
+	}
+	width := 0
+	for _, owner := range report.Critical {
+		width = max(width, len(owner.Owner))
+	}
+	for _, owner := range report.Critical {
+		fmt.Printf("  %s%s  %d\n", owner.Owner, strings.Repeat(" ", width-len(owner.Owner)), owner.Assets)
+	}
+	if report.UnownedCritical > 0 {
+		warningPrinter.Printf("  %d critical assets have no owner\n", report.UnownedCritical)
+	}
+}
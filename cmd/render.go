@@ -34,6 +34,7 @@ import (
 	"github.com/bruin-data/bruin/pkg/sail"
 	"github.com/bruin-data/bruin/pkg/snowflake"
 	"github.com/bruin-data/bruin/pkg/spark"
+	"github.com/bruin-data/bruin/pkg/sqlite"
 	"github.com/bruin-data/bruin/pkg/sqlparser"
 	"github.com/bruin-data/bruin/pkg/starrocks"
 	"github.com/bruin-data/bruin/pkg/synapse"
@@ -311,6 +312,8 @@ func Render() *cli.Command {
 					pipeline.AssetTypeSynapseQuerySensor:      synapse.NewRenderer(fullRefresh),
 					pipeline.AssetTypeVerticaQuery:            vertica.NewMaterializer(fullRefresh),
 					pipeline.AssetTypeVerticaQuerySensor:      vertica.NewMaterializer(fullRefresh),
+					pipeline.AssetTypeSQLiteQuery:             sqlite.NewMaterializer(fullRefresh),
+					pipeline.AssetTypeSQLiteQuerySensor:       sqlite.NewMaterializer(fullRefresh),
 					pipeline.AssetTypeFabricQuery:             fabric.NewMaterializer(fullRefresh),
 					pipeline.AssetTypeFabricQueryLegacy:       fabric.NewMaterializer(fullRefresh),
 					pipeline.AssetTypeFabricQuerySensor:       fabric.NewMaterializer(fullRefresh),
@@ -318,6 +321,7 @@ func Render() *cli.Command {
 					pipeline.AssetTypeAthenaQuery:             athena.NewRenderer(fullRefresh, resultsLocation),
 					pipeline.AssetTypeAthenaSQLSensor:         athena.NewRenderer(fullRefresh, resultsLocation),
 					pipeline.AssetTypeDuckDBQuery:             duck.NewMaterializer(fullRefresh),
+					pipeline.AssetTypeIcebergQuery:            duck.NewIcebergMaterializer(fullRefresh),
 					pipeline.AssetTypeDuckDBQuerySensor:       duck.NewMaterializer(fullRefresh),
 					pipeline.AssetTypeClickHouse:              clickhouse.NewRenderer(fullRefresh),
 					pipeline.AssetTypeClickHouseQuerySensor:   clickhouse.NewRenderer(fullRefresh),
@@ -385,6 +389,9 @@ type hookWrappingQueryMaterializer interface {
 	WrapsHooks() bool
 }
 
+// grantRenderer returns the statements that apply the grants defined on the asset.
+type grantRenderer func(asset *pipeline.Asset) ([]string, error)
+
 type taskCreator interface {
 	CreateAssetFromFile(path string, foundPipeline *pipeline.Pipeline) (*pipeline.Asset, error)
 }
@@ -394,6 +401,7 @@ type RenderCommand struct {
 	materializers map[pipeline.AssetType]queryMaterializer
 	builder       taskCreator
 	hoister       pipeline.DeclareHoister
+	grants        map[pipeline.AssetType]grantRenderer
 
 	output   string
 	writer   io.Writer
@@ -439,7 +447,7 @@ func (r *RenderCommand) Run(pl *pipeline.Pipeline, task *pipeline.Asset, modifie
 			}
 
 			qq.Query = materialized
-			if task.Materialization.Strategy == pipeline.MaterializationStrategyTimeInterval {
+			if task.Materialization.UsesIntervalDates() {
 				var rextractedQueries []*query.Query
 
 				rextractedQueries, err = extractor.ExtractQueriesFromString(materialized)
@@ -456,6 +464,12 @@ func (r *RenderCommand) Run(pl *pipeline.Pipeline, task *pipeline.Asset, modifie
 			if !hooksWrapped {
 				qq.Query = pipeline.WrapHooks(qq.Query, task.Hooks, r.hoister, task.Type)
 			}
+
+			qq.Query, err = r.appendGrants(task, qq.Query)
+			if err != nil {
+				r.printErrorOrJsonf("Failed to render the grants: %v\n", err.Error())
+				return cli.Exit("", 1)
+			}
 		}
 	}
 
@@ -483,6 +497,27 @@ func (r *RenderCommand) Run(pl *pipeline.Pipeline, task *pipeline.Asset, modifie
 	return err
 }
 
+func (r *RenderCommand) appendGrants(task *pipeline.Asset, rendered string) (string, error) {
+	renderGrants, ok := r.grants[task.Type]
+	if !ok || task.Grants.IsEmpty() || task.Materialization.Type == pipeline.MaterializationTypeNone {
+		return rendered, nil
+	}
+
+	grants, err := renderGrants(task)
+	if err != nil {
+		return "", err
+	}
+	if len(grants) == 0 {
+		return rendered, nil
+	}
+
+	rendered = strings.TrimSpace(rendered)
+	if !strings.HasSuffix(rendered, ";") {
+		rendered += ";"
+	}
+	return rendered + "\n" + strings.Join(grants, "\n"), nil
+}
+
 func isQuerySensorAsset(assetType pipeline.AssetType) bool {
 	return strings.HasSuffix(string(assetType), ".sensor.query")
 }
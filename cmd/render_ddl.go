@@ -2,11 +2,15 @@ package cmd
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	path2 "path"
+	"strings"
 	"time"
 
+	"github.com/bruin-data/bruin/pkg/ansisql"
 	"github.com/bruin-data/bruin/pkg/athena"
 	"github.com/bruin-data/bruin/pkg/bigquery"
 	"github.com/bruin-data/bruin/pkg/clickhouse"
@@ -29,6 +33,7 @@ import (
 	"github.com/bruin-data/bruin/pkg/sail"
 	"github.com/bruin-data/bruin/pkg/snowflake"
 	"github.com/bruin-data/bruin/pkg/spark"
+	"github.com/bruin-data/bruin/pkg/sqlite"
 	"github.com/bruin-data/bruin/pkg/starrocks"
 	"github.com/bruin-data/bruin/pkg/synapse"
 	"github.com/bruin-data/bruin/pkg/trino"
@@ -61,6 +66,15 @@ func RenderDDL() *cli.Command {
 				Name:  "apply-interval-modifiers",
 				Usage: "applies interval modifiers if flag is given",
 			},
+			&cli.BoolFlag{
+				Name:  "migrate",
+				Usage: "render the statements that migrate the constraints of the existing table to the ones defined on the asset",
+			},
+			&cli.StringFlag{
+				Name:    "environment",
+				Aliases: []string{"env"},
+				Usage:   "the environment to read the existing table from when --migrate is given",
+			},
 		},
 		Action: func(ctx context.Context, c *cli.Command) error {
 			inputPath := c.Args().Get(0)
@@ -158,6 +172,10 @@ func RenderDDL() *cli.Command {
 			// Force the asset to use DDL strategy for schema generation
 			asset.Materialization.Strategy = pipeline.MaterializationStrategyDDL
 
+			if c.Bool("migrate") {
+				return renderConstraintMigration(ctx, c, inputPath, pl, asset)
+			}
+
 			resultsLocation := "s3://{destination-bucket}"
 			if asset.Type == pipeline.AssetTypeAthenaQuery {
 				connName, err := pl.GetConnectionNameForAsset(asset)
@@ -166,19 +184,9 @@ func RenderDDL() *cli.Command {
 					return cli.Exit("", 1)
 				}
 
-				configFilePath := c.String("config-file")
-				if configFilePath == "" {
-					repoRoot, err := git.FindRepoFromPath(inputPath)
-					if err != nil {
-						printError(err, c.String("output"), "Failed to find the git repository root:")
-						return cli.Exit("", 1)
-					}
-					configFilePath = path2.Join(repoRoot.Path, ".bruin.yml")
-				}
-
-				cm, err := config.LoadOrCreate(afero.NewOsFs(), configFilePath)
+				cm, err := loadRenderDDLConfig(inputPath, c.String("config-file"))
 				if err != nil {
-					printError(err, c.String("output"), fmt.Sprintf("Failed to load the config file at '%s':", configFilePath))
+					printError(err, c.String("output"), "Failed to load the config file:")
 					return cli.Exit("", 1)
 				}
 
@@ -251,6 +259,8 @@ func RenderDDL() *cli.Command {
 					pipeline.AssetTypeSynapseQuerySensor:      synapse.NewRenderer(false),
 					pipeline.AssetTypeVerticaQuery:            vertica.NewMaterializer(false),
 					pipeline.AssetTypeVerticaQuerySensor:      vertica.NewMaterializer(false),
+					pipeline.AssetTypeSQLiteQuery:             sqlite.NewMaterializer(false),
+					pipeline.AssetTypeSQLiteQuerySensor:       sqlite.NewMaterializer(false),
 					pipeline.AssetTypeFabricQuery:             fabric.NewMaterializer(false),
 					pipeline.AssetTypeFabricQueryLegacy:       fabric.NewMaterializer(false),
 					pipeline.AssetTypeFabricQuerySensor:       fabric.NewMaterializer(false),
@@ -258,10 +268,18 @@ func RenderDDL() *cli.Command {
 					pipeline.AssetTypeAthenaQuery:             athena.NewRenderer(false, resultsLocation),
 					pipeline.AssetTypeAthenaSQLSensor:         athena.NewRenderer(false, resultsLocation),
 					pipeline.AssetTypeDuckDBQuery:             duck.NewMaterializer(false),
+					pipeline.AssetTypeIcebergQuery:            duck.NewIcebergMaterializer(false),
 					pipeline.AssetTypeDuckDBQuerySensor:       duck.NewMaterializer(false),
 					pipeline.AssetTypeClickHouse:              clickhouse.NewRenderer(false),
 					pipeline.AssetTypeClickHouseQuerySensor:   clickhouse.NewRenderer(false),
 				},
+				grants: map[pipeline.AssetType]grantRenderer{
+					pipeline.AssetTypeBigqueryQuery:   bigquery.GrantQueries,
+					pipeline.AssetTypeSnowflakeQuery:  snowflake.GrantDialect.RenderGrants,
+					pipeline.AssetTypeRedshiftQuery:   postgres.GrantDialect.RenderGrants,
+					pipeline.AssetTypePostgresQuery:   postgres.GrantDialect.RenderGrants,
+					pipeline.AssetTypeDatabricksQuery: databricks.GrantDialect.RenderGrants,
+				},
 				builder: DefaultPipelineBuilder,
 				writer:  os.Stdout,
 				output:  c.String("output"),
@@ -276,3 +294,106 @@ func RenderDDL() *cli.Command {
 		},
 	}
 }
+
+// constraintDialects are the platforms whose table constraints can be migrated with --migrate.
+var constraintDialects = map[pipeline.AssetType]ansisql.ConstraintDialect{
+	pipeline.AssetTypePostgresQuery:   postgres.ConstraintDialect,
+	pipeline.AssetTypeSnowflakeQuery:  snowflake.ConstraintDialect,
+	pipeline.AssetTypeDatabricksQuery: databricks.ConstraintDialect,
+}
+
+// constraintMigrationUnsupportedReasons explain why the platforms that emit constraints in their
+// DDL cannot have them migrated on an existing table.
+var constraintMigrationUnsupportedReasons = map[pipeline.AssetType]string{
+	pipeline.AssetTypeBigqueryQuery: "BigQuery cannot add NOT NULL to an existing column",
+	pipeline.AssetTypeDuckDBQuery:   "DuckDB cannot add a foreign key to an existing table",
+	pipeline.AssetTypeMySQLQuery:    "MySQL can only change the nullability of a column by redefining its full type",
+}
+
+func constraintDialectFor(assetType pipeline.AssetType) (ansisql.ConstraintDialect, error) {
+	if dialect, ok := constraintDialects[assetType]; ok {
+		return dialect, nil
+	}
+	if reason, ok := constraintMigrationUnsupportedReasons[assetType]; ok {
+		return ansisql.ConstraintDialect{}, errors.Errorf("constraint migrations are not supported for '%s' assets, %s", assetType, reason)
+	}
+	return ansisql.ConstraintDialect{}, errors.Errorf("constraint migrations are not supported for '%s' assets", assetType)
+}
+
+func loadRenderDDLConfig(inputPath, configFilePath string) (*config.Config, error) {
+	if configFilePath == "" {
+		repoRoot, err := git.FindRepoFromPath(inputPath)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to find the git repository root")
+		}
+		configFilePath = path2.Join(repoRoot.Path, ".bruin.yml")
+	}
+
+	cm, err := config.LoadOrCreate(afero.NewOsFs(), configFilePath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to load the config file at '%s'", configFilePath)
+	}
+	return cm, nil
+}
+
+// renderConstraintMigration reads the constraints of the asset's existing table and prints the
+// statements that bring them in line with the asset definition, without running them.
+func renderConstraintMigration(ctx context.Context, c *cli.Command, inputPath string, pl *pipeline.Pipeline, asset *pipeline.Asset) error {
+	output := c.String("output")
+	dialect, err := constraintDialectFor(asset.Type)
+	if err != nil {
+		printError(err, output, "Failed to render the constraint migration:")
+		return cli.Exit("", 1)
+	}
+
+	cm, err := loadRenderDDLConfig(inputPath, c.String("config-file"))
+	if err != nil {
+		printError(err, output, "Failed to load the config file:")
+		return cli.Exit("", 1)
+	}
+
+	manager, err := buildOverrideManager(ctx, cm, c.String("environment"))
+	if err != nil {
+		printError(err, output, "Failed to create the connection manager:")
+		return cli.Exit("", 1)
+	}
+
+	connName, err := pl.GetConnectionNameForAsset(asset)
+	if err != nil {
+		printError(err, output, "Failed to get the connection name for the asset:")
+		return cli.Exit("", 1)
+	}
+
+	client, ok := manager.GetConnection(connName).(ansisql.MetadataClient)
+	if !ok {
+		printError(errors.Errorf("connection '%s' does not exist or cannot query the table", connName), output, "Failed to get the connection:")
+		return cli.Exit("", 1)
+	}
+
+	queries, err := dialect.RenderMigration(ctx, client, asset)
+	if err != nil {
+		printError(err, output, "Failed to render the constraint migration:")
+		return cli.Exit("", 1)
+	}
+
+	return writeConstraintMigration(os.Stdout, output, asset, queries)
+}
+
+func writeConstraintMigration(w io.Writer, output string, asset *pipeline.Asset, queries []string) error {
+	rendered := strings.Join(queries, "\n")
+	if len(queries) == 0 {
+		rendered = fmt.Sprintf("-- the constraints of '%s' are up to date", asset.Name)
+	}
+
+	if output == "json" {
+		js, err := json.Marshal(map[string]string{"query": rendered})
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(js)
+		return err
+	}
+
+	_, err := fmt.Fprintln(w, highlightCode(rendered, "sql"))
+	return err
+}
@@ -1,13 +1,16 @@
 package cmd
 
 import (
+	"bytes"
 	"testing"
 	"time"
 
 	"github.com/bruin-data/bruin/pkg/pipeline"
 	"github.com/bruin-data/bruin/pkg/query"
+	"github.com/bruin-data/bruin/pkg/snowflake"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 func TestRenderDDLCommand_Run(t *testing.T) { //nolint:paralleltest
@@ -165,6 +168,32 @@ func TestRenderDDLCommand_Run(t *testing.T) { //nolint:paralleltest
 			},
 			wantErr: assert.NoError,
 		},
+		{
+			name: "should append grants after the DDL",
+			args: args{
+				task: &pipeline.Asset{
+					Type: pipeline.AssetTypeSnowflakeQuery,
+					ExecutableFile: pipeline.ExecutableFile{
+						Path: "/path/to/executable2",
+					},
+					Name:            "snowflake-asset",
+					Materialization: pipeline.Materialization{Type: pipeline.MaterializationTypeTable},
+					Grants:          pipeline.Grants{"select": {"role_bi", "role_analyst"}},
+				},
+			},
+			setup: func(f *fields) {
+				f.extractor.On("ExtractQueriesFromString", snowflakeAsset.ExecutableFile.Content).
+					Return([]*query.Query{{Query: "SELECT * FROM sf_table"}}, nil)
+				f.sfMaterializer.On("Render", mock.Anything, "SELECT * FROM sf_table").
+					Return("CREATE TABLE snowflake-asset AS (SELECT * FROM sf_table)", nil)
+
+				f.writer.On("Write", []byte("CREATE TABLE snowflake-asset AS (SELECT * FROM sf_table);\n"+
+					"GRANT SELECT ON TABLE snowflake-asset TO ROLE role_bi;\n"+
+					"GRANT SELECT ON TABLE snowflake-asset TO ROLE role_analyst;\n")).
+					Return(0, nil)
+			},
+			wantErr: assert.NoError,
+		},
 		{
 			name: "should return raw SQL for unsupported platform (MSSQL)",
 			args: args{
@@ -206,6 +235,9 @@ func TestRenderDDLCommand_Run(t *testing.T) { //nolint:paralleltest
 					pipeline.AssetTypeBigqueryQuery:  f.bqMaterializer,
 					pipeline.AssetTypeSnowflakeQuery: f.sfMaterializer,
 				},
+				grants: map[pipeline.AssetType]grantRenderer{
+					pipeline.AssetTypeSnowflakeQuery: snowflake.GrantDialect.RenderGrants,
+				},
 				builder: f.builder,
 				writer:  f.writer,
 			}
@@ -226,3 +258,84 @@ func TestRenderDDLCommand_Run(t *testing.T) { //nolint:paralleltest
 		})
 	}
 }
+
+func TestWriteConstraintMigration(t *testing.T) {
+	t.Parallel()
+
+	asset := &pipeline.Asset{Name: "sales.orders"}
+
+	tests := []struct {
+		name    string
+		output  string
+		queries []string
+		want    string
+	}{
+		{
+			name:    "statements are printed one per line",
+			queries: []string{"ALTER TABLE a;", "ALTER TABLE b;"},
+			want:    "ALTER TABLE a;\nALTER TABLE b;\n",
+		},
+		{
+			name: "up to date tables are reported as a comment",
+			want: "-- the constraints of 'sales.orders' are up to date\n",
+		},
+		{
+			name:    "json output",
+			output:  "json",
+			queries: []string{"ALTER TABLE a;"},
+			want:    `{"query":"ALTER TABLE a;"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var buf bytes.Buffer
+			require.NoError(t, writeConstraintMigration(&buf, tt.output, asset, tt.queries))
+			assert.Equal(t, tt.want, buf.String())
+		})
+	}
+}
+
+func TestConstraintDialectFor(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		assetType pipeline.AssetType
+		wantErr   string
+	}{
+		{
+			name:      "snowflake is supported",
+			assetType: pipeline.AssetTypeSnowflakeQuery,
+		},
+		{
+			name:      "bigquery explains why it is not supported",
+			assetType: pipeline.AssetTypeBigqueryQuery,
+			wantErr:   "constraint migrations are not supported for 'bq.sql' assets, BigQuery cannot add NOT NULL to an existing column",
+		},
+		{
+			name:      "duckdb explains why it is not supported",
+			assetType: pipeline.AssetTypeDuckDBQuery,
+			wantErr:   "constraint migrations are not supported for 'duckdb.sql' assets, DuckDB cannot add a foreign key to an existing table",
+		},
+		{
+			name:      "other platforms are not supported",
+			assetType: pipeline.AssetTypePython,
+			wantErr:   "constraint migrations are not supported for 'python' assets",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			dialect, err := constraintDialectFor(tt.assetType)
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.NotNil(t, dialect.CurrentConstraints)
+		})
+	}
+}
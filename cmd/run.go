@@ -28,6 +28,7 @@ import (
 	"github.com/bruin-data/bruin/pkg/databricks"
 	dataprocserverless "github.com/bruin-data/bruin/pkg/dataproc_serverless"
 	"github.com/bruin-data/bruin/pkg/date"
+	"github.com/bruin-data/bruin/pkg/devenv"
 	"github.com/bruin-data/bruin/pkg/doris"
 	"github.com/bruin-data/bruin/pkg/dremio"
 	duck "github.com/bruin-data/bruin/pkg/duckdb"
@@ -57,6 +58,7 @@ import (
 	"github.com/bruin-data/bruin/pkg/scheduler"
 	"github.com/bruin-data/bruin/pkg/snowflake"
 	"github.com/bruin-data/bruin/pkg/spark"
+	"github.com/bruin-data/bruin/pkg/sqlite"
 	"github.com/bruin-data/bruin/pkg/sqlparser"
 	"github.com/bruin-data/bruin/pkg/starrocks"
 	"github.com/bruin-data/bruin/pkg/synapse"
@@ -255,6 +257,57 @@ func find(slice []scheduler.TaskInstance, item scheduler.TaskInstance) (int, boo
 	return -1, false
 }
 
+// newDeferTarget builds the environment that `--defer-to` resolves missing upstreams from.
+func newDeferTarget(cm *config.Config, name string) (*devenv.DeferTarget, error) {
+	if cm.SelectedEnvironment.SchemaPrefix == "" {
+		return nil, errors.Errorf("--defer-to requires a developer environment with a schema prefix, '%s' has none", cm.SelectedEnvironmentName)
+	}
+	if name == cm.SelectedEnvironmentName {
+		return nil, errors.Errorf("cannot defer to '%s', it is the environment being run", name)
+	}
+
+	env, ok := cm.Environments[name]
+	if !ok {
+		return nil, errors.Errorf("environment '%s' to defer to is not found in the configuration file", name)
+	}
+
+	schemaPrefix := env.SchemaPrefix
+	if schemaPrefix != "" && !strings.HasSuffix(schemaPrefix, "_") {
+		schemaPrefix += "_"
+	}
+
+	databases := make(map[string]string)
+	if env.Connections != nil {
+		for connName := range env.Connections.ConnectionsSummaryList() {
+			if database := env.Connections.DatabaseName(connName); database != "" {
+				databases[connName] = database
+			}
+		}
+	}
+
+	return &devenv.DeferTarget{
+		Environment:  name,
+		SchemaPrefix: schemaPrefix,
+		Databases:    databases,
+	}, nil
+}
+
+func printDeferredReferences(deferTarget *devenv.DeferTarget) {
+	if deferTarget == nil {
+		return
+	}
+
+	deferred := deferTarget.Deferred()
+	if len(deferred) == 0 {
+		return
+	}
+
+	summaryPrinter.Printf("\n Deferred references to '%s':\n", deferTarget.Environment)
+	for _, reference := range deferred {
+		summaryPrinter.Printf("   %s: %s -> %s\n", reference.Asset, reference.Reference, reference.Target)
+	}
+}
+
 func printExecutionSummary(results []*scheduler.TaskExecutionResult, s *scheduler.Scheduler, duration time.Duration, _ int) {
 	summary := analyzeResults(results, s)
 	summary.Duration = duration
@@ -591,6 +644,10 @@ func Run(isDebug *bool) *cli.Command {
 				Aliases: []string{"e", "env"},
 				Usage:   "the environment to use",
 			},
+			&cli.StringFlag{
+				Name:  "defer-to",
+				Usage: "read upstreams that are not built in the developer environment from the given environment instead",
+			},
 			&cli.BoolFlag{
 				Name:  "push-metadata",
 				Usage: "push the metadata to the destination database if supports, currently supported: BigQuery",
@@ -847,6 +904,15 @@ func Run(isDebug *bool) *cli.Command {
 			runCtx = context.WithValue(runCtx, python.LocalIngestr, c.String("debug-ingestr-src"))
 			runCtx = context.WithValue(runCtx, config.EnvironmentContextKey, cm.SelectedEnvironment)
 			runCtx = context.WithValue(runCtx, config.EnvironmentNameContextKey, cm.SelectedEnvironmentName)
+			var deferTarget *devenv.DeferTarget
+			if deferTo := c.String("defer-to"); deferTo != "" {
+				deferTarget, err = newDeferTarget(cm, deferTo)
+				if err != nil {
+					errorPrinter.Println(err.Error())
+					return cli.Exit("", 1)
+				}
+				runCtx = context.WithValue(runCtx, devenv.DeferTargetContextKey, deferTarget)
+			}
 			runCtx = context.WithValue(runCtx, config.ConfigFilePathContextKey, configFilePath)
 			runCtx = context.WithValue(runCtx, pipeline.RunConfigRunID, runID)
 			runCtx = context.WithValue(runCtx, pipeline.RunConfigFullRefresh, runConfig.FullRefresh)
@@ -953,6 +1019,13 @@ func Run(isDebug *bool) *cli.Command {
 				return cli.Exit("", 1)
 			}
 
+			err = git.EnsureGivenPatternIsInGitignore(afero.NewOsFs(), repoRoot.Path, "logs/microbatch")
+			if err != nil {
+				errorPrinter.Printf("Failed to add the microbatch state folder to .gitignore: %v\n", err)
+				return cli.Exit("", 1)
+			}
+			runCtx = context.WithValue(runCtx, pipeline.RunConfigMicrobatchStatePath, filepath.Join(repoRoot.Path, "logs/microbatch", preview.Pipeline.Name))
+
 			// Initialize selectedAssets early (will be populated later if multiple assets are specified)
 			var selectedAssets []*pipeline.Asset
 
@@ -1493,6 +1566,7 @@ func Run(isDebug *bool) *cli.Command {
 
 				// Also print summary to piped stdout (for log file)
 				printExecutionSummary(results, s, duration, len(results))
+				printDeferredReferences(deferTarget)
 				if len(errorsInTaskResults) > 0 {
 					if singleCheckID != "" {
 						printSingleCheckError(errorsInTaskResults[0])
@@ -1548,6 +1622,7 @@ func Run(isDebug *bool) *cli.Command {
 						printErrorsMinimal(errorsInTaskResults)
 					} else {
 						printExecutionSummary(results, s, duration, len(results))
+						printDeferredReferences(deferTarget)
 						printErrorsInResults(errorsInTaskResults, s)
 					}
 					return cli.Exit("", 1)
@@ -1564,6 +1639,7 @@ func Run(isDebug *bool) *cli.Command {
 					summaryPrinter.Printf("\n\nExecuted %d tasks in %s\n", len(results), duration.Truncate(time.Millisecond).String())
 				} else {
 					printExecutionSummary(results, s, duration, len(results))
+					printDeferredReferences(deferTarget)
 				}
 			}
 			return nil
@@ -1922,19 +1998,23 @@ type databricksExecutorSet struct {
 	seed        executor.Operator
 	querySensor executor.Operator
 	tableSensor executor.Operator
+	metaPush    executor.Operator
 }
 
 func registerDatabricksExecutors(executors map[pipeline.AssetType]executor.Config, set databricksExecutorSet, registerPythonChecks bool) {
 	executors[pipeline.AssetTypeDatabricksQuery][scheduler.TaskInstanceTypeMain] = set.main
 	executors[pipeline.AssetTypeDatabricksQuery][scheduler.TaskInstanceTypeColumnCheck] = set.check
 	executors[pipeline.AssetTypeDatabricksQuery][scheduler.TaskInstanceTypeCustomCheck] = set.customCheck
+	executors[pipeline.AssetTypeDatabricksQuery][scheduler.TaskInstanceTypeMetadataPush] = set.metaPush
 
 	executors[pipeline.AssetTypeDatabricksSeed][scheduler.TaskInstanceTypeMain] = set.seed
 	executors[pipeline.AssetTypeDatabricksSeed][scheduler.TaskInstanceTypeColumnCheck] = set.check
 	executors[pipeline.AssetTypeDatabricksSeed][scheduler.TaskInstanceTypeCustomCheck] = set.customCheck
+	executors[pipeline.AssetTypeDatabricksSeed][scheduler.TaskInstanceTypeMetadataPush] = set.metaPush
 
 	executors[pipeline.AssetTypeDatabricksSource][scheduler.TaskInstanceTypeColumnCheck] = set.check
 	executors[pipeline.AssetTypeDatabricksSource][scheduler.TaskInstanceTypeCustomCheck] = set.customCheck
+	executors[pipeline.AssetTypeDatabricksSource][scheduler.TaskInstanceTypeMetadataPush] = set.metaPush
 
 	executors[pipeline.AssetTypeDatabricksQuerySensor][scheduler.TaskInstanceTypeMain] = set.querySensor
 	executors[pipeline.AssetTypeDatabricksQuerySensor][scheduler.TaskInstanceTypeColumnCheck] = set.check
@@ -1947,6 +2027,7 @@ func registerDatabricksExecutors(executors map[pipeline.AssetType]executor.Confi
 	if registerPythonChecks {
 		executors[pipeline.AssetTypePython][scheduler.TaskInstanceTypeColumnCheck] = set.check
 		executors[pipeline.AssetTypePython][scheduler.TaskInstanceTypeCustomCheck] = set.customCheck
+		executors[pipeline.AssetTypePython][scheduler.TaskInstanceTypeMetadataPush] = set.metaPush
 	}
 }
 
@@ -2370,6 +2451,33 @@ func SetupExecutors(
 		}
 	}
 
+	if s.WillRunTaskOfType(pipeline.AssetTypeSQLiteQuery) || estimateCustomCheckType == pipeline.AssetTypeSQLiteQuery ||
+		s.WillRunTaskOfType(pipeline.AssetTypeSQLiteSeed) || s.WillRunTaskOfType(pipeline.AssetTypeSQLiteQuerySensor) {
+		sqliteOperator := sqlite.NewBasicOperator(conn, wholeFileExtractor, pipeline.HookWrapperMaterializer{
+			Mat:     sqlite.NewMaterializer(fullRefresh),
+			Hoister: hoister,
+		})
+		sqliteCheckRunner := sqlite.NewColumnCheckOperator(conn)
+		sqliteQuerySensor := ansisql.NewQuerySensor(conn, wholeFileExtractor, sensorMode)
+
+		mainExecutors[pipeline.AssetTypeSQLiteQuery][scheduler.TaskInstanceTypeMain] = sqliteOperator
+		mainExecutors[pipeline.AssetTypeSQLiteQuery][scheduler.TaskInstanceTypeColumnCheck] = sqliteCheckRunner
+		mainExecutors[pipeline.AssetTypeSQLiteQuery][scheduler.TaskInstanceTypeCustomCheck] = customCheckRunner
+
+		mainExecutors[pipeline.AssetTypeSQLiteSeed][scheduler.TaskInstanceTypeMain] = seedOperator
+		mainExecutors[pipeline.AssetTypeSQLiteSeed][scheduler.TaskInstanceTypeColumnCheck] = sqliteCheckRunner
+		mainExecutors[pipeline.AssetTypeSQLiteSeed][scheduler.TaskInstanceTypeCustomCheck] = customCheckRunner
+
+		mainExecutors[pipeline.AssetTypeSQLiteQuerySensor][scheduler.TaskInstanceTypeMain] = sqliteQuerySensor
+		mainExecutors[pipeline.AssetTypeSQLiteQuerySensor][scheduler.TaskInstanceTypeColumnCheck] = sqliteCheckRunner
+		mainExecutors[pipeline.AssetTypeSQLiteQuerySensor][scheduler.TaskInstanceTypeCustomCheck] = customCheckRunner
+
+		if estimateCustomCheckType == pipeline.AssetTypeSQLiteQuery {
+			mainExecutors[pipeline.AssetTypePython][scheduler.TaskInstanceTypeColumnCheck] = sqliteCheckRunner
+			mainExecutors[pipeline.AssetTypePython][scheduler.TaskInstanceTypeCustomCheck] = customCheckRunner
+		}
+	}
+
 	//nolint: dupl
 	if s.WillRunTaskOfType(pipeline.AssetTypeFabricQuery) || s.WillRunTaskOfType(pipeline.AssetTypeFabricQueryLegacy) ||
 		estimateCustomCheckType == pipeline.AssetTypeFabricQuery || estimateCustomCheckType == pipeline.AssetTypeFabricQueryLegacy ||
@@ -2439,6 +2547,7 @@ func SetupExecutors(
 			seed:        seedOperator,
 			querySensor: databricksQuerySensor,
 			tableSensor: databricksTableSensor,
+			metaPush:    databricks.NewMetadataPushOperator(conn),
 		}, estimateCustomCheckType == pipeline.AssetTypeDatabricksQuery)
 	}
 
@@ -2499,14 +2608,17 @@ func SetupExecutors(
 		}, parser)
 		duckDBCheckRunner := duck.NewColumnCheckOperator(conn)
 		duckDBQuerySensor := ansisql.NewQuerySensor(conn, wholeFileExtractor, sensorMode)
+		duckDBMetadataPushOperator := duck.NewMetadataPushOperator(conn)
 
 		mainExecutors[pipeline.AssetTypeDuckDBQuery][scheduler.TaskInstanceTypeMain] = duckDBOperator
 		mainExecutors[pipeline.AssetTypeDuckDBQuery][scheduler.TaskInstanceTypeColumnCheck] = duckDBCheckRunner
 		mainExecutors[pipeline.AssetTypeDuckDBQuery][scheduler.TaskInstanceTypeCustomCheck] = customCheckRunner
+		mainExecutors[pipeline.AssetTypeDuckDBQuery][scheduler.TaskInstanceTypeMetadataPush] = duckDBMetadataPushOperator
 
 		mainExecutors[pipeline.AssetTypeDuckDBSeed][scheduler.TaskInstanceTypeMain] = seedOperator
 		mainExecutors[pipeline.AssetTypeDuckDBSeed][scheduler.TaskInstanceTypeColumnCheck] = duckDBCheckRunner
 		mainExecutors[pipeline.AssetTypeDuckDBSeed][scheduler.TaskInstanceTypeCustomCheck] = customCheckRunner
+		mainExecutors[pipeline.AssetTypeDuckDBSeed][scheduler.TaskInstanceTypeMetadataPush] = duckDBMetadataPushOperator
 
 		mainExecutors[pipeline.AssetTypeDuckDBQuerySensor][scheduler.TaskInstanceTypeMain] = duckDBQuerySensor
 		mainExecutors[pipeline.AssetTypeDuckDBQuerySensor][scheduler.TaskInstanceTypeColumnCheck] = duckDBCheckRunner
@@ -2515,6 +2627,27 @@ func SetupExecutors(
 		if estimateCustomCheckType == pipeline.AssetTypeDuckDBQuery {
 			mainExecutors[pipeline.AssetTypePython][scheduler.TaskInstanceTypeColumnCheck] = duckDBCheckRunner
 			mainExecutors[pipeline.AssetTypePython][scheduler.TaskInstanceTypeCustomCheck] = customCheckRunner
+			mainExecutors[pipeline.AssetTypePython][scheduler.TaskInstanceTypeMetadataPush] = duckDBMetadataPushOperator
+		}
+	}
+
+	if s.WillRunTaskOfType(pipeline.AssetTypeIcebergQuery) || estimateCustomCheckType == pipeline.AssetTypeIcebergQuery {
+		// iceberg.sql assets run on an in-memory DuckDB database that attaches the Iceberg catalog.
+		icebergConn := duck.NewIcebergConnections(conn)
+		icebergOperator := duck.NewBasicOperator(icebergConn, wholeFileExtractor, pipeline.HookWrapperMaterializer{
+			Mat:     duck.NewIcebergMaterializer(fullRefresh),
+			Hoister: hoister,
+		}, parser)
+		icebergCheckRunner := duck.NewColumnCheckOperator(icebergConn)
+		icebergCustomCheckRunner := ansisql.NewCustomCheckOperator(icebergConn, renderer)
+
+		mainExecutors[pipeline.AssetTypeIcebergQuery][scheduler.TaskInstanceTypeMain] = icebergOperator
+		mainExecutors[pipeline.AssetTypeIcebergQuery][scheduler.TaskInstanceTypeColumnCheck] = icebergCheckRunner
+		mainExecutors[pipeline.AssetTypeIcebergQuery][scheduler.TaskInstanceTypeCustomCheck] = icebergCustomCheckRunner
+
+		if estimateCustomCheckType == pipeline.AssetTypeIcebergQuery {
+			mainExecutors[pipeline.AssetTypePython][scheduler.TaskInstanceTypeColumnCheck] = icebergCheckRunner
+			mainExecutors[pipeline.AssetTypePython][scheduler.TaskInstanceTypeCustomCheck] = icebergCustomCheckRunner
 		}
 	}
 
@@ -2528,14 +2661,17 @@ func SetupExecutors(
 		checkRunner := clickhouse.NewColumnCheckOperator(conn)
 		clickHouseQuerySensor := ansisql.NewQuerySensor(conn, wholeFileExtractor, sensorMode)
 		clickHouseTableSensor := ansisql.NewTableSensor(conn, sensorMode, wholeFileExtractor)
+		clickHouseMetadataPushOperator := clickhouse.NewMetadataPushOperator(conn)
 
 		mainExecutors[pipeline.AssetTypeClickHouse][scheduler.TaskInstanceTypeMain] = clickHouseOperator
 		mainExecutors[pipeline.AssetTypeClickHouse][scheduler.TaskInstanceTypeColumnCheck] = checkRunner
 		mainExecutors[pipeline.AssetTypeClickHouse][scheduler.TaskInstanceTypeCustomCheck] = customCheckRunner
+		mainExecutors[pipeline.AssetTypeClickHouse][scheduler.TaskInstanceTypeMetadataPush] = clickHouseMetadataPushOperator
 
 		mainExecutors[pipeline.AssetTypeClickHouseSeed][scheduler.TaskInstanceTypeMain] = seedOperator
 		mainExecutors[pipeline.AssetTypeClickHouseSeed][scheduler.TaskInstanceTypeColumnCheck] = checkRunner
 		mainExecutors[pipeline.AssetTypeClickHouseSeed][scheduler.TaskInstanceTypeCustomCheck] = customCheckRunner
+		mainExecutors[pipeline.AssetTypeClickHouseSeed][scheduler.TaskInstanceTypeMetadataPush] = clickHouseMetadataPushOperator
 
 		mainExecutors[pipeline.AssetTypeClickHouseQuerySensor][scheduler.TaskInstanceTypeMain] = clickHouseQuerySensor
 		mainExecutors[pipeline.AssetTypeClickHouseQuerySensor][scheduler.TaskInstanceTypeColumnCheck] = checkRunner
@@ -2548,6 +2684,7 @@ func SetupExecutors(
 		if estimateCustomCheckType == pipeline.AssetTypeClickHouse {
 			mainExecutors[pipeline.AssetTypePython][scheduler.TaskInstanceTypeColumnCheck] = checkRunner
 			mainExecutors[pipeline.AssetTypePython][scheduler.TaskInstanceTypeCustomCheck] = customCheckRunner
+			mainExecutors[pipeline.AssetTypePython][scheduler.TaskInstanceTypeMetadataPush] = clickHouseMetadataPushOperator
 		}
 	}
 
@@ -2627,14 +2764,17 @@ func SetupExecutors(
 		mysqlCheckRunner := mysql.NewColumnCheckOperator(conn)
 		mysqlQuerySensor := ansisql.NewQuerySensor(conn, wholeFileExtractor, sensorMode)
 		mysqlTableSensor := ansisql.NewTableSensor(conn, sensorMode, wholeFileExtractor)
+		mysqlMetadataPushOperator := mysql.NewMetadataPushOperator(conn)
 
 		mainExecutors[pipeline.AssetTypeMySQLQuery][scheduler.TaskInstanceTypeMain] = mysqlOperator
 		mainExecutors[pipeline.AssetTypeMySQLQuery][scheduler.TaskInstanceTypeColumnCheck] = mysqlCheckRunner
 		mainExecutors[pipeline.AssetTypeMySQLQuery][scheduler.TaskInstanceTypeCustomCheck] = customCheckRunner
+		mainExecutors[pipeline.AssetTypeMySQLQuery][scheduler.TaskInstanceTypeMetadataPush] = mysqlMetadataPushOperator
 
 		mainExecutors[pipeline.AssetTypeMySQLSeed][scheduler.TaskInstanceTypeMain] = seedOperator
 		mainExecutors[pipeline.AssetTypeMySQLSeed][scheduler.TaskInstanceTypeColumnCheck] = mysqlCheckRunner
 		mainExecutors[pipeline.AssetTypeMySQLSeed][scheduler.TaskInstanceTypeCustomCheck] = customCheckRunner
+		mainExecutors[pipeline.AssetTypeMySQLSeed][scheduler.TaskInstanceTypeMetadataPush] = mysqlMetadataPushOperator
 
 		mainExecutors[pipeline.AssetTypeMySQLQuerySensor][scheduler.TaskInstanceTypeMain] = mysqlQuerySensor
 		mainExecutors[pipeline.AssetTypeMySQLQuerySensor][scheduler.TaskInstanceTypeColumnCheck] = mysqlCheckRunner
@@ -2706,6 +2846,13 @@ func SetupExecutors(
 		mainExecutors[pipeline.AssetTypeAgentClaudeCode][scheduler.TaskInstanceTypeMain] = claudeCodeOperator
 	}
 
+	// R assets materialize into the same destinations as Python assets, their checks run the same way.
+	for _, checkType := range []scheduler.TaskInstanceType{scheduler.TaskInstanceTypeColumnCheck, scheduler.TaskInstanceTypeCustomCheck} {
+		if checkOperator, ok := mainExecutors[pipeline.AssetTypePython][checkType]; ok {
+			mainExecutors[pipeline.AssetTypeR][checkType] = checkOperator
+		}
+	}
+
 	return mainExecutors, nil
 }
 
@@ -50,6 +50,7 @@ func TestRegisterDatabricksExecutors(t *testing.T) {
 		seed:        &registrationTestOperator{},
 		querySensor: &registrationTestOperator{},
 		tableSensor: &registrationTestOperator{},
+		metaPush:    &registrationTestOperator{},
 	}
 	registerDatabricksExecutors(configs, set, true)
 
@@ -57,6 +58,8 @@ func TestRegisterDatabricksExecutors(t *testing.T) {
 	assert.Same(t, set.seed, configs[pipeline.AssetTypeDatabricksSeed][scheduler.TaskInstanceTypeMain])
 	assert.Same(t, set.querySensor, configs[pipeline.AssetTypeDatabricksQuerySensor][scheduler.TaskInstanceTypeMain])
 	assert.Same(t, set.tableSensor, configs[pipeline.AssetTypeDatabricksTableSensor][scheduler.TaskInstanceTypeMain])
+	assert.Same(t, set.metaPush, configs[pipeline.AssetTypeDatabricksQuery][scheduler.TaskInstanceTypeMetadataPush])
+	assert.Same(t, set.metaPush, configs[pipeline.AssetTypeDatabricksSeed][scheduler.TaskInstanceTypeMetadataPush])
 
 	for _, assetType := range []pipeline.AssetType{
 		pipeline.AssetTypeDatabricksQuery,
@@ -200,27 +200,34 @@ func assetColumnSchemas(pl *pipeline.Pipeline) map[string][]pipeline.Column {
 // resolveConnectionManager loads the project config and builds the connection
 // manager used to resolve a SQL asset's connection.
 func resolveConnectionManager(ctx context.Context, pipelinePath, env string) (config.ConnectionAndDetailsGetter, error) {
+	_, manager, err := resolveEnvironmentConnections(ctx, pipelinePath, env)
+	return manager, err
+}
+
+// resolveEnvironmentConnections loads the config of the repository with the given environment
+// selected, and returns it together with the connection manager of that environment.
+func resolveEnvironmentConnections(ctx context.Context, pipelinePath, env string) (*config.Config, config.ConnectionAndDetailsGetter, error) {
 	repoRoot, err := git.FindRepoFromPath(pipelinePath)
 	if err != nil {
-		return nil, fmt.Errorf("failed to find the git repository root: %w", err)
+		return nil, nil, fmt.Errorf("failed to find the git repository root: %w", err)
 	}
 	configFilePath := filepath.Join(repoRoot.Path, ".bruin.yml")
 	cm, err := config.LoadOrCreate(afero.NewOsFs(), configFilePath)
 	if err != nil {
-		return nil, fmt.Errorf("failed to load the config: %w", err)
+		return nil, nil, fmt.Errorf("failed to load the config: %w", err)
 	}
 	if env != "" {
 		if err := cm.SelectEnvironment(env); err != nil {
-			return nil, fmt.Errorf("failed to use the environment %q: %w", env, err)
+			return nil, nil, fmt.Errorf("failed to use the environment %q: %w", env, err)
 		}
 	}
 	ctx = context.WithValue(ctx, config.ConfigFilePathContextKey, configFilePath)
 	ctx = context.WithValue(ctx, config.EnvironmentNameContextKey, cm.SelectedEnvironmentName)
 	manager, errs := connectionManagerFromConfig(ctx, cm, makeLogger(false))
 	if len(errs) > 0 {
-		return nil, errs[0]
+		return nil, nil, errs[0]
 	}
-	return manager, nil
+	return cm, manager, nil
 }
 
 // runAssetUnitTests runs an asset's unit tests against its configured connection.
@@ -258,6 +258,7 @@ j=d.createElement(s),dl=l!='dataLayer'?'&l='+l:'';j.async=true;j.src=
                             {text: "Postgres", link: "/platforms/postgres"},
                             {text: "Redshift", link: "/platforms/redshift"},
                             {text: "Snowflake", link: "/platforms/snowflake"},
+                            {text: "SQLite", link: "/platforms/sqlite"},
                             {text: "StarRocks", link: "/platforms/starrocks"},
                             {text: "Synapse", link: "/platforms/synapse"},
                             {text: "S3", link: "/platforms/s3"},
@@ -407,12 +408,17 @@ j=d.createElement(s),dl=l!='dataLayer'?'&l='+l:'';j.async=true;j.src=
                     {text: "AI Skills", link: "/commands/ai-skills"},
                     {text: "Clean", link: "/commands/clean"},
                     {text: "Connections", link: "/commands/connections"},
+                    {text: "Contract", link: "/commands/contract"},
                     {text: "Curl", link: "/commands/curl"},
                     {text: "Data Diff", link: "/commands/data-diff"},
+                    {text: "Docs", link: "/commands/docs"},
                     {text: "Environments", link: "/commands/environments"},
+                    {text: "Export", link: "/commands/export"},
                     {text: "Format", link: "/commands/format"},
                     {text: "Import", link: "/commands/import"},
                     {text: "Lineage", link: "/commands/lineage"},
+                    {text: "Migrate Dialect", link: "/commands/migrate-dialect"},
+                    {text: "Owners", link: "/commands/owners"},
                     {text: "Patch", link: "/commands/patch"},
                     {text: "Render", link: "/commands/render"},
                     {text: "Query", link: "/commands/query"},
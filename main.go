@@ -69,8 +69,12 @@ func main() {
 			cmd.Lineage(),
 			cmd.CleanCmd(),
 			cmd.Format(&isDebug),
+			cmd.MigrateDialect(),
 			cmd.AI(&isDebug),
 			cmd.Docs(),
+			cmd.Export(),
+			cmd.Contract(),
+			cmd.Owners(),
 			cmd.Init(),
 			cmd.Internal(),
 			cmd.Environments(&isDebug),
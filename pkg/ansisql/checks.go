@@ -82,7 +82,10 @@ func (c *CountableQueryCheck) CustomCheck(ctx context.Context, ti *scheduler.Cus
 }
 
 func (c *CountableQueryCheck) check(ctx context.Context, connectionName string) error {
-	q := c.conn.GetConnection(connectionName)
+	q, err := config.ResolveConnection(c.conn, connectionName)
+	if err != nil {
+		return err
+	}
 	if q == nil {
 		return config.NewConnectionNotFoundError(ctx, "", connectionName)
 	}
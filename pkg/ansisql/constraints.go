@@ -0,0 +1,223 @@
+package ansisql
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/bruin-data/bruin/pkg/query"
+	"github.com/pkg/errors"
+)
+
+// ForeignKey is a single-column foreign key, the way they are defined on asset columns.
+type ForeignKey struct {
+	Name      string
+	Column    string
+	Table     string
+	RefColumn string
+}
+
+// TableConstraints are the primary key, not-null and foreign key constraints of a table.
+type TableConstraints struct {
+	PrimaryKeyName string
+	PrimaryKey     []string
+	NotNull        []string
+	ForeignKeys    []ForeignKey
+}
+
+// ConstraintDialect describes how a SQL platform reads and changes the constraints of a table,
+// it is used to migrate the constraints of existing tables to the ones defined on the asset.
+type ConstraintDialect struct {
+	// CurrentConstraints returns the constraints currently defined on the asset's table.
+	CurrentConstraints func(ctx context.Context, conn MetadataClient, asset *pipeline.Asset) (*TableConstraints, error)
+
+	// PrimaryKeyRequiresNotNull is set for the platforms that reject primary keys on nullable
+	// columns, the columns are made NOT NULL before the primary key is added.
+	PrimaryKeyRequiresNotNull bool
+
+	AddPrimaryKey  func(asset *pipeline.Asset, columns []string) string
+	DropPrimaryKey func(asset *pipeline.Asset, name string) string
+	SetNotNull     func(asset *pipeline.Asset, column string) string
+	DropNotNull    func(asset *pipeline.Asset, column string) string
+	AddForeignKey  func(asset *pipeline.Asset, key ForeignKey) string
+	DropForeignKey func(asset *pipeline.Asset, key ForeignKey) string
+}
+
+// AssetConstraints returns the constraints defined on the columns of the asset.
+func AssetConstraints(asset *pipeline.Asset) *TableConstraints {
+	constraints := &TableConstraints{
+		PrimaryKey: asset.ColumnNamesWithPrimaryKey(),
+	}
+	for _, column := range asset.Columns {
+		if column.IsNotNull() {
+			constraints.NotNull = append(constraints.NotNull, column.Name)
+		}
+		if column.HasForeignKey() {
+			constraints.ForeignKeys = append(constraints.ForeignKeys, ForeignKey{
+				Column:    column.Name,
+				Table:     column.ForeignKey.Table,
+				RefColumn: column.ForeignKey.Column,
+			})
+		}
+	}
+	return constraints
+}
+
+// MigrationQueries returns the statements that bring the constraints of the table in line with
+// the asset definition. Constraints are only dropped when the asset defines a different one in
+// their place: the primary key is replaced when the asset defines other columns, a foreign key
+// is replaced when the column references another table, and NOT NULL is only dropped from the
+// columns that are explicitly `nullable: true`.
+func (d ConstraintDialect) MigrationQueries(asset *pipeline.Asset, current *TableConstraints) []string {
+	wanted := AssetConstraints(asset)
+	queries := make([]string, 0)
+
+	currentKeys := make(map[string]ForeignKey, len(current.ForeignKeys))
+	for _, key := range current.ForeignKeys {
+		currentKeys[strings.ToLower(key.Column)] = key
+	}
+	missingKeys := make([]ForeignKey, 0)
+	for _, key := range wanted.ForeignKeys {
+		existing, ok := currentKeys[strings.ToLower(key.Column)]
+		if ok && sameForeignKey(existing, key) {
+			continue
+		}
+		if ok {
+			queries = append(queries, d.DropForeignKey(asset, existing))
+		}
+		missingKeys = append(missingKeys, key)
+	}
+
+	replacePrimaryKey := len(wanted.PrimaryKey) > 0 && !sameColumns(current.PrimaryKey, wanted.PrimaryKey)
+	if replacePrimaryKey && len(current.PrimaryKey) > 0 {
+		queries = append(queries, d.DropPrimaryKey(asset, current.PrimaryKeyName))
+	}
+
+	notNull := make(map[string]bool, len(current.NotNull))
+	for _, column := range current.NotNull {
+		notNull[strings.ToLower(column)] = true
+	}
+	primaryKey := make(map[string]bool, len(wanted.PrimaryKey))
+	for _, column := range wanted.PrimaryKey {
+		primaryKey[strings.ToLower(column)] = true
+	}
+	for _, column := range asset.Columns {
+		name := strings.ToLower(column.Name)
+		requiredByKey := d.PrimaryKeyRequiresNotNull && replacePrimaryKey && primaryKey[name]
+		switch {
+		case (column.IsNotNull() || requiredByKey) && !notNull[name]:
+			queries = append(queries, d.SetNotNull(asset, column.Name))
+		case column.IsExplicitlyNullable() && notNull[name] && !primaryKey[name]:
+			queries = append(queries, d.DropNotNull(asset, column.Name))
+		}
+	}
+
+	if replacePrimaryKey {
+		queries = append(queries, d.AddPrimaryKey(asset, wanted.PrimaryKey))
+	}
+	for _, key := range missingKeys {
+		queries = append(queries, d.AddForeignKey(asset, key))
+	}
+
+	return queries
+}
+
+// RenderMigration reads the constraints of the asset's table and returns the statements that
+// migrate them to the asset definition, without running them.
+func (d ConstraintDialect) RenderMigration(ctx context.Context, conn MetadataClient, asset *pipeline.Asset) ([]string, error) {
+	current, err := d.CurrentConstraints(ctx, conn, asset)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get the current constraints of '%s'", asset.Name)
+	}
+	return d.MigrationQueries(asset, current), nil
+}
+
+// QueryTableConstraints runs a query that returns one row per constrained column, in the form of
+// (kind, constraint name, column, referenced table, referenced column) where kind is one of
+// `primary_key`, `foreign_key` or `not_null`. Primary key columns must be ordered by their
+// position in the key.
+func QueryTableConstraints(ctx context.Context, conn MetadataClient, q string) (*TableConstraints, error) {
+	rows, err := conn.Select(ctx, &query.Query{Query: q})
+	if err != nil {
+		return nil, err
+	}
+
+	constraints := &TableConstraints{}
+	for _, row := range rows {
+		if len(row) < 3 {
+			continue
+		}
+		name, column := stringValue(row[1]), stringValue(row[2])
+		switch stringValue(row[0]) {
+		case "primary_key":
+			constraints.PrimaryKeyName = name
+			constraints.PrimaryKey = append(constraints.PrimaryKey, column)
+		case "not_null":
+			constraints.NotNull = append(constraints.NotNull, column)
+		case "foreign_key":
+			if len(row) < 5 {
+				continue
+			}
+			constraints.ForeignKeys = append(constraints.ForeignKeys, ForeignKey{
+				Name:      name,
+				Column:    column,
+				Table:     stringValue(row[3]),
+				RefColumn: stringValue(row[4]),
+			})
+		}
+	}
+	return constraints, nil
+}
+
+// InformationSchemaConstraintsQuery returns the query that lists the constraints of a table from
+// the standard information_schema views, in the form expected by QueryTableConstraints.
+func InformationSchemaConstraintsQuery(informationSchema, schema, table string) string {
+	filter := fmt.Sprintf("tc.table_schema = %s AND tc.table_name = %s", QuoteLiteral(schema), QuoteLiteral(table))
+	return fmt.Sprintf(`SELECT 'primary_key' AS kind, tc.constraint_name, kcu.column_name, '' AS ref_table, '' AS ref_column, kcu.ordinal_position
+FROM %[1]s.table_constraints tc
+JOIN %[1]s.key_column_usage kcu ON tc.constraint_schema = kcu.constraint_schema AND tc.constraint_name = kcu.constraint_name
+WHERE tc.constraint_type = 'PRIMARY KEY' AND %[2]s
+UNION ALL
+SELECT 'foreign_key' AS kind, tc.constraint_name, kcu.column_name, concat_ws('.', ccu.table_schema, ccu.table_name) AS ref_table, ccu.column_name AS ref_column, kcu.ordinal_position
+FROM %[1]s.table_constraints tc
+JOIN %[1]s.key_column_usage kcu ON tc.constraint_schema = kcu.constraint_schema AND tc.constraint_name = kcu.constraint_name
+JOIN %[1]s.constraint_column_usage ccu ON tc.constraint_schema = ccu.constraint_schema AND tc.constraint_name = ccu.constraint_name
+WHERE tc.constraint_type = 'FOREIGN KEY' AND %[2]s
+UNION ALL
+SELECT 'not_null' AS kind, '' AS constraint_name, tc.column_name, '' AS ref_table, '' AS ref_column, tc.ordinal_position
+FROM %[1]s.columns tc
+WHERE tc.is_nullable = 'NO' AND %[2]s
+ORDER BY kind, ordinal_position`, informationSchema, filter)
+}
+
+func sameColumns(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !strings.EqualFold(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// sameForeignKey compares an existing foreign key with the one in the asset definition, the
+// referenced table of the asset may be qualified only partially.
+func sameForeignKey(existing, defined ForeignKey) bool {
+	if !strings.EqualFold(existing.RefColumn, defined.RefColumn) {
+		return false
+	}
+	existingParts := strings.Split(strings.ToLower(existing.Table), ".")
+	definedParts := strings.Split(strings.ToLower(defined.Table), ".")
+	if len(definedParts) > len(existingParts) {
+		return false
+	}
+	for i := 1; i <= len(definedParts); i++ {
+		if strings.Trim(existingParts[len(existingParts)-i], `"`+"`") != strings.Trim(definedParts[len(definedParts)-i], `"`+"`") {
+			return false
+		}
+	}
+	return true
+}
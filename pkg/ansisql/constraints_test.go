@@ -0,0 +1,161 @@
+package ansisql
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/bruin-data/bruin/pkg/query"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var testConstraintDialect = ConstraintDialect{
+	AddPrimaryKey: func(asset *pipeline.Asset, columns []string) string {
+		return fmt.Sprintf("ADD PK %s (%s)", asset.Name, strings.Join(columns, ", "))
+	},
+	DropPrimaryKey: func(asset *pipeline.Asset, name string) string {
+		return fmt.Sprintf("DROP PK %s %s", asset.Name, name)
+	},
+	SetNotNull: func(asset *pipeline.Asset, column string) string {
+		return fmt.Sprintf("SET NOT NULL %s.%s", asset.Name, column)
+	},
+	DropNotNull: func(asset *pipeline.Asset, column string) string {
+		return fmt.Sprintf("DROP NOT NULL %s.%s", asset.Name, column)
+	},
+	AddForeignKey: func(asset *pipeline.Asset, key ForeignKey) string {
+		return fmt.Sprintf("ADD FK %s (%s) -> %s (%s)", asset.Name, key.Column, key.Table, key.RefColumn)
+	},
+	DropForeignKey: func(asset *pipeline.Asset, key ForeignKey) string {
+		return fmt.Sprintf("DROP FK %s %s", asset.Name, key.Name)
+	},
+}
+
+func TestConstraintDialect_MigrationQueries(t *testing.T) {
+	t.Parallel()
+
+	notNull := false
+	nullable := true
+
+	tests := []struct {
+		name    string
+		dialect ConstraintDialect
+		columns []pipeline.Column
+		current *TableConstraints
+		want    []string
+	}{
+		{
+			name:    "missing constraints are added",
+			dialect: testConstraintDialect,
+			columns: []pipeline.Column{
+				{Name: "id", PrimaryKey: true, Nullable: pipeline.DefaultTrueBool{Value: &notNull}},
+				{Name: "customer_id", ForeignKey: &pipeline.ColumnReference{Table: "customers", Column: "id"}},
+			},
+			current: &TableConstraints{},
+			want: []string{
+				"SET NOT NULL sales.orders.id",
+				"ADD PK sales.orders (id)",
+				"ADD FK sales.orders (customer_id) -> customers (id)",
+			},
+		},
+		{
+			name:    "matching constraints are left untouched, regardless of case and qualification",
+			dialect: testConstraintDialect,
+			columns: []pipeline.Column{
+				{Name: "id", PrimaryKey: true, Nullable: pipeline.DefaultTrueBool{Value: &notNull}},
+				{Name: "customer_id", ForeignKey: &pipeline.ColumnReference{Table: "customers", Column: "id"}},
+			},
+			current: &TableConstraints{
+				PrimaryKeyName: "orders_pkey",
+				PrimaryKey:     []string{"ID"},
+				NotNull:        []string{"ID"},
+				ForeignKeys:    []ForeignKey{{Name: "orders_customer_fk", Column: "CUSTOMER_ID", Table: "DB.SALES.CUSTOMERS", RefColumn: "ID"}},
+			},
+			want: []string{},
+		},
+		{
+			name:    "changed constraints are replaced",
+			dialect: testConstraintDialect,
+			columns: []pipeline.Column{
+				{Name: "id", PrimaryKey: true},
+				{Name: "region", PrimaryKey: true},
+				{Name: "customer_id", ForeignKey: &pipeline.ColumnReference{Table: "crm.customers", Column: "id"}},
+				{Name: "note", Nullable: pipeline.DefaultTrueBool{Value: &nullable}},
+			},
+			current: &TableConstraints{
+				PrimaryKeyName: "orders_pkey",
+				PrimaryKey:     []string{"id"},
+				NotNull:        []string{"id", "note"},
+				ForeignKeys:    []ForeignKey{{Name: "orders_customer_fk", Column: "customer_id", Table: "sales.customers", RefColumn: "id"}},
+			},
+			want: []string{
+				"DROP FK sales.orders orders_customer_fk",
+				"DROP PK sales.orders orders_pkey",
+				"DROP NOT NULL sales.orders.note",
+				"ADD PK sales.orders (id, region)",
+				"ADD FK sales.orders (customer_id) -> crm.customers (id)",
+			},
+		},
+		{
+			name:    "constraints that are not defined on the asset are never dropped",
+			dialect: testConstraintDialect,
+			columns: []pipeline.Column{
+				{Name: "id"},
+				{Name: "customer_id"},
+			},
+			current: &TableConstraints{
+				PrimaryKeyName: "orders_pkey",
+				PrimaryKey:     []string{"id"},
+				NotNull:        []string{"id", "customer_id"},
+				ForeignKeys:    []ForeignKey{{Name: "orders_customer_fk", Column: "customer_id", Table: "customers", RefColumn: "id"}},
+			},
+			want: []string{},
+		},
+		{
+			name: "primary key columns are made not null first when the platform requires it",
+			dialect: func() ConstraintDialect {
+				d := testConstraintDialect
+				d.PrimaryKeyRequiresNotNull = true
+				return d
+			}(),
+			columns: []pipeline.Column{
+				{Name: "id", PrimaryKey: true},
+			},
+			current: &TableConstraints{},
+			want: []string{
+				"SET NOT NULL sales.orders.id",
+				"ADD PK sales.orders (id)",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			asset := &pipeline.Asset{Name: "sales.orders", Columns: tt.columns}
+			require.Equal(t, tt.want, tt.dialect.MigrationQueries(asset, tt.current))
+		})
+	}
+}
+
+func TestQueryTableConstraints(t *testing.T) {
+	t.Parallel()
+
+	conn := new(mockGrantClient)
+	conn.On("Select", mock.Anything, &query.Query{Query: "SELECT constraints"}).Return([][]interface{}{
+		{"foreign_key", "orders_customer_fk", "customer_id", "public.customers", "id", int64(2)},
+		{"not_null", "", "id", "", "", int64(1)},
+		{"primary_key", "orders_pkey", "id", "", "", int64(1)},
+		{"primary_key", "orders_pkey", "region", "", "", int64(2)},
+	}, nil)
+
+	constraints, err := QueryTableConstraints(t.Context(), conn, "SELECT constraints")
+	require.NoError(t, err)
+	require.Equal(t, &TableConstraints{
+		PrimaryKeyName: "orders_pkey",
+		PrimaryKey:     []string{"id", "region"},
+		NotNull:        []string{"id"},
+		ForeignKeys:    []ForeignKey{{Name: "orders_customer_fk", Column: "customer_id", Table: "public.customers", RefColumn: "id"}},
+	}, constraints)
+}
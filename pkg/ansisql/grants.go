@@ -0,0 +1,143 @@
+package ansisql
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/bruin-data/bruin/pkg/executor"
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/bruin-data/bruin/pkg/query"
+	"github.com/pkg/errors"
+)
+
+type grantClient interface {
+	Select(ctx context.Context, query *query.Query) ([][]interface{}, error)
+	RunQueryWithoutResult(ctx context.Context, query *query.Query) error
+}
+
+// GrantDialect describes how a SQL platform lists and grants the privileges on a table, it is
+// used to apply the `grants` of an asset after it is materialized.
+type GrantDialect struct {
+	// CurrentGrantsQuery returns the query that lists the privileges granted on the asset's
+	// table, one privilege and grantee per row.
+	CurrentGrantsQuery func(asset *pipeline.Asset) string
+	PrivilegeColumn    int
+	GranteeColumn      int
+
+	// GrantStatement returns the statement that grants the privilege on the asset's table to
+	// the grantee.
+	GrantStatement func(asset *pipeline.Asset, privilege, grantee string) string
+
+	// AllPrivileges returns the privileges the platform lists instead of `ALL PRIVILEGES` once
+	// it is granted on the asset's table. A grantee that holds all of them already has
+	// `ALL PRIVILEGES`, it is nil for the platforms that list `ALL PRIVILEGES` as it is.
+	AllPrivileges func(asset *pipeline.Asset) []string
+}
+
+// GrantQueries returns the statements that grant all the given privileges, sorted by privilege
+// and in the order the grantees are defined.
+func (d GrantDialect) GrantQueries(asset *pipeline.Asset, grants pipeline.Grants) []string {
+	queries := make([]string, 0)
+	for _, privilege := range grants.Privileges() {
+		for _, grantee := range grants.Grantees(privilege) {
+			queries = append(queries, d.GrantStatement(asset, privilege, grantee))
+		}
+	}
+	return queries
+}
+
+// RenderGrants returns the statements that grant every privilege defined on the asset.
+func (d GrantDialect) RenderGrants(asset *pipeline.Asset) ([]string, error) {
+	return d.GrantQueries(asset, asset.Grants), nil
+}
+
+// ApplyGrants grants the privileges defined on the asset that its table does not have yet.
+// Privileges granted outside of the asset definition are never revoked.
+func (d GrantDialect) ApplyGrants(ctx context.Context, conn grantClient, asset *pipeline.Asset) error {
+	if asset.Grants.IsEmpty() || asset.Materialization.Type == pipeline.MaterializationTypeNone {
+		return nil
+	}
+
+	rows, err := conn.Select(ctx, &query.Query{Query: d.CurrentGrantsQuery(asset)})
+	if err != nil {
+		return errors.Wrapf(err, "failed to get the current grants on '%s'", asset.Name)
+	}
+
+	current := pipeline.Grants{}
+	for _, row := range rows {
+		if len(row) <= d.PrivilegeColumn || len(row) <= d.GranteeColumn {
+			continue
+		}
+		privilege := fmt.Sprint(row[d.PrivilegeColumn])
+		current[privilege] = append(current[privilege], fmt.Sprint(row[d.GranteeColumn]))
+	}
+
+	d.addAllPrivileges(asset, current)
+
+	queries := d.GrantQueries(asset, asset.Grants.Missing(current))
+	if len(queries) == 0 {
+		return nil
+	}
+
+	writer := ctx.Value(executor.KeyPrinter)
+	for _, q := range queries {
+		LogQueryIfVerbose(ctx, writer, q)
+		if err := conn.RunQueryWithoutResult(ctx, &query.Query{Query: q}); err != nil {
+			return errors.Wrapf(err, "failed to apply the grants on '%s'", asset.Name)
+		}
+	}
+
+	return nil
+}
+
+// addAllPrivileges adds `ALL PRIVILEGES` to the current grants of the grantees that hold every
+// privilege it expands to, so that it is not granted again on every run.
+func (d GrantDialect) addAllPrivileges(asset *pipeline.Asset, current pipeline.Grants) {
+	if d.AllPrivileges == nil {
+		return
+	}
+
+	held := make(map[string]map[string]bool)
+	grantees := make([]string, 0)
+	for _, privilege := range current.Privileges() {
+		for _, grantee := range current.Grantees(privilege) {
+			normalized := pipeline.NormalizeGrantee(grantee)
+			if held[normalized] == nil {
+				held[normalized] = make(map[string]bool)
+				grantees = append(grantees, grantee)
+			}
+			held[normalized][privilege] = true
+		}
+	}
+
+	for _, grantee := range grantees {
+		privileges := held[pipeline.NormalizeGrantee(grantee)]
+		hasAll := true
+		for _, privilege := range d.AllPrivileges(asset) {
+			if !privileges[pipeline.NormalizePrivilege(privilege)] {
+				hasAll = false
+				break
+			}
+		}
+		if hasAll {
+			current["ALL"] = append(current["ALL"], grantee)
+			current["ALL PRIVILEGES"] = append(current["ALL PRIVILEGES"], grantee)
+		}
+	}
+}
+
+// SplitTableName splits a `schema.table` or `database.schema.table` name into its schema and
+// table parts, defaulting the schema to the given one.
+func SplitTableName(name, defaultSchema string) (string, string) {
+	parts := strings.Split(name, ".")
+	if len(parts) == 1 {
+		return defaultSchema, parts[0]
+	}
+	return parts[len(parts)-2], parts[len(parts)-1]
+}
+
+// QuoteLiteral returns the value as a single-quoted SQL string literal.
+func QuoteLiteral(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
@@ -0,0 +1,155 @@
+package ansisql
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/bruin-data/bruin/pkg/query"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type mockGrantClient struct {
+	mock.Mock
+}
+
+func (m *mockGrantClient) Select(ctx context.Context, q *query.Query) ([][]interface{}, error) {
+	res := m.Called(ctx, q)
+	return res.Get(0).([][]interface{}), res.Error(1)
+}
+
+func (m *mockGrantClient) RunQueryWithoutResult(ctx context.Context, q *query.Query) error {
+	return m.Called(ctx, q).Error(0)
+}
+
+var testGrantDialect = GrantDialect{
+	CurrentGrantsQuery: func(asset *pipeline.Asset) string {
+		return "SHOW GRANTS ON " + asset.Name
+	},
+	PrivilegeColumn: 1,
+	GranteeColumn:   0,
+	GrantStatement: func(asset *pipeline.Asset, privilege, grantee string) string {
+		return fmt.Sprintf("GRANT %s ON %s TO %s;", privilege, asset.Name, grantee)
+	},
+	AllPrivileges: func(*pipeline.Asset) []string {
+		return []string{"SELECT", "INSERT"}
+	},
+}
+
+func TestGrantDialect_ApplyGrants(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		asset   *pipeline.Asset
+		setup   func(m *mockGrantClient)
+		wantErr bool
+	}{
+		{
+			name: "assets without grants are skipped",
+			asset: &pipeline.Asset{
+				Name:            "schema.table",
+				Materialization: pipeline.Materialization{Type: pipeline.MaterializationTypeTable},
+			},
+		},
+		{
+			name: "assets without materialization are skipped",
+			asset: &pipeline.Asset{
+				Name:   "schema.table",
+				Grants: pipeline.Grants{"select": {"role_bi"}},
+			},
+		},
+		{
+			name: "only the missing grants are issued",
+			asset: &pipeline.Asset{
+				Name:            "schema.table",
+				Materialization: pipeline.Materialization{Type: pipeline.MaterializationTypeTable},
+				Grants:          pipeline.Grants{"select": {"role_bi", "role_analyst"}, "insert": {"role_etl"}},
+			},
+			setup: func(m *mockGrantClient) {
+				m.On("Select", mock.Anything, &query.Query{Query: "SHOW GRANTS ON schema.table"}).
+					Return([][]interface{}{{"ROLE_BI", "SELECT"}, {"role_admin", "DELETE"}}, nil)
+				m.On("RunQueryWithoutResult", mock.Anything, &query.Query{Query: "GRANT INSERT ON schema.table TO role_etl;"}).
+					Return(nil)
+				m.On("RunQueryWithoutResult", mock.Anything, &query.Query{Query: "GRANT SELECT ON schema.table TO role_analyst;"}).
+					Return(nil)
+			},
+		},
+		{
+			name: "nothing is issued when every grant exists",
+			asset: &pipeline.Asset{
+				Name:            "schema.table",
+				Materialization: pipeline.Materialization{Type: pipeline.MaterializationTypeView},
+				Grants:          pipeline.Grants{"select": {"role_bi"}},
+			},
+			setup: func(m *mockGrantClient) {
+				m.On("Select", mock.Anything, mock.Anything).
+					Return([][]interface{}{{"role_bi", "SELECT"}}, nil)
+			},
+		},
+		{
+			name: "all privileges are matched against the privileges they expand to",
+			asset: &pipeline.Asset{
+				Name:            "schema.table",
+				Materialization: pipeline.Materialization{Type: pipeline.MaterializationTypeTable},
+				Grants:          pipeline.Grants{"all privileges": {"role_admin", "role_etl"}, "all": {"role_bi"}},
+			},
+			setup: func(m *mockGrantClient) {
+				m.On("Select", mock.Anything, mock.Anything).
+					Return([][]interface{}{{"ROLE_ADMIN", "SELECT"}, {"role_admin", "INSERT"}, {"role_etl", "SELECT"}, {"role_bi", "INSERT"}, {"role_bi", "SELECT"}}, nil)
+				m.On("RunQueryWithoutResult", mock.Anything, &query.Query{Query: "GRANT ALL PRIVILEGES ON schema.table TO role_etl;"}).
+					Return(nil)
+			},
+		},
+		{
+			name: "errors while granting are returned",
+			asset: &pipeline.Asset{
+				Name:            "schema.table",
+				Materialization: pipeline.Materialization{Type: pipeline.MaterializationTypeTable},
+				Grants:          pipeline.Grants{"select": {"role_bi"}},
+			},
+			setup: func(m *mockGrantClient) {
+				m.On("Select", mock.Anything, mock.Anything).
+					Return([][]interface{}{}, nil)
+				m.On("RunQueryWithoutResult", mock.Anything, mock.Anything).
+					Return(context.Canceled)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := new(mockGrantClient)
+			if tt.setup != nil {
+				tt.setup(client)
+			}
+
+			err := testGrantDialect.ApplyGrants(t.Context(), client, tt.asset)
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			client.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGrantDialect_RenderGrants(t *testing.T) {
+	t.Parallel()
+
+	queries, err := testGrantDialect.RenderGrants(&pipeline.Asset{
+		Name:   "schema.table",
+		Grants: pipeline.Grants{"select": {"role_bi", "role_analyst"}, "insert": {"role_etl"}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"GRANT INSERT ON schema.table TO role_etl;",
+		"GRANT SELECT ON schema.table TO role_bi;",
+		"GRANT SELECT ON schema.table TO role_analyst;",
+	}, queries)
+}
@@ -0,0 +1,232 @@
+package ansisql
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/bruin-data/bruin/pkg/config"
+	"github.com/bruin-data/bruin/pkg/executor"
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/bruin-data/bruin/pkg/query"
+	"github.com/bruin-data/bruin/pkg/scheduler"
+	"github.com/pkg/errors"
+)
+
+const (
+	// OwnerProperty and TagsProperty are the table properties the owner and the tags of an asset
+	// are pushed to on the platforms that support table properties.
+	OwnerProperty = "bruin.owner"
+	TagsProperty  = "bruin.tags"
+)
+
+// MetadataClient is the connection the metadata of an asset is read and pushed through.
+type MetadataClient interface {
+	Select(ctx context.Context, query *query.Query) ([][]interface{}, error)
+	RunQueryWithoutResult(ctx context.Context, query *query.Query) error
+}
+
+// TableMetadata is the metadata currently set on a materialized table.
+type TableMetadata struct {
+	Comment    string
+	Columns    []ColumnMetadata
+	Properties map[string]string
+}
+
+// ColumnMetadata is the comment currently set on a column. Definition is only filled by the
+// platforms that need the full column definition to change its comment.
+type ColumnMetadata struct {
+	Name       string
+	Comment    string
+	Definition string
+}
+
+// MetadataDialect describes how a SQL platform reads and sets the comments of a table, it is
+// used by the metadata push to only change the values that differ from the asset definition.
+type MetadataDialect struct {
+	Platform string
+
+	// CurrentMetadata returns the comments currently set on the asset's table and its columns.
+	CurrentMetadata func(ctx context.Context, conn MetadataClient, asset *pipeline.Asset) (*TableMetadata, error)
+
+	TableCommentStatement func(asset *pipeline.Asset, comment string) string
+
+	// ColumnCommentStatement returns the statement that sets the comment of the column, an empty
+	// statement leaves the column untouched.
+	ColumnCommentStatement func(asset *pipeline.Asset, column ColumnMetadata, comment string) string
+
+	// PropertiesStatement returns the statement that sets the given table properties, platforms
+	// without table properties leave it nil.
+	PropertiesStatement func(asset *pipeline.Asset, properties map[string]string) string
+}
+
+// AssetProperties returns the table properties the owner and the tags of the asset are pushed as.
+func AssetProperties(asset *pipeline.Asset) map[string]string {
+	properties := make(map[string]string)
+	if asset.Owner != "" {
+		properties[OwnerProperty] = asset.Owner
+	}
+	if len(asset.Tags) > 0 {
+		properties[TagsProperty] = strings.Join(asset.Tags, ",")
+	}
+	return properties
+}
+
+// MetadataQueries returns the statements that bring the table's metadata in line with the asset
+// definition. Empty descriptions and columns that do not exist in the table are left untouched.
+func (d MetadataDialect) MetadataQueries(asset *pipeline.Asset, current *TableMetadata) []string {
+	queries := make([]string, 0)
+	if asset.Description != "" && asset.Description != current.Comment {
+		queries = append(queries, d.TableCommentStatement(asset, asset.Description))
+	}
+
+	existing := make(map[string]ColumnMetadata, len(current.Columns))
+	for _, column := range current.Columns {
+		existing[strings.ToLower(column.Name)] = column
+	}
+	for _, column := range asset.Columns {
+		if column.Description == "" {
+			continue
+		}
+		currentColumn, ok := existing[strings.ToLower(column.Name)]
+		if !ok || currentColumn.Comment == column.Description {
+			continue
+		}
+		if q := d.ColumnCommentStatement(asset, currentColumn, column.Description); q != "" {
+			queries = append(queries, q)
+		}
+	}
+
+	if d.PropertiesStatement != nil {
+		changed := make(map[string]string)
+		for key, value := range AssetProperties(asset) {
+			if current.Properties[key] != value {
+				changed[key] = value
+			}
+		}
+		if len(changed) > 0 {
+			queries = append(queries, d.PropertiesStatement(asset, changed))
+		}
+	}
+
+	return queries
+}
+
+// PushMetadata pushes the descriptions, and the owner and tags where supported, of the asset
+// to its table, issuing statements only for the values that changed.
+func (d MetadataDialect) PushMetadata(ctx context.Context, conn MetadataClient, asset *pipeline.Asset) error {
+	current, err := d.CurrentMetadata(ctx, conn, asset)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get the current metadata of '%s'", asset.Name)
+	}
+
+	writer := ctx.Value(executor.KeyPrinter)
+	for _, q := range d.MetadataQueries(asset, current) {
+		LogQueryIfVerbose(ctx, writer, q)
+		if err := conn.RunQueryWithoutResult(ctx, &query.Query{Query: q}); err != nil {
+			return errors.Wrapf(err, "failed to push the metadata of '%s'", asset.Name)
+		}
+	}
+	return nil
+}
+
+// QueryTableMetadata runs a query that returns one row per table comment, column comment and
+// property, in the form of (kind, name, value[, definition]) where kind is one of `table`,
+// `column` or `property`.
+func QueryTableMetadata(ctx context.Context, conn MetadataClient, q string) (*TableMetadata, error) {
+	rows, err := conn.Select(ctx, &query.Query{Query: q})
+	if err != nil {
+		return nil, err
+	}
+
+	metadata := &TableMetadata{Properties: make(map[string]string)}
+	for _, row := range rows {
+		if len(row) < 3 {
+			continue
+		}
+		name, value := stringValue(row[1]), stringValue(row[2])
+		switch stringValue(row[0]) {
+		case "table":
+			metadata.Comment = value
+		case "column":
+			column := ColumnMetadata{Name: name, Comment: value}
+			if len(row) > 3 {
+				column.Definition = stringValue(row[3])
+			}
+			metadata.Columns = append(metadata.Columns, column)
+		case "property":
+			metadata.Properties[name] = value
+		}
+	}
+	return metadata, nil
+}
+
+func stringValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case []byte:
+		return string(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// SortedKeys returns the keys of the properties in a stable order, for rendering statements.
+func SortedKeys(properties map[string]string) []string {
+	keys := make([]string, 0, len(properties))
+	for key := range properties {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// MetadataPushOperator pushes the metadata of assets through a MetadataDialect.
+type MetadataPushOperator struct {
+	connection config.ConnectionGetter
+	dialect    MetadataDialect
+}
+
+func NewMetadataPushOperator(conn config.ConnectionGetter, dialect MetadataDialect) *MetadataPushOperator {
+	return &MetadataPushOperator{
+		connection: conn,
+		dialect:    dialect,
+	}
+}
+
+func (o *MetadataPushOperator) Run(ctx context.Context, ti scheduler.TaskInstance) error {
+	asset := ti.GetAsset()
+	connName, err := ti.GetPipeline().GetConnectionNameForAsset(asset)
+	if err != nil {
+		return err
+	}
+
+	rawConn := o.connection.GetConnection(connName)
+	if rawConn == nil {
+		return config.NewConnectionNotFoundError(ctx, "", connName)
+	}
+
+	client, ok := rawConn.(MetadataClient)
+	if !ok {
+		return errors.Errorf("connection '%s' does not support metadata push", connName)
+	}
+
+	writer, ok := ctx.Value(executor.KeyPrinter).(io.Writer)
+	if !ok || writer == nil {
+		return errors.New("no writer found in context, please create an issue for this: https://github.com/bruin-data/bruin/issues")
+	}
+
+	if asset.Materialization.Type == pipeline.MaterializationTypeView {
+		_, _ = writer.Write([]byte("Skipping metadata update: Column comments are not supported for Views.\n"))
+		return nil
+	}
+
+	if err := o.dialect.PushMetadata(ctx, client, asset); err != nil {
+		_, _ = fmt.Fprintf(writer, "Failed to push metadata to %s, skipping...\n", o.dialect.Platform)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+package ansisql
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/bruin-data/bruin/pkg/query"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var testMetadataDialect = MetadataDialect{
+	TableCommentStatement: func(asset *pipeline.Asset, comment string) string {
+		return fmt.Sprintf("COMMENT ON TABLE %s IS %s", asset.Name, QuoteLiteral(comment))
+	},
+	ColumnCommentStatement: func(asset *pipeline.Asset, column ColumnMetadata, comment string) string {
+		if column.Definition == "skip" {
+			return ""
+		}
+		return fmt.Sprintf("COMMENT ON COLUMN %s.%s IS %s", asset.Name, column.Name, QuoteLiteral(comment))
+	},
+	PropertiesStatement: func(asset *pipeline.Asset, properties map[string]string) string {
+		values := make([]string, 0, len(properties))
+		for _, key := range SortedKeys(properties) {
+			values = append(values, key+"="+properties[key])
+		}
+		return fmt.Sprintf("SET PROPERTIES %s (%s)", asset.Name, strings.Join(values, ", "))
+	},
+}
+
+func TestMetadataDialect_MetadataQueries(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		asset   *pipeline.Asset
+		current *TableMetadata
+		want    []string
+	}{
+		{
+			name: "only changed values are pushed",
+			asset: &pipeline.Asset{
+				Name:        "sales.orders",
+				Description: "Orders",
+				Columns: []pipeline.Column{
+					{Name: "id", Description: "The id"},
+					{Name: "amount", Description: "The amount"},
+					{Name: "no_description"},
+					{Name: "dropped", Description: "Not in the table anymore"},
+				},
+			},
+			current: &TableMetadata{
+				Comment: "Old orders",
+				Columns: []ColumnMetadata{
+					{Name: "ID", Comment: ""},
+					{Name: "AMOUNT", Comment: "The amount"},
+					{Name: "NO_DESCRIPTION", Comment: "kept as is"},
+				},
+			},
+			want: []string{
+				"COMMENT ON TABLE sales.orders IS 'Orders'",
+				"COMMENT ON COLUMN sales.orders.ID IS 'The id'",
+			},
+		},
+		{
+			name: "owner and tags are pushed as properties when they differ",
+			asset: &pipeline.Asset{
+				Name:  "sales.orders",
+				Owner: "jane@example.com",
+				Tags:  []string{"finance", "daily"},
+			},
+			current: &TableMetadata{
+				Properties: map[string]string{OwnerProperty: "jane@example.com", TagsProperty: "finance"},
+			},
+			want: []string{
+				"SET PROPERTIES sales.orders (bruin.tags=finance,daily)",
+			},
+		},
+		{
+			name: "columns with an empty statement are skipped",
+			asset: &pipeline.Asset{
+				Name:    "sales.orders",
+				Columns: []pipeline.Column{{Name: "total", Description: "Generated"}},
+			},
+			current: &TableMetadata{
+				Columns: []ColumnMetadata{{Name: "total", Definition: "skip"}},
+			},
+			want: []string{},
+		},
+		{
+			name: "nothing changed",
+			asset: &pipeline.Asset{
+				Name:        "sales.orders",
+				Description: "Orders",
+				Owner:       "jane@example.com",
+				Columns:     []pipeline.Column{{Name: "id", Description: "The id"}},
+			},
+			current: &TableMetadata{
+				Comment:    "Orders",
+				Columns:    []ColumnMetadata{{Name: "id", Comment: "The id"}},
+				Properties: map[string]string{OwnerProperty: "jane@example.com"},
+			},
+			want: []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			require.Equal(t, tt.want, testMetadataDialect.MetadataQueries(tt.asset, tt.current))
+		})
+	}
+}
+
+func TestQueryTableMetadata(t *testing.T) {
+	t.Parallel()
+
+	conn := new(mockGrantClient)
+	conn.On("Select", mock.Anything, &query.Query{Query: "SELECT metadata"}).Return([][]interface{}{
+		{"table", "orders", "Orders"},
+		{"column", "id", nil, "INT NOT NULL"},
+		{"column", "amount", []byte("The amount")},
+		{"property", "bruin.owner", "jane@example.com"},
+	}, nil)
+
+	metadata, err := QueryTableMetadata(t.Context(), conn, "SELECT metadata")
+	require.NoError(t, err)
+	require.Equal(t, &TableMetadata{
+		Comment: "Orders",
+		Columns: []ColumnMetadata{
+			{Name: "id", Definition: "INT NOT NULL"},
+			{Name: "amount", Comment: "The amount"},
+		},
+		Properties: map[string]string{OwnerProperty: "jane@example.com"},
+	}, metadata)
+}
@@ -0,0 +1,130 @@
+package ansisql
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/bruin-data/bruin/pkg/diff"
+	"github.com/bruin-data/bruin/pkg/helpers"
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/bruin-data/bruin/pkg/query"
+	"github.com/pkg/errors"
+)
+
+type schemaEvolutionClient interface {
+	TableExistsChecker
+	RunQueryWithoutResult(ctx context.Context, query *query.Query) error
+	SelectWithSchema(ctx context.Context, queryObj *query.Query) (*query.QueryResult, error)
+	GetTableSummary(ctx context.Context, tableName string, schemaOnly bool) (*diff.TableSummaryResult, error)
+}
+
+// SchemaEvolver applies `materialization.on_schema_change` for incremental strategies. It compares
+// the columns produced by the asset query with the existing table and issues the ALTER TABLE
+// statements required by the policy before the materialized statement runs.
+type SchemaEvolver struct {
+	planner *diff.SchemaEvolutionPlanner
+}
+
+func NewSchemaEvolver(dialect diff.DatabaseDialect, typeMapper diff.TypeMapping) *SchemaEvolver {
+	return &SchemaEvolver{
+		planner: diff.NewSchemaEvolutionPlanner(dialect, typeMapper),
+	}
+}
+
+// EvolveSchema brings the asset table in line with the query output. It is a no-op for assets
+// that do not use an incremental strategy, use the `ignore` policy, or whose table does not
+// exist yet.
+func (e *SchemaEvolver) EvolveSchema(ctx context.Context, conn any, asset *pipeline.Asset, assetQuery string, writer interface{}) error {
+	if !asset.Materialization.ShouldEvolveSchema() {
+		return nil
+	}
+
+	client, ok := conn.(schemaEvolutionClient)
+	if !ok {
+		return errors.Errorf("`on_schema_change` is not supported for asset type '%s'", asset.Type)
+	}
+
+	exists, err := e.tableExists(ctx, client, asset.Name)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return nil
+	}
+
+	summary, err := client.GetTableSummary(ctx, asset.Name, true)
+	if err != nil {
+		return errors.Wrapf(err, "failed to fetch the schema of '%s'", asset.Name)
+	}
+	if summary == nil || summary.Table == nil {
+		return nil
+	}
+
+	queryColumns, err := e.queryColumns(ctx, client, assetQuery)
+	if err != nil {
+		return err
+	}
+
+	statements, err := e.planner.Plan(diff.SchemaChangePolicy(asset.Materialization.OnSchemaChange), asset.Name, queryColumns, summary.Table.Columns)
+	if err != nil {
+		return err
+	}
+
+	for _, statement := range statements {
+		q := &query.Query{Query: statement}
+		LogQueryIfVerbose(ctx, writer, q.Query)
+		if err := client.RunQueryWithoutResult(ctx, q); err != nil {
+			return errors.Wrapf(err, "failed to evolve the schema of '%s'", asset.Name)
+		}
+	}
+
+	return nil
+}
+
+func (e *SchemaEvolver) tableExists(ctx context.Context, client schemaEvolutionClient, tableName string) (bool, error) {
+	existsQuery, err := client.BuildTableExistsQuery(tableName)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to build table exists query")
+	}
+
+	res, err := client.Select(ctx, &query.Query{Query: existsQuery})
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to check if table '%s' exists", tableName)
+	}
+
+	count, err := helpers.CastResultToInteger(res, true)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to parse table exists result")
+	}
+
+	return count > 0, nil
+}
+
+// queryColumns wraps the asset query in a `LIMIT 0` select so that the output schema can be read
+// without scanning any data.
+func (e *SchemaEvolver) queryColumns(ctx context.Context, client schemaEvolutionClient, assetQuery string) ([]*diff.Column, error) {
+	probe := &query.Query{
+		Query: fmt.Sprintf("SELECT * FROM (\n%s\n) AS __bruin_schema_probe LIMIT 0", strings.TrimSuffix(strings.TrimSpace(assetQuery), ";")),
+	}
+
+	result, err := client.SelectWithSchema(ctx, probe)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to determine the output columns of the asset query")
+	}
+
+	columns := make([]*diff.Column, 0, len(result.Columns))
+	for i, name := range result.Columns {
+		columnType := ""
+		if i < len(result.ColumnTypes) {
+			columnType = result.ColumnTypes[i]
+		}
+		columns = append(columns, &diff.Column{
+			Name:     name,
+			Type:     columnType,
+			Nullable: true,
+		})
+	}
+
+	return columns, nil
+}
@@ -0,0 +1,139 @@
+package ansisql
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bruin-data/bruin/pkg/diff"
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/bruin-data/bruin/pkg/query"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type mockSchemaEvolutionDB struct {
+	mock.Mock
+}
+
+func (m *mockSchemaEvolutionDB) RunQueryWithoutResult(ctx context.Context, q *query.Query) error {
+	args := m.Called(ctx, q)
+	return args.Error(0)
+}
+
+func (m *mockSchemaEvolutionDB) Select(ctx context.Context, q *query.Query) ([][]interface{}, error) {
+	args := m.Called(ctx, q)
+	return args.Get(0).([][]interface{}), args.Error(1)
+}
+
+func (m *mockSchemaEvolutionDB) BuildTableExistsQuery(tableName string) (string, error) {
+	return "SELECT COUNT(*) FROM tables WHERE name = '" + tableName + "'", nil
+}
+
+func (m *mockSchemaEvolutionDB) SelectWithSchema(ctx context.Context, q *query.Query) (*query.QueryResult, error) {
+	args := m.Called(ctx, q)
+	return args.Get(0).(*query.QueryResult), args.Error(1)
+}
+
+func (m *mockSchemaEvolutionDB) GetTableSummary(ctx context.Context, tableName string, schemaOnly bool) (*diff.TableSummaryResult, error) {
+	args := m.Called(ctx, tableName, schemaOnly)
+	return args.Get(0).(*diff.TableSummaryResult), args.Error(1)
+}
+
+func TestSchemaEvolver_EvolveSchema(t *testing.T) {
+	t.Parallel()
+
+	existsQuery := &query.Query{Query: "SELECT COUNT(*) FROM tables WHERE name = 'analytics.users'"}
+	probeQuery := &query.Query{Query: "SELECT * FROM (\nSELECT id, email FROM raw.users\n) AS __bruin_schema_probe LIMIT 0"}
+
+	tests := []struct {
+		name            string
+		materialization pipeline.Materialization
+		setup           func(db *mockSchemaEvolutionDB)
+		wantErr         string
+	}{
+		{
+			name: "create+replace is never evolved",
+			materialization: pipeline.Materialization{
+				Type:           pipeline.MaterializationTypeTable,
+				Strategy:       pipeline.MaterializationStrategyCreateReplace,
+				OnSchemaChange: pipeline.MaterializationOnSchemaChangeSyncAllColumns,
+			},
+		},
+		{
+			name: "missing table is left to the materialization",
+			materialization: pipeline.Materialization{
+				Type:           pipeline.MaterializationTypeTable,
+				Strategy:       pipeline.MaterializationStrategyAppend,
+				OnSchemaChange: pipeline.MaterializationOnSchemaChangeAppendNewColumns,
+			},
+			setup: func(db *mockSchemaEvolutionDB) {
+				db.On("Select", mock.Anything, existsQuery).Return([][]interface{}{{int64(0)}}, nil)
+			},
+		},
+		{
+			name: "new columns are added before the incremental statement",
+			materialization: pipeline.Materialization{
+				Type:           pipeline.MaterializationTypeTable,
+				Strategy:       pipeline.MaterializationStrategyAppend,
+				OnSchemaChange: pipeline.MaterializationOnSchemaChangeAppendNewColumns,
+			},
+			setup: func(db *mockSchemaEvolutionDB) {
+				db.On("Select", mock.Anything, existsQuery).Return([][]interface{}{{int64(1)}}, nil)
+				db.On("GetTableSummary", mock.Anything, "analytics.users", true).Return(&diff.TableSummaryResult{
+					Table: &diff.Table{Name: "analytics.users", Columns: []*diff.Column{{Name: "id", Type: "BIGINT"}}},
+				}, nil)
+				db.On("SelectWithSchema", mock.Anything, probeQuery).Return(&query.QueryResult{
+					Columns:     []string{"id", "email"},
+					ColumnTypes: []string{"BIGINT", "VARCHAR"},
+				}, nil)
+				db.On("RunQueryWithoutResult", mock.Anything, &query.Query{Query: "ALTER TABLE \"analytics\".\"users\" ADD COLUMN \"email\" VARCHAR;"}).Return(nil)
+			},
+		},
+		{
+			name: "fail stops the run when the schema drifted",
+			materialization: pipeline.Materialization{
+				Type:           pipeline.MaterializationTypeTable,
+				Strategy:       pipeline.MaterializationStrategyMerge,
+				OnSchemaChange: pipeline.MaterializationOnSchemaChangeFail,
+			},
+			setup: func(db *mockSchemaEvolutionDB) {
+				db.On("Select", mock.Anything, existsQuery).Return([][]interface{}{{int64(1)}}, nil)
+				db.On("GetTableSummary", mock.Anything, "analytics.users", true).Return(&diff.TableSummaryResult{
+					Table: &diff.Table{Name: "analytics.users", Columns: []*diff.Column{{Name: "id", Type: "BIGINT"}}},
+				}, nil)
+				db.On("SelectWithSchema", mock.Anything, probeQuery).Return(&query.QueryResult{
+					Columns:     []string{"id", "email"},
+					ColumnTypes: []string{"BIGINT", "VARCHAR"},
+				}, nil)
+			},
+			wantErr: "new columns: email",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			db := new(mockSchemaEvolutionDB)
+			if tt.setup != nil {
+				tt.setup(db)
+			}
+
+			asset := &pipeline.Asset{
+				Name:            "analytics.users",
+				Type:            pipeline.AssetTypeDuckDBQuery,
+				Materialization: tt.materialization,
+			}
+
+			evolver := NewSchemaEvolver(diff.DialectDuckDB, diff.NewDuckDBTypeMapper())
+			err := evolver.EvolveSchema(t.Context(), db, asset, "SELECT id, email FROM raw.users;", nil)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+
+			db.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,150 @@
+package bigquery
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"cloud.google.com/go/iam"
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/pkg/errors"
+)
+
+// grantRoles maps the SQL privileges used in asset grants to the BigQuery IAM roles that give the
+// same access on a table, IAM roles can also be used directly, e.g. `roles/bigquery.dataEditor`.
+var grantRoles = map[string]iam.RoleName{
+	"SELECT": "roles/bigquery.dataViewer",
+	"INSERT": "roles/bigquery.dataEditor",
+	"UPDATE": "roles/bigquery.dataEditor",
+	"DELETE": "roles/bigquery.dataEditor",
+	"ALL":    "roles/bigquery.dataOwner",
+}
+
+type grant struct {
+	role    iam.RoleName
+	members []string
+}
+
+func assetGrants(asset *pipeline.Asset) ([]grant, error) {
+	privileges := make([]string, 0, len(asset.Grants))
+	for privilege := range asset.Grants {
+		privileges = append(privileges, privilege)
+	}
+	sort.Strings(privileges)
+
+	grants := make([]grant, 0, len(privileges))
+	for _, privilege := range privileges {
+		role, err := grantRole(privilege)
+		if err != nil {
+			return nil, err
+		}
+
+		members := make([]string, 0, len(asset.Grants[privilege]))
+		for _, member := range asset.Grants[privilege] {
+			member = strings.TrimSpace(member)
+			if member == "" {
+				continue
+			}
+			if !strings.Contains(member, ":") {
+				return nil, errors.Errorf("BigQuery grantees must be IAM members such as 'group:analysts@example.com', '%s' given", member)
+			}
+			members = append(members, member)
+		}
+		if len(members) > 0 {
+			grants = append(grants, grant{role: role, members: members})
+		}
+	}
+
+	return grants, nil
+}
+
+func grantRole(privilege string) (iam.RoleName, error) {
+	privilege = strings.TrimSpace(privilege)
+	if strings.HasPrefix(privilege, "roles/") || strings.HasPrefix(privilege, "projects/") || strings.HasPrefix(privilege, "organizations/") {
+		return iam.RoleName(privilege), nil
+	}
+
+	role, ok := grantRoles[pipeline.NormalizePrivilege(privilege)]
+	if !ok {
+		return "", errors.Errorf("unsupported BigQuery privilege '%s', use one of select, insert, update, delete, all or an IAM role such as 'roles/bigquery.dataViewer'", privilege)
+	}
+	return role, nil
+}
+
+// GrantQueries returns the DCL statements that grant the asset's privileges, they are equivalent
+// to the IAM policy bindings ApplyGrants adds to the table.
+func GrantQueries(asset *pipeline.Asset) ([]string, error) {
+	grants, err := assetGrants(asset)
+	if err != nil {
+		return nil, err
+	}
+
+	objectType := "TABLE"
+	if asset.Materialization.Type == pipeline.MaterializationTypeView {
+		objectType = "VIEW"
+	}
+
+	queries := make([]string, 0, len(grants))
+	for _, g := range grants {
+		members := make([]string, 0, len(g.members))
+		for _, member := range g.members {
+			members = append(members, fmt.Sprintf("%q", member))
+		}
+		queries = append(queries, fmt.Sprintf("GRANT `%s` ON %s `%s` TO %s;", g.role, objectType, asset.Name, strings.Join(members, ", ")))
+	}
+	return queries, nil
+}
+
+// ApplyGrants adds the members in the asset's grants to the IAM policy of its table, the policy is
+// only updated when a member is missing and existing bindings are never removed.
+func (d *Client) ApplyGrants(ctx context.Context, asset *pipeline.Asset) error {
+	if asset.Grants.IsEmpty() || asset.Materialization.Type == pipeline.MaterializationTypeNone {
+		return nil
+	}
+
+	grants, err := assetGrants(asset)
+	if err != nil {
+		return err
+	}
+
+	tableRef, err := d.getTableRef(ctx, asset.Name)
+	if err != nil {
+		return err
+	}
+
+	handle := tableRef.IAM()
+	policy, err := handle.Policy(ctx)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get the IAM policy of '%s'", asset.Name)
+	}
+
+	if !addMissingMembers(policy, grants) {
+		return nil
+	}
+
+	if err := handle.SetPolicy(ctx, policy); err != nil {
+		return errors.Wrapf(err, "failed to update the IAM policy of '%s'", asset.Name)
+	}
+	return nil
+}
+
+func addMissingMembers(policy *iam.Policy, grants []grant) bool {
+	changed := false
+	for _, g := range grants {
+		existing := make(map[string]bool)
+		for _, member := range policy.Members(g.role) {
+			existing[strings.ToLower(member)] = true
+		}
+
+		for _, member := range g.members {
+			if existing[strings.ToLower(member)] {
+				continue
+			}
+			policy.Add(member, g.role)
+			existing[strings.ToLower(member)] = true
+			changed = true
+		}
+	}
+	return changed
+}
@@ -0,0 +1,95 @@
+package bigquery
+
+import (
+	"testing"
+
+	"cloud.google.com/go/iam"
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGrantQueries(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		asset   *pipeline.Asset
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "privileges are mapped to IAM roles",
+			asset: &pipeline.Asset{
+				Name:            "project.dataset.orders",
+				Materialization: pipeline.Materialization{Type: pipeline.MaterializationTypeTable},
+				Grants: pipeline.Grants{
+					"select":                    {"group:bi@example.com", "user:jane@example.com"},
+					"roles/bigquery.dataEditor": {"serviceAccount:etl@project.iam.gserviceaccount.com"},
+				},
+			},
+			want: []string{
+				"GRANT `roles/bigquery.dataEditor` ON TABLE `project.dataset.orders` TO \"serviceAccount:etl@project.iam.gserviceaccount.com\";",
+				"GRANT `roles/bigquery.dataViewer` ON TABLE `project.dataset.orders` TO \"group:bi@example.com\", \"user:jane@example.com\";",
+			},
+		},
+		{
+			name: "views are granted on as views",
+			asset: &pipeline.Asset{
+				Name:            "dataset.orders_view",
+				Materialization: pipeline.Materialization{Type: pipeline.MaterializationTypeView},
+				Grants:          pipeline.Grants{"select": {"group:bi@example.com"}},
+			},
+			want: []string{
+				"GRANT `roles/bigquery.dataViewer` ON VIEW `dataset.orders_view` TO \"group:bi@example.com\";",
+			},
+		},
+		{
+			name: "grantees must be IAM members",
+			asset: &pipeline.Asset{
+				Name:   "dataset.orders",
+				Grants: pipeline.Grants{"select": {"role_bi"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "unknown privileges are rejected",
+			asset: &pipeline.Asset{
+				Name:   "dataset.orders",
+				Grants: pipeline.Grants{"truncate": {"group:bi@example.com"}},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := GrantQueries(tt.asset)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestAddMissingMembers(t *testing.T) {
+	t.Parallel()
+
+	policy := &iam.Policy{}
+	policy.Add("group:bi@example.com", "roles/bigquery.dataViewer")
+	policy.Add("user:admin@example.com", "roles/bigquery.dataOwner")
+
+	changed := addMissingMembers(policy, []grant{
+		{role: "roles/bigquery.dataViewer", members: []string{"group:BI@example.com", "user:jane@example.com"}},
+	})
+	require.True(t, changed)
+	require.ElementsMatch(t, []string{"group:bi@example.com", "user:jane@example.com"}, policy.Members("roles/bigquery.dataViewer"))
+	require.Equal(t, []string{"user:admin@example.com"}, policy.Members("roles/bigquery.dataOwner"))
+
+	require.False(t, addMissingMembers(policy, []grant{
+		{role: "roles/bigquery.dataViewer", members: []string{"user:jane@example.com"}},
+	}))
+}
@@ -33,16 +33,17 @@ var matMap = pipeline.AssetMaterializationMap{
 		pipeline.MaterializationStrategyDeleteInsert:  errorMaterializer,
 	},
 	pipeline.MaterializationTypeTable: {
-		pipeline.MaterializationStrategyNone:           buildCreateReplaceQuery,
-		pipeline.MaterializationStrategyAppend:         buildAppendQuery,
-		pipeline.MaterializationStrategyCreateReplace:  buildCreateReplaceQuery,
-		pipeline.MaterializationStrategyDeleteInsert:   buildIncrementalQuery,
-		pipeline.MaterializationStrategyTruncateInsert: buildTruncateInsertQuery,
-		pipeline.MaterializationStrategyMerge:          mergeMaterializer,
-		pipeline.MaterializationStrategyTimeInterval:   buildTimeIntervalQuery,
-		pipeline.MaterializationStrategyDDL:            buildDDLQuery,
-		pipeline.MaterializationStrategySCD2ByColumn:   buildSCD2ByColumnQuery,
-		pipeline.MaterializationStrategySCD2ByTime:     buildSCD2QueryByTime,
+		pipeline.MaterializationStrategyNone:            buildCreateReplaceQuery,
+		pipeline.MaterializationStrategyAppend:          buildAppendQuery,
+		pipeline.MaterializationStrategyCreateReplace:   buildCreateReplaceQuery,
+		pipeline.MaterializationStrategyDeleteInsert:    buildIncrementalQuery,
+		pipeline.MaterializationStrategyTruncateInsert:  buildTruncateInsertQuery,
+		pipeline.MaterializationStrategyMerge:           mergeMaterializer,
+		pipeline.MaterializationStrategyTimeInterval:    buildTimeIntervalQuery,
+		pipeline.MaterializationStrategyInsertOverwrite: buildInsertOverwriteQuery,
+		pipeline.MaterializationStrategyDDL:             buildDDLQuery,
+		pipeline.MaterializationStrategySCD2ByColumn:    buildSCD2ByColumnQuery,
+		pipeline.MaterializationStrategySCD2ByTime:      buildSCD2QueryByTime,
 	},
 }
 
@@ -585,6 +586,110 @@ func buildTimeIntervalQuery(asset *pipeline.Asset, query string) (string, error)
 	return strings.Join(queries, ";\n") + ";", nil
 }
 
+// buildInsertOverwriteQuery replaces whole partitions of the target with a single MERGE, which
+// BigQuery applies atomically. Target rows in the affected partitions that are not in the source
+// are deleted (`ON FALSE` never matches), and every source row is inserted. The affected
+// partitions are either the interval dates, when `time_granularity` is set, or the distinct
+// partitions of the query result. Both forms filter the target on the partition expression, so
+// only the affected partitions are scanned. On the first run, the target is created empty with its
+// partitioning before the MERGE.
+func buildInsertOverwriteQuery(asset *pipeline.Asset, query string) (string, error) {
+	mat := asset.Materialization
+	if mat.PartitionBy == "" {
+		return "", errors.New("materialization strategy insert_overwrite requires the `partition_by` field to be set")
+	}
+
+	partition, err := parseMergePartitionExpression(asset)
+	if err != nil {
+		return "", err
+	}
+
+	query = strings.TrimSuffix(strings.TrimSpace(query), ";")
+	targetPartitionExpression := partition.render("target")
+
+	if mat.TimeGranularity != "" {
+		startVar, endVar, err := intervalVariables(mat.TimeGranularity)
+		if err != nil {
+			return "", err
+		}
+
+		createTarget, err := buildCreateOverwriteTargetQuery(asset, fmt.Sprintf("(%s)", query))
+		if err != nil {
+			return "", err
+		}
+
+		return createTarget + ";\n" + strings.Join([]string{
+			fmt.Sprintf("MERGE %s target", asset.Name),
+			fmt.Sprintf("USING (%s) source", query),
+			"ON FALSE",
+			fmt.Sprintf("WHEN NOT MATCHED BY SOURCE AND %s BETWEEN '%s' AND '%s' THEN DELETE", targetPartitionExpression, startVar, endVar),
+			"WHEN NOT MATCHED THEN INSERT ROW",
+		}, "\n") + ";", nil
+	}
+
+	suffix := helpers.PrefixGenerator()
+	sourceTable := "__bruin_overwrite_source_" + suffix
+	partitionVariable := "bruin_overwrite_partitions_" + suffix
+	sourcePartitionExpression := partition.render("source")
+
+	createTarget, err := buildCreateOverwriteTargetQuery(asset, sourceTable)
+	if err != nil {
+		return "", err
+	}
+
+	statements := []string{
+		fmt.Sprintf("DECLARE %s ARRAY<%s>", partitionVariable, partition.dataType),
+		fmt.Sprintf("CREATE TEMP TABLE %s AS %s", sourceTable, query),
+		fmt.Sprintf(
+			"ASSERT NOT EXISTS (SELECT 1 FROM %s source WHERE %s IS NULL) AS 'insert_overwrite requires non-null partition values'",
+			sourceTable,
+			sourcePartitionExpression,
+		),
+		createTarget,
+		fmt.Sprintf("SET %s = ARRAY(SELECT DISTINCT %s FROM %s source)", partitionVariable, sourcePartitionExpression, sourceTable),
+		strings.Join([]string{
+			fmt.Sprintf("MERGE %s target", asset.Name),
+			fmt.Sprintf("USING %s source", sourceTable),
+			"ON FALSE",
+			fmt.Sprintf("WHEN NOT MATCHED BY SOURCE AND %s IN UNNEST(%s) THEN DELETE", targetPartitionExpression, partitionVariable),
+			"WHEN NOT MATCHED THEN INSERT ROW",
+		}, "\n"),
+	}
+
+	return strings.Join(statements, ";\n") + ";", nil
+}
+
+// buildCreateOverwriteTargetQuery creates the target of insert_overwrite empty, with the columns of
+// the source and the partitioning, clustering and options of the asset, when it does not exist yet.
+func buildCreateOverwriteTargetQuery(asset *pipeline.Asset, source string) (string, error) {
+	mat := asset.Materialization
+	clauses := []string{"PARTITION BY " + mat.PartitionBy}
+	if len(mat.ClusterBy) > 0 {
+		clauses = append(clauses, "CLUSTER BY "+strings.Join(mat.ClusterBy, ", "))
+	}
+
+	optionsClause, err := buildTableOptions(asset, true)
+	if err != nil {
+		return "", err
+	}
+	if optionsClause != "" {
+		clauses = append(clauses, optionsClause)
+	}
+
+	return fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s %s AS SELECT * FROM %s LIMIT 0", asset.Name, strings.Join(clauses, " "), source), nil
+}
+
+func intervalVariables(granularity pipeline.MaterializationTimeGranularity) (string, string, error) {
+	switch granularity {
+	case pipeline.MaterializationTimeGranularityDate:
+		return "{{start_date}}", "{{end_date}}", nil
+	case pipeline.MaterializationTimeGranularityTimestamp:
+		return "{{start_timestamp}}", "{{end_timestamp}}", nil
+	default:
+		return "", "", errors.New("time_granularity must be either 'date', or 'timestamp'")
+	}
+}
+
 func buildDDLQuery(asset *pipeline.Asset, query string) (string, error) {
 	columnDefs := make([]string, 0, len(asset.Columns))
 	primaryKeys := []string{}
@@ -600,13 +705,16 @@ func buildDDLQuery(asset *pipeline.Asset, query string) (string, error) {
 		if col.Default != "" {
 			def += " DEFAULT " + col.Default
 		}
+		if col.IsNotNull() {
+			def += " NOT NULL"
+		}
 		if col.Description != "" {
 			def += fmt.Sprintf(` OPTIONS(description=%q)`, col.Description)
 		}
 		if col.PrimaryKey {
 			primaryKeys = append(primaryKeys, col.Name)
 		}
-		if col.ForeignKey != nil && col.ForeignKey.Table != "" && col.ForeignKey.Column != "" {
+		if col.HasForeignKey() {
 			foreignKeys = append(foreignKeys, fmt.Sprintf(
 				"FOREIGN KEY (%s) REFERENCES %s(%s) NOT ENFORCED",
 				col.Name, col.ForeignKey.Table, col.ForeignKey.Column,
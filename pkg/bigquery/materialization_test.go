@@ -783,6 +783,64 @@ COMMIT TRANSACTION;`,
 				"INSERT INTO my\\.asset SELECT dt, event_name from source_table where dt between '{{start_date}}' and '{{end_date}}';\n" +
 				"COMMIT TRANSACTION;$",
 		},
+		{
+			name: "insert_overwrite replaces the partitions in the query result",
+			task: &pipeline.Asset{
+				Name: "my.asset",
+				Materialization: pipeline.Materialization{
+					Type:        pipeline.MaterializationTypeTable,
+					Strategy:    pipeline.MaterializationStrategyInsertOverwrite,
+					PartitionBy: "DATE(ts)",
+				},
+				Columns: []pipeline.Column{{Name: "ts", Type: "TIMESTAMP"}},
+			},
+			query:      "SELECT ts, event_name FROM source_table;",
+			exactMatch: true,
+			want: "DECLARE bruin_overwrite_partitions_abcefghi ARRAY<DATE>;\n" +
+				"CREATE TEMP TABLE __bruin_overwrite_source_abcefghi AS SELECT ts, event_name FROM source_table;\n" +
+				"ASSERT NOT EXISTS (SELECT 1 FROM __bruin_overwrite_source_abcefghi source WHERE DATE(source.ts) IS NULL) AS 'insert_overwrite requires non-null partition values';\n" +
+				"CREATE TABLE IF NOT EXISTS my.asset PARTITION BY DATE(ts) AS SELECT * FROM __bruin_overwrite_source_abcefghi LIMIT 0;\n" +
+				"SET bruin_overwrite_partitions_abcefghi = ARRAY(SELECT DISTINCT DATE(source.ts) FROM __bruin_overwrite_source_abcefghi source);\n" +
+				"MERGE my.asset target\n" +
+				"USING __bruin_overwrite_source_abcefghi source\n" +
+				"ON FALSE\n" +
+				"WHEN NOT MATCHED BY SOURCE AND DATE(target.ts) IN UNNEST(bruin_overwrite_partitions_abcefghi) THEN DELETE\n" +
+				"WHEN NOT MATCHED THEN INSERT ROW;",
+		},
+		{
+			name: "insert_overwrite with time_granularity replaces the interval",
+			task: &pipeline.Asset{
+				Name: "my.asset",
+				Materialization: pipeline.Materialization{
+					Type:            pipeline.MaterializationTypeTable,
+					Strategy:        pipeline.MaterializationStrategyInsertOverwrite,
+					PartitionBy:     "dt",
+					ClusterBy:       []string{"event_name"},
+					TimeGranularity: pipeline.MaterializationTimeGranularityDate,
+				},
+				Columns: []pipeline.Column{{Name: "dt", Type: "DATE"}},
+			},
+			query:      "SELECT dt, event_name FROM source_table WHERE dt BETWEEN '{{start_date}}' AND '{{end_date}}'",
+			exactMatch: true,
+			want: "CREATE TABLE IF NOT EXISTS my.asset PARTITION BY dt CLUSTER BY event_name AS SELECT * FROM (SELECT dt, event_name FROM source_table WHERE dt BETWEEN '{{start_date}}' AND '{{end_date}}') LIMIT 0;\n" +
+				"MERGE my.asset target\n" +
+				"USING (SELECT dt, event_name FROM source_table WHERE dt BETWEEN '{{start_date}}' AND '{{end_date}}') source\n" +
+				"ON FALSE\n" +
+				"WHEN NOT MATCHED BY SOURCE AND target.dt BETWEEN '{{start_date}}' AND '{{end_date}}' THEN DELETE\n" +
+				"WHEN NOT MATCHED THEN INSERT ROW;",
+		},
+		{
+			name: "insert_overwrite requires partition_by",
+			task: &pipeline.Asset{
+				Name: "my.asset",
+				Materialization: pipeline.Materialization{
+					Type:     pipeline.MaterializationTypeTable,
+					Strategy: pipeline.MaterializationStrategyInsertOverwrite,
+				},
+			},
+			query:   "SELECT 1",
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1167,6 +1225,19 @@ func TestBuildDDLQuery(t *testing.T) {
 			},
 			want: "CREATE TABLE IF NOT EXISTS orders (\n  amount NUMERIC(10, 2) DEFAULT 0,\n  name STRING COLLATE \"und:ci\",\n  customer_id INT64,\n  FOREIGN KEY (customer_id) REFERENCES customers(id) NOT ENFORCED\n)",
 		},
+		{
+			name: "table with a not null foreign key",
+			asset: &pipeline.Asset{
+				Name: "orders",
+				Columns: []pipeline.Column{
+					{Name: "customer_id", Type: "INT64", Nullable: pipeline.DefaultTrueBool{Value: boolp(false)}, ForeignKey: &pipeline.ColumnReference{Table: "customers", Column: "id"}},
+				},
+				Materialization: pipeline.Materialization{
+					Type: pipeline.MaterializationTypeTable,
+				},
+			},
+			want: "CREATE TABLE IF NOT EXISTS orders (\n  customer_id INT64 NOT NULL,\n  FOREIGN KEY (customer_id) REFERENCES customers(id) NOT ENFORCED\n)",
+		},
 	}
 
 	for _, tt := range tests {
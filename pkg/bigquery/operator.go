@@ -12,11 +12,13 @@ import (
 	"github.com/bruin-data/bruin/pkg/devenv"
 	"github.com/bruin-data/bruin/pkg/executor"
 	"github.com/bruin-data/bruin/pkg/helpers"
+	"github.com/bruin-data/bruin/pkg/microbatch"
 	"github.com/bruin-data/bruin/pkg/pipeline"
 	"github.com/bruin-data/bruin/pkg/query"
 	"github.com/bruin-data/bruin/pkg/scheduler"
 	"github.com/bruin-data/bruin/pkg/sqlparser"
 	"github.com/pkg/errors"
+	"github.com/spf13/afero"
 )
 
 const CharacterLimit = 10000
@@ -36,11 +38,24 @@ type queryLimitChecker interface {
 	CheckQueryLimits(ctx context.Context, q *query.Query) error
 }
 
+type grantApplier interface {
+	ApplyGrants(ctx context.Context, asset *pipeline.Asset) error
+}
+
+type policyApplier interface {
+	ApplyPolicies(ctx context.Context, asset *pipeline.Asset) error
+}
+
+type microbatcher interface {
+	Run(ctx context.Context, asset *pipeline.Asset, runBatch func(ctx context.Context) error) error
+}
+
 type BasicOperator struct {
 	connection   config.ConnectionGetter
 	extractor    query.QueryExtractor
 	materializer materializer
 	devEnv       devEnv
+	microbatcher microbatcher
 }
 
 func NewBasicOperator(conn config.ConnectionGetter, extractor query.QueryExtractor, materializer materializer, parser *sqlparser.SQLParser) *BasicOperator {
@@ -53,6 +68,7 @@ func NewBasicOperator(conn config.ConnectionGetter, extractor query.QueryExtract
 			Conn:    conn,
 			Parser:  parser,
 		},
+		microbatcher: microbatch.NewRunner(afero.NewOsFs()),
 	}
 }
 
@@ -61,6 +77,16 @@ func (o BasicOperator) Run(ctx context.Context, ti scheduler.TaskInstance) error
 }
 
 func (o BasicOperator) RunTask(ctx context.Context, p *pipeline.Pipeline, t *pipeline.Asset) error {
+	if o.microbatcher != nil && t.Materialization.IsMicrobatched() && !o.materializer.IsFullRefresh() {
+		return o.microbatcher.Run(ctx, t, func(batchCtx context.Context) error {
+			return o.runTask(batchCtx, p, t)
+		})
+	}
+
+	return o.runTask(ctx, p, t)
+}
+
+func (o BasicOperator) runTask(ctx context.Context, p *pipeline.Pipeline, t *pipeline.Asset) error {
 	ctx = query.WithQueryType(ctx, query.QueryTypeMain)
 	extractor, err := o.extractor.CloneForAsset(ctx, p, t)
 	if err != nil {
@@ -88,10 +114,10 @@ func (o BasicOperator) RunTask(ctx context.Context, p *pipeline.Pipeline, t *pip
 	}
 	q.Query = materialized
 
-	if t.Materialization.Strategy == pipeline.MaterializationStrategyTimeInterval {
+	if t.Materialization.UsesIntervalDates() {
 		renderedQueries, err := extractor.ExtractQueriesFromString(materialized)
 		if err != nil {
-			return errors.Wrap(err, "cannot re-extract/render materialized query for time_interval strategy")
+			return errors.Wrap(err, "cannot re-extract/render materialized query with interval dates")
 		}
 
 		if len(renderedQueries) == 0 {
@@ -172,6 +198,16 @@ func (o BasicOperator) RunTask(ctx context.Context, p *pipeline.Pipeline, t *pip
 		}
 	}
 
+	if applier, ok := conn.(grantApplier); ok {
+		if err := applier.ApplyGrants(ctx, t); err != nil {
+			return err
+		}
+	}
+
+	if applier, ok := conn.(policyApplier); ok {
+		return applier.ApplyPolicies(ctx, t)
+	}
+
 	return nil
 }
 
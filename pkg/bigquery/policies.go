@@ -0,0 +1,112 @@
+package bigquery
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"cloud.google.com/go/bigquery"
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/bruin-data/bruin/pkg/query"
+	"github.com/pkg/errors"
+)
+
+// ApplyPolicies sets the policy tags given as the `masking_policy` of the asset's columns and
+// creates its row access policy. Policy tags are only updated when they differ from the ones on
+// the table, the row access policy is created with CREATE OR REPLACE.
+func (d *Client) ApplyPolicies(ctx context.Context, asset *pipeline.Asset) error {
+	if !asset.HasPolicies() || asset.Materialization.Type == pipeline.MaterializationTypeNone {
+		return nil
+	}
+
+	if err := d.applyPolicyTags(ctx, asset); err != nil {
+		return err
+	}
+
+	if asset.RowAccessPolicy == nil {
+		return nil
+	}
+
+	q, err := RowAccessPolicyQuery(asset)
+	if err != nil {
+		return err
+	}
+	if err := d.RunQueryWithoutResult(ctx, &query.Query{Query: q}); err != nil {
+		return errors.Wrapf(err, "failed to create the row access policy on '%s'", asset.Name)
+	}
+	return nil
+}
+
+func (d *Client) applyPolicyTags(ctx context.Context, asset *pipeline.Asset) error {
+	policyTags := make(map[string]string)
+	for _, column := range asset.Columns {
+		if policy := strings.TrimSpace(column.MaskingPolicy); policy != "" {
+			policyTags[strings.ToLower(column.Name)] = policy
+		}
+	}
+	if len(policyTags) == 0 {
+		return nil
+	}
+
+	if asset.Materialization.Type == pipeline.MaterializationTypeView {
+		return errors.New("masking policies are applied as policy tags in BigQuery, which are not supported on views")
+	}
+
+	tableRef, err := d.getTableRef(ctx, asset.Name)
+	if err != nil {
+		return err
+	}
+
+	meta, err := tableRef.Metadata(ctx)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get the metadata of '%s'", asset.Name)
+	}
+
+	if !setPolicyTags(meta.Schema, policyTags) {
+		return nil
+	}
+
+	if _, err := tableRef.Update(ctx, bigquery.TableMetadataToUpdate{Schema: meta.Schema}, meta.ETag); err != nil {
+		return errors.Wrapf(err, "failed to set the policy tags of '%s'", asset.Name)
+	}
+	return nil
+}
+
+// setPolicyTags sets the policy tags on the top-level fields of the schema and reports whether any
+// of them changed.
+func setPolicyTags(schema bigquery.Schema, policyTags map[string]string) bool {
+	changed := false
+	for _, field := range schema {
+		policy, ok := policyTags[strings.ToLower(field.Name)]
+		if !ok {
+			continue
+		}
+		if field.PolicyTags != nil && len(field.PolicyTags.Names) == 1 && field.PolicyTags.Names[0] == policy {
+			continue
+		}
+		field.PolicyTags = &bigquery.PolicyTagList{Names: []string{policy}}
+		changed = true
+	}
+	return changed
+}
+
+// RowAccessPolicyQuery returns the statement that creates the row access policy of the asset.
+func RowAccessPolicyQuery(asset *pipeline.Asset) (string, error) {
+	policy := asset.RowAccessPolicy
+	if strings.TrimSpace(policy.Filter) == "" {
+		return "", errors.New("row_access_policy.filter is required for BigQuery assets")
+	}
+	if asset.Materialization.Type != pipeline.MaterializationTypeTable {
+		return "", errors.New("row access policies are only supported for table materializations on BigQuery")
+	}
+
+	q := fmt.Sprintf("CREATE OR REPLACE ROW ACCESS POLICY %s ON `%s`", policy.PolicyName(asset.Name), asset.Name)
+	if len(policy.Grantees) > 0 {
+		grantees := make([]string, 0, len(policy.Grantees))
+		for _, grantee := range policy.Grantees {
+			grantees = append(grantees, fmt.Sprintf("%q", grantee))
+		}
+		q += fmt.Sprintf(" GRANT TO (%s)", strings.Join(grantees, ", "))
+	}
+	return q + fmt.Sprintf(" FILTER USING (%s);", policy.Filter), nil
+}
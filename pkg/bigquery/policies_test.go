@@ -0,0 +1,49 @@
+package bigquery
+
+import (
+	"testing"
+
+	"cloud.google.com/go/bigquery"
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRowAccessPolicyQuery(t *testing.T) {
+	t.Parallel()
+
+	got, err := RowAccessPolicyQuery(&pipeline.Asset{
+		Name:            "project.dataset.orders",
+		Materialization: pipeline.Materialization{Type: pipeline.MaterializationTypeTable},
+		RowAccessPolicy: &pipeline.RowAccessPolicy{
+			Name:     "eu_only",
+			Filter:   "region = 'EU'",
+			Grantees: []string{"group:eu-analysts@example.com"},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "CREATE OR REPLACE ROW ACCESS POLICY eu_only ON `project.dataset.orders` GRANT TO (\"group:eu-analysts@example.com\") FILTER USING (region = 'EU');", got)
+
+	_, err = RowAccessPolicyQuery(&pipeline.Asset{
+		Name:            "dataset.orders",
+		Materialization: pipeline.Materialization{Type: pipeline.MaterializationTypeTable},
+		RowAccessPolicy: &pipeline.RowAccessPolicy{Name: "eu_only"},
+	})
+	require.Error(t, err)
+}
+
+func TestSetPolicyTags(t *testing.T) {
+	t.Parallel()
+
+	emailTag := "projects/p/locations/eu/taxonomies/1/policyTags/2"
+	schema := bigquery.Schema{
+		{Name: "id"},
+		{Name: "Email"},
+		{Name: "phone", PolicyTags: &bigquery.PolicyTagList{Names: []string{emailTag}}},
+	}
+
+	require.True(t, setPolicyTags(schema, map[string]string{"email": emailTag, "phone": emailTag}))
+	require.Nil(t, schema[0].PolicyTags)
+	require.Equal(t, []string{emailTag}, schema[1].PolicyTags.Names)
+
+	require.False(t, setPolicyTags(schema, map[string]string{"email": emailTag, "phone": emailTag}))
+}
@@ -0,0 +1,162 @@
+package catalog
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/bruin-data/bruin/pkg/glossary"
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/pkg/errors"
+)
+
+const (
+	FormatDataHubMCE   = "datahub-mce"
+	FormatOpenMetadata = "openmetadata"
+)
+
+// Formats are the catalog formats the metadata can be exported in.
+var Formats = []string{FormatDataHubMCE, FormatOpenMetadata}
+
+type Options struct {
+	// Glossary adds the entities and attributes of the Bruin glossary as glossary terms, the columns
+	// that refer to an attribute are tagged with its term.
+	Glossary *glossary.Glossary
+	// Database is prepended to the asset names that only consist of a schema and a table, since the
+	// catalogs identify the tables by their fully qualified names.
+	Database string
+	// Origin is the DataHub environment of the datasets, PROD by default.
+	Origin string
+	// Services are the names of the OpenMetadata database services per platform, e.g. `bigquery`,
+	// the name of the platform itself is used for the platforms without a service.
+	Services map[string]string
+	// GlossaryName is the OpenMetadata glossary the terms are created in, and the classification
+	// the asset tags are created in, Bruin by default.
+	GlossaryName string
+}
+
+// File is one of the files the catalog ingests.
+type File struct {
+	Name    string
+	Content []byte
+}
+
+type Result struct {
+	Files []*File
+	// Warnings are the parts of the metadata that have no equivalent in the catalog and were left
+	// out, e.g. checks the catalog has no test for.
+	Warnings []string
+}
+
+// Export builds the files the file-based ingestion of the catalog understands.
+func Export(format string, pipelines []*pipeline.Pipeline, opts Options) (*Result, error) {
+	if opts.Origin == "" {
+		opts.Origin = "PROD"
+	}
+	if opts.GlossaryName == "" {
+		opts.GlossaryName = "Bruin"
+	}
+
+	datasets := resolveDatasets(pipelines, opts.Database)
+	switch format {
+	case FormatDataHubMCE:
+		return exportDataHub(datasets, opts)
+	case FormatOpenMetadata:
+		return exportOpenMetadata(datasets, opts)
+	default:
+		return nil, errors.Errorf("unknown catalog format '%s', possible values are: %s", format, strings.Join(Formats, ", "))
+	}
+}
+
+// dataset is an asset that produces a table, the unit both catalogs describe.
+type dataset struct {
+	asset    *pipeline.Asset
+	pipeline *pipeline.Pipeline
+	// platform is the connection type of the asset, e.g. `google_cloud_platform`.
+	platform string
+	// name is the fully qualified name of the table.
+	name string
+}
+
+type datasets struct {
+	list   []*dataset
+	byName map[string]*dataset
+	// skipped are the assets that do not produce a table on a known platform.
+	skipped []string
+}
+
+func resolveDatasets(pipelines []*pipeline.Pipeline, database string) *datasets {
+	ds := &datasets{
+		list:   make([]*dataset, 0),
+		byName: make(map[string]*dataset),
+	}
+	for _, p := range pipelines {
+		for _, asset := range p.Assets {
+			platform := asset.TablePlatform()
+			if platform == "" {
+				ds.skipped = append(ds.skipped, asset.Name)
+				continue
+			}
+			d := &dataset{asset: asset, pipeline: p, platform: platform, name: qualifiedName(asset.Name, database)}
+			ds.list = append(ds.list, d)
+			if _, ok := ds.byName[strings.ToLower(asset.Name)]; !ok {
+				ds.byName[strings.ToLower(asset.Name)] = d
+			}
+		}
+	}
+	return ds
+}
+
+// lookup resolves a table referenced by an asset to the dataset that produces it, the tables that
+// are not produced by an asset are assumed to live on the platform of the referencing asset.
+func (ds *datasets) lookup(table string, from *dataset, database string) *dataset {
+	if d, ok := ds.byName[strings.ToLower(table)]; ok {
+		return d
+	}
+	return &dataset{platform: from.platform, name: qualifiedName(table, database)}
+}
+
+func qualifiedName(name, database string) string {
+	if database != "" && strings.Count(name, ".") == 1 {
+		return database + "." + name
+	}
+	return name
+}
+
+// checkID returns a stable identifier for a check, so that exporting the same asset again updates
+// the check rather than creating a new one.
+func checkID(parts ...string) string {
+	sum := sha256.Sum256([]byte(strings.Join(parts, "\x00")))
+	return hex.EncodeToString(sum[:16])
+}
+
+// checkValue renders the value of a column check the way the catalogs take their parameters.
+func checkValue(value pipeline.ColumnCheckValue) string {
+	js, err := value.MarshalJSON()
+	if err != nil || string(js) == "null" {
+		return ""
+	}
+	if value.String != nil {
+		return *value.String
+	}
+	return string(js)
+}
+
+func sortedAttributes(attributes map[string]*glossary.Attribute) []*glossary.Attribute {
+	sorted := make([]*glossary.Attribute, 0, len(attributes))
+	for _, attribute := range attributes {
+		sorted = append(sorted, attribute)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+	return sorted
+}
+
+func marshalFile(name string, content any) (*File, error) {
+	js, err := json.MarshalIndent(content, "", "  ")
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to marshal %s", name)
+	}
+	return &File{Name: name, Content: append(js, '\n')}, nil
+}
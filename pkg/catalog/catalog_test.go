@@ -0,0 +1,165 @@
+package catalog
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/bruin-data/bruin/pkg/glossary"
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func catalogTestPipeline() *pipeline.Pipeline {
+	minAmount := 0
+	return &pipeline.Pipeline{
+		Name: "sales",
+		Assets: []*pipeline.Asset{
+			{
+				Name:       "raw.orders",
+				Type:       pipeline.AssetTypeIngestr,
+				Parameters: pipeline.ParameterMap{"destination": "bigquery"},
+				Columns:    []pipeline.Column{{Name: "id", Type: "INT64"}},
+			},
+			{
+				Name:        "mart.orders",
+				Type:        pipeline.AssetTypeBigqueryQuery,
+				Description: "Orders of the shop.",
+				Owner:       "jane@example.com",
+				Tags:        []string{"finance"},
+				Upstreams:   []pipeline.Upstream{{Type: "asset", Value: "raw.orders"}},
+				Columns: []pipeline.Column{
+					{
+						Name:            "order_id",
+						Type:            "INT64",
+						PrimaryKey:      true,
+						EntityAttribute: &pipeline.EntityAttribute{Entity: "Order", Attribute: "id"},
+						Upstreams:       []*pipeline.UpstreamColumn{{Table: "raw.orders", Column: "id"}},
+						Checks: []pipeline.ColumnCheck{
+							{Name: "not_null"},
+							{Name: "min", Value: pipeline.ColumnCheckValue{Int: &minAmount}},
+							{Name: "relationships"},
+						},
+					},
+				},
+				CustomChecks: []pipeline.CustomCheck{{Name: "has_rows", Query: "SELECT COUNT(*) > 0 FROM mart.orders", Value: 1}},
+			},
+			{Name: "wait_for_orders", Type: pipeline.AssetTypeBigqueryTableSensor},
+		},
+	}
+}
+
+func catalogTestGlossary() *glossary.Glossary {
+	return &glossary.Glossary{Entities: []*glossary.Entity{{
+		Name:        "Order",
+		Description: "An order placed in the shop.",
+		Attributes:  map[string]*glossary.Attribute{"id": {Name: "id", Description: "The identifier of the order."}},
+	}}}
+}
+
+func TestExport_DataHub(t *testing.T) {
+	t.Parallel()
+
+	result, err := Export(FormatDataHubMCE, []*pipeline.Pipeline{catalogTestPipeline()}, Options{
+		Database: "project",
+		Glossary: catalogTestGlossary(),
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Files, 1)
+	assert.Equal(t, "datahub_mce.json", result.Files[0].Name)
+	assert.Equal(t, []string{"asset 'wait_for_orders' does not produce a table on a known platform, it is left out"}, result.Warnings)
+
+	var records []map[string]any
+	require.NoError(t, json.Unmarshal(result.Files[0].Content, &records))
+	// the glossary node and term, two datasets and the four assertions of mart.orders
+	require.Len(t, records, 8)
+
+	content := string(result.Files[0].Content)
+	assert.Contains(t, content, `"urn": "urn:li:glossaryNode:Order"`)
+	assert.Contains(t, content, `"urn": "urn:li:glossaryTerm:Order.id"`)
+	assert.Contains(t, content, `"urn": "urn:li:dataset:(urn:li:dataPlatform:bigquery,project.raw.orders,PROD)"`)
+	assert.Contains(t, content, `"owner": "urn:li:corpuser:jane@example.com"`)
+	assert.Contains(t, content, `"tag": "urn:li:tag:finance"`)
+	assert.Contains(t, content, `"urn:li:schemaField:(urn:li:dataset:(urn:li:dataPlatform:bigquery,project.raw.orders,PROD),id)"`)
+	assert.Contains(t, content, `"operator": "NOT_NULL"`)
+	assert.Contains(t, content, `"nativeType": "custom_check:has_rows"`)
+
+	minAssertion := records[5]
+	assert.Equal(t, "assertion", minAssertion["entityType"])
+	info := minAssertion["aspect"].(map[string]any)["json"].(map[string]any)["datasetAssertion"].(map[string]any)
+	assert.Equal(t, "GREATER_THAN_OR_EQUAL_TO", info["operator"])
+	assert.Equal(t, map[string]any{"value": map[string]any{"value": "0", "type": "NUMBER"}}, info["parameters"])
+}
+
+func TestExport_OpenMetadata(t *testing.T) {
+	t.Parallel()
+
+	result, err := Export(FormatOpenMetadata, []*pipeline.Pipeline{catalogTestPipeline()}, Options{
+		Database: "project",
+		Glossary: catalogTestGlossary(),
+		Services: map[string]string{"bigquery": "warehouse"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"asset 'wait_for_orders' does not produce a table on a known platform, it is left out",
+		"check 'relationships' of column 'mart.orders.order_id' has no OpenMetadata test definition, it is left out",
+	}, result.Warnings)
+
+	files := make(map[string]string, len(result.Files))
+	names := make([]string, 0, len(result.Files))
+	for _, file := range result.Files {
+		files[file.Name] = string(file.Content)
+		names = append(names, file.Name)
+	}
+	assert.Equal(t, openMetadataFiles, names)
+
+	var tables []*omTable
+	require.NoError(t, json.Unmarshal([]byte(files["tables.json"]), &tables))
+	require.Len(t, tables, 2)
+	assert.Equal(t, &omTable{
+		Name:           "orders",
+		DatabaseSchema: "warehouse.project.mart",
+		Description:    "Orders of the shop.",
+		Owners:         []*omEntityReference{{Type: "user", Name: "jane@example.com"}},
+		Tags:           []*omTagLabel{{TagFQN: "Bruin.finance", Source: "Classification", LabelType: "Manual", State: "Confirmed"}},
+		Columns: []*omColumn{{
+			Name:            "order_id",
+			DataType:        "BIGINT",
+			DataTypeDisplay: "INT64",
+			Constraint:      "PRIMARY_KEY",
+			Tags:            []*omTagLabel{{TagFQN: "Bruin.Order.id", Source: "Glossary", LabelType: "Manual", State: "Confirmed"}},
+		}},
+	}, tables[1])
+
+	var lineage []*omLineage
+	require.NoError(t, json.Unmarshal([]byte(files["lineage.json"]), &lineage))
+	require.Len(t, lineage, 1)
+	assert.Equal(t, "warehouse.project.raw.orders", lineage[0].Edge.FromEntity.FullyQualifiedName)
+	assert.Equal(t, []*omColumnLineage{{
+		FromColumns: []string{"warehouse.project.raw.orders.id"},
+		ToColumn:    "warehouse.project.mart.orders.order_id",
+	}}, lineage[0].Edge.LineageDetails.ColumnsLineage)
+
+	var testCases []*omTestCase
+	require.NoError(t, json.Unmarshal([]byte(files["test_cases.json"]), &testCases))
+	require.Len(t, testCases, 3)
+	assert.Equal(t, "columnValuesToBeNotNull", testCases[0].TestDefinition)
+	assert.Equal(t, "<#E::table::warehouse.project.mart.orders::columns::order_id>", testCases[0].EntityLink)
+	assert.Equal(t, []*omParameter{{Name: "minValue", Value: "0"}}, testCases[1].ParameterValues)
+	assert.Equal(t, "tableCustomSQLQuery", testCases[2].TestDefinition)
+	assert.Equal(t, "SELECT 1 WHERE (SELECT COUNT(*) > 0 FROM mart.orders) <> 1", testCases[2].ParameterValues[0].Value)
+
+	assert.Contains(t, files["glossary_terms.json"], `"parent": "Bruin.Order"`)
+	assert.Contains(t, files["databases.json"], `"service": "warehouse"`)
+}
+
+func TestExport_UnqualifiedTables(t *testing.T) {
+	t.Parallel()
+
+	result, err := Export(FormatOpenMetadata, []*pipeline.Pipeline{catalogTestPipeline()}, Options{})
+	require.NoError(t, err)
+	assert.Contains(t, result.Warnings, "asset 'mart.orders' is not named as database.schema.table, use --database to set the database of the schema.table names")
+
+	_, err = Export("atlas", nil, Options{})
+	require.EqualError(t, err, "unknown catalog format 'atlas', possible values are: datahub-mce, openmetadata")
+}
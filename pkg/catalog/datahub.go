@@ -0,0 +1,413 @@
+package catalog
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/bruin-data/bruin/pkg/pipeline"
+)
+
+// The DataHub file source reads a JSON array of metadata change events, each wrapping a snapshot
+// of an entity, and metadata change proposals for the entities that have no snapshot, like
+// assertions. The union types are keyed by their fully qualified record name.
+const (
+	dataHubDatasetSnapshot      = "com.linkedin.pegasus2avro.metadata.snapshot.DatasetSnapshot"
+	dataHubGlossaryNodeSnapshot = "com.linkedin.pegasus2avro.metadata.snapshot.GlossaryNodeSnapshot"
+	dataHubGlossaryTermSnapshot = "com.linkedin.pegasus2avro.metadata.snapshot.GlossaryTermSnapshot"
+	dataHubAspectPrefix         = "com.linkedin.pegasus2avro."
+
+	dataHubActor = "urn:li:corpuser:bruin"
+)
+
+var dataHubPlatforms = map[string]string{
+	"google_cloud_platform": "bigquery",
+	"synapse":               "mssql",
+	"fabric":                "mssql",
+	"motherduck":            "duckdb",
+}
+
+type dataHubEvent struct {
+	ProposedSnapshot map[string]*dataHubSnapshot `json:"proposedSnapshot"`
+}
+
+type dataHubSnapshot struct {
+	URN     string           `json:"urn"`
+	Aspects []map[string]any `json:"aspects"`
+}
+
+type dataHubProposal struct {
+	EntityType string         `json:"entityType"`
+	EntityURN  string         `json:"entityUrn"`
+	ChangeType string         `json:"changeType"`
+	AspectName string         `json:"aspectName"`
+	Aspect     map[string]any `json:"aspect"`
+}
+
+type dataHubAuditStamp struct {
+	Time  int64  `json:"time"`
+	Actor string `json:"actor"`
+}
+
+var dataHubStamp = dataHubAuditStamp{Actor: dataHubActor}
+
+func aspect(name string, value any) map[string]any {
+	return map[string]any{dataHubAspectPrefix + name: value}
+}
+
+func dataHubPlatform(platform string) string {
+	if name, ok := dataHubPlatforms[platform]; ok {
+		return name
+	}
+	return platform
+}
+
+func dataHubDatasetURN(d *dataset, origin string) string {
+	platform := dataHubPlatform(d.platform)
+	name := d.name
+	// the Snowflake source of DataHub lowercases the identifiers of the tables it ingests.
+	if platform == "snowflake" {
+		name = strings.ToLower(name)
+	}
+	return fmt.Sprintf("urn:li:dataset:(urn:li:dataPlatform:%s,%s,%s)", platform, name, origin)
+}
+
+func dataHubFieldURN(datasetURN, column string) string {
+	return fmt.Sprintf("urn:li:schemaField:(%s,%s)", datasetURN, column)
+}
+
+func dataHubTagURN(tag string) string {
+	return "urn:li:tag:" + tag
+}
+
+func dataHubTermURN(entity, attribute string) string {
+	return "urn:li:glossaryTerm:" + entity + "." + attribute
+}
+
+func dataHubOwnership(owner string) map[string]any {
+	return aspect("common.Ownership", map[string]any{
+		"owners":       []map[string]any{{"owner": "urn:li:corpuser:" + owner, "type": "DATAOWNER"}},
+		"lastModified": dataHubStamp,
+	})
+}
+
+func dataHubTags(tags []string) map[string]any {
+	associations := make([]map[string]string, 0, len(tags))
+	for _, tag := range tags {
+		associations = append(associations, map[string]string{"tag": dataHubTagURN(tag)})
+	}
+	return map[string]any{"tags": associations}
+}
+
+func exportDataHub(ds *datasets, opts Options) (*Result, error) {
+	result := &Result{}
+	for _, name := range ds.skipped {
+		result.Warnings = append(result.Warnings, fmt.Sprintf("asset '%s' does not produce a table on a known platform, it is left out", name))
+	}
+
+	records := make([]any, 0)
+	records = append(records, dataHubGlossary(opts)...)
+	for _, d := range ds.list {
+		urn := dataHubDatasetURN(d, opts.Origin)
+		records = append(records, &dataHubEvent{
+			ProposedSnapshot: map[string]*dataHubSnapshot{
+				dataHubDatasetSnapshot: {URN: urn, Aspects: dataHubDatasetAspects(ds, d, urn, opts)},
+			},
+		})
+
+		records = append(records, dataHubAssertions(d, urn)...)
+	}
+
+	file, err := marshalFile("datahub_mce.json", records)
+	if err != nil {
+		return nil, err
+	}
+	result.Files = []*File{file}
+	return result, nil
+}
+
+// dataHubGlossary turns the entities of the glossary into glossary nodes and their attributes into
+// the terms of those nodes.
+func dataHubGlossary(opts Options) []any {
+	if opts.Glossary == nil {
+		return nil
+	}
+
+	entities := append(opts.Glossary.Entities[:0:0], opts.Glossary.Entities...)
+	sort.Slice(entities, func(i, j int) bool { return entities[i].Name < entities[j].Name })
+
+	records := make([]any, 0)
+	for _, entity := range entities {
+		nodeURN := "urn:li:glossaryNode:" + entity.Name
+		records = append(records, &dataHubEvent{
+			ProposedSnapshot: map[string]*dataHubSnapshot{
+				dataHubGlossaryNodeSnapshot: {
+					URN: nodeURN,
+					Aspects: []map[string]any{
+						aspect("glossary.GlossaryNodeInfo", map[string]any{"name": entity.Name, "definition": entity.Description}),
+					},
+				},
+			},
+		})
+
+		for _, attribute := range sortedAttributes(entity.Attributes) {
+			records = append(records, &dataHubEvent{
+				ProposedSnapshot: map[string]*dataHubSnapshot{
+					dataHubGlossaryTermSnapshot: {
+						URN: dataHubTermURN(entity.Name, attribute.Name),
+						Aspects: []map[string]any{
+							aspect("glossary.GlossaryTermInfo", map[string]any{
+								"name":       attribute.Name,
+								"definition": attribute.Description,
+								"termSource": "INTERNAL",
+								"parentNode": nodeURN,
+							}),
+						},
+					},
+				},
+			})
+		}
+	}
+	return records
+}
+
+func dataHubDatasetAspects(ds *datasets, d *dataset, urn string, opts Options) []map[string]any {
+	asset := d.asset
+	properties := map[string]any{
+		"name":             asset.Name,
+		"customProperties": dataHubCustomProperties(d),
+	}
+	if asset.Description != "" {
+		properties["description"] = asset.Description
+	}
+
+	aspects := []map[string]any{aspect("dataset.DatasetProperties", properties)}
+	if asset.Owner != "" {
+		aspects = append(aspects, dataHubOwnership(asset.Owner))
+	}
+	if len(asset.Tags) > 0 {
+		aspects = append(aspects, aspect("common.GlobalTags", dataHubTags(asset.Tags)))
+	}
+	if len(asset.Columns) > 0 {
+		aspects = append(aspects, aspect("schema.SchemaMetadata", dataHubSchema(d, opts)))
+	}
+	if lineage := dataHubLineage(ds, d, urn, opts); lineage != nil {
+		aspects = append(aspects, aspect("dataset.UpstreamLineage", lineage))
+	}
+	return aspects
+}
+
+func dataHubCustomProperties(d *dataset) map[string]string {
+	properties := map[string]string{
+		"bruin_pipeline":   d.pipeline.Name,
+		"bruin_asset_type": string(d.asset.Type),
+	}
+	if d.asset.Materialization.Type != pipeline.MaterializationTypeNone {
+		properties["bruin_materialization"] = string(d.asset.Materialization.Type)
+	}
+	for key, value := range d.asset.Meta {
+		properties[key] = value
+	}
+	return properties
+}
+
+func dataHubSchema(d *dataset, opts Options) map[string]any {
+	fields := make([]map[string]any, 0, len(d.asset.Columns))
+	for _, column := range d.asset.Columns {
+		field := map[string]any{
+			"fieldPath":      column.Name,
+			"nativeDataType": column.Type,
+			"type":           map[string]any{"type": map[string]any{dataHubAspectPrefix + "schema." + dataHubFieldType(column.Type): map[string]any{}}},
+			"nullable":       column.Nullable.Bool(),
+			"isPartOfKey":    column.PrimaryKey,
+		}
+		if column.Description != "" {
+			field["description"] = column.Description
+		}
+		if len(column.Tags) > 0 {
+			field["globalTags"] = dataHubTags(column.Tags)
+		}
+		if ea := column.EntityAttribute; ea != nil && opts.Glossary != nil {
+			field["glossaryTerms"] = map[string]any{
+				"terms":      []map[string]string{{"urn": dataHubTermURN(ea.Entity, ea.Attribute)}},
+				"auditStamp": dataHubStamp,
+			}
+		}
+		fields = append(fields, field)
+	}
+
+	return map[string]any{
+		"schemaName":     d.asset.Name,
+		"platform":       "urn:li:dataPlatform:" + dataHubPlatform(d.platform),
+		"version":        0,
+		"hash":           "",
+		"platformSchema": map[string]any{dataHubAspectPrefix + "schema.OtherSchema": map[string]string{"rawSchema": ""}},
+		"fields":         fields,
+	}
+}
+
+// dataHubFieldType maps the native type of a column to the type class DataHub groups columns by.
+func dataHubFieldType(nativeType string) string {
+	t := strings.ToUpper(nativeType)
+	switch {
+	case t == "":
+		return "NullType"
+	case strings.Contains(t, "BOOL"):
+		return "BooleanType"
+	case strings.Contains(t, "INT"), strings.Contains(t, "NUM"), strings.Contains(t, "DEC"),
+		strings.Contains(t, "FLOAT"), strings.Contains(t, "DOUBLE"), strings.Contains(t, "REAL"):
+		return "NumberType"
+	case strings.Contains(t, "TIME"), strings.Contains(t, "DATE"):
+		return "TimeType"
+	case strings.Contains(t, "ARRAY"), strings.HasPrefix(t, "LIST"):
+		return "ArrayType"
+	case strings.Contains(t, "STRUCT"), strings.Contains(t, "RECORD"), strings.Contains(t, "OBJECT"),
+		strings.Contains(t, "MAP"), strings.Contains(t, "JSON"), strings.Contains(t, "VARIANT"):
+		return "RecordType"
+	case strings.Contains(t, "BYTE"), strings.Contains(t, "BINARY"), strings.Contains(t, "BLOB"):
+		return "BytesType"
+	default:
+		return "StringType"
+	}
+}
+
+func dataHubLineage(ds *datasets, d *dataset, urn string, opts Options) map[string]any {
+	upstreams := make([]map[string]any, 0)
+	seen := make(map[string]bool)
+	addUpstream := func(upstreamURN string) {
+		if seen[upstreamURN] || upstreamURN == urn {
+			return
+		}
+		seen[upstreamURN] = true
+		upstreams = append(upstreams, map[string]any{"dataset": upstreamURN, "type": "TRANSFORMED", "auditStamp": dataHubStamp})
+	}
+
+	for _, upstream := range d.asset.Upstreams {
+		if upstream.Type != "" && upstream.Type != "asset" {
+			continue
+		}
+		if parent, ok := ds.byName[strings.ToLower(upstream.Value)]; ok {
+			addUpstream(dataHubDatasetURN(parent, opts.Origin))
+		}
+	}
+
+	fineGrained := make([]map[string]any, 0)
+	for _, column := range d.asset.Columns {
+		sources := make([]string, 0)
+		for _, upstream := range column.Upstreams {
+			if upstream == nil || upstream.Table == "" {
+				continue
+			}
+			parentURN := dataHubDatasetURN(ds.lookup(upstream.Table, d, opts.Database), opts.Origin)
+			addUpstream(parentURN)
+			sources = append(sources, dataHubFieldURN(parentURN, upstream.Column))
+		}
+		if len(sources) == 0 {
+			continue
+		}
+		fineGrained = append(fineGrained, map[string]any{
+			"upstreamType":   "FIELD_SET",
+			"upstreams":      sources,
+			"downstreamType": "FIELD",
+			"downstreams":    []string{dataHubFieldURN(urn, column.Name)},
+		})
+	}
+
+	if len(upstreams) == 0 {
+		return nil
+	}
+	lineage := map[string]any{"upstreams": upstreams}
+	if len(fineGrained) > 0 {
+		lineage["fineGrainedLineages"] = fineGrained
+	}
+	return lineage
+}
+
+// dataHubCheckParameters maps the built-in column checks to the standard operators of DataHub
+// assertions, the aggregation is applied to the column before it is compared.
+var dataHubCheckParameters = map[string]struct {
+	operator    string
+	aggregation string
+	value       string
+	valueType   string
+}{
+	"not_null":        {operator: "NOT_NULL", aggregation: "IDENTITY"},
+	"unique":          {operator: "EQUAL_TO", aggregation: "UNIQUE_PROPOTION", value: "1.0", valueType: "NUMBER"},
+	"positive":        {operator: "GREATER_THAN", aggregation: "IDENTITY", value: "0", valueType: "NUMBER"},
+	"negative":        {operator: "LESS_THAN", aggregation: "IDENTITY", value: "0", valueType: "NUMBER"},
+	"non_negative":    {operator: "GREATER_THAN_OR_EQUAL_TO", aggregation: "IDENTITY", value: "0", valueType: "NUMBER"},
+	"min":             {operator: "GREATER_THAN_OR_EQUAL_TO", aggregation: "IDENTITY", valueType: "NUMBER"},
+	"max":             {operator: "LESS_THAN_OR_EQUAL_TO", aggregation: "IDENTITY", valueType: "NUMBER"},
+	"accepted_values": {operator: "IN", aggregation: "IDENTITY", valueType: "LIST"},
+	"pattern":         {operator: "REGEX_MATCH", aggregation: "IDENTITY", valueType: "STRING"},
+}
+
+// dataHubAssertions describes the column and custom checks of the asset as dataset assertions. The
+// checks without a standard operator are kept as native assertions with their parameters.
+func dataHubAssertions(d *dataset, urn string) []any {
+	proposals := make([]any, 0)
+	for _, column := range d.asset.Columns {
+		for _, check := range column.Checks {
+			value := checkValue(check.Value)
+			assertion := map[string]any{
+				"dataset":    urn,
+				"scope":      "DATASET_COLUMN",
+				"fields":     []string{dataHubFieldURN(urn, column.Name)},
+				"nativeType": check.Name,
+			}
+			if params, ok := dataHubCheckParameters[check.Name]; ok {
+				assertion["operator"] = params.operator
+				assertion["aggregation"] = params.aggregation
+				if params.value != "" {
+					value = params.value
+				}
+				if params.valueType != "" && value != "" {
+					assertion["parameters"] = map[string]any{"value": map[string]string{"value": value, "type": params.valueType}}
+				}
+			} else {
+				assertion["operator"] = "_NATIVE_"
+				if value != "" {
+					assertion["nativeParameters"] = map[string]string{"value": value}
+				}
+			}
+			info := map[string]any{"type": "DATASET", "datasetAssertion": assertion}
+			if check.Description != "" {
+				info["description"] = check.Description
+			}
+			proposals = append(proposals, dataHubAssertion(checkID(urn, column.Name, check.Name), info))
+		}
+	}
+
+	for _, check := range d.asset.CustomChecks {
+		parameters := map[string]string{"query": check.Query, "value": fmt.Sprint(check.Value)}
+		if check.Count != nil {
+			parameters["count"] = fmt.Sprint(*check.Count)
+		}
+		info := map[string]any{
+			"type": "DATASET",
+			"datasetAssertion": map[string]any{
+				"dataset":          urn,
+				"scope":            "DATASET_ROWS",
+				"operator":         "_NATIVE_",
+				"nativeType":       "custom_check:" + check.Name,
+				"nativeParameters": parameters,
+			},
+		}
+		if check.Description != "" {
+			info["description"] = check.Description
+		}
+		proposals = append(proposals, dataHubAssertion(checkID(urn, check.Name), info))
+	}
+
+	return proposals
+}
+
+func dataHubAssertion(id string, info map[string]any) *dataHubProposal {
+	return &dataHubProposal{
+		EntityType: "assertion",
+		EntityURN:  "urn:li:assertion:" + id,
+		ChangeType: "UPSERT",
+		AspectName: "assertionInfo",
+		Aspect:     map[string]any{"json": info},
+	}
+}
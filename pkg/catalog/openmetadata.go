@@ -0,0 +1,550 @@
+package catalog
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/bruin-data/bruin/pkg/pipeline"
+)
+
+// The OpenMetadata export is a set of files with the create requests of the entities, in the order
+// they need to be created in: the referenced entities come before the ones referring to them.
+// Entities are referenced by their fully qualified names, the owners and the lineage tables are
+// resolved to the ids the API needs by the OpenMetadataLoader.
+var openMetadataFiles = []string{
+	"classifications.json",
+	"tags.json",
+	"glossaries.json",
+	"glossary_terms.json",
+	"databases.json",
+	"database_schemas.json",
+	"tables.json",
+	"lineage.json",
+	"test_cases.json",
+}
+
+var openMetadataServices = map[string]string{
+	"google_cloud_platform": "bigquery",
+	"motherduck":            "duckdb",
+}
+
+type omTagLabel struct {
+	TagFQN    string `json:"tagFQN"`
+	Source    string `json:"source"`
+	LabelType string `json:"labelType"`
+	State     string `json:"state"`
+}
+
+type omEntityReference struct {
+	Type               string `json:"type"`
+	Name               string `json:"name,omitempty"`
+	FullyQualifiedName string `json:"fullyQualifiedName,omitempty"`
+}
+
+type omColumn struct {
+	Name            string        `json:"name"`
+	DataType        string        `json:"dataType"`
+	DataTypeDisplay string        `json:"dataTypeDisplay,omitempty"`
+	Description     string        `json:"description,omitempty"`
+	Constraint      string        `json:"constraint,omitempty"`
+	Tags            []*omTagLabel `json:"tags,omitempty"`
+}
+
+type omTable struct {
+	Name           string               `json:"name"`
+	DatabaseSchema string               `json:"databaseSchema"`
+	Description    string               `json:"description,omitempty"`
+	Columns        []*omColumn          `json:"columns"`
+	TableType      string               `json:"tableType,omitempty"`
+	Owners         []*omEntityReference `json:"owners,omitempty"`
+	Tags           []*omTagLabel        `json:"tags,omitempty"`
+}
+
+type omColumnLineage struct {
+	FromColumns []string `json:"fromColumns"`
+	ToColumn    string   `json:"toColumn"`
+}
+
+type omLineage struct {
+	Edge omLineageEdge `json:"edge"`
+}
+
+type omLineageEdge struct {
+	FromEntity     *omEntityReference `json:"fromEntity"`
+	ToEntity       *omEntityReference `json:"toEntity"`
+	LineageDetails omLineageDetails   `json:"lineageDetails"`
+}
+
+type omLineageDetails struct {
+	ColumnsLineage []*omColumnLineage `json:"columnsLineage,omitempty"`
+	Source         string             `json:"source"`
+}
+
+type omParameter struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type omTestCase struct {
+	Name            string         `json:"name"`
+	Description     string         `json:"description,omitempty"`
+	EntityLink      string         `json:"entityLink"`
+	TestDefinition  string         `json:"testDefinition"`
+	ParameterValues []*omParameter `json:"parameterValues"`
+}
+
+// omTableName is the fully qualified name of a table and the names it is built from.
+type omTableName struct {
+	service, database, schema, table string
+}
+
+func (n omTableName) databaseFQN() string {
+	return omFQN(n.service, n.database)
+}
+
+func (n omTableName) schemaFQN() string {
+	return omFQN(n.service, n.database, n.schema)
+}
+
+func (n omTableName) fqn() string {
+	return omFQN(n.service, n.database, n.schema, n.table)
+}
+
+// omFQN joins the parts of a fully qualified name, the parts that contain dots are quoted.
+func omFQN(parts ...string) string {
+	quoted := make([]string, len(parts))
+	for i, part := range parts {
+		if strings.Contains(part, ".") {
+			part = `"` + part + `"`
+		}
+		quoted[i] = part
+	}
+	return strings.Join(quoted, ".")
+}
+
+type openMetadataExport struct {
+	opts       Options
+	datasets   *datasets
+	result     *Result
+	tags       map[string]bool
+	databases  map[string]map[string]any
+	schemas    map[string]map[string]any
+	tables     []*omTable
+	lineage    []*omLineage
+	testCases  []*omTestCase
+	tableNames map[*dataset]omTableName
+}
+
+func exportOpenMetadata(ds *datasets, opts Options) (*Result, error) {
+	e := &openMetadataExport{
+		opts:       opts,
+		datasets:   ds,
+		result:     &Result{},
+		tags:       make(map[string]bool),
+		databases:  make(map[string]map[string]any),
+		schemas:    make(map[string]map[string]any),
+		tables:     make([]*omTable, 0),
+		lineage:    make([]*omLineage, 0),
+		testCases:  make([]*omTestCase, 0),
+		tableNames: make(map[*dataset]omTableName),
+	}
+	for _, name := range ds.skipped {
+		e.warn("asset '%s' does not produce a table on a known platform, it is left out", name)
+	}
+
+	for _, d := range ds.list {
+		name, ok := e.tableName(d)
+		if !ok {
+			e.warn("asset '%s' is not named as database.schema.table, use --database to set the database of the schema.table names", d.asset.Name)
+			continue
+		}
+		e.tableNames[d] = name
+	}
+	for _, d := range ds.list {
+		if name, ok := e.tableNames[d]; ok {
+			e.addTable(d, name)
+		}
+	}
+
+	contents := map[string]any{
+		"classifications.json":  []map[string]string{},
+		"tags.json":             e.tagRequests(),
+		"glossaries.json":       []map[string]string{},
+		"glossary_terms.json":   e.glossaryTerms(),
+		"databases.json":        sortedRequests(e.databases),
+		"database_schemas.json": sortedRequests(e.schemas),
+		"tables.json":           e.tables,
+		"lineage.json":          e.lineage,
+		"test_cases.json":       e.testCases,
+	}
+	if len(e.tags) > 0 {
+		contents["classifications.json"] = []map[string]string{{
+			"name":        opts.GlossaryName,
+			"description": "Tags of the Bruin assets and columns.",
+		}}
+	}
+	if opts.Glossary != nil && len(opts.Glossary.Entities) > 0 {
+		contents["glossaries.json"] = []map[string]string{{
+			"name":        opts.GlossaryName,
+			"displayName": opts.GlossaryName,
+			"description": "The entities and attributes of the Bruin glossary.",
+		}}
+	}
+
+	for _, name := range openMetadataFiles {
+		file, err := marshalFile(name, contents[name])
+		if err != nil {
+			return nil, err
+		}
+		e.result.Files = append(e.result.Files, file)
+	}
+	return e.result, nil
+}
+
+func (e *openMetadataExport) warn(format string, args ...any) {
+	e.result.Warnings = append(e.result.Warnings, fmt.Sprintf(format, args...))
+}
+
+func (e *openMetadataExport) tableName(d *dataset) (omTableName, bool) {
+	parts := strings.Split(d.name, ".")
+	if len(parts) != 3 {
+		return omTableName{}, false
+	}
+
+	platform := d.platform
+	if service, ok := openMetadataServices[platform]; ok {
+		platform = service
+	}
+	service := platform
+	if name, ok := e.opts.Services[platform]; ok {
+		service = name
+	}
+	return omTableName{service: service, database: parts[0], schema: parts[1], table: parts[2]}, true
+}
+
+// referencedTable resolves a table the asset reads from, the tables that are not produced by an
+// asset are assumed to live in the service of the asset.
+func (e *openMetadataExport) referencedTable(table string, from *dataset) (omTableName, bool) {
+	d := e.datasets.lookup(table, from, e.opts.Database)
+	if name, ok := e.tableNames[d]; ok {
+		return name, true
+	}
+	return e.tableName(d)
+}
+
+func (e *openMetadataExport) addTable(d *dataset, name omTableName) {
+	asset := d.asset
+	e.databases[name.databaseFQN()] = map[string]any{"name": name.database, "service": name.service}
+	e.schemas[name.schemaFQN()] = map[string]any{"name": name.schema, "database": name.databaseFQN()}
+
+	table := &omTable{
+		Name:           name.table,
+		DatabaseSchema: name.schemaFQN(),
+		Description:    asset.Description,
+		Columns:        make([]*omColumn, 0, len(asset.Columns)),
+		Tags:           e.tagLabels(asset.Tags),
+	}
+	if asset.Materialization.Type == pipeline.MaterializationTypeView {
+		table.TableType = "View"
+	}
+	if asset.Owner != "" {
+		table.Owners = []*omEntityReference{{Type: "user", Name: asset.Owner}}
+	}
+
+	for _, column := range asset.Columns {
+		c := &omColumn{
+			Name:            column.Name,
+			DataType:        omDataType(column.Type),
+			DataTypeDisplay: column.Type,
+			Description:     column.Description,
+			Tags:            e.tagLabels(column.Tags),
+		}
+		if column.PrimaryKey {
+			c.Constraint = "PRIMARY_KEY"
+		}
+		if ea := column.EntityAttribute; ea != nil && e.opts.Glossary != nil {
+			c.Tags = append(c.Tags, &omTagLabel{
+				TagFQN:    omFQN(e.opts.GlossaryName, ea.Entity, ea.Attribute),
+				Source:    "Glossary",
+				LabelType: "Manual",
+				State:     "Confirmed",
+			})
+		}
+		table.Columns = append(table.Columns, c)
+	}
+	e.tables = append(e.tables, table)
+
+	e.addLineage(d, name)
+	e.addTestCases(d, name)
+}
+
+func (e *openMetadataExport) tagLabels(tags []string) []*omTagLabel {
+	labels := make([]*omTagLabel, 0, len(tags))
+	for _, tag := range tags {
+		e.tags[tag] = true
+		labels = append(labels, &omTagLabel{
+			TagFQN:    omFQN(e.opts.GlossaryName, tag),
+			Source:    "Classification",
+			LabelType: "Manual",
+			State:     "Confirmed",
+		})
+	}
+	return labels
+}
+
+func (e *openMetadataExport) tagRequests() []map[string]string {
+	tags := make([]string, 0, len(e.tags))
+	for tag := range e.tags {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+
+	requests := make([]map[string]string, 0, len(tags))
+	for _, tag := range tags {
+		requests = append(requests, map[string]string{"classification": e.opts.GlossaryName, "name": tag, "description": tag})
+	}
+	return requests
+}
+
+// glossaryTerms creates a term for every entity of the glossary, with the attributes of the entity
+// as its child terms.
+func (e *openMetadataExport) glossaryTerms() []map[string]string {
+	terms := make([]map[string]string, 0)
+	if e.opts.Glossary == nil {
+		return terms
+	}
+
+	entities := append(e.opts.Glossary.Entities[:0:0], e.opts.Glossary.Entities...)
+	sort.Slice(entities, func(i, j int) bool { return entities[i].Name < entities[j].Name })
+	for _, entity := range entities {
+		terms = append(terms, map[string]string{
+			"glossary":    e.opts.GlossaryName,
+			"name":        entity.Name,
+			"description": descriptionOrName(entity.Description, entity.Name),
+		})
+		for _, attribute := range sortedAttributes(entity.Attributes) {
+			terms = append(terms, map[string]string{
+				"glossary":    e.opts.GlossaryName,
+				"parent":      omFQN(e.opts.GlossaryName, entity.Name),
+				"name":        attribute.Name,
+				"description": descriptionOrName(attribute.Description, attribute.Name),
+			})
+		}
+	}
+	return terms
+}
+
+// descriptionOrName fills in the description OpenMetadata requires for glossary terms.
+func descriptionOrName(description, name string) string {
+	if description != "" {
+		return description
+	}
+	return name
+}
+
+func (e *openMetadataExport) addLineage(d *dataset, name omTableName) {
+	edges := make(map[string]*omLineage)
+	order := make([]string, 0)
+	edge := func(from omTableName) *omLineage {
+		key := from.fqn()
+		if l, ok := edges[key]; ok {
+			return l
+		}
+		l := &omLineage{Edge: omLineageEdge{
+			FromEntity:     &omEntityReference{Type: "table", FullyQualifiedName: key},
+			ToEntity:       &omEntityReference{Type: "table", FullyQualifiedName: name.fqn()},
+			LineageDetails: omLineageDetails{Source: "Manual"},
+		}}
+		edges[key] = l
+		order = append(order, key)
+		return l
+	}
+
+	for _, upstream := range d.asset.Upstreams {
+		if upstream.Type != "" && upstream.Type != "asset" {
+			continue
+		}
+		parent, ok := e.datasets.byName[strings.ToLower(upstream.Value)]
+		if !ok {
+			continue
+		}
+		if from, ok := e.tableNames[parent]; ok && from != name {
+			edge(from)
+		}
+	}
+
+	for _, column := range d.asset.Columns {
+		sources := make(map[string][]string)
+		for _, upstream := range column.Upstreams {
+			if upstream == nil || upstream.Table == "" {
+				continue
+			}
+			from, ok := e.referencedTable(upstream.Table, d)
+			if !ok || from == name {
+				continue
+			}
+			edge(from)
+			sources[from.fqn()] = append(sources[from.fqn()], omFQN(from.service, from.database, from.schema, from.table, upstream.Column))
+		}
+		for key, fromColumns := range sources {
+			l := edges[key]
+			l.Edge.LineageDetails.ColumnsLineage = append(l.Edge.LineageDetails.ColumnsLineage, &omColumnLineage{
+				FromColumns: fromColumns,
+				ToColumn:    omFQN(name.service, name.database, name.schema, name.table, column.Name),
+			})
+		}
+	}
+
+	for _, key := range order {
+		e.lineage = append(e.lineage, edges[key])
+	}
+}
+
+// addTestCases maps the column checks to the test definitions OpenMetadata ships with, the checks
+// that compare every value of the column run as custom SQL tests that fail when rows are returned.
+func (e *openMetadataExport) addTestCases(d *dataset, name omTableName) {
+	tableLink := fmt.Sprintf("<#E::table::%s>", name.fqn())
+	for _, column := range d.asset.Columns {
+		columnLink := fmt.Sprintf("<#E::table::%s::columns::%s>", name.fqn(), column.Name)
+		for _, check := range column.Checks {
+			value := checkValue(check.Value)
+			testCase := &omTestCase{
+				Name:            column.Name + "_" + check.Name,
+				Description:     check.Description,
+				EntityLink:      columnLink,
+				ParameterValues: make([]*omParameter, 0),
+			}
+
+			switch check.Name {
+			case "not_null":
+				testCase.TestDefinition = "columnValuesToBeNotNull"
+			case "unique":
+				testCase.TestDefinition = "columnValuesToBeUnique"
+			case "accepted_values":
+				testCase.TestDefinition = "columnValuesToBeInSet"
+				testCase.ParameterValues = append(testCase.ParameterValues, &omParameter{Name: "allowedValues", Value: value})
+			case "pattern":
+				testCase.TestDefinition = "columnValuesToMatchRegex"
+				testCase.ParameterValues = append(testCase.ParameterValues, &omParameter{Name: "regex", Value: value})
+			case "min", "non_negative":
+				if check.Name == "non_negative" {
+					value = "0"
+				}
+				testCase.TestDefinition = "columnValuesToBeBetween"
+				testCase.ParameterValues = append(testCase.ParameterValues, &omParameter{Name: "minValue", Value: value})
+			case "max":
+				testCase.TestDefinition = "columnValuesToBeBetween"
+				testCase.ParameterValues = append(testCase.ParameterValues, &omParameter{Name: "maxValue", Value: value})
+			case "positive", "negative":
+				condition := "<= 0"
+				if check.Name == "negative" {
+					condition = ">= 0"
+				}
+				testCase.EntityLink = tableLink
+				testCase.TestDefinition = "tableCustomSQLQuery"
+				testCase.ParameterValues = append(testCase.ParameterValues, customSQLParameters(
+					fmt.Sprintf("SELECT * FROM %s WHERE %s %s", d.name, column.Name, condition),
+				)...)
+			default:
+				e.warn("check '%s' of column '%s.%s' has no OpenMetadata test definition, it is left out", check.Name, d.asset.Name, column.Name)
+				continue
+			}
+			e.testCases = append(e.testCases, testCase)
+		}
+	}
+
+	for _, check := range d.asset.CustomChecks {
+		// a custom check passes when its query returns the expected value, or the expected number of
+		// rows when count is set, so the test query returns a row when that is not the case.
+		query := fmt.Sprintf("SELECT 1 WHERE (%s) <> %d", check.Query, check.Value)
+		if check.Count != nil {
+			query = fmt.Sprintf("SELECT 1 WHERE (SELECT COUNT(*) FROM (%s) AS check_rows) <> %d", check.Query, *check.Count)
+		}
+		e.testCases = append(e.testCases, &omTestCase{
+			Name:            check.Name,
+			Description:     check.Description,
+			EntityLink:      tableLink,
+			TestDefinition:  "tableCustomSQLQuery",
+			ParameterValues: customSQLParameters(query),
+		})
+	}
+}
+
+func customSQLParameters(query string) []*omParameter {
+	return []*omParameter{
+		{Name: "sqlExpression", Value: query},
+		{Name: "strategy", Value: "ROWS"},
+		{Name: "threshold", Value: "0"},
+	}
+}
+
+func sortedRequests(requests map[string]map[string]any) []map[string]any {
+	keys := make([]string, 0, len(requests))
+	for key := range requests {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	sorted := make([]map[string]any, 0, len(keys))
+	for _, key := range keys {
+		sorted = append(sorted, requests[key])
+	}
+	return sorted
+}
+
+// omDataType maps the native type of a column to the data types OpenMetadata knows, the native type
+// is kept as the display type.
+func omDataType(nativeType string) string {
+	t := strings.ToUpper(strings.TrimSpace(nativeType))
+	if i := strings.IndexAny(t, "(<"); i >= 0 {
+		t = strings.TrimSpace(t[:i])
+	}
+
+	switch t {
+	case "":
+		return "UNKNOWN"
+	case "BOOL", "BOOLEAN":
+		return "BOOLEAN"
+	case "TINYINT", "INT8", "BYTEINT":
+		return "TINYINT"
+	case "SMALLINT", "INT2":
+		return "SMALLINT"
+	case "INT", "INTEGER", "INT4", "MEDIUMINT":
+		return "INT"
+	case "BIGINT", "INT64", "LONG", "HUGEINT":
+		return "BIGINT"
+	case "FLOAT", "FLOAT4", "REAL":
+		return "FLOAT"
+	case "DOUBLE", "FLOAT8", "FLOAT64", "DOUBLE PRECISION":
+		return "DOUBLE"
+	case "DECIMAL", "NUMERIC", "NUMBER", "BIGNUMERIC", "BIGDECIMAL":
+		return "DECIMAL"
+	case "DATE":
+		return "DATE"
+	case "TIME":
+		return "TIME"
+	case "DATETIME":
+		return "DATETIME"
+	case "TIMESTAMP", "TIMESTAMP_NTZ", "TIMESTAMP_LTZ", "TIMESTAMP_TZ", "TIMESTAMPTZ", "TIMESTAMP WITH TIME ZONE", "TIMESTAMP WITHOUT TIME ZONE":
+		return "TIMESTAMP"
+	case "STRING", "TEXT", "VARCHAR", "CHAR", "CHARACTER VARYING", "NVARCHAR", "NCHAR", "CHARACTER":
+		return "STRING"
+	case "BYTES", "BINARY", "VARBINARY", "BLOB", "BYTEA":
+		return "BYTES"
+	case "JSON", "JSONB", "VARIANT":
+		return "JSON"
+	case "ARRAY":
+		return "ARRAY"
+	case "STRUCT", "RECORD", "OBJECT":
+		return "STRUCT"
+	case "MAP":
+		return "MAP"
+	case "UUID":
+		return "UUID"
+	case "GEOGRAPHY", "GEOMETRY":
+		return "GEOGRAPHY"
+	default:
+		return "UNKNOWN"
+	}
+}
@@ -0,0 +1,252 @@
+package catalog
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// openMetadataEndpoints are the API paths the create requests of each export file are sent to.
+var openMetadataEndpoints = map[string]string{
+	"classifications.json":  "/classifications",
+	"tags.json":             "/tags",
+	"glossaries.json":       "/glossaries",
+	"glossary_terms.json":   "/glossaryTerms",
+	"databases.json":        "/databases",
+	"database_schemas.json": "/databaseSchemas",
+	"tables.json":           "/tables",
+	"lineage.json":          "/lineage",
+	"test_cases.json":       "/dataQuality/testCases",
+}
+
+// errOpenMetadataNotFound is returned for the entities that do not exist on the server.
+var errOpenMetadataNotFound = errors.New("entity not found")
+
+// OpenMetadataLoader creates the entities of an OpenMetadata export through the REST API of a
+// server. The export refers to owners and lineage tables by their names, while the API needs the
+// ids of the entities, so the loader looks them up before sending the requests that use them.
+type OpenMetadataLoader struct {
+	baseURL    string
+	token      string
+	httpClient *http.Client
+	// ids are the ids of the entities that were looked up or created, by type and name.
+	ids map[string]string
+}
+
+// NewOpenMetadataLoader creates a loader for the server at the given address, e.g.
+// `http://localhost:8585/api`, authenticating with the JWT token of a bot or a user.
+func NewOpenMetadataLoader(server, token string) *OpenMetadataLoader {
+	server = strings.TrimSuffix(strings.TrimSuffix(server, "/"), "/api")
+	return &OpenMetadataLoader{
+		baseURL:    server + "/api/v1",
+		token:      token,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+		ids:        make(map[string]string),
+	}
+}
+
+// Load creates or updates the entities of the export files in the order they need to be created
+// in. The references that do not resolve to an entity are left out, and returned as warnings.
+func (l *OpenMetadataLoader) Load(ctx context.Context, files []*File) ([]string, error) {
+	byName := make(map[string]*File, len(files))
+	for _, file := range files {
+		byName[file.Name] = file
+	}
+
+	warnings := make([]string, 0)
+	for _, name := range openMetadataFiles {
+		file, ok := byName[name]
+		if !ok {
+			continue
+		}
+		var requests []map[string]any
+		if err := json.Unmarshal(file.Content, &requests); err != nil {
+			return warnings, errors.Wrapf(err, "failed to parse %s", name)
+		}
+
+		for _, request := range requests {
+			var err error
+			switch name {
+			case "tables.json":
+				warnings, err = l.loadTable(ctx, request, warnings)
+			case "lineage.json":
+				warnings, err = l.loadLineage(ctx, request, warnings)
+			case "test_cases.json":
+				// test cases cannot be updated with a create request, the existing ones are kept.
+				err = l.do(ctx, http.MethodPost, openMetadataEndpoints[name], request, nil)
+				if isOpenMetadataConflict(err) {
+					err = nil
+				}
+			default:
+				err = l.do(ctx, http.MethodPut, openMetadataEndpoints[name], request, nil)
+			}
+			if err != nil {
+				return warnings, errors.Wrapf(err, "failed to load '%v' from %s", request["name"], name)
+			}
+		}
+	}
+	return warnings, nil
+}
+
+func (l *OpenMetadataLoader) loadTable(ctx context.Context, request map[string]any, warnings []string) ([]string, error) {
+	fqn := fmt.Sprintf("%v.%s", request["databaseSchema"], omFQN(fmt.Sprint(request["name"])))
+	if owners, ok := request["owners"].([]any); ok {
+		resolved := make([]any, 0, len(owners))
+		for _, owner := range owners {
+			ref, _ := owner.(map[string]any)
+			name := fmt.Sprint(ref["name"])
+			resolvedRef, err := l.resolveOwner(ctx, name)
+			if errors.Is(err, errOpenMetadataNotFound) {
+				warnings = append(warnings, fmt.Sprintf("owner '%s' of table '%s' is not a user or a team in OpenMetadata, it is left out", name, fqn))
+				continue
+			}
+			if err != nil {
+				return warnings, err
+			}
+			resolved = append(resolved, resolvedRef)
+		}
+		request["owners"] = resolved
+	}
+
+	var created struct {
+		ID                 string `json:"id"`
+		FullyQualifiedName string `json:"fullyQualifiedName"`
+	}
+	if err := l.do(ctx, http.MethodPut, openMetadataEndpoints["tables.json"], request, &created); err != nil {
+		return warnings, err
+	}
+	if created.FullyQualifiedName != "" {
+		fqn = created.FullyQualifiedName
+	}
+	l.ids["table:"+fqn] = created.ID
+	return warnings, nil
+}
+
+func (l *OpenMetadataLoader) loadLineage(ctx context.Context, request map[string]any, warnings []string) ([]string, error) {
+	edge, _ := request["edge"].(map[string]any)
+	for _, side := range []string{"fromEntity", "toEntity"} {
+		ref, _ := edge[side].(map[string]any)
+		fqn := fmt.Sprint(ref["fullyQualifiedName"])
+		id, err := l.lookup(ctx, "table", "/tables/name/"+url.PathEscape(fqn), fqn)
+		if errors.Is(err, errOpenMetadataNotFound) {
+			from, _ := edge["fromEntity"].(map[string]any)
+			to, _ := edge["toEntity"].(map[string]any)
+			warnings = append(warnings, fmt.Sprintf("lineage from '%v' to '%v' is left out, table '%s' does not exist in OpenMetadata", from["fullyQualifiedName"], to["fullyQualifiedName"], fqn))
+			return warnings, nil
+		}
+		if err != nil {
+			return warnings, err
+		}
+		ref["id"] = id
+	}
+	return warnings, l.do(ctx, http.MethodPut, openMetadataEndpoints["lineage.json"], request, nil)
+}
+
+// resolveOwner looks the owner up as a user first and as a team second, since Bruin does not tell
+// them apart.
+func (l *OpenMetadataLoader) resolveOwner(ctx context.Context, name string) (map[string]any, error) {
+	for _, kind := range []struct{ entityType, path string }{{"user", "/users/name/"}, {"team", "/teams/name/"}} {
+		id, err := l.lookup(ctx, kind.entityType, kind.path+url.PathEscape(name), name)
+		if errors.Is(err, errOpenMetadataNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		return map[string]any{"id": id, "type": kind.entityType}, nil
+	}
+	return nil, errOpenMetadataNotFound
+}
+
+func (l *OpenMetadataLoader) lookup(ctx context.Context, entityType, path, name string) (string, error) {
+	key := entityType + ":" + name
+	if id, ok := l.ids[key]; ok {
+		return id, nil
+	}
+
+	var entity struct {
+		ID string `json:"id"`
+	}
+	if err := l.do(ctx, http.MethodGet, path, nil, &entity); err != nil {
+		return "", err
+	}
+	l.ids[key] = entity.ID
+	return entity.ID, nil
+}
+
+// openMetadataError is an error response of the API.
+type openMetadataError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *openMetadataError) Error() string {
+	return fmt.Sprintf("OpenMetadata API error (HTTP %d): %s", e.StatusCode, e.Message)
+}
+
+func isOpenMetadataConflict(err error) bool {
+	var apiErr *openMetadataError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusConflict
+}
+
+func (l *OpenMetadataLoader) do(ctx context.Context, method, path string, body any, result any) error {
+	var bodyReader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal request body")
+		}
+		bodyReader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, l.baseURL+path, bodyReader)
+	if err != nil {
+		return errors.Wrap(err, "failed to create request")
+	}
+	req.Header.Set("Accept", "application/json")
+	if l.token != "" {
+		req.Header.Set("Authorization", "Bearer "+l.token)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := l.httpClient.Do(req)
+	if err != nil {
+		return errors.Wrapf(err, "request to %s failed", path)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return errors.Wrap(err, "failed to read response")
+	}
+	if method == http.MethodGet && resp.StatusCode == http.StatusNotFound {
+		return errOpenMetadataNotFound
+	}
+	if resp.StatusCode >= 400 {
+		apiErr := &openMetadataError{StatusCode: resp.StatusCode, Message: string(respBody)}
+		var payload struct {
+			Message string `json:"message"`
+		}
+		if json.Unmarshal(respBody, &payload) == nil && payload.Message != "" {
+			apiErr.Message = payload.Message
+		}
+		return apiErr
+	}
+
+	if result != nil {
+		if err := json.Unmarshal(respBody, result); err != nil {
+			return errors.Wrap(err, "failed to parse response")
+		}
+	}
+	return nil
+}
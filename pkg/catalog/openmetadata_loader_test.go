@@ -0,0 +1,159 @@
+package catalog
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeOpenMetadata is an OpenMetadata server that keeps the requests it receives, the tables it
+// creates get their FQN as their id.
+type fakeOpenMetadata struct {
+	mu       sync.Mutex
+	users    map[string]string
+	tables   map[string]bool
+	requests map[string][]map[string]any
+}
+
+func (f *fakeOpenMetadata) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	path := strings.TrimPrefix(r.URL.Path, "/api/v1")
+	if r.Method == http.MethodGet {
+		switch {
+		case strings.HasPrefix(path, "/users/name/"):
+			if id, ok := f.users[strings.TrimPrefix(path, "/users/name/")]; ok {
+				_ = json.NewEncoder(w).Encode(map[string]string{"id": id})
+				return
+			}
+		case strings.HasPrefix(path, "/tables/name/"):
+			if fqn := strings.TrimPrefix(path, "/tables/name/"); f.tables[fqn] {
+				_ = json.NewEncoder(w).Encode(map[string]string{"id": "id-" + fqn})
+				return
+			}
+		}
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	var body map[string]any
+	_ = json.NewDecoder(r.Body).Decode(&body)
+	f.requests[r.Method+" "+path] = append(f.requests[r.Method+" "+path], body)
+	if path == "/tables" {
+		fqn := body["databaseSchema"].(string) + "." + body["name"].(string)
+		f.tables[fqn] = true
+		_ = json.NewEncoder(w).Encode(map[string]string{"id": "id-" + fqn, "fullyQualifiedName": fqn})
+		return
+	}
+	if path == "/dataQuality/testCases" && body["name"] == "has_rows" {
+		w.WriteHeader(http.StatusConflict)
+		_ = json.NewEncoder(w).Encode(map[string]string{"message": "entity already exists"})
+		return
+	}
+	_, _ = w.Write([]byte("{}"))
+}
+
+func TestOpenMetadataLoader_Load(t *testing.T) {
+	t.Parallel()
+
+	result, err := Export(FormatOpenMetadata, []*pipeline.Pipeline{catalogTestPipeline()}, Options{
+		Database: "project",
+		Glossary: catalogTestGlossary(),
+		Services: map[string]string{"bigquery": "warehouse"},
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name         string
+		users        map[string]string
+		wantOwners   []any
+		wantWarnings []string
+	}{
+		{
+			name:       "owners are resolved to their ids",
+			users:      map[string]string{"jane@example.com": "user-1"},
+			wantOwners: []any{map[string]any{"id": "user-1", "type": "user"}},
+		},
+		{
+			name:       "unknown owners are left out",
+			users:      map[string]string{},
+			wantOwners: []any{},
+			wantWarnings: []string{
+				"owner 'jane@example.com' of table 'warehouse.project.mart.orders' is not a user or a team in OpenMetadata, it is left out",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			fake := &fakeOpenMetadata{users: tt.users, tables: make(map[string]bool), requests: make(map[string][]map[string]any)}
+			server := httptest.NewServer(fake)
+			t.Cleanup(server.Close)
+
+			warnings, err := NewOpenMetadataLoader(server.URL+"/api/", "token").Load(t.Context(), result.Files)
+			require.NoError(t, err)
+			if tt.wantWarnings == nil {
+				tt.wantWarnings = []string{}
+			}
+			assert.Equal(t, tt.wantWarnings, warnings)
+
+			assert.Len(t, fake.requests["PUT /classifications"], 1)
+			assert.Len(t, fake.requests["PUT /glossaryTerms"], 2)
+			assert.Len(t, fake.requests["PUT /databaseSchemas"], 2)
+			assert.Len(t, fake.requests["POST /dataQuality/testCases"], 3)
+
+			tables := fake.requests["PUT /tables"]
+			require.Len(t, tables, 2)
+			assert.Equal(t, tt.wantOwners, tables[1]["owners"])
+
+			lineage := fake.requests["PUT /lineage"]
+			require.Len(t, lineage, 1)
+			edge := lineage[0]["edge"].(map[string]any)
+			assert.Equal(t, "id-warehouse.project.raw.orders", edge["fromEntity"].(map[string]any)["id"])
+			assert.Equal(t, "id-warehouse.project.mart.orders", edge["toEntity"].(map[string]any)["id"])
+		})
+	}
+}
+
+func TestOpenMetadataLoader_Load_MissingLineageTable(t *testing.T) {
+	t.Parallel()
+
+	content := []byte(`[{"edge": {
+		"fromEntity": {"type": "table", "fullyQualifiedName": "warehouse.project.raw.events"},
+		"toEntity": {"type": "table", "fullyQualifiedName": "warehouse.project.mart.events"},
+		"lineageDetails": {"source": "Manual"}
+	}}]`)
+	fake := &fakeOpenMetadata{tables: map[string]bool{"warehouse.project.mart.events": true}, requests: make(map[string][]map[string]any)}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	warnings, err := NewOpenMetadataLoader(server.URL, "").Load(t.Context(), []*File{{Name: "lineage.json", Content: content}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"lineage from 'warehouse.project.raw.events' to 'warehouse.project.mart.events' is left out, table 'warehouse.project.raw.events' does not exist in OpenMetadata",
+	}, warnings)
+	assert.Empty(t, fake.requests["PUT /lineage"])
+}
+
+func TestOpenMetadataLoader_Load_Error(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"code": 400, "message": "service warehouse does not exist"}`))
+	}))
+	t.Cleanup(server.Close)
+
+	files := []*File{{Name: "databases.json", Content: []byte(`[{"name": "project", "service": "warehouse"}]`)}}
+	_, err := NewOpenMetadataLoader(server.URL, "").Load(t.Context(), files)
+	require.EqualError(t, err, "failed to load 'project' from databases.json: OpenMetadata API error (HTTP 400): service warehouse does not exist")
+}
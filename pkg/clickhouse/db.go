@@ -54,6 +54,7 @@ type ClickHouseConfig interface {
 type connection interface {
 	Query(ctx context.Context, sql string, args ...any) (driver.Rows, error)
 	Exec(ctx context.Context, sql string, arguments ...any) error
+	PrepareBatch(ctx context.Context, query string, opts ...driver.PrepareBatchOption) (driver.Batch, error)
 }
 
 func NewClient(c ClickHouseConfig) (*Client, error) {
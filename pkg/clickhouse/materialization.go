@@ -22,14 +22,15 @@ var matMap = AssetMaterializationMap{
 		pipeline.MaterializationStrategyDeleteInsert:  errorMaterializer,
 	},
 	pipeline.MaterializationTypeTable: {
-		pipeline.MaterializationStrategyNone:           buildCreateReplaceQuery,
-		pipeline.MaterializationStrategyAppend:         buildAppendQuery,
-		pipeline.MaterializationStrategyCreateReplace:  buildCreateReplaceQuery,
-		pipeline.MaterializationStrategyDeleteInsert:   buildIncrementalQuery,
-		pipeline.MaterializationStrategyTruncateInsert: buildTruncateInsertQuery,
-		pipeline.MaterializationStrategyMerge:          buildMergeQuery,
-		pipeline.MaterializationStrategyTimeInterval:   buildTimeIntervalQuery,
-		pipeline.MaterializationStrategyDDL:            buildDDLQuery,
+		pipeline.MaterializationStrategyNone:            buildCreateReplaceQuery,
+		pipeline.MaterializationStrategyAppend:          buildAppendQuery,
+		pipeline.MaterializationStrategyCreateReplace:   buildCreateReplaceQuery,
+		pipeline.MaterializationStrategyDeleteInsert:    buildIncrementalQuery,
+		pipeline.MaterializationStrategyTruncateInsert:  buildTruncateInsertQuery,
+		pipeline.MaterializationStrategyMerge:           buildMergeQuery,
+		pipeline.MaterializationStrategyTimeInterval:    buildTimeIntervalQuery,
+		pipeline.MaterializationStrategyInsertOverwrite: buildInsertOverwriteQuery,
+		pipeline.MaterializationStrategyDDL:             buildDDLQuery,
 	},
 }
 
@@ -171,10 +172,17 @@ func buildCreateReplaceQuery(task *pipeline.Asset, query string) ([]string, erro
 
 	query = strings.TrimSuffix(query, ";")
 
+	// insert_overwrite replaces whole partitions, so the table has to be created with them.
+	partitionBy := ""
+	if task.Materialization.Strategy == pipeline.MaterializationStrategyInsertOverwrite && task.Materialization.PartitionBy != "" {
+		partitionBy = fmt.Sprintf(" PARTITION BY (%s)", task.Materialization.PartitionBy)
+	}
+
 	return []string{
 		fmt.Sprintf(
-			"CREATE OR REPLACE TABLE %s PRIMARY KEY (%s) AS %s",
+			"CREATE OR REPLACE TABLE %s%s PRIMARY KEY (%s) AS %s",
 			task.Name,
+			partitionBy,
 			strings.Join(primaryKeys, ", "),
 			query,
 		),
@@ -214,6 +222,74 @@ func buildTimeIntervalQuery(asset *pipeline.Asset, query string) ([]string, erro
 	return queries, nil
 }
 
+// replacePartitionsStatement is not ClickHouse SQL: `ALTER TABLE ... REPLACE PARTITION` takes a
+// single partition, and the partitions to replace are only known once the query result is staged.
+// The operator expands it into one `REPLACE PARTITION` per partition of the staging table, the
+// renderer into the query that lists them and a comment with the statement run for each.
+const replacePartitionsStatement = "REPLACE PARTITIONS"
+
+// parseReplacePartitions returns the target and the staging table of a `REPLACE PARTITIONS`
+// statement.
+func parseReplacePartitions(statement string) (string, string, error) {
+	parts := strings.Fields(strings.TrimPrefix(statement, replacePartitionsStatement))
+	if len(parts) != 3 || !strings.EqualFold(parts[1], "FROM") {
+		return "", "", fmt.Errorf("invalid partition replacement statement: %s", statement)
+	}
+	return parts[0], parts[2], nil
+}
+
+// partitionIDsQuery lists the partitions of the staging table.
+func partitionIDsQuery(staging string) string {
+	return "SELECT DISTINCT _partition_id FROM " + staging
+}
+
+// replacePartitionQuery swaps a single partition of the staging table into the target.
+func replacePartitionQuery(target, partitionID, staging string) string {
+	return fmt.Sprintf("ALTER TABLE %s REPLACE PARTITION ID '%s' FROM %s", target, strings.ReplaceAll(partitionID, "'", "''"), staging)
+}
+
+// expandReplacePartitions renders a `REPLACE PARTITIONS` statement as the SQL the operator runs
+// for it: the query that lists the partitions of the staging table, preceded by the statement
+// that is run for each of them.
+func expandReplacePartitions(statement string) (string, error) {
+	target, staging, err := parseReplacePartitions(statement)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf(
+		"-- for each partition ID returned by the query below: %s\n%s",
+		replacePartitionQuery(target, "<partition_id>", staging),
+		partitionIDsQuery(staging),
+	), nil
+}
+
+// buildInsertOverwriteQuery stages the query result in a table with the same structure as the
+// target, and then swaps every partition that appears in it into the target. Partitions that are
+// not in the query result are left untouched.
+func buildInsertOverwriteQuery(asset *pipeline.Asset, query string) ([]string, error) {
+	if asset.Materialization.PartitionBy == "" {
+		return nil, errors.New("materialization strategy insert_overwrite requires the `partition_by` field to be set")
+	}
+	if asset.Materialization.TimeGranularity != "" {
+		return nil, errors.New("materialization strategy insert_overwrite does not support `time_granularity` on ClickHouse, the partitions are taken from the query result")
+	}
+
+	assetNameParts := strings.Split(asset.Name, ".")
+	var tempTableName string
+	if len(assetNameParts) == 2 {
+		tempTableName = assetNameParts[0] + ".__bruin_tmp_" + helpers.PrefixGenerator()
+	} else {
+		tempTableName = "__bruin_tmp_" + helpers.PrefixGenerator()
+	}
+
+	return []string{
+		fmt.Sprintf("CREATE TABLE %s AS %s", tempTableName, asset.Name),
+		fmt.Sprintf("INSERT INTO %s SETTINGS insert_deduplicate = 0 %s", tempTableName, query),
+		fmt.Sprintf("%s %s FROM %s", replacePartitionsStatement, asset.Name, tempTableName),
+		"DROP TABLE IF EXISTS " + tempTableName,
+	}, nil
+}
+
 func buildDDLQuery(asset *pipeline.Asset, query string) ([]string, error) {
 	columnDefs := make([]string, 0, len(asset.Columns))
 	primaryKeys := ""
@@ -395,6 +395,55 @@ func TestMaterializer_Render(t *testing.T) {
 					"PRIMARY KEY (id, category)",
 			},
 		},
+		{
+			name: "insert_overwrite stages the query result and replaces its partitions",
+			task: &pipeline.Asset{
+				Name: "my.asset",
+				Materialization: pipeline.Materialization{
+					Type:        pipeline.MaterializationTypeTable,
+					Strategy:    pipeline.MaterializationStrategyInsertOverwrite,
+					PartitionBy: "toYYYYMM(dt)",
+				},
+			},
+			query: "SELECT dt, revenue FROM source_table",
+			want: []string{
+				"CREATE TABLE my.__bruin_tmp_abcefghi AS my.asset",
+				"INSERT INTO my.__bruin_tmp_abcefghi SETTINGS insert_deduplicate = 0 SELECT dt, revenue FROM source_table",
+				"REPLACE PARTITIONS my.asset FROM my.__bruin_tmp_abcefghi",
+				"DROP TABLE IF EXISTS my.__bruin_tmp_abcefghi",
+			},
+		},
+		{
+			name: "insert_overwrite full refresh creates a partitioned table",
+			task: &pipeline.Asset{
+				Name: "my.asset",
+				Materialization: pipeline.Materialization{
+					Type:        pipeline.MaterializationTypeTable,
+					Strategy:    pipeline.MaterializationStrategyInsertOverwrite,
+					PartitionBy: "toYYYYMM(dt)",
+				},
+				Columns: []pipeline.Column{{Name: "id", PrimaryKey: true}},
+			},
+			fullRefresh: true,
+			query:       "SELECT 1 AS id, today() AS dt",
+			want: []string{
+				"CREATE OR REPLACE TABLE my.asset PARTITION BY (toYYYYMM(dt)) PRIMARY KEY (id) AS SELECT 1 AS id, today() AS dt",
+			},
+		},
+		{
+			name: "insert_overwrite does not support time_granularity",
+			task: &pipeline.Asset{
+				Name: "my.asset",
+				Materialization: pipeline.Materialization{
+					Type:            pipeline.MaterializationTypeTable,
+					Strategy:        pipeline.MaterializationStrategyInsertOverwrite,
+					PartitionBy:     "dt",
+					TimeGranularity: pipeline.MaterializationTimeGranularityDate,
+				},
+			},
+			query:   "SELECT 1",
+			wantErr: true,
+		},
 		{
 			name: "table with partitioning",
 			task: &pipeline.Asset{
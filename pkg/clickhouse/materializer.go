@@ -54,7 +54,9 @@ func (m *Materializer) RenderWithCleanup(asset *pipeline.Asset, query string) ([
 		strategy = pipeline.MaterializationStrategyCreateReplace
 	}
 
-	if strategy != pipeline.MaterializationStrategyMerge && strategy != pipeline.MaterializationStrategyDeleteInsert {
+	if strategy != pipeline.MaterializationStrategyMerge &&
+		strategy != pipeline.MaterializationStrategyDeleteInsert &&
+		strategy != pipeline.MaterializationStrategyInsertOverwrite {
 		return queries, nil, nil
 	}
 
@@ -89,6 +91,16 @@ func (r *Renderer) Render(asset *pipeline.Asset, query string) (string, error) {
 		return "", err
 	}
 
+	for i, q := range queries {
+		if !strings.HasPrefix(q, replacePartitionsStatement+" ") {
+			continue
+		}
+		queries[i], err = expandReplacePartitions(q)
+		if err != nil {
+			return "", err
+		}
+	}
+
 	result := strings.Join(queries, ";")
 	return result, nil
 }
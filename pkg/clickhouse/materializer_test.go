@@ -110,3 +110,25 @@ func TestMaterializer_RenderWithCleanupFullRefreshDoesNotReturnMergeCleanup(t *t
 	require.Len(t, queries, 1)
 	require.Empty(t, cleanup)
 }
+
+func TestRenderer_RenderExpandsPartitionReplacement(t *testing.T) {
+	t.Parallel()
+
+	asset := &pipeline.Asset{
+		Name: "my.asset",
+		Materialization: pipeline.Materialization{
+			Type:        pipeline.MaterializationTypeTable,
+			Strategy:    pipeline.MaterializationStrategyInsertOverwrite,
+			PartitionBy: "toYYYYMM(dt)",
+		},
+	}
+
+	rendered, err := NewRenderer(false).Render(asset, "SELECT dt FROM source")
+	require.NoError(t, err)
+	require.Equal(t, "CREATE TABLE my.__bruin_tmp_abcefghi AS my.asset;"+
+		"INSERT INTO my.__bruin_tmp_abcefghi SETTINGS insert_deduplicate = 0 SELECT dt FROM source;"+
+		"-- for each partition ID returned by the query below: ALTER TABLE my.asset REPLACE PARTITION ID '<partition_id>' FROM my.__bruin_tmp_abcefghi\n"+
+		"SELECT DISTINCT _partition_id FROM my.__bruin_tmp_abcefghi;"+
+		"DROP TABLE IF EXISTS my.__bruin_tmp_abcefghi", rendered)
+	require.NotContains(t, rendered, replacePartitionsStatement)
+}
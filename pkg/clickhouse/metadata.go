@@ -0,0 +1,53 @@
+package clickhouse
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/bruin-data/bruin/pkg/ansisql"
+	"github.com/bruin-data/bruin/pkg/config"
+	"github.com/bruin-data/bruin/pkg/pipeline"
+)
+
+// MetadataDialect pushes asset and column descriptions as ClickHouse comments, the current
+// comments are read from system.tables and system.columns.
+var MetadataDialect = ansisql.MetadataDialect{
+	Platform: "ClickHouse",
+	CurrentMetadata: func(ctx context.Context, conn ansisql.MetadataClient, asset *pipeline.Asset) (*ansisql.TableMetadata, error) {
+		return ansisql.QueryTableMetadata(ctx, conn, currentMetadataQuery(asset.Name))
+	},
+	TableCommentStatement: func(asset *pipeline.Asset, comment string) string {
+		return fmt.Sprintf("ALTER TABLE %s MODIFY COMMENT %s", asset.Name, quoteLiteral(comment))
+	},
+	ColumnCommentStatement: func(asset *pipeline.Asset, column ansisql.ColumnMetadata, comment string) string {
+		return fmt.Sprintf("ALTER TABLE %s COMMENT COLUMN %s %s", asset.Name, quoteColumn(column.Name), quoteLiteral(comment))
+	},
+}
+
+func NewMetadataPushOperator(conn config.ConnectionGetter) *ansisql.MetadataPushOperator {
+	return ansisql.NewMetadataPushOperator(conn, MetadataDialect)
+}
+
+func currentMetadataQuery(name string) string {
+	parts := strings.Split(name, ".")
+	database := "currentDatabase()"
+	if len(parts) > 1 {
+		database = quoteLiteral(parts[len(parts)-2])
+	}
+	table := quoteLiteral(parts[len(parts)-1])
+
+	return fmt.Sprintf(
+		"SELECT 'table', name, comment FROM system.tables WHERE database = %s AND name = %s UNION ALL SELECT 'column', name, comment FROM system.columns WHERE database = %s AND table = %s",
+		database, table, database, table,
+	)
+}
+
+// quoteLiteral quotes a ClickHouse string literal, where backslashes are escape characters.
+func quoteLiteral(value string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(value) + "'"
+}
+
+func quoteColumn(name string) string {
+	return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+}
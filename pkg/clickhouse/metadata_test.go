@@ -0,0 +1,43 @@
+package clickhouse
+
+import (
+	"testing"
+
+	"github.com/bruin-data/bruin/pkg/ansisql"
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetadataDialect_MetadataQueries(t *testing.T) {
+	t.Parallel()
+
+	asset := &pipeline.Asset{
+		Name:        "analytics.events",
+		Description: `Events, see C:\docs`,
+		Columns: []pipeline.Column{
+			{Name: "id", Description: "The id"},
+			{Name: "name", Description: "Event's name"},
+		},
+	}
+	current := &ansisql.TableMetadata{
+		Columns: []ansisql.ColumnMetadata{
+			{Name: "id", Comment: "The id"},
+			{Name: "name"},
+		},
+	}
+
+	require.Equal(t, []string{
+		`ALTER TABLE analytics.events MODIFY COMMENT 'Events, see C:\\docs'`,
+		"ALTER TABLE analytics.events COMMENT COLUMN `name` 'Event\\'s name'",
+	}, MetadataDialect.MetadataQueries(asset, current))
+}
+
+func TestCurrentMetadataQuery(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t,
+		"SELECT 'table', name, comment FROM system.tables WHERE database = currentDatabase() AND name = 'events' UNION ALL SELECT 'column', name, comment FROM system.columns WHERE database = currentDatabase() AND table = 'events'",
+		currentMetadataQuery("events"),
+	)
+	require.Contains(t, currentMetadataQuery("analytics.events"), "WHERE database = 'analytics' AND table = 'events'")
+}
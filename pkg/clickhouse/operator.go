@@ -2,6 +2,8 @@ package clickhouse
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/bruin-data/bruin/pkg/ansisql"
@@ -105,6 +107,14 @@ func (o BasicOperator) RunTask(ctx context.Context, p *pipeline.Pipeline, t *pip
 
 	var lastQuery *query.Query
 	for _, queryString := range materializedQueries {
+		if strings.HasPrefix(queryString, replacePartitionsStatement+" ") {
+			err = o.replacePartitions(ctx, p, t, conn, writer, queryString)
+			if err != nil {
+				return o.cleanupAfterFailure(ctx, p, t, conn, writer, cleanupQueries, err)
+			}
+			continue
+		}
+
 		q := &query.Query{Query: queryString}
 		if o.devEnv != nil {
 			q, err = o.devEnv.Modify(ctx, p, t, q)
@@ -138,6 +148,63 @@ func (o BasicOperator) RunTask(ctx context.Context, p *pipeline.Pipeline, t *pip
 	return nil
 }
 
+// replacePartitions expands a `REPLACE PARTITIONS <target> FROM <staging>` statement rendered for
+// the insert_overwrite strategy into one `ALTER TABLE ... REPLACE PARTITION` per partition that
+// exists in the staging table.
+func (o BasicOperator) replacePartitions(
+	ctx context.Context,
+	p *pipeline.Pipeline,
+	t *pipeline.Asset,
+	conn ClickHouseClient,
+	writer interface{},
+	statement string,
+) error {
+	target, staging, err := parseReplacePartitions(statement)
+	if err != nil {
+		return err
+	}
+
+	partitionsQuery := &query.Query{Query: partitionIDsQuery(staging)}
+	if o.devEnv != nil {
+		partitionsQuery, err = o.devEnv.Modify(ctx, p, t, partitionsQuery)
+		if err != nil {
+			return err
+		}
+	}
+
+	ansisql.LogQueryIfVerbose(ctx, writer, partitionsQuery.Query)
+	rows, err := conn.Select(ctx, partitionsQuery)
+	if err != nil {
+		return errors.Wrap(err, "failed to list the partitions to overwrite")
+	}
+
+	for _, row := range rows {
+		if len(row) == 0 {
+			continue
+		}
+
+		partitionID := fmt.Sprint(row[0])
+		if partitionID == "all" {
+			return errors.Errorf("insert_overwrite requires '%s' to be partitioned, run it with --full-refresh to recreate it with `partition_by`", target)
+		}
+
+		replaceQuery := &query.Query{Query: replacePartitionQuery(target, partitionID, staging)}
+		if o.devEnv != nil {
+			replaceQuery, err = o.devEnv.Modify(ctx, p, t, replaceQuery)
+			if err != nil {
+				return err
+			}
+		}
+
+		ansisql.LogQueryIfVerbose(ctx, writer, replaceQuery.Query)
+		if err := conn.RunQueryWithoutResult(ctx, replaceQuery); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (o BasicOperator) cleanupAfterFailure(
 	ctx context.Context,
 	p *pipeline.Pipeline,
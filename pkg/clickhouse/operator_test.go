@@ -293,3 +293,46 @@ func TestBasicOperator_RunTaskCleansUpAfterQueryFailure(t *testing.T) {
 	require.EqualError(t, err, "insert failed")
 	client.AssertExpectations(t)
 }
+
+func TestBasicOperator_RunTaskReplacesStagedPartitions(t *testing.T) {
+	t.Parallel()
+
+	asset := &pipeline.Asset{
+		Type: pipeline.AssetTypeClickHouse,
+		ExecutableFile: pipeline.ExecutableFile{
+			Path:    "test-file.sql",
+			Content: "some query",
+		},
+	}
+	client := new(mockQuerierWithResult)
+	extractor := new(mockExtractor)
+	mat := new(mockMaterializerWithCleanup)
+	conn := new(mockConnectionFetcher)
+
+	extractor.On("ExtractQueriesFromString", "some query").
+		Return([]*query.Query{{Query: "select * from events"}}, nil)
+	mat.On("RenderWithCleanup", asset, "select * from events").
+		Return(
+			[]string{"CREATE TABLE db.tmp AS db.events", "REPLACE PARTITIONS db.events FROM db.tmp", "DROP TABLE IF EXISTS db.tmp"},
+			[]string{"DROP TABLE IF EXISTS db.tmp"},
+			nil,
+		)
+	conn.On("GetConnection", mock.Anything).Return(client)
+	client.On("RunQueryWithoutResult", mock.Anything, &query.Query{Query: "CREATE TABLE db.tmp AS db.events"}).Return(nil).Once()
+	client.On("Select", mock.Anything, &query.Query{Query: "SELECT DISTINCT _partition_id FROM db.tmp"}).
+		Return([][]interface{}{{"202401"}, {"202402"}}, nil).
+		Once()
+	client.On("RunQueryWithoutResult", mock.Anything, &query.Query{Query: "ALTER TABLE db.events REPLACE PARTITION ID '202401' FROM db.tmp"}).Return(nil).Once()
+	client.On("RunQueryWithoutResult", mock.Anything, &query.Query{Query: "ALTER TABLE db.events REPLACE PARTITION ID '202402' FROM db.tmp"}).Return(nil).Once()
+	client.On("RunQueryWithoutResult", mock.Anything, &query.Query{Query: "DROP TABLE IF EXISTS db.tmp"}).Return(nil).Once()
+
+	o := BasicOperator{
+		connection:   conn,
+		extractor:    extractor,
+		materializer: mat,
+	}
+
+	err := o.RunTask(t.Context(), &pipeline.Pipeline{}, asset)
+	require.NoError(t, err)
+	client.AssertExpectations(t)
+}
@@ -0,0 +1,140 @@
+package clickhouse
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/bruin-data/bruin/pkg/ansisql"
+	"github.com/bruin-data/bruin/pkg/seed"
+	"github.com/pkg/errors"
+)
+
+// seedBatchSize is the number of rows sent to ClickHouse in a single native batch.
+const seedBatchSize = 100_000
+
+// LoadSeed loads the seed rows into a staging table with native batches, then swaps it
+// with the table through EXCHANGE TABLES.
+func (c *Client) LoadSeed(ctx context.Context, table *seed.Table, rows *seed.Rows) error {
+	if database, _, ok := strings.Cut(table.Name, "."); ok && database != "default" {
+		if err := c.connection.Exec(ctx, "CREATE DATABASE IF NOT EXISTS "+ansisql.QuoteIdentifierWithBackticks(database)); err != nil {
+			return errors.Wrapf(err, "failed to create database %s", database)
+		}
+	}
+
+	tableName := ansisql.QuoteIdentifierWithBackticks(table.Name)
+	stagingName := ansisql.QuoteIdentifierWithBackticks(seed.StagingTableName(table.Name))
+
+	orderBy := make([]string, 0)
+	for _, col := range table.Columns {
+		if col.PrimaryKey {
+			orderBy = append(orderBy, ansisql.QuoteIdentifierWithBackticks(col.Name))
+		}
+	}
+	orderByClause := "tuple()"
+	if len(orderBy) > 0 {
+		orderByClause = "(" + strings.Join(orderBy, ", ") + ")"
+	}
+
+	createQuery := fmt.Sprintf(
+		"CREATE TABLE %s (\n%s\n) ENGINE = MergeTree() ORDER BY %s",
+		stagingName,
+		table.ColumnDefinitions(ansisql.QuoteIdentifierWithBackticks, seedColumnType),
+		orderByClause,
+	)
+	if err := c.connection.Exec(ctx, createQuery); err != nil {
+		return errors.Wrapf(err, "failed to create the staging table for %s", table.Name)
+	}
+	// After the exchange the staging table holds the previous data.
+	defer func() {
+		_ = c.connection.Exec(context.WithoutCancel(ctx), "DROP TABLE IF EXISTS "+stagingName)
+	}()
+
+	if err := c.insertSeedRows(ctx, stagingName, table, rows); err != nil {
+		return err
+	}
+
+	queries := []string{
+		fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s AS %s", tableName, stagingName),
+		fmt.Sprintf("EXCHANGE TABLES %s AND %s", stagingName, tableName),
+	}
+	for _, q := range queries {
+		if err := c.connection.Exec(ctx, q); err != nil {
+			return errors.Wrapf(err, "failed to replace table %s", table.Name)
+		}
+	}
+
+	return nil
+}
+
+func (c *Client) insertSeedRows(ctx context.Context, tableName string, table *seed.Table, rows *seed.Rows) error {
+	columns := make([]string, len(table.Columns))
+	for i, name := range table.ColumnNames() {
+		columns[i] = ansisql.QuoteIdentifierWithBackticks(name)
+	}
+	insertQuery := fmt.Sprintf("INSERT INTO %s (%s)", tableName, strings.Join(columns, ", "))
+
+	for {
+		batch, err := c.connection.PrepareBatch(ctx, insertQuery)
+		if err != nil {
+			return errors.Wrapf(err, "failed to prepare the batch for table %s", table.Name)
+		}
+
+		count := 0
+		for count < seedBatchSize && rows.Next() {
+			values, _ := rows.Values()
+			if err := batch.Append(values...); err != nil {
+				_ = batch.Abort()
+				return errors.Wrapf(err, "failed to append a row to the batch for table %s", table.Name)
+			}
+			count++
+		}
+		if err := rows.Err(); err != nil {
+			_ = batch.Abort()
+			return err
+		}
+
+		if count == 0 {
+			_ = batch.Abort()
+			return nil
+		}
+		if err := batch.Send(); err != nil {
+			return errors.Wrapf(err, "failed to insert into table %s", table.Name)
+		}
+		if count < seedBatchSize {
+			return nil
+		}
+	}
+}
+
+func seedColumnType(col seed.Column) string {
+	var typ string
+	switch col.Kind {
+	case seed.KindInteger:
+		typ = "Int64"
+	case seed.KindFloat:
+		typ = "Float64"
+	case seed.KindDecimal:
+		typ = "Decimal(38, 9)"
+		if col.Precision > 0 {
+			typ = fmt.Sprintf("Decimal(%d, %d)", col.Precision, col.Scale)
+		}
+	case seed.KindBoolean:
+		typ = "Bool"
+	case seed.KindDate:
+		typ = "Date32"
+	case seed.KindTimestamp:
+		typ = "DateTime64(6)"
+		if col.TimeZone {
+			typ = "DateTime64(6, 'UTC')"
+		}
+	default:
+		typ = "String"
+	}
+
+	// The sorting key cannot be nullable.
+	if col.NotNull || col.PrimaryKey {
+		return typ
+	}
+	return "Nullable(" + typ + ")"
+}
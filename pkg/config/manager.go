@@ -200,6 +200,16 @@ type ConnectionResolver interface {
 	ResolveConnection(name string) (any, error)
 }
 
+// ResolveConnection prefers ConnectionResolver so that the reason a lookup failed reaches the
+// caller. Plain ConnectionGetter implementations collapse every failure into a nil connection.
+func ResolveConnection(conn ConnectionGetter, name string) (any, error) {
+	if resolver, ok := conn.(ConnectionResolver); ok {
+		return resolver.ResolveConnection(name)
+	}
+
+	return conn.GetConnection(name), nil
+}
+
 func (c *Connections) ConnectionsSummaryList() map[string]string {
 	if c.typeNameMap == nil {
 		c.buildConnectionKeyMap()
@@ -238,6 +248,38 @@ func (c *Connections) GetConnection(name string) any {
 	return c.byKey[name]
 }
 
+// DatabaseName returns the database, project or catalog the given connection points to, or an
+// empty string for connections that do not have one.
+func (c *Connections) DatabaseName(name string) string {
+	switch conn := c.GetConnection(name).(type) {
+	case *SnowflakeConnection:
+		return conn.Database
+	case *PostgresConnection:
+		return conn.Database
+	case *RedshiftConnection:
+		return conn.Database
+	case *GoogleCloudPlatformConnection:
+		return conn.ProjectID
+	case *DatabricksConnection:
+		return conn.Catalog
+	default:
+		return ""
+	}
+}
+
+// IdentifierQuotes returns the opening and closing characters the given connection quotes
+// identifiers with.
+func (c *Connections) IdentifierQuotes(name string) (string, string) {
+	switch c.GetConnection(name).(type) {
+	case *GoogleCloudPlatformConnection, *DatabricksConnection, *ClickHouseConnection, *AthenaConnection:
+		return "`", "`"
+	case *MsSQLConnection, *FabricConnection, *SynapseConnection:
+		return "[", "]"
+	default:
+		return `"`, `"`
+	}
+}
+
 func (c *Connections) buildConnectionKeyMap() {
 	c.byKey = make(map[string]any)
 	c.typeNameMap = make(map[string]string)
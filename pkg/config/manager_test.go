@@ -3814,3 +3814,41 @@ environments:
 	require.Equal(t, "gs://bucket/warehouse", remote.Storage.Path)
 	require.Equal(t, filepath.Join(configLocation, "creds/sa.json"), remote.Storage.KeyFile)
 }
+
+func TestConnections_DatabaseName(t *testing.T) {
+	t.Parallel()
+
+	connections := &Connections{
+		Snowflake:           []SnowflakeConnection{{ConnectionMetadata: ConnectionMetadata{Name: "sf"}, Database: "ANALYTICS"}},
+		GoogleCloudPlatform: []GoogleCloudPlatformConnection{{ConnectionMetadata: ConnectionMetadata{Name: "gcp"}, ProjectID: "my-project"}},
+		Databricks:          []DatabricksConnection{{ConnectionMetadata: ConnectionMetadata{Name: "dbx"}, Catalog: "main"}},
+		Mongo:               []MongoConnection{{ConnectionMetadata: ConnectionMetadata{Name: "mongo"}, Database: "app"}},
+	}
+
+	assert.Equal(t, "ANALYTICS", connections.DatabaseName("sf"))
+	assert.Equal(t, "my-project", connections.DatabaseName("gcp"))
+	assert.Equal(t, "main", connections.DatabaseName("dbx"))
+	assert.Empty(t, connections.DatabaseName("mongo"))
+	assert.Empty(t, connections.DatabaseName("missing"))
+}
+
+func TestConnections_IdentifierQuotes(t *testing.T) {
+	t.Parallel()
+
+	connections := &Connections{
+		Snowflake:           []SnowflakeConnection{{ConnectionMetadata: ConnectionMetadata{Name: "sf"}}},
+		GoogleCloudPlatform: []GoogleCloudPlatformConnection{{ConnectionMetadata: ConnectionMetadata{Name: "gcp"}}},
+		MsSQL:               []MsSQLConnection{{ConnectionMetadata: ConnectionMetadata{Name: "mssql"}}},
+	}
+
+	tests := map[string][2]string{
+		"sf":      {`"`, `"`},
+		"gcp":     {"`", "`"},
+		"mssql":   {"[", "]"},
+		"missing": {`"`, `"`},
+	}
+	for name, want := range tests {
+		open, closing := connections.IdentifierQuotes(name)
+		assert.Equal(t, want, [2]string{open, closing}, name)
+	}
+}
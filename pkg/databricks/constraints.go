@@ -0,0 +1,52 @@
+package databricks
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/bruin-data/bruin/pkg/ansisql"
+	"github.com/bruin-data/bruin/pkg/pipeline"
+)
+
+// ConstraintDialect migrates the informational primary and foreign keys, and the not-null
+// constraints of Unity Catalog tables. Primary key columns must be NOT NULL in Databricks.
+var ConstraintDialect = ansisql.ConstraintDialect{
+	CurrentConstraints: func(ctx context.Context, conn ansisql.MetadataClient, asset *pipeline.Asset) (*ansisql.TableConstraints, error) {
+		// information_schema is catalog-scoped in Unity Catalog
+		informationSchema := "information_schema"
+		if parts := strings.Split(asset.Name, "."); len(parts) == 3 {
+			informationSchema = parts[0] + ".information_schema"
+		}
+		schema, table := ansisql.SplitTableName(asset.Name, "default")
+		return ansisql.QueryTableConstraints(ctx, conn, ansisql.InformationSchemaConstraintsQuery(
+			informationSchema, strings.ToLower(schema), strings.ToLower(table),
+		))
+	},
+	PrimaryKeyRequiresNotNull: true,
+	AddPrimaryKey: func(asset *pipeline.Asset, columns []string) string {
+		quoted := make([]string, 0, len(columns))
+		for _, column := range columns {
+			quoted = append(quoted, quoteColumn(column))
+		}
+		return fmt.Sprintf("ALTER TABLE %s ADD PRIMARY KEY (%s)", asset.Name, strings.Join(quoted, ", "))
+	},
+	DropPrimaryKey: func(asset *pipeline.Asset, name string) string {
+		return fmt.Sprintf("ALTER TABLE %s DROP PRIMARY KEY", asset.Name)
+	},
+	SetNotNull: func(asset *pipeline.Asset, column string) string {
+		return fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s SET NOT NULL", asset.Name, quoteColumn(column))
+	},
+	DropNotNull: func(asset *pipeline.Asset, column string) string {
+		return fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s DROP NOT NULL", asset.Name, quoteColumn(column))
+	},
+	AddForeignKey: func(asset *pipeline.Asset, key ansisql.ForeignKey) string {
+		return fmt.Sprintf(
+			"ALTER TABLE %s ADD FOREIGN KEY (%s) REFERENCES %s (%s)",
+			asset.Name, quoteColumn(key.Column), key.Table, quoteColumn(key.RefColumn),
+		)
+	},
+	DropForeignKey: func(asset *pipeline.Asset, key ansisql.ForeignKey) string {
+		return fmt.Sprintf("ALTER TABLE %s DROP FOREIGN KEY (%s)", asset.Name, quoteColumn(key.Column))
+	},
+}
@@ -0,0 +1,38 @@
+package databricks
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/bruin-data/bruin/pkg/ansisql"
+	"github.com/bruin-data/bruin/pkg/pipeline"
+)
+
+// GrantDialect applies the grants of Databricks assets as Unity Catalog grants. Grantees are
+// quoted with backticks since principals are usually emails or group names with spaces.
+var GrantDialect = ansisql.GrantDialect{
+	CurrentGrantsQuery: func(asset *pipeline.Asset) string {
+		return "SHOW GRANTS ON TABLE " + asset.Name
+	},
+	// SHOW GRANTS returns principal, action_type, object_type, object_key
+	PrivilegeColumn: 1,
+	GranteeColumn:   0,
+	GrantStatement: func(asset *pipeline.Asset, privilege, grantee string) string {
+		return fmt.Sprintf("GRANT %s ON TABLE %s TO %s;", privilege, asset.Name, quotePrincipal(grantee))
+	},
+}
+
+func quotePrincipal(principal string) string {
+	if strings.HasPrefix(principal, "`") && strings.HasSuffix(principal, "`") {
+		return principal
+	}
+	// principals are often emails, they must not be split on dots like table names
+	return "`" + strings.ReplaceAll(principal, "`", "``") + "`"
+}
+
+// ApplyGrants applies the grants of an asset whose table was loaded without running SQL through
+// the operator, e.g. a materialized Python asset.
+func (db *DB) ApplyGrants(ctx context.Context, asset *pipeline.Asset) error {
+	return GrantDialect.ApplyGrants(ctx, db, asset)
+}
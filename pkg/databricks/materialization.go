@@ -24,16 +24,17 @@ var matMap = AssetMaterializationMap{
 		pipeline.MaterializationStrategyDeleteInsert:  errorMaterializer,
 	},
 	pipeline.MaterializationTypeTable: {
-		pipeline.MaterializationStrategyNone:           buildCreateReplaceQuery,
-		pipeline.MaterializationStrategyAppend:         buildAppendQuery,
-		pipeline.MaterializationStrategyCreateReplace:  buildCreateReplaceQuery,
-		pipeline.MaterializationStrategyDeleteInsert:   buildIncrementalQuery,
-		pipeline.MaterializationStrategyTruncateInsert: buildTruncateInsertQuery,
-		pipeline.MaterializationStrategyMerge:          buildMergeQuery,
-		pipeline.MaterializationStrategyTimeInterval:   buildTimeIntervalQuery,
-		pipeline.MaterializationStrategyDDL:            buildDDLQuery,
-		pipeline.MaterializationStrategySCD2ByColumn:   buildSCD2ByColumnQuery,
-		pipeline.MaterializationStrategySCD2ByTime:     buildSCD2QueryByTime,
+		pipeline.MaterializationStrategyNone:            buildCreateReplaceQuery,
+		pipeline.MaterializationStrategyAppend:          buildAppendQuery,
+		pipeline.MaterializationStrategyCreateReplace:   buildCreateReplaceQuery,
+		pipeline.MaterializationStrategyDeleteInsert:    buildIncrementalQuery,
+		pipeline.MaterializationStrategyTruncateInsert:  buildTruncateInsertQuery,
+		pipeline.MaterializationStrategyMerge:           buildMergeQuery,
+		pipeline.MaterializationStrategyTimeInterval:    buildTimeIntervalQuery,
+		pipeline.MaterializationStrategyInsertOverwrite: buildInsertOverwriteQuery,
+		pipeline.MaterializationStrategyDDL:             buildDDLQuery,
+		pipeline.MaterializationStrategySCD2ByColumn:    buildSCD2ByColumnQuery,
+		pipeline.MaterializationStrategySCD2ByTime:      buildSCD2QueryByTime,
 	},
 }
 
@@ -195,13 +196,54 @@ func buildTimeIntervalQuery(asset *pipeline.Asset, query string) ([]string, erro
 	return queries, nil
 }
 
+// buildInsertOverwriteQuery replaces whole partitions of a Delta table in a single statement. With
+// `time_granularity` set, `REPLACE WHERE` replaces the interval, and Delta rejects the write if the
+// query returns rows outside of it. Otherwise `REPLACE USING` replaces the partitions that appear
+// in the query result.
+func buildInsertOverwriteQuery(asset *pipeline.Asset, query string) ([]string, error) {
+	mat := asset.Materialization
+	if mat.PartitionBy == "" {
+		return nil, errors.New("materialization strategy insert_overwrite requires the `partition_by` field to be set")
+	}
+
+	if mat.TimeGranularity == "" {
+		return []string{
+			fmt.Sprintf("INSERT INTO %s REPLACE USING (%s) %s", asset.Name, mat.PartitionBy, query),
+		}, nil
+	}
+
+	startVar := "{{start_timestamp}}"
+	endVar := "{{end_timestamp}}"
+	switch mat.TimeGranularity {
+	case pipeline.MaterializationTimeGranularityDate:
+		startVar = "{{start_date}}"
+		endVar = "{{end_date}}"
+	case pipeline.MaterializationTimeGranularityTimestamp:
+	default:
+		return nil, errors.New("time_granularity must be either 'date', or 'timestamp'")
+	}
+
+	return []string{
+		fmt.Sprintf("INSERT INTO %s REPLACE WHERE %s BETWEEN '%s' AND '%s' %s", asset.Name, mat.PartitionBy, startVar, endVar, query),
+	}, nil
+}
+
 func buildDDLQuery(asset *pipeline.Asset, query string) ([]string, error) {
 	columnDefs := make([]string, 0, len(asset.Columns))
+	primaryKeys := make([]string, 0)
+	foreignKeys := make([]string, 0)
 
 	for _, col := range asset.Columns {
 		def := fmt.Sprintf("%s %s", col.Name, col.SQLType())
+		// Unity Catalog requires primary key columns to be NOT NULL
+		if col.IsNotNull() || col.PrimaryKey {
+			def += " NOT NULL"
+		}
 		if col.PrimaryKey {
-			def += " PRIMARY KEY"
+			primaryKeys = append(primaryKeys, col.Name)
+		}
+		if col.HasForeignKey() {
+			foreignKeys = append(foreignKeys, fmt.Sprintf("FOREIGN KEY (%s) REFERENCES %s (%s)", col.Name, col.ForeignKey.Table, col.ForeignKey.Column))
 		}
 		if col.Description != "" {
 			def += fmt.Sprintf(" COMMENT '%s'", col.Description)
@@ -209,6 +251,11 @@ func buildDDLQuery(asset *pipeline.Asset, query string) ([]string, error) {
 		columnDefs = append(columnDefs, def)
 	}
 
+	if len(primaryKeys) > 0 {
+		columnDefs = append(columnDefs, fmt.Sprintf("PRIMARY KEY (%s)", strings.Join(primaryKeys, ", ")))
+	}
+	columnDefs = append(columnDefs, foreignKeys...)
+
 	partitionBy := ""
 	if asset.Materialization.PartitionBy != "" {
 		partitionBy = fmt.Sprintf("\nPARTITIONED BY (%s)", asset.Materialization.PartitionBy)
@@ -278,6 +278,49 @@ func TestMaterializer_Render(t *testing.T) {
 				"INSERT INTO my.asset SELECT dt, event_name from source_table where dt between '{{start_date}}' and '{{end_date}}'",
 			},
 		},
+		{
+			name: "insert_overwrite replaces the partitions in the query result",
+			task: &pipeline.Asset{
+				Name: "my.asset",
+				Materialization: pipeline.Materialization{
+					Type:        pipeline.MaterializationTypeTable,
+					Strategy:    pipeline.MaterializationStrategyInsertOverwrite,
+					PartitionBy: "dt, country",
+				},
+			},
+			query: "SELECT dt, country, revenue FROM source_table",
+			want: []string{
+				"INSERT INTO my.asset REPLACE USING \\(dt, country\\) SELECT dt, country, revenue FROM source_table",
+			},
+		},
+		{
+			name: "insert_overwrite with time_granularity replaces the interval",
+			task: &pipeline.Asset{
+				Name: "my.asset",
+				Materialization: pipeline.Materialization{
+					Type:            pipeline.MaterializationTypeTable,
+					Strategy:        pipeline.MaterializationStrategyInsertOverwrite,
+					PartitionBy:     "ts",
+					TimeGranularity: pipeline.MaterializationTimeGranularityTimestamp,
+				},
+			},
+			query: "SELECT ts, revenue FROM source_table",
+			want: []string{
+				"INSERT INTO my.asset REPLACE WHERE ts BETWEEN '{{start_timestamp}}' AND '{{end_timestamp}}' SELECT ts, revenue FROM source_table",
+			},
+		},
+		{
+			name: "insert_overwrite requires partition_by",
+			task: &pipeline.Asset{
+				Name: "my.asset",
+				Materialization: pipeline.Materialization{
+					Type:     pipeline.MaterializationTypeTable,
+					Strategy: pipeline.MaterializationStrategyInsertOverwrite,
+				},
+			},
+			query:   "SELECT 1",
+			wantErr: true,
+		},
 		{
 			name: "empty table",
 			task: &pipeline.Asset{
@@ -328,7 +371,8 @@ func TestMaterializer_Render(t *testing.T) {
 			want: []string{
 				"CREATE TABLE IF NOT EXISTS two_col_table \\(\n" +
 					"id INT64,\n" +
-					"name STRING PRIMARY KEY COMMENT \\'The name of the person\\'\n" +
+					"name STRING NOT NULL COMMENT \\'The name of the person\\',\n" +
+					"PRIMARY KEY \\(name\\)\n" +
 					"\\)",
 			},
 		},
@@ -370,8 +414,9 @@ func TestMaterializer_Render(t *testing.T) {
 			},
 			want: []string{
 				"CREATE TABLE IF NOT EXISTS my_partitioned_table \\(\n" +
-					"id INT64 PRIMARY KEY,\n" +
-					"timestamp TIMESTAMP COMMENT 'Event timestamp'\n" +
+					"id INT64 NOT NULL,\n" +
+					"timestamp TIMESTAMP COMMENT 'Event timestamp',\n" +
+					"PRIMARY KEY \\(id\\)\n" +
 					"\\)" +
 					"\nPARTITIONED BY \\(timestamp\\)",
 			},
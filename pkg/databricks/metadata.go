@@ -0,0 +1,99 @@
+package databricks
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/bruin-data/bruin/pkg/ansisql"
+	"github.com/bruin-data/bruin/pkg/config"
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/bruin-data/bruin/pkg/query"
+	"github.com/pkg/errors"
+)
+
+// MetadataDialect pushes asset and column descriptions as Databricks comments, and the owner and
+// tags of the asset as table properties. The owner cannot be stored in the reserved `owner`
+// property, the properties are prefixed with `bruin.` instead.
+var MetadataDialect = ansisql.MetadataDialect{
+	Platform:        "Databricks",
+	CurrentMetadata: currentMetadata,
+	TableCommentStatement: func(asset *pipeline.Asset, comment string) string {
+		return fmt.Sprintf("COMMENT ON TABLE %s IS %s", asset.Name, quoteLiteral(comment))
+	},
+	ColumnCommentStatement: func(asset *pipeline.Asset, column ansisql.ColumnMetadata, comment string) string {
+		return fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s COMMENT %s", asset.Name, quoteColumn(column.Name), quoteLiteral(comment))
+	},
+	PropertiesStatement: func(asset *pipeline.Asset, properties map[string]string) string {
+		values := make([]string, 0, len(properties))
+		for _, key := range ansisql.SortedKeys(properties) {
+			values = append(values, fmt.Sprintf("%s = %s", quoteLiteral(key), quoteLiteral(properties[key])))
+		}
+		return fmt.Sprintf("ALTER TABLE %s SET TBLPROPERTIES (%s)", asset.Name, strings.Join(values, ", "))
+	},
+}
+
+func NewMetadataPushOperator(conn config.ConnectionGetter) *ansisql.MetadataPushOperator {
+	return ansisql.NewMetadataPushOperator(conn, MetadataDialect)
+}
+
+func currentMetadata(ctx context.Context, conn ansisql.MetadataClient, asset *pipeline.Asset) (*ansisql.TableMetadata, error) {
+	rows, err := conn.Select(ctx, &query.Query{Query: "DESCRIBE TABLE EXTENDED " + asset.Name})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to describe the table")
+	}
+	metadata := parseDescribeExtended(rows)
+
+	properties, err := conn.Select(ctx, &query.Query{Query: "SHOW TBLPROPERTIES " + asset.Name})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get the table properties")
+	}
+	for _, row := range properties {
+		if len(row) >= 2 {
+			metadata.Properties[fmt.Sprint(row[0])] = fmt.Sprint(row[1])
+		}
+	}
+	return metadata, nil
+}
+
+// parseDescribeExtended reads the columns and the table comment from the output of DESCRIBE
+// TABLE EXTENDED. Columns are listed first, followed by sections whose headers start with `#`,
+// the table comment is in the `Comment` row of the detailed table information.
+func parseDescribeExtended(rows [][]interface{}) *ansisql.TableMetadata {
+	metadata := &ansisql.TableMetadata{Properties: make(map[string]string)}
+	inColumns := true
+	for _, row := range rows {
+		if len(row) < 3 {
+			continue
+		}
+		name := strings.TrimSpace(valueOrEmpty(row[0]))
+		if name == "" || strings.HasPrefix(name, "#") {
+			inColumns = false
+			continue
+		}
+		if inColumns {
+			metadata.Columns = append(metadata.Columns, ansisql.ColumnMetadata{Name: name, Comment: valueOrEmpty(row[2])})
+			continue
+		}
+		if name == "Comment" {
+			metadata.Comment = valueOrEmpty(row[1])
+		}
+	}
+	return metadata
+}
+
+func valueOrEmpty(value interface{}) string {
+	if value == nil {
+		return ""
+	}
+	return fmt.Sprint(value)
+}
+
+// quoteLiteral quotes a Databricks string literal, where backslashes are escape characters.
+func quoteLiteral(value string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(value) + "'"
+}
+
+func quoteColumn(name string) string {
+	return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+}
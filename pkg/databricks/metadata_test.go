@@ -0,0 +1,63 @@
+package databricks
+
+import (
+	"testing"
+
+	"github.com/bruin-data/bruin/pkg/ansisql"
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDescribeExtended(t *testing.T) {
+	t.Parallel()
+
+	metadata := parseDescribeExtended([][]interface{}{
+		{"id", "bigint", "The id"},
+		{"country", "string", nil},
+		{"# Partition Information", "", ""},
+		{"# col_name", "data_type", "comment"},
+		{"country", "string", nil},
+		{"", "", ""},
+		{"# Detailed Table Information", "", ""},
+		{"Catalog", "main", ""},
+		{"Comment", "All the orders", ""},
+		{"Owner", "jane@example.com", ""},
+	})
+
+	require.Equal(t, &ansisql.TableMetadata{
+		Comment: "All the orders",
+		Columns: []ansisql.ColumnMetadata{
+			{Name: "id", Comment: "The id"},
+			{Name: "country"},
+		},
+		Properties: map[string]string{},
+	}, metadata)
+}
+
+func TestMetadataDialect_MetadataQueries(t *testing.T) {
+	t.Parallel()
+
+	asset := &pipeline.Asset{
+		Name:        "main.sales.orders",
+		Description: "All the orders",
+		Owner:       "jane@example.com",
+		Tags:        []string{"finance", "pii"},
+		Columns: []pipeline.Column{
+			{Name: "id", Description: "The id"},
+			{Name: "country", Description: "Country's ISO code"},
+		},
+	}
+	current := &ansisql.TableMetadata{
+		Comment: "All the orders",
+		Columns: []ansisql.ColumnMetadata{
+			{Name: "id", Comment: "The id"},
+			{Name: "country"},
+		},
+		Properties: map[string]string{ansisql.OwnerProperty: "john@example.com"},
+	}
+
+	require.Equal(t, []string{
+		"ALTER TABLE main.sales.orders ALTER COLUMN `country` COMMENT 'Country\\'s ISO code'",
+		"ALTER TABLE main.sales.orders SET TBLPROPERTIES ('bruin.owner' = 'jane@example.com', 'bruin.tags' = 'finance,pii')",
+	}, MetadataDialect.MetadataQueries(asset, current))
+}
@@ -85,7 +85,7 @@ func (o BasicOperator) RunTask(ctx context.Context, p *pipeline.Pipeline, t *pip
 		return err
 	}
 
-	if t.Materialization.Strategy == pipeline.MaterializationStrategyTimeInterval {
+	if t.Materialization.UsesIntervalDates() {
 		materializedQueries, err = extractor.ReextractQueriesFromSlice(materializedQueries)
 		if err != nil {
 			return err
@@ -138,20 +138,14 @@ func (o BasicOperator) RunTask(ctx context.Context, p *pipeline.Pipeline, t *pip
 		lastQuery = q
 	}
 
-	if o.devEnv == nil {
-		return nil
-	}
-
-	if lastQuery == nil {
-		return nil
-	}
-
-	err = o.devEnv.RegisterAssetForSchemaCache(ctx, p, t, lastQuery)
-	if err != nil {
-		return errors.Wrap(err, "cannot register asset for schema cache")
+	if o.devEnv != nil && lastQuery != nil {
+		err = o.devEnv.RegisterAssetForSchemaCache(ctx, p, t, lastQuery)
+		if err != nil {
+			return errors.Wrap(err, "cannot register asset for schema cache")
+		}
 	}
 
-	return nil
+	return GrantDialect.ApplyGrants(ctx, conn, t)
 }
 
 func NewColumnCheckOperator(manager config.ConnectionGetter) *ansisql.ColumnCheckOperator {
@@ -0,0 +1,131 @@
+package devenv
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/bruin-data/bruin/pkg/pipeline"
+)
+
+type ContextKey string
+
+// DeferTargetContextKey holds the *DeferTarget of a run started with `--defer-to`.
+const DeferTargetContextKey ContextKey = "defer_target"
+
+// crossDatabaseDialects are the dialects where a table of another database can be referenced with a
+// three-part name, which is what a deferred reference to another database needs.
+var crossDatabaseDialects = map[string]bool{
+	"bigquery":   true,
+	"databricks": true,
+	"redshift":   true,
+	"snowflake":  true,
+}
+
+// DeferTarget is the environment that references to upstream tables fall back to when the upstream
+// has not been built in the developer environment yet.
+type DeferTarget struct {
+	Environment  string
+	SchemaPrefix string
+	// Databases maps connection names to the database, project or catalog the connection points to in
+	// the deferred environment. References are qualified with it when it differs from the one of the
+	// developer environment.
+	Databases map[string]string
+
+	lock     sync.Mutex
+	deferred []DeferredReference
+}
+
+// DeferredReference is a table reference that was resolved against the deferred environment.
+type DeferredReference struct {
+	Asset     string `json:"asset"`
+	Reference string `json:"reference"`
+	Target    string `json:"target"`
+}
+
+// Deferred returns the references that were resolved against the deferred environment so far,
+// sorted by asset and reference.
+func (t *DeferTarget) Deferred() []DeferredReference {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	deferred := append([]DeferredReference(nil), t.deferred...)
+	sort.Slice(deferred, func(i, j int) bool {
+		if deferred[i].Asset != deferred[j].Asset {
+			return deferred[i].Asset < deferred[j].Asset
+		}
+		return deferred[i].Reference < deferred[j].Reference
+	})
+
+	return deferred
+}
+
+func (t *DeferTarget) record(asset, reference, target string) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	for _, existing := range t.deferred {
+		if existing.Asset == asset && existing.Reference == reference {
+			return
+		}
+	}
+
+	t.deferred = append(t.deferred, DeferredReference{Asset: asset, Reference: reference, Target: target})
+}
+
+// resolve returns the name of the referenced table in the deferred environment. A table in another
+// database fails on the dialects that cannot query another database, reading it from the developer
+// database instead would silently run the asset against the wrong data.
+func (t *DeferTarget) resolve(dialect, connName, currentDatabase, reference string) (string, error) {
+	resolved := pipeline.PrefixSchemaName(reference, t.SchemaPrefix)
+	if strings.Count(reference, ".") != 1 {
+		return resolved, nil
+	}
+
+	database := t.Databases[connName]
+	if database == "" || strings.EqualFold(database, currentDatabase) {
+		return resolved, nil
+	}
+
+	if !crossDatabaseDialects[strings.ToLower(dialect)] {
+		return "", fmt.Errorf(
+			"cannot defer '%s' to the '%s' environment: it is in the database '%s' there, and %s cannot query another database than '%s', build the upstream in the developer environment instead",
+			reference, t.Environment, database, dialect, currentDatabase,
+		)
+	}
+
+	return database + "." + resolved, nil
+}
+
+// upstreamReferences returns the lowercased names of the asset upstreams, which are the only
+// references that are deferred.
+func upstreamReferences(a *pipeline.Asset) map[string]bool {
+	upstreams := make(map[string]bool, len(a.Upstreams))
+	for _, upstream := range a.Upstreams {
+		if upstream.Type != "" && upstream.Type != "asset" {
+			continue
+		}
+		upstreams[strings.ToLower(upstream.Value)] = true
+	}
+
+	return upstreams
+}
+
+// isUpstreamReference checks if the table reference points to one of the upstreams. Upstream names
+// are already prefixed for the developer environment, and may leave out the database.
+func isUpstreamReference(upstreams map[string]bool, reference, schemaPrefix string) bool {
+	reference = strings.ToLower(reference)
+	candidates := []string{reference}
+	if parts := strings.Split(reference, "."); len(parts) == 3 {
+		candidates = append(candidates, parts[1]+"."+parts[2])
+	}
+
+	for _, candidate := range candidates {
+		if upstreams[candidate] || upstreams[pipeline.PrefixSchemaName(candidate, strings.ToLower(schemaPrefix))] {
+			return true
+		}
+	}
+
+	return false
+}
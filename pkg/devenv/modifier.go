@@ -143,6 +143,30 @@ func (d *DevEnvQueryModifier) Modify(ctx context.Context, p *pipeline.Pipeline,
 		renameMapping[strings.Join(originalAssetNameParts, ".")] = assetName
 	}
 
+	// with `--defer-to`, upstreams that were not built in the developer environment are read from the
+	// deferred environment instead.
+	deferTarget, _ := ctx.Value(DeferTargetContextKey).(*DeferTarget)
+	var upstreams map[string]bool
+	if deferTarget != nil {
+		upstreams = upstreamReferences(a)
+	}
+	deferReference := func(tableReference string) error {
+		if deferTarget == nil || !isUpstreamReference(upstreams, tableReference, env.SchemaPrefix) {
+			return nil
+		}
+
+		deferredTable, err := deferTarget.resolve(d.Dialect, connName, dbSummary.Name, tableReference)
+		if err != nil {
+			return err
+		}
+		if deferredTable != tableReference {
+			renameMapping[tableReference] = deferredTable
+		}
+		deferTarget.record(a.Name, tableReference, deferredTable)
+
+		return nil
+	}
+
 	for _, tableReference := range usedTables {
 		parts := strings.Split(tableReference, ".")
 
@@ -156,6 +180,8 @@ func (d *DevEnvQueryModifier) Modify(ctx context.Context, p *pipeline.Pipeline,
 
 			if d.databaseSummaryTableExists(dbSummary, dbSummary.Name, devSchema, table) {
 				renameMapping[tableReference] = devTable
+			} else if err := deferReference(tableReference); err != nil {
+				return nil, err
 			}
 		case 3:
 			// database.schema.table -> database.dev_schema.table
@@ -182,6 +208,8 @@ func (d *DevEnvQueryModifier) Modify(ctx context.Context, p *pipeline.Pipeline,
 
 			if tableExists {
 				renameMapping[tableReference] = devTable
+			} else if err := deferReference(tableReference); err != nil {
+				return nil, err
 			}
 		default:
 			continue
@@ -852,3 +852,146 @@ func TestDevEnvQueryModifierBatchesCrossCatalogTableChecks(t *testing.T) {
 	connectionFetcher.AssertExpectations(t)
 	sqlParser.AssertExpectations(t)
 }
+
+func TestDevEnvQueryModifier_Modify_DefersMissingUpstreams(t *testing.T) {
+	t.Parallel()
+
+	p := &pipeline.Pipeline{
+		DefaultConnections: map[string]string{"snowflake": "sf-default"},
+	}
+	asset := &pipeline.Asset{
+		Name: "dev_analytics.orders",
+		Type: pipeline.AssetTypeSnowflakeQuery,
+		Upstreams: []pipeline.Upstream{
+			{Type: "asset", Value: "dev_raw.events"},
+			{Type: "asset", Value: "dev_raw.users"},
+		},
+	}
+	connection := new(mockConnectionInstance)
+	connection.On("GetDatabaseSummary", mock.Anything).Return(&ansisql.DBDatabase{
+		Name: "DEV_DB",
+		Schemas: []*ansisql.DBSchema{{
+			Name:   "dev_raw",
+			Tables: []*ansisql.DBTable{{Name: "users"}},
+		}},
+	}, nil)
+	connectionFetcher := new(mockConnectionFetcher)
+	connectionFetcher.On("GetConnection", "sf-default").Return(connection)
+	sqlParser := new(mockSQLParser)
+	inputQuery := "SELECT * FROM raw.events JOIN raw.users USING (user_id) JOIN lookup.countries USING (country)"
+	sqlParser.On("UsedTables", inputQuery, "snowflake").
+		Return([]string{"raw.events", "raw.users", "lookup.countries"}, nil)
+	expectedMapping := map[string]string{
+		"analytics.orders": "dev_analytics.orders",
+		"raw.events":       "PROD_DB.raw.events",
+		"raw.users":        "dev_raw.users",
+	}
+	outputQuery := "SELECT * FROM PROD_DB.raw.events JOIN dev_raw.users USING (user_id) JOIN lookup.countries USING (country)"
+	sqlParser.On("RenameTables", inputQuery, "snowflake", expectedMapping).Return(outputQuery, nil)
+
+	modifier := &DevEnvQueryModifier{
+		Dialect: "snowflake",
+		Conn:    connectionFetcher,
+		Parser:  sqlParser,
+	}
+	deferTarget := &DeferTarget{
+		Environment: "production",
+		Databases:   map[string]string{"sf-default": "PROD_DB"},
+	}
+	ctx := context.WithValue(t.Context(), config.EnvironmentContextKey, &config.Environment{SchemaPrefix: "dev_"})
+	ctx = context.WithValue(ctx, DeferTargetContextKey, deferTarget)
+
+	got, err := modifier.Modify(ctx, p, asset, &query.Query{Query: inputQuery})
+	require.NoError(t, err)
+	require.Equal(t, &query.Query{Query: outputQuery}, got)
+	assert.Equal(t, []DeferredReference{
+		{Asset: "dev_analytics.orders", Reference: "raw.events", Target: "PROD_DB.raw.events"},
+	}, deferTarget.Deferred())
+	connection.AssertExpectations(t)
+	connectionFetcher.AssertExpectations(t)
+	sqlParser.AssertExpectations(t)
+}
+
+func TestDeferTarget_resolve(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name            string
+		target          *DeferTarget
+		dialect         string
+		currentDatabase string
+		reference       string
+		want            string
+		wantErr         string
+	}{
+		{
+			name:      "no prefix and no database keeps the reference",
+			target:    &DeferTarget{},
+			dialect:   "snowflake",
+			reference: "raw.events",
+			want:      "raw.events",
+		},
+		{
+			name:      "the schema prefix of the deferred environment is applied",
+			target:    &DeferTarget{SchemaPrefix: "stg_"},
+			dialect:   "postgres",
+			reference: "raw.events",
+			want:      "stg_raw.events",
+		},
+		{
+			name:            "a different database qualifies the reference",
+			target:          &DeferTarget{Databases: map[string]string{"conn": "prod-project"}},
+			dialect:         "bigquery",
+			currentDatabase: "dev-project",
+			reference:       "raw.events",
+			want:            "prod-project.raw.events",
+		},
+		{
+			name:            "the same database does not qualify the reference",
+			target:          &DeferTarget{Databases: map[string]string{"conn": "PROD"}},
+			dialect:         "snowflake",
+			currentDatabase: "prod",
+			reference:       "raw.events",
+			want:            "raw.events",
+		},
+		{
+			name:            "postgres cannot reference other databases",
+			target:          &DeferTarget{Environment: "production", Databases: map[string]string{"conn": "prod"}},
+			dialect:         "postgres",
+			currentDatabase: "dev",
+			reference:       "raw.events",
+			wantErr:         "cannot defer 'raw.events' to the 'production' environment: it is in the database 'prod' there, and postgres cannot query another database than 'dev', build the upstream in the developer environment instead",
+		},
+		{
+			name:            "postgres defers within the same database",
+			target:          &DeferTarget{SchemaPrefix: "stg_", Databases: map[string]string{"conn": "dev"}},
+			dialect:         "postgres",
+			currentDatabase: "dev",
+			reference:       "raw.events",
+			want:            "stg_raw.events",
+		},
+		{
+			name:            "three-part references keep their database",
+			target:          &DeferTarget{SchemaPrefix: "stg_", Databases: map[string]string{"conn": "prod"}},
+			dialect:         "databricks",
+			currentDatabase: "dev",
+			reference:       "shared.raw.events",
+			want:            "shared.stg_raw.events",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := tt.target.resolve(tt.dialect, "conn", tt.currentDatabase, tt.reference)
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
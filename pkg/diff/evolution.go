@@ -0,0 +1,209 @@
+package diff
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SchemaChangePolicy controls how an incremental materialization reacts when the
+// query output no longer matches the columns of the existing table.
+type SchemaChangePolicy string
+
+const (
+	SchemaChangePolicyFail             SchemaChangePolicy = "fail"
+	SchemaChangePolicyIgnore           SchemaChangePolicy = "ignore"
+	SchemaChangePolicyAppendNewColumns SchemaChangePolicy = "append_new_columns"
+	SchemaChangePolicySyncAllColumns   SchemaChangePolicy = "sync_all_columns"
+)
+
+// queryTableName is the placeholder name used for the query side of the comparison, it never
+// ends up in a generated statement.
+const queryTableName = "__bruin_query_output"
+
+// SchemaChangeError is returned when the policy is `fail` and the query output drifted from the
+// existing table.
+type SchemaChangeError struct {
+	Table          string
+	AddedColumns   []string
+	RemovedColumns []string
+	ChangedColumns []string
+}
+
+func (e *SchemaChangeError) Error() string {
+	parts := make([]string, 0, 3)
+	if len(e.AddedColumns) > 0 {
+		parts = append(parts, "new columns: "+strings.Join(e.AddedColumns, ", "))
+	}
+	if len(e.RemovedColumns) > 0 {
+		parts = append(parts, "removed columns: "+strings.Join(e.RemovedColumns, ", "))
+	}
+	if len(e.ChangedColumns) > 0 {
+		parts = append(parts, "columns with changed types: "+strings.Join(e.ChangedColumns, ", "))
+	}
+
+	return fmt.Sprintf(
+		"the query output for '%s' does not match the existing table (%s), set `materialization.on_schema_change` to 'append_new_columns' or 'sync_all_columns' to evolve the table automatically",
+		e.Table,
+		strings.Join(parts, "; "),
+	)
+}
+
+// SchemaEvolutionPlanner compares the columns a query produces with the columns of the table it
+// writes into and generates the ALTER TABLE statements required by a SchemaChangePolicy.
+type SchemaEvolutionPlanner struct {
+	dialect    DatabaseDialect
+	typeMapper TypeMapping
+}
+
+// NewSchemaEvolutionPlanner creates a planner for the given dialect. The type mapper is used to
+// decide whether a column type actually changed: drivers often report a different spelling of the
+// same type for a query result than the catalog does for a table (e.g. `int8` vs `bigint`), so only
+// changes of the normalized type category are treated as a type change.
+func NewSchemaEvolutionPlanner(dialect DatabaseDialect, typeMapper TypeMapping) *SchemaEvolutionPlanner {
+	return &SchemaEvolutionPlanner{
+		dialect:    normalizeDialect(dialect),
+		typeMapper: typeMapper,
+	}
+}
+
+// Plan returns the statements that bring `tableName` in line with the query output according to
+// the policy. Column names are matched case-insensitively.
+func (p *SchemaEvolutionPlanner) Plan(policy SchemaChangePolicy, tableName string, queryColumns, tableColumns []*Column) ([]string, error) {
+	if policy == "" || policy == SchemaChangePolicyIgnore || len(tableColumns) == 0 {
+		return []string{}, nil
+	}
+
+	qualifiedName := p.qualifiedTableName(tableName)
+	comparison := p.compare(qualifiedName, queryColumns, tableColumns)
+	if !comparison.HasSchemaDifferences {
+		return []string{}, nil
+	}
+
+	switch policy {
+	case SchemaChangePolicyFail:
+		return nil, p.schemaChangeError(tableName, qualifiedName, &comparison)
+	case SchemaChangePolicyAppendNewColumns:
+		comparison.ColumnDifferences = nil
+		missing := make([]MissingColumn, 0, len(comparison.MissingColumns))
+		for _, col := range comparison.MissingColumns {
+			if col.MissingFrom == qualifiedName {
+				missing = append(missing, col)
+			}
+		}
+		comparison.MissingColumns = missing
+	case SchemaChangePolicySyncAllColumns:
+	case SchemaChangePolicyIgnore:
+		return []string{}, nil
+	default:
+		return nil, fmt.Errorf("unsupported schema change policy '%s'", policy)
+	}
+
+	for _, col := range comparison.MissingColumns {
+		if col.MissingFrom == qualifiedName && col.Type == "" {
+			return nil, fmt.Errorf("cannot add column '%s' to '%s': the type of the column could not be determined from the query output", col.ColumnName, tableName)
+		}
+	}
+
+	return NewAlterStatementGenerator(p.dialect, false).GenerateAlterStatements(&comparison), nil
+}
+
+// qualifiedTableName quotes every part of the table name up front. The ALTER statement generator
+// leaves already-quoted identifiers untouched, which keeps multi-part names intact on every dialect.
+func (p *SchemaEvolutionPlanner) qualifiedTableName(tableName string) string {
+	if p.dialect == DialectBigQuery {
+		return fmt.Sprintf("`%s`", tableName)
+	}
+
+	parts := strings.Split(tableName, ".")
+	for i, part := range parts {
+		switch p.dialect {
+		case DialectTSQL:
+			parts[i] = fmt.Sprintf("[%s]", strings.ReplaceAll(part, "]", "]]"))
+		case DialectSnowflake:
+			// Snowflake stores unquoted identifiers in uppercase.
+			parts[i] = fmt.Sprintf("\"%s\"", strings.ToUpper(part))
+		default:
+			parts[i] = fmt.Sprintf("\"%s\"", part)
+		}
+	}
+
+	return strings.Join(parts, ".")
+}
+
+// compare builds a SchemaComparisonResult where the query output is Table1 and the existing table
+// is Table2, so that the default (non-reverse) AlterStatementGenerator alters the existing table.
+func (p *SchemaEvolutionPlanner) compare(tableName string, queryColumns, tableColumns []*Column) SchemaComparisonResult {
+	existing := make(map[string]*Column, len(tableColumns))
+	for _, col := range tableColumns {
+		existing[strings.ToLower(col.Name)] = col
+	}
+
+	// Query results carry neither nullability nor constraints, so those are taken over from the
+	// table to keep them out of the comparison; only additions, removals and type changes matter.
+	normalizedQueryColumns := make([]*Column, 0, len(queryColumns))
+	for _, col := range queryColumns {
+		normalized := &Column{
+			Name:           col.Name,
+			Type:           col.Type,
+			NormalizedType: p.mapType(col.Type),
+			Nullable:       true,
+		}
+
+		if tableCol, ok := existing[strings.ToLower(col.Name)]; ok {
+			normalized.Name = tableCol.Name
+			normalized.Nullable = tableCol.Nullable
+			normalized.PrimaryKey = tableCol.PrimaryKey
+			normalized.Unique = tableCol.Unique
+			if p.mapType(tableCol.Type) == normalized.NormalizedType {
+				normalized.Type = tableCol.Type
+			}
+		}
+
+		normalizedQueryColumns = append(normalizedQueryColumns, normalized)
+	}
+
+	normalizedTableColumns := make([]*Column, 0, len(tableColumns))
+	for _, col := range tableColumns {
+		normalizedTableColumns = append(normalizedTableColumns, &Column{
+			Name:           col.Name,
+			Type:           col.Type,
+			NormalizedType: p.mapType(col.Type),
+			Nullable:       col.Nullable,
+			PrimaryKey:     col.PrimaryKey,
+			Unique:         col.Unique,
+		})
+	}
+
+	return CompareTableSchemas(
+		&TableSummaryResult{Table: &Table{Name: queryTableName, Columns: normalizedQueryColumns}},
+		&TableSummaryResult{Table: &Table{Name: tableName, Columns: normalizedTableColumns}},
+		queryTableName,
+		tableName,
+	)
+}
+
+func (p *SchemaEvolutionPlanner) schemaChangeError(tableName, qualifiedName string, comparison *SchemaComparisonResult) error {
+	err := &SchemaChangeError{Table: tableName}
+	for _, col := range comparison.MissingColumns {
+		if col.MissingFrom == qualifiedName {
+			err.AddedColumns = append(err.AddedColumns, col.ColumnName)
+		} else {
+			err.RemovedColumns = append(err.RemovedColumns, col.ColumnName)
+		}
+	}
+	for _, col := range comparison.ColumnDifferences {
+		if col.TypeDifference != nil {
+			err.ChangedColumns = append(err.ChangedColumns, fmt.Sprintf("%s (%s -> %s)", col.ColumnName, col.TypeDifference.Table2Type, col.TypeDifference.Table1Type))
+		}
+	}
+
+	return err
+}
+
+func (p *SchemaEvolutionPlanner) mapType(databaseType string) CommonDataType {
+	if p.typeMapper == nil {
+		return CommonTypeUnknown
+	}
+
+	return p.typeMapper.MapType(databaseType)
+}
@@ -0,0 +1,158 @@
+package diff
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchemaEvolutionPlanner_Plan(t *testing.T) {
+	t.Parallel()
+
+	tableColumns := []*Column{
+		{Name: "id", Type: "bigint", Nullable: false},
+		{Name: "name", Type: "varchar", Nullable: true},
+		{Name: "legacy", Type: "text", Nullable: true},
+	}
+
+	queryColumns := []*Column{
+		{Name: "id", Type: "int8"},
+		{Name: "name", Type: "text"},
+		{Name: "email", Type: "text"},
+	}
+
+	tests := []struct {
+		name        string
+		dialect     DatabaseDialect
+		policy      SchemaChangePolicy
+		query       []*Column
+		table       []*Column
+		want        []string
+		wantErrText string
+	}{
+		{
+			name:    "ignore never generates statements",
+			dialect: DialectPostgreSQL,
+			policy:  SchemaChangePolicyIgnore,
+			query:   queryColumns,
+			table:   tableColumns,
+			want:    []string{},
+		},
+		{
+			name:    "missing table generates nothing",
+			dialect: DialectPostgreSQL,
+			policy:  SchemaChangePolicySyncAllColumns,
+			query:   queryColumns,
+			table:   nil,
+			want:    []string{},
+		},
+		{
+			name:    "type spellings of the same category are not a change",
+			dialect: DialectPostgreSQL,
+			policy:  SchemaChangePolicyFail,
+			query:   []*Column{{Name: "id", Type: "int8"}, {Name: "name", Type: "text"}},
+			table:   tableColumns[:2],
+			want:    []string{},
+		},
+		{
+			name:    "append_new_columns only adds columns",
+			dialect: DialectPostgreSQL,
+			policy:  SchemaChangePolicyAppendNewColumns,
+			query:   queryColumns,
+			table:   tableColumns,
+			want: []string{
+				"ALTER TABLE \"analytics\".\"users\"\n  ADD COLUMN \"email\" text;",
+			},
+		},
+		{
+			name:    "sync_all_columns adds and drops columns",
+			dialect: DialectDuckDB,
+			policy:  SchemaChangePolicySyncAllColumns,
+			query:   queryColumns,
+			table:   tableColumns,
+			want: []string{
+				"ALTER TABLE \"analytics\".\"users\" ADD COLUMN \"email\" text;",
+				"ALTER TABLE \"analytics\".\"users\" DROP COLUMN \"legacy\";",
+			},
+		},
+		{
+			name:    "sync_all_columns changes types across categories",
+			dialect: DialectPostgreSQL,
+			policy:  SchemaChangePolicySyncAllColumns,
+			query:   []*Column{{Name: "id", Type: "text"}},
+			table:   []*Column{{Name: "id", Type: "bigint"}},
+			want: []string{
+				"ALTER TABLE \"analytics\".\"users\"\n  ALTER COLUMN \"id\" TYPE text;",
+			},
+		},
+		{
+			name:    "column names are matched case-insensitively",
+			dialect: DialectSnowflake,
+			policy:  SchemaChangePolicyAppendNewColumns,
+			query:   []*Column{{Name: "id", Type: "NUMBER"}, {Name: "EMAIL", Type: "VARCHAR"}},
+			table:   []*Column{{Name: "ID", Type: "NUMBER"}},
+			want: []string{
+				"ALTER TABLE \"ANALYTICS\".\"USERS\"\n  ADD COLUMN \"EMAIL\" VARCHAR;",
+			},
+		},
+		{
+			name:        "fail reports every difference",
+			dialect:     DialectPostgreSQL,
+			policy:      SchemaChangePolicyFail,
+			query:       queryColumns,
+			table:       tableColumns,
+			wantErrText: "new columns: email; removed columns: legacy",
+		},
+		{
+			name:        "new columns without a type cannot be added",
+			dialect:     DialectPostgreSQL,
+			policy:      SchemaChangePolicyAppendNewColumns,
+			query:       []*Column{{Name: "id", Type: "int8"}, {Name: "email", Type: ""}},
+			table:       []*Column{{Name: "id", Type: "bigint"}},
+			wantErrText: "cannot add column 'email' to 'analytics.users'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mapper := NewPostgresTypeMapper()
+			switch tt.dialect {
+			case DialectDuckDB:
+				mapper = NewDuckDBTypeMapper()
+			case DialectSnowflake:
+				mapper = NewSnowflakeTypeMapper()
+			}
+
+			planner := NewSchemaEvolutionPlanner(tt.dialect, mapper)
+			got, err := planner.Plan(tt.policy, "analytics.users", tt.query, tt.table)
+			if tt.wantErrText != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErrText)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSchemaEvolutionPlanner_FailReturnsSchemaChangeError(t *testing.T) {
+	t.Parallel()
+
+	planner := NewSchemaEvolutionPlanner(DialectPostgreSQL, NewPostgresTypeMapper())
+	_, err := planner.Plan(
+		SchemaChangePolicyFail,
+		"users",
+		[]*Column{{Name: "id", Type: "text"}},
+		[]*Column{{Name: "id", Type: "bigint"}},
+	)
+
+	var schemaErr *SchemaChangeError
+	require.ErrorAs(t, err, &schemaErr)
+	assert.Equal(t, "users", schemaErr.Table)
+	assert.Equal(t, []string{"id (bigint -> text)"}, schemaErr.ChangedColumns)
+}
@@ -13,6 +13,7 @@ import (
 	"time"
 
 	"github.com/bruin-data/bruin/pkg/git"
+	"github.com/bruin-data/bruin/pkg/glossary"
 	bruinpath "github.com/bruin-data/bruin/pkg/path"
 	"github.com/bruin-data/bruin/pkg/pipeline"
 )
@@ -33,6 +34,17 @@ type Options struct {
 	// ExcludeCode strips asset source content from the generated docs. By
 	// default the full asset source is embedded so it can be viewed inline.
 	ExcludeCode bool
+	// Operational embeds the run history, check outcomes, row counts and
+	// column lineage of the assets, which turns the docs into a lightweight
+	// data catalog. Only the definitions are documented when it is nil.
+	Operational *OperationalOptions
+	// GlossaryReader reads the glossary of the repository so that its
+	// entities can be browsed and searched next to the assets.
+	GlossaryReader GlossaryReader
+}
+
+type GlossaryReader interface {
+	GetGlossary(pipelinePath string) (*glossary.Glossary, error)
 }
 
 type Result struct {
@@ -50,6 +62,10 @@ type siteData struct {
 	Repository  *repositoryInfo      `json:"repository,omitempty"`
 	Stats       stats                `json:"stats"`
 	Pipelines   []*pipeline.Pipeline `json:"pipelines"`
+	// Operations is keyed by pipeline name, it is only present when the
+	// operational data is requested.
+	Operations map[string]*pipelineOperations `json:"operations,omitempty"`
+	Glossary   *glossary.Glossary             `json:"glossary,omitempty"`
 }
 
 type repositoryInfo struct {
@@ -105,6 +121,29 @@ func Generate(ctx context.Context, builder PipelineBuilder, opts Options) (*Resu
 		return nil, fmt.Errorf("no Bruin pipelines found under %s", searchRoot)
 	}
 
+	// the column lineage is parsed from the asset sources, so it has to be
+	// extracted before the sources are stripped.
+	var operations map[string]*pipelineOperations
+	if opts.Operational != nil {
+		repoRoot := ""
+		if repo != nil {
+			repoRoot = repo.Path
+		}
+		operations, err = collectOperations(ctx, pipelines, repoRoot, opts.Operational)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var glossaryData *glossary.Glossary
+	if opts.GlossaryReader != nil && repo != nil {
+		glossaryData, err = opts.GlossaryReader.GetGlossary(searchRoot)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the glossary: %w", err)
+		}
+		glossaryData = sortedGlossary(glossaryData)
+	}
+
 	assetCount := 0
 	for _, pl := range pipelines {
 		if opts.ExcludeCode {
@@ -138,7 +177,9 @@ func Generate(ctx context.Context, builder PipelineBuilder, opts Options) (*Resu
 			Pipelines: len(pipelines),
 			Assets:    assetCount,
 		},
-		Pipelines: pipelines,
+		Pipelines:  pipelines,
+		Operations: operations,
+		Glossary:   glossaryData,
 	}
 
 	jsonData, err := json.Marshal(data)
@@ -250,6 +291,21 @@ func relPath(root, value string) string {
 	return filepath.ToSlash(rel)
 }
 
+// sortedGlossary orders the glossary by name, the glossary files are read into
+// maps so their order is random otherwise. Empty glossaries are left out.
+func sortedGlossary(g *glossary.Glossary) *glossary.Glossary {
+	if g == nil || (len(g.Entities) == 0 && len(g.Domains) == 0) {
+		return nil
+	}
+	sort.SliceStable(g.Entities, func(i, j int) bool {
+		return strings.ToLower(g.Entities[i].Name) < strings.ToLower(g.Entities[j].Name)
+	})
+	sort.SliceStable(g.Domains, func(i, j int) bool {
+		return strings.ToLower(g.Domains[i].Name) < strings.ToLower(g.Domains[j].Name)
+	})
+	return g
+}
+
 func pipelineSortKey(pl *pipeline.Pipeline) string {
 	return strings.ToLower(pl.Name) + "\x00" + strings.ToLower(pl.SelectedVariant) + "\x00" + pl.DefinitionFile.Path
 }
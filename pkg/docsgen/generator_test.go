@@ -3,6 +3,7 @@ package docsgen
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"os"
 	"path/filepath"
 	"runtime"
@@ -10,7 +11,9 @@ import (
 	"testing"
 	"time"
 
+	"github.com/bruin-data/bruin/pkg/glossary"
 	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/bruin-data/bruin/pkg/scheduler"
 	"github.com/stretchr/testify/require"
 )
 
@@ -192,6 +195,135 @@ func TestGenerateExcludeCodeStripsAssetContent(t *testing.T) {
 	require.Empty(t, asset["executable_file"].(map[string]any)["content"])
 }
 
+type fakeRowCounter map[string]int64
+
+func (f fakeRowCounter) CountRows(_ context.Context, _ *pipeline.Pipeline, asset *pipeline.Asset) (int64, error) {
+	count, ok := f[asset.Name]
+	if !ok {
+		return 0, errors.New("table does not exist")
+	}
+	return count, nil
+}
+
+type fakeGlossaryReader struct {
+	glossary *glossary.Glossary
+}
+
+func (f fakeGlossaryReader) GetGlossary(_ string) (*glossary.Glossary, error) {
+	return f.glossary, nil
+}
+
+func TestGenerateEmbedsOperationalData(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(root, ".git"), 0o755))
+	pipelineDir := filepath.Join(root, "warehouse")
+	require.NoError(t, os.MkdirAll(pipelineDir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(pipelineDir, "pipeline.yml"), []byte("name: warehouse\n"), 0o644))
+
+	runsDir := filepath.Join(root, "logs", "runs", "warehouse")
+	require.NoError(t, os.MkdirAll(runsDir, 0o755))
+	startedAt := time.Date(2026, 6, 2, 9, 0, 0, 0, time.UTC)
+	states := []*scheduler.PipelineState{
+		{
+			RunID:     "older",
+			TimeStamp: time.Date(2026, 6, 1, 9, 0, 0, 0, time.UTC),
+			State: []*scheduler.PipelineAssetState{
+				{Name: "orders", Status: "failed", Checks: []*scheduler.PipelineCheckState{{Name: "not_null", Column: "id", Status: "failed"}}},
+			},
+		},
+		{
+			RunID:     "latest",
+			TimeStamp: time.Date(2026, 6, 2, 9, 5, 0, 0, time.UTC),
+			State: []*scheduler.PipelineAssetState{
+				{Name: "orders", Status: "succeeded", StartedAt: &startedAt, DurationMs: 4200, Checks: []*scheduler.PipelineCheckState{{Name: "not_null", Column: "id", Status: "succeeded"}}},
+				{Name: "customers", Status: "skipped"},
+			},
+		},
+	}
+	for _, state := range states {
+		content, err := json.Marshal(state)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(filepath.Join(runsDir, state.RunID+".json"), content, 0o644))
+	}
+
+	pipelinePath := filepath.Join(pipelineDir, "pipeline.yml")
+	builder := fakePipelineBuilder{
+		pipelines: map[string][]*pipeline.Pipeline{
+			pipelineDir: {
+				{
+					Name:           "warehouse",
+					DefinitionFile: pipeline.DefinitionFile{Name: "pipeline.yml", Path: pipelinePath},
+					Assets: []*pipeline.Asset{
+						{
+							Name:            "orders",
+							Type:            pipeline.AssetTypeBigqueryQuery,
+							Materialization: pipeline.Materialization{Type: pipeline.MaterializationTypeTable},
+							Columns:         []pipeline.Column{{Name: "id"}},
+						},
+						{
+							Name: "customers",
+							Type: pipeline.AssetTypeBigqueryQuery,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	outputPath := filepath.Join(root, "docs.html")
+	_, err := Generate(t.Context(), builder, Options{
+		InputPath:  root,
+		OutputPath: outputPath,
+		Operational: &OperationalOptions{
+			RowCounter: fakeRowCounter{"orders": 1234, "customers": 10},
+			ColumnLineage: func(_ context.Context, p *pipeline.Pipeline) error {
+				p.Assets[0].Columns[0].Upstreams = []*pipeline.UpstreamColumn{{Table: "raw.orders", Column: "order_id"}}
+				return nil
+			},
+		},
+		GlossaryReader: fakeGlossaryReader{glossary: &glossary.Glossary{
+			Entities: []*glossary.Entity{{Name: "Order"}, {Name: "Customer"}},
+		}},
+	})
+	require.NoError(t, err)
+
+	html, err := os.ReadFile(outputPath)
+	require.NoError(t, err)
+	data := extractDocsData(t, string(html))
+
+	operations := data["operations"].(map[string]any)["warehouse"].(map[string]any)
+	require.Equal(t, []any{
+		map[string]any{"run_id": "latest", "timestamp": "2026-06-02T09:05:00Z"},
+		map[string]any{"run_id": "older", "timestamp": "2026-06-01T09:00:00Z"},
+	}, operations["runs"])
+
+	assets := operations["assets"].(map[string]any)
+	require.NotContains(t, assets, "customers")
+	require.Equal(t, map[string]any{
+		"row_count": float64(1234),
+		"runs": []any{
+			map[string]any{"run_id": "latest", "timestamp": "2026-06-02T09:05:00Z", "status": "succeeded", "started_at": "2026-06-02T09:00:00Z", "duration_ms": float64(4200)},
+			map[string]any{"run_id": "older", "timestamp": "2026-06-01T09:00:00Z", "status": "failed"},
+		},
+		"checks": []any{
+			map[string]any{"name": "not_null", "column": "id", "outcomes": []any{
+				map[string]any{"run_id": "latest", "timestamp": "2026-06-02T09:05:00Z", "status": "succeeded"},
+				map[string]any{"run_id": "older", "timestamp": "2026-06-01T09:00:00Z", "status": "failed"},
+			}},
+		},
+	}, assets["orders"])
+
+	asset := data["pipelines"].([]any)[0].(map[string]any)["assets"].([]any)[1].(map[string]any)
+	require.Equal(t, "orders", asset["name"])
+	require.Equal(t, []any{map[string]any{"column": "order_id", "table": "raw.orders"}}, asset["columns"].([]any)[0].(map[string]any)["upstreams"])
+
+	entities := data["glossary"].(map[string]any)["entities"].([]any)
+	require.Equal(t, "Customer", entities[0].(map[string]any)["name"])
+	require.Equal(t, "Order", entities[1].(map[string]any)["name"])
+}
+
 func TestGenerateReturnsHelpfulErrorWhenNoPipelinesAreFound(t *testing.T) {
 	t.Parallel()
 
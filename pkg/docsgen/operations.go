@@ -0,0 +1,241 @@
+package docsgen
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/bruin-data/bruin/pkg/scheduler"
+)
+
+const defaultRunHistory = 20
+
+// OperationalOptions adds what happened to the assets to the docs, next to their definitions.
+type OperationalOptions struct {
+	// RunHistory is the number of most recent runs of each pipeline to read from the run state
+	// that `bruin run` keeps under logs/runs in the repository, 20 by default.
+	RunHistory int
+	// RowCounter counts the rows of the tables the assets produce, the row counts are left out when
+	// it is nil.
+	RowCounter RowCounter
+	// ColumnLineage fills the column-level upstreams of the assets of a pipeline, which are drawn as
+	// column lineage graphs. The column lineage is left out when it is nil.
+	ColumnLineage func(ctx context.Context, p *pipeline.Pipeline) error
+}
+
+type RowCounter interface {
+	CountRows(ctx context.Context, p *pipeline.Pipeline, asset *pipeline.Asset) (int64, error)
+}
+
+type pipelineOperations struct {
+	Runs   []*runSummary               `json:"runs"`
+	Assets map[string]*assetOperations `json:"assets"`
+}
+
+type runSummary struct {
+	RunID     string `json:"run_id"`
+	Timestamp string `json:"timestamp"`
+}
+
+type assetOperations struct {
+	// Runs are the runs that executed the asset, the most recent one first.
+	Runs     []*assetRun     `json:"runs,omitempty"`
+	Checks   []*checkHistory `json:"checks,omitempty"`
+	RowCount *int64          `json:"row_count,omitempty"`
+}
+
+type assetRun struct {
+	RunID      string `json:"run_id"`
+	Timestamp  string `json:"timestamp"`
+	Status     string `json:"status"`
+	StartedAt  string `json:"started_at,omitempty"`
+	DurationMs int64  `json:"duration_ms,omitempty"`
+}
+
+type checkHistory struct {
+	Name     string          `json:"name"`
+	Column   string          `json:"column,omitempty"`
+	Outcomes []*checkOutcome `json:"outcomes"`
+}
+
+type checkOutcome struct {
+	RunID     string `json:"run_id"`
+	Timestamp string `json:"timestamp"`
+	Status    string `json:"status"`
+}
+
+// collectOperations gathers the operational data of the pipelines, keyed by pipeline name. The
+// column lineage is written into the pipelines themselves.
+func collectOperations(ctx context.Context, pipelines []*pipeline.Pipeline, repoRoot string, opts *OperationalOptions) (map[string]*pipelineOperations, error) {
+	history := opts.RunHistory
+	if history <= 0 {
+		history = defaultRunHistory
+	}
+
+	operations := make(map[string]*pipelineOperations, len(pipelines))
+	for _, pl := range pipelines {
+		if opts.ColumnLineage != nil {
+			if err := opts.ColumnLineage(ctx, pl); err != nil {
+				return nil, fmt.Errorf("failed to extract the column lineage of pipeline %s: %w", pl.Name, err)
+			}
+		}
+
+		ops, ok := operations[pl.Name]
+		if !ok {
+			ops = &pipelineOperations{
+				Runs:   make([]*runSummary, 0),
+				Assets: make(map[string]*assetOperations),
+			}
+			if repoRoot != "" {
+				states, err := readRunStates(filepath.Join(repoRoot, "logs", "runs", pl.Name), history)
+				if err != nil {
+					return nil, err
+				}
+				ops.addRuns(states)
+			}
+			operations[pl.Name] = ops
+		}
+
+		if opts.RowCounter == nil {
+			continue
+		}
+		for _, asset := range pl.Assets {
+			if !producesTable(asset) {
+				continue
+			}
+			// row counts are best effort, a table that does not exist yet or a connection that is
+			// not configured locally should not stop the docs from being generated.
+			count, err := opts.RowCounter.CountRows(ctx, pl, asset)
+			if err != nil {
+				continue
+			}
+			ops.asset(asset.Name).RowCount = &count
+		}
+	}
+
+	return operations, nil
+}
+
+func (ops *pipelineOperations) asset(name string) *assetOperations {
+	a, ok := ops.Assets[name]
+	if !ok {
+		a = &assetOperations{}
+		ops.Assets[name] = a
+	}
+	return a
+}
+
+// addRuns adds the runs to the history of the assets, the states are expected to be ordered from
+// the most recent run to the oldest one.
+func (ops *pipelineOperations) addRuns(states []*scheduler.PipelineState) {
+	for _, state := range states {
+		timestamp := state.TimeStamp.UTC().Format(time.RFC3339)
+		ops.Runs = append(ops.Runs, &runSummary{RunID: state.RunID, Timestamp: timestamp})
+
+		for _, assetState := range state.State {
+			// assets that were not part of the run are recorded as skipped or pending.
+			if !isExecutedStatus(assetState.Status) {
+				continue
+			}
+
+			a := ops.asset(assetState.Name)
+			run := &assetRun{
+				RunID:      state.RunID,
+				Timestamp:  timestamp,
+				Status:     assetState.Status,
+				DurationMs: assetState.DurationMs,
+			}
+			if assetState.StartedAt != nil {
+				run.StartedAt = assetState.StartedAt.UTC().Format(time.RFC3339)
+			}
+			a.Runs = append(a.Runs, run)
+
+			for _, check := range assetState.Checks {
+				if !isExecutedStatus(check.Status) {
+					continue
+				}
+				history := a.checkHistory(check.Name, check.Column)
+				history.Outcomes = append(history.Outcomes, &checkOutcome{
+					RunID:     state.RunID,
+					Timestamp: timestamp,
+					Status:    check.Status,
+				})
+			}
+		}
+	}
+}
+
+func (a *assetOperations) checkHistory(name, column string) *checkHistory {
+	for _, check := range a.Checks {
+		if check.Name == name && check.Column == column {
+			return check
+		}
+	}
+	check := &checkHistory{Name: name, Column: column, Outcomes: make([]*checkOutcome, 0)}
+	a.Checks = append(a.Checks, check)
+	return check
+}
+
+func isExecutedStatus(status string) bool {
+	switch scheduler.StatusFromString(status) {
+	case scheduler.Succeeded, scheduler.Failed, scheduler.UpstreamFailed:
+		return true
+	default:
+		return false
+	}
+}
+
+// readRunStates reads the most recent run states in the folder, newest first. A pipeline that has
+// never been run has no folder.
+func readRunStates(dir string, limit int) ([]*scheduler.PipelineState, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read the run state in %s: %w", dir, err)
+	}
+
+	states := make([]*scheduler.PipelineState, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+		content, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the run state %s: %w", entry.Name(), err)
+		}
+		state := &scheduler.PipelineState{}
+		// state files from older or interrupted runs may be unreadable, they are not worth failing
+		// the docs for.
+		if err := json.Unmarshal(content, state); err != nil {
+			continue
+		}
+		if state.RunID == "" {
+			state.RunID = strings.TrimSuffix(entry.Name(), ".json")
+		}
+		states = append(states, state)
+	}
+
+	sort.SliceStable(states, func(i, j int) bool {
+		return states[i].TimeStamp.After(states[j].TimeStamp)
+	})
+	if len(states) > limit {
+		states = states[:limit]
+	}
+	return states, nil
+}
+
+func producesTable(asset *pipeline.Asset) bool {
+	return asset.Materialization.Type == pipeline.MaterializationTypeTable || isSeedAsset(asset)
+}
+
+func isSeedAsset(asset *pipeline.Asset) bool {
+	return strings.HasSuffix(string(asset.Type), ".seed")
+}
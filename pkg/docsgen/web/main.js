@@ -1,4 +1,4 @@
-import { computed, createApp, inject, onMounted, provide, reactive, ref, watch } from "vue/dist/vue.esm-bundler.js";
+import { computed, createApp, inject, nextTick, onMounted, provide, reactive, ref, watch } from "vue/dist/vue.esm-bundler.js";
 import hljs from "highlight.js/lib/core";
 import sql from "highlight.js/lib/languages/sql";
 import python from "highlight.js/lib/languages/python";
@@ -75,6 +75,24 @@ function formatGeneratedAt(value) {
   return parsed.toLocaleString(undefined, { dateStyle: "medium", timeStyle: "short" });
 }
 
+function formatDuration(ms) {
+  if (!ms) return "";
+  if (ms < 1000) return `${ms}ms`;
+  const seconds = ms / 1000;
+  if (seconds < 60) return `${seconds.toFixed(seconds < 10 ? 1 : 0)}s`;
+  const minutes = Math.floor(seconds / 60);
+  if (minutes < 60) return `${minutes}m ${Math.round(seconds % 60)}s`;
+  return `${Math.floor(minutes / 60)}h ${minutes % 60}m`;
+}
+
+function formatCount(value) {
+  return typeof value === "number" ? value.toLocaleString() : "";
+}
+
+function statusLabel(status) {
+  return String(status || "unknown").replaceAll("_", " ");
+}
+
 /* -------- type → colour mapping ---- */
 
 const TYPE_COLORS = {
@@ -150,6 +168,111 @@ const pipelines = (rawData.pipelines || []).map((pipeline, index) => ({
   assets: pipeline.assets || [],
 }));
 
+const glossaryEntities = (rawData.glossary?.entities || []).map((entity) => ({
+  ...entity,
+  __key: `glossary::${entity.name}`,
+  attributes: Object.values(entity.attributes || {}).sort((a, b) => normalize(a.name).localeCompare(normalize(b.name))),
+}));
+
+function operationsOf(pipeline, asset) {
+  return rawData.operations?.[pipeline?.name]?.assets?.[asset?.name] || null;
+}
+
+/* ----------------------------------------------------------- full-text search */
+
+function searchText(...values) {
+  return normalize(values.flat().filter(Boolean).join(" "));
+}
+
+const searchDocuments = (() => {
+  const docs = [];
+  for (const pipeline of pipelines) {
+    for (const asset of pipeline.assets) {
+      const key = assetKey(pipeline, asset);
+      docs.push({
+        kind: "asset",
+        key,
+        title: asset.name,
+        subtitle: `${pipeline.name} · ${typeLabel(asset.type)}`,
+        type: asset.type,
+        name: normalize(asset.name),
+        text: searchText(asset.name, asset.type, asset.description, asset.owner, listValue(asset.tags), asset.definition_file?.path),
+        pipelineKey: pipeline.__key,
+        assetKey: key,
+      });
+      for (const column of asset.columns || []) {
+        docs.push({
+          kind: "column",
+          key: `${key}::${column.name}`,
+          title: `${asset.name}.${column.name}`,
+          subtitle: column.description || column.type || "",
+          type: asset.type,
+          name: normalize(column.name),
+          text: searchText(column.name, column.type, column.description, listValue(column.tags), column.entity_attribute?.entity),
+          pipelineKey: pipeline.__key,
+          assetKey: key,
+          column: column.name,
+        });
+      }
+    }
+  }
+  for (const entity of glossaryEntities) {
+    docs.push({
+      kind: "entity",
+      key: entity.__key,
+      title: entity.name,
+      subtitle: entity.description || "",
+      name: normalize(entity.name),
+      text: searchText(entity.name, entity.description, listValue(entity.domains)),
+      entityKey: entity.__key,
+    });
+    for (const attribute of entity.attributes) {
+      docs.push({
+        kind: "attribute",
+        key: `${entity.__key}::${attribute.name}`,
+        title: `${entity.name}.${attribute.name}`,
+        subtitle: attribute.description || attribute.type || "",
+        name: normalize(attribute.name),
+        text: searchText(attribute.name, attribute.type, attribute.description, listValue(attribute.tags)),
+        entityKey: entity.__key,
+      });
+    }
+  }
+  return docs;
+})();
+
+const SEARCH_KIND_ORDER = { asset: 0, entity: 1, column: 2, attribute: 3 };
+const SEARCH_LIMIT = 60;
+
+// Every term has to appear in the document; name matches rank above matches in
+// descriptions, types and tags.
+function runSearch(query) {
+  const terms = normalize(query).split(/\s+/).filter(Boolean);
+  if (!terms.length) return [];
+  const results = [];
+  for (const doc of searchDocuments) {
+    let score = 0;
+    for (const term of terms) {
+      if (doc.name === term) score += 10;
+      else if (doc.name.startsWith(term)) score += 6;
+      else if (doc.name.includes(term)) score += 4;
+      else if (doc.text.includes(term)) score += 1;
+      else {
+        score = 0;
+        break;
+      }
+    }
+    if (score > 0) results.push({ ...doc, score });
+  }
+  results.sort(
+    (a, b) =>
+      b.score - a.score ||
+      SEARCH_KIND_ORDER[a.kind] - SEARCH_KIND_ORDER[b.kind] ||
+      normalize(a.title).localeCompare(normalize(b.title)),
+  );
+  return results.slice(0, SEARCH_LIMIT);
+}
+
 /* ----------------------------------------------------------- tree builders */
 
 function assetLeaf(pipeline, asset) {
@@ -212,23 +335,14 @@ function sortNodes(nodes) {
   for (const n of nodes) if (n.children) sortNodes(n.children);
 }
 
-function leafMatches(leaf, term) {
-  const a = leaf.asset;
-  return (
-    normalize(a.name).includes(term) ||
-    normalize(a.type).includes(term) ||
-    normalize(a.description).includes(term) ||
-    normalize(a.definition_file?.path).includes(term) ||
-    listValue(a.tags).some((t) => normalize(t).includes(term)) ||
-    (a.columns || []).some((c) => normalize(c.name).includes(term))
-  );
-}
-
-function filterNode(node, term) {
-  if (node.type === "asset") return leafMatches(node, term) ? node : null;
-  const kids = (node.children || []).map((c) => filterNode(c, term)).filter(Boolean);
-  if (!kids.length) return null;
-  return { ...node, children: kids };
+function glossaryRoot() {
+  if (!glossaryEntities.length) return null;
+  return {
+    type: "glossary",
+    name: "Glossary",
+    key: "glossary",
+    children: glossaryEntities.map((entity) => ({ type: "entity", name: entity.name, key: entity.__key, entity })),
+  };
 }
 
 /* ============================ TypeIcon component ============================ */
@@ -278,15 +392,16 @@ const TreeNode = {
   template: `
     <div class="tree-node">
       <button
-        v-if="node.type !== 'asset'"
+        v-if="node.type !== 'asset' && node.type !== 'entity'"
         class="tree-row"
-        :class="{ root: node.type === 'pipeline' }"
+        :class="{ root: node.type === 'pipeline' || node.type === 'glossary' }"
         :style="{ paddingLeft: (8 + depth * 13) + 'px' }"
         type="button"
         @click="ctx.toggle(node.key)"
       >
         <svg class="tree-chevron" :class="{ open: ctx.isExpanded(node.key) }" width="11" height="11" viewBox="0 0 24 24" fill="none" stroke="currentColor" stroke-width="2.6" stroke-linecap="round" stroke-linejoin="round"><path d="m9 6 6 6-6 6"></path></svg>
-        <svg v-if="node.type === 'pipeline'" class="tree-folder-ic" width="15" height="15" viewBox="0 0 24 24" fill="none" stroke="currentColor" stroke-width="2" stroke-linecap="round" stroke-linejoin="round"><path d="M3 7h7l2 2h9v9a2 2 0 0 1-2 2H3z"></path></svg>
+        <svg v-if="node.type === 'glossary'" class="tree-folder-ic" width="15" height="15" viewBox="0 0 24 24" fill="none" stroke="currentColor" stroke-width="2" stroke-linecap="round" stroke-linejoin="round"><path d="M4 19.5A2.5 2.5 0 0 1 6.5 17H20V3H6.5A2.5 2.5 0 0 0 4 5.5z"></path><path d="M4 19.5A2.5 2.5 0 0 0 6.5 22H20v-5"></path></svg>
+        <svg v-else-if="node.type === 'pipeline'" class="tree-folder-ic" width="15" height="15" viewBox="0 0 24 24" fill="none" stroke="currentColor" stroke-width="2" stroke-linecap="round" stroke-linejoin="round"><path d="M3 7h7l2 2h9v9a2 2 0 0 1-2 2H3z"></path></svg>
         <type-icon v-else-if="node.kind === 'type'" :type="node.refType" :size="18"></type-icon>
         <svg v-else class="tree-folder-ic" width="15" height="15" viewBox="0 0 24 24" fill="none" stroke="currentColor" stroke-width="2" stroke-linecap="round" stroke-linejoin="round"><path d="M3 7h7l2 2h9v9a2 2 0 0 1-2 2H3z"></path></svg>
         <span class="tree-label">{{ node.name }}</span>
@@ -300,7 +415,10 @@ const TreeNode = {
         type="button"
         @click="ctx.selectLeaf(node)"
       >
-        <type-icon :type="node.asset.type" :size="18"></type-icon>
+        <type-icon v-if="node.asset" :type="node.asset.type" :size="18"></type-icon>
+        <span v-else class="type-icon entity-icon" style="width:18px;height:18px">
+          <svg width="11" height="11" viewBox="0 0 24 24" fill="none" stroke="currentColor" stroke-width="2.2" stroke-linecap="round" stroke-linejoin="round"><path d="M12 2 2 7l10 5 10-5z"></path><path d="m2 17 10 5 10-5M2 12l10 5 10-5"></path></svg>
+        </span>
         <span class="tree-label">{{ node.name }}</span>
       </button>
 
@@ -321,6 +439,9 @@ const app = createApp({
     const selectedPipelineKey = ref(firstPipeline?.__key || "");
     const selectedAssetKey = ref(firstAsset ? assetKey(firstPipeline, firstAsset) : "");
     const search = ref("");
+    const selectedEntityKey = ref("");
+    const focusedColumn = ref("");
+    const hoveredColumnNode = ref("");
     const treeMode = ref("project");
     const showLineage = ref(false);
     const collapsedNodes = reactive(new Set());
@@ -364,24 +485,48 @@ const app = createApp({
         sortNodes(node.children);
         return node;
       });
-      const term = normalize(search.value);
-      if (!term) return roots;
-      return roots.map((r) => filterNode(r, term)).filter(Boolean);
+      const glossary = glossaryRoot();
+      if (glossary) roots.push(glossary);
+      return roots;
     });
 
+    const searchResults = computed(() => runSearch(search.value));
+
     provide("treeCtx", {
-      isExpanded: (key) => (normalize(search.value) ? true : !collapsedNodes.has(key)),
+      isExpanded: (key) => !collapsedNodes.has(key),
       toggle: (key) => {
         if (collapsedNodes.has(key)) collapsedNodes.delete(key);
         else collapsedNodes.add(key);
       },
-      isActive: (key) => selectedAssetKey.value === key,
+      isActive: (key) => (selectedEntityKey.value ? selectedEntityKey.value === key : selectedAssetKey.value === key),
       selectLeaf: (leaf) => {
+        if (leaf.type === "entity") {
+          selectedEntityKey.value = leaf.key;
+          return;
+        }
+        selectedEntityKey.value = "";
         selectedPipelineKey.value = leaf.pipelineKey;
         selectedAssetKey.value = leaf.key;
       },
     });
 
+    const selectedEntity = computed(() => glossaryEntities.find((e) => e.__key === selectedEntityKey.value) || null);
+
+    function openSearchResult(result) {
+      if (result.entityKey) {
+        selectedEntityKey.value = result.entityKey;
+        return;
+      }
+      selectedEntityKey.value = "";
+      selectedPipelineKey.value = result.pipelineKey;
+      selectedAssetKey.value = result.assetKey;
+      focusedColumn.value = result.column || "";
+      if (result.column) {
+        if (collapsedSections.has("columns")) collapsedSections.delete("columns");
+        nextTick(() => document.getElementById(`column-${result.column}`)?.scrollIntoView({ block: "center" }));
+      }
+    }
+
     /* ---- selection ---- */
 
     const selectedPipeline = computed(
@@ -420,6 +565,28 @@ const app = createApp({
       );
     });
 
+    const operations = computed(() => operationsOf(selectedPipeline.value, selectedAsset.value));
+
+    const lastRun = computed(() => operations.value?.runs?.[0] || null);
+
+    // runHistory is ordered from the oldest run to the most recent one, the way it is drawn.
+    const runHistory = computed(() => (operations.value?.runs || []).slice().reverse());
+
+    function checkHistory(column, name) {
+      const checks = operations.value?.checks || [];
+      const found = checks.find((c) => c.name === name && (c.column || "") === (column || ""));
+      return found ? found.outcomes.slice().reverse() : [];
+    }
+
+    function lastCheckStatus(column, name) {
+      const history = checkHistory(column, name);
+      return history.length ? history[history.length - 1].status : "";
+    }
+
+    function outcomeTitle(outcome) {
+      return `${statusLabel(outcome.status)} · ${formatGeneratedAt(outcome.started_at || outcome.timestamp)}${outcome.duration_ms ? " · " + formatDuration(outcome.duration_ms) : ""}`;
+    }
+
     const detailRows = computed(() => {
       const a = selectedAsset.value;
       if (!a) return [];
@@ -439,6 +606,11 @@ const app = createApp({
       if (owner) rows.push({ k: "Owner", v: owner });
       const schedule = describeSchedule(selectedPipeline.value?.schedule);
       if (schedule) rows.push({ k: "Schedule", v: schedule });
+      if (lastRun.value) {
+        rows.push({ k: "Last run", v: formatGeneratedAt(lastRun.value.started_at || lastRun.value.timestamp), status: lastRun.value.status });
+        if (lastRun.value.duration_ms) rows.push({ k: "Duration", v: formatDuration(lastRun.value.duration_ms) });
+      }
+      if (typeof operations.value?.row_count === "number") rows.push({ k: "Row count", v: formatCount(operations.value.row_count) });
       return rows;
     });
 
@@ -538,6 +710,103 @@ const app = createApp({
       return { nodes, edges, width: Math.max(width, 320), height: Math.max(height, 200), empty: nodes.length === 0 };
     });
 
+    /* ---- column lineage graph ---- */
+
+    // The columns of the selected asset sit in the middle, the columns they are
+    // computed from on the left and the columns computed from them on the right.
+    const columnLineage = computed(() => {
+      const asset = selectedAsset.value;
+      if (!asset) return { empty: true };
+      const assetName = normalize(asset.name);
+
+      const upstream = new Map();
+      const downstream = new Map();
+      const edges = [];
+      for (const column of asset.columns || []) {
+        for (const u of column.upstreams || []) {
+          if (!u?.table || !u?.column) continue;
+          const id = `${u.table}.${u.column}`;
+          if (!upstream.has(id)) upstream.set(id, { id, table: u.table, column: u.column });
+          edges.push({ from: id, to: `${asset.name}.${column.name}` });
+        }
+      }
+      for (const other of pipelineAssets.value) {
+        if (normalize(other.name) === assetName) continue;
+        for (const column of other.columns || []) {
+          for (const u of column.upstreams || []) {
+            if (normalize(u?.table) !== assetName) continue;
+            const source = (asset.columns || []).find((c) => normalize(c.name) === normalize(u.column));
+            if (!source) continue;
+            const id = `${other.name}.${column.name}`;
+            if (!downstream.has(id)) downstream.set(id, { id, table: other.name, column: column.name });
+            edges.push({ from: `${asset.name}.${source.name}`, to: id });
+          }
+        }
+      }
+      if (!edges.length) return { empty: true };
+
+      const NODE_W = 210;
+      const NODE_H = 28;
+      const GAP_X = 90;
+      const GAP_Y = 8;
+      const PAD = 18;
+      const sortById = (a, b) => normalize(a.id).localeCompare(normalize(b.id));
+      const lanes = [
+        [...upstream.values()].sort(sortById),
+        (asset.columns || []).map((c) => ({ id: `${asset.name}.${c.name}`, table: asset.name, column: c.name, own: true })),
+        [...downstream.values()].sort(sortById),
+      ].filter((lane) => lane.length);
+
+      const maxRows = Math.max(...lanes.map((lane) => lane.length));
+      const height = PAD * 2 + maxRows * (NODE_H + GAP_Y) - GAP_Y;
+      const pos = new Map();
+      const nodes = [];
+      lanes.forEach((lane, laneIndex) => {
+        const laneHeight = lane.length * (NODE_H + GAP_Y) - GAP_Y;
+        const offsetY = PAD + (height - PAD * 2 - laneHeight) / 2;
+        lane.forEach((node, rowIndex) => {
+          const x = PAD + laneIndex * (NODE_W + GAP_X);
+          const y = offsetY + rowIndex * (NODE_H + GAP_Y);
+          pos.set(node.id, { x, y });
+          const label = node.own ? node.column : node.id;
+          nodes.push({
+            ...node,
+            x,
+            y,
+            w: NODE_W,
+            h: NODE_H,
+            label: label.length > 28 ? label.slice(0, 27) + "…" : label,
+            linked: !node.own && assetByName.value.has(node.table),
+          });
+        });
+      });
+
+      const hovered = hoveredColumnNode.value;
+      const paths = edges
+        .filter((e) => pos.has(e.from) && pos.has(e.to))
+        .map((e) => {
+          const from = pos.get(e.from);
+          const to = pos.get(e.to);
+          const x1 = from.x + NODE_W;
+          const y1 = from.y + NODE_H / 2;
+          const x2 = to.x;
+          const y2 = to.y + NODE_H / 2;
+          const mid = (x1 + x2) / 2;
+          return {
+            key: `${e.from}->${e.to}`,
+            d: `M ${x1} ${y1} C ${mid} ${y1}, ${mid} ${y2}, ${x2} ${y2}`,
+            hl: hovered && (e.from === hovered || e.to === hovered),
+          };
+        });
+
+      const width = PAD * 2 + lanes.length * (NODE_W + GAP_X) - GAP_X;
+      return { nodes, edges: paths, width, height: Math.max(height, 60), empty: false };
+    });
+
+    function openColumnNode(node) {
+      if (node.linked) openAssetByName(node.table);
+    }
+
     /* ---- section + actions ---- */
 
     function isOpen(id) {
@@ -576,6 +845,8 @@ const app = createApp({
     }
 
     function selectAsset(asset) {
+      selectedEntityKey.value = "";
+      focusedColumn.value = "";
       selectedAssetKey.value = asset.__key || assetKey(selectedPipeline.value, asset);
     }
 
@@ -597,6 +868,20 @@ const app = createApp({
       rawData,
       pipelines,
       search,
+      searchResults,
+      openSearchResult,
+      selectedEntity,
+      focusedColumn,
+      hoveredColumnNode,
+      operations,
+      runHistory,
+      checkHistory,
+      lastCheckStatus,
+      outcomeTitle,
+      statusLabel,
+      formatDuration,
+      columnLineage,
+      openColumnNode,
       treeMode,
       theme,
       showLineage,
@@ -666,7 +951,7 @@ const app = createApp({
                 <circle cx="11" cy="11" r="7"></circle>
                 <path d="m21 21-4.3-4.3"></path>
               </svg>
-              <input v-model="search" type="search" placeholder="Search…" spellcheck="false" />
+              <input v-model="search" type="search" placeholder="Search assets, columns, glossary…" spellcheck="false" />
               <button v-if="search" class="search-clear" type="button" @click="search = ''" aria-label="Clear search">
                 <svg width="13" height="13" viewBox="0 0 24 24" fill="none" stroke="currentColor" stroke-width="2.4" stroke-linecap="round"><path d="M18 6 6 18M6 6l12 12"></path></svg>
               </button>
@@ -684,15 +969,74 @@ const app = createApp({
             </div>
           </div>
 
-          <div class="tree">
+          <div class="tree" v-if="search">
+            <button v-for="result in searchResults" :key="result.key" class="search-result" type="button" @click="openSearchResult(result)">
+              <type-icon v-if="result.type" :type="result.type" :size="18"></type-icon>
+              <span v-else class="type-icon entity-icon" style="width:18px;height:18px">
+                <svg width="11" height="11" viewBox="0 0 24 24" fill="none" stroke="currentColor" stroke-width="2.2" stroke-linecap="round" stroke-linejoin="round"><path d="M12 2 2 7l10 5 10-5z"></path><path d="m2 17 10 5 10-5M2 12l10 5 10-5"></path></svg>
+              </span>
+              <span class="search-result-body">
+                <span class="search-result-title">{{ result.title }}</span>
+                <span class="search-result-sub" v-if="result.subtitle">{{ result.subtitle }}</span>
+              </span>
+              <span class="search-kind">{{ result.kind }}</span>
+            </button>
+            <p v-if="!searchResults.length" class="empty">Nothing matches your search.</p>
+          </div>
+          <div class="tree" v-else>
             <tree-node v-for="root in tree" :key="root.key" :node="root" :depth="0"></tree-node>
-            <p v-if="!tree.length" class="empty">No assets match your search.</p>
           </div>
         </aside>
 
         <!-- ======================= main ======================= -->
         <main class="main">
-          <div class="scroll-area" v-if="selectedAsset">
+          <div class="scroll-area" v-if="selectedEntity">
+            <div class="page">
+              <nav class="crumb">
+                <span>Glossary</span>
+                <svg width="13" height="13" viewBox="0 0 24 24" fill="none" stroke="currentColor" stroke-width="2.2" stroke-linecap="round" stroke-linejoin="round"><path d="m9 6 6 6-6 6"></path></svg>
+                <span>{{ selectedEntity.name }}</span>
+              </nav>
+
+              <div class="asset-title">
+                <h1>{{ selectedEntity.name }}</h1>
+              </div>
+
+              <section class="dsection" v-if="selectedEntity.description || listValue(selectedEntity.domains).length">
+                <div class="dsection-body">
+                  <p class="description" v-if="selectedEntity.description">{{ selectedEntity.description }}</p>
+                  <div class="tag-row" v-if="listValue(selectedEntity.domains).length" style="margin-top:10px">
+                    <span v-for="domain in selectedEntity.domains" :key="domain" class="tag">{{ domain }}</span>
+                  </div>
+                </div>
+              </section>
+
+              <section class="dsection" v-if="selectedEntity.attributes.length">
+                <button class="dsection-head" type="button" @click="toggleSection('attributes')">
+                  <svg class="chev" :class="{ collapsed: !isOpen('attributes') }" width="13" height="13" viewBox="0 0 24 24" fill="none" stroke="currentColor" stroke-width="2.4" stroke-linecap="round" stroke-linejoin="round"><path d="m6 9 6 6 6-6"></path></svg>
+                  Attributes <span class="dcount">{{ selectedEntity.attributes.length }}</span>
+                </button>
+                <div class="dsection-body" v-show="isOpen('attributes')">
+                  <div class="table-wrap">
+                    <table>
+                      <thead>
+                        <tr><th>Attribute</th><th>Type</th><th>Description</th></tr>
+                      </thead>
+                      <tbody>
+                        <tr v-for="attribute in selectedEntity.attributes" :key="attribute.name">
+                          <td><span class="col-name">{{ attribute.name }}</span></td>
+                          <td><span v-if="attribute.type" class="col-type">{{ attribute.type }}</span></td>
+                          <td class="col-desc">{{ attribute.description || "—" }}</td>
+                        </tr>
+                      </tbody>
+                    </table>
+                  </div>
+                </div>
+              </section>
+            </div>
+          </div>
+
+          <div class="scroll-area" v-else-if="selectedAsset">
             <div class="page">
               <nav class="crumb">
                 <span>{{ selectedPipeline?.name }}</span>
@@ -718,7 +1062,10 @@ const app = createApp({
                   <div class="detail-grid">
                     <div class="drow" v-for="row in detailRows" :key="row.k">
                       <div class="dk">{{ row.k }}</div>
-                      <div class="dv" :class="{ mono: row.mono }">{{ row.v }}</div>
+                      <div class="dv" :class="{ mono: row.mono }">
+                        <span v-if="row.status" class="status-badge" :class="row.status">{{ statusLabel(row.status) }}</span>
+                        {{ row.v }}
+                      </div>
                     </div>
                     <div class="drow" v-if="listValue(selectedAsset.tags).length">
                       <div class="dk">Tags</div>
@@ -756,7 +1103,7 @@ const app = createApp({
                         <tr><th>Column</th><th>Type</th><th>Description</th><th>Checks</th></tr>
                       </thead>
                       <tbody>
-                        <tr v-for="col in selectedAsset.columns" :key="col.name">
+                        <tr v-for="col in selectedAsset.columns" :key="col.name" :id="'column-' + col.name" :class="{ focused: focusedColumn === col.name }">
                           <td>
                             <span class="col-name">
                               {{ col.name }}
@@ -771,7 +1118,7 @@ const app = createApp({
                           <td class="col-desc">{{ col.description || "—" }}</td>
                           <td>
                             <div class="checks-cell" v-if="(col.checks || []).length">
-                              <span v-for="check in col.checks" :key="check.name" class="check-badge" :class="{ nonblocking: !isDefaultTrue(check.blocking) }">
+                              <span v-for="check in col.checks" :key="check.name" class="check-badge" :class="[{ nonblocking: !isDefaultTrue(check.blocking) }, lastCheckStatus(col.name, check.name)]">
                                 <svg width="11" height="11" viewBox="0 0 24 24" fill="none" stroke="currentColor" stroke-width="3" stroke-linecap="round" stroke-linejoin="round"><path d="M20 6 9 17l-5-5"></path></svg>
                                 {{ check.name }}
                               </span>
@@ -797,6 +1144,9 @@ const app = createApp({
                       <div class="check-card-head">
                         <span class="check-badge"><svg width="11" height="11" viewBox="0 0 24 24" fill="none" stroke="currentColor" stroke-width="3" stroke-linecap="round" stroke-linejoin="round"><path d="M20 6 9 17l-5-5"></path></svg>check</span>
                         <strong>{{ check.name || "custom check" }}</strong>
+                        <span class="history-strip" v-if="checkHistory('', check.name).length">
+                          <span v-for="outcome in checkHistory('', check.name)" :key="outcome.run_id" class="history-dot" :class="outcome.status" :title="outcomeTitle(outcome)"></span>
+                        </span>
                       </div>
                       <p v-if="check.description" class="muted" style="margin-bottom:9px">{{ check.description }}</p>
                       <pre class="code hljs"><code v-html="highlightSQL(check.query)"></code></pre>
@@ -805,6 +1155,82 @@ const app = createApp({
                 </div>
               </section>
 
+              <!-- Runs -->
+              <section class="dsection" v-if="runHistory.length">
+                <button class="dsection-head" type="button" @click="toggleSection('runs')">
+                  <svg class="chev" :class="{ collapsed: !isOpen('runs') }" width="13" height="13" viewBox="0 0 24 24" fill="none" stroke="currentColor" stroke-width="2.4" stroke-linecap="round" stroke-linejoin="round"><path d="m6 9 6 6 6-6"></path></svg>
+                  Runs <span class="dcount">{{ runHistory.length }}</span>
+                </button>
+                <div class="dsection-body" v-show="isOpen('runs')">
+                  <div class="history-strip large">
+                    <span v-for="run in runHistory" :key="run.run_id" class="history-dot" :class="run.status" :title="outcomeTitle(run)"></span>
+                  </div>
+                  <div class="table-wrap">
+                    <table>
+                      <thead>
+                        <tr><th>Run</th><th>Status</th><th>Started</th><th>Duration</th></tr>
+                      </thead>
+                      <tbody>
+                        <tr v-for="run in operations.runs" :key="run.run_id">
+                          <td class="mono">{{ run.run_id }}</td>
+                          <td><span class="status-badge" :class="run.status">{{ statusLabel(run.status) }}</span></td>
+                          <td>{{ formatGeneratedAt(run.started_at || run.timestamp) }}</td>
+                          <td>{{ formatDuration(run.duration_ms) || "—" }}</td>
+                        </tr>
+                      </tbody>
+                    </table>
+                  </div>
+                </div>
+              </section>
+
+              <!-- Check history -->
+              <section class="dsection" v-if="(operations?.checks || []).length">
+                <button class="dsection-head" type="button" @click="toggleSection('check-history')">
+                  <svg class="chev" :class="{ collapsed: !isOpen('check-history') }" width="13" height="13" viewBox="0 0 24 24" fill="none" stroke="currentColor" stroke-width="2.4" stroke-linecap="round" stroke-linejoin="round"><path d="m6 9 6 6 6-6"></path></svg>
+                  Check history <span class="dcount">{{ operations.checks.length }}</span>
+                </button>
+                <div class="dsection-body" v-show="isOpen('check-history')">
+                  <div class="check-list">
+                    <div class="check-history-row" v-for="check in operations.checks" :key="(check.column || '') + '::' + check.name">
+                      <span class="check-history-name">
+                        <span v-if="check.column" class="mono">{{ check.column }}</span>
+                        <span v-if="check.column" class="muted"> · </span>
+                        {{ check.name }}
+                      </span>
+                      <span class="history-strip">
+                        <span v-for="outcome in checkHistory(check.column, check.name)" :key="outcome.run_id" class="history-dot" :class="outcome.status" :title="outcomeTitle(outcome)"></span>
+                      </span>
+                    </div>
+                  </div>
+                </div>
+              </section>
+
+              <!-- Column lineage -->
+              <section class="dsection" v-if="!columnLineage.empty">
+                <button class="dsection-head" type="button" @click="toggleSection('column-lineage')">
+                  <svg class="chev" :class="{ collapsed: !isOpen('column-lineage') }" width="13" height="13" viewBox="0 0 24 24" fill="none" stroke="currentColor" stroke-width="2.4" stroke-linecap="round" stroke-linejoin="round"><path d="m6 9 6 6 6-6"></path></svg>
+                  Column lineage
+                </button>
+                <div class="dsection-body column-lineage" v-show="isOpen('column-lineage')">
+                  <svg class="lineage-svg" :width="columnLineage.width" :height="columnLineage.height" :viewBox="'0 0 ' + columnLineage.width + ' ' + columnLineage.height">
+                    <path v-for="edge in columnLineage.edges" :key="edge.key" class="edge" :class="{ hl: edge.hl }" :d="edge.d"></path>
+                    <g
+                      v-for="node in columnLineage.nodes"
+                      :key="node.id"
+                      class="cnode"
+                      :class="{ own: node.own, linked: node.linked, active: hoveredColumnNode === node.id }"
+                      @mouseenter="hoveredColumnNode = node.id"
+                      @mouseleave="hoveredColumnNode = ''"
+                      @click="openColumnNode(node)"
+                    >
+                      <title>{{ node.id }}</title>
+                      <rect class="gnode-box" :x="node.x" :y="node.y" :width="node.w" :height="node.h" rx="4"></rect>
+                      <text class="cnode-label" :x="node.x + 10" :y="node.y + 18">{{ node.label }}</text>
+                    </g>
+                  </svg>
+                </div>
+              </section>
+
               <!-- Referenced By -->
               <section class="dsection">
                 <button class="dsection-head" type="button" @click="toggleSection('referenced')">
@@ -895,7 +1321,7 @@ const app = createApp({
           </div>
 
           <!-- floating lineage graph button -->
-          <button v-if="selectedAsset && !showLineage" class="lineage-fab" type="button" @click="showLineage = true">
+          <button v-if="selectedAsset && !selectedEntity && !showLineage" class="lineage-fab" type="button" @click="showLineage = true">
             <svg width="17" height="17" viewBox="0 0 24 24" fill="none" stroke="currentColor" stroke-width="2" stroke-linecap="round" stroke-linejoin="round"><circle cx="5" cy="6" r="2"></circle><circle cx="5" cy="18" r="2"></circle><circle cx="19" cy="12" r="2"></circle><path d="M7 6.5 17 11M7 17.5 17 13"></path></svg>
             Lineage graph
           </button>
@@ -0,0 +1,380 @@
+//go:build !bruin_no_duckdb
+
+package duck
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/apache/arrow-adbc/go/adbc"
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/ipc"
+	"github.com/bruin-data/bruin/pkg/executor"
+	"github.com/bruin-data/bruin/pkg/helpers"
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/pkg/errors"
+)
+
+// LoadArrowFile loads the Arrow IPC file produced by a Python asset into the asset table
+// without going through ingestr. The record batches are bulk-ingested through ADBC into a
+// temporary table, and the materialization strategy of the asset is applied from there
+// with the same queries the SQL assets use. Column names are passed through normalize so
+// that the tables match the ones ingestr would create.
+//
+// It returns false without touching the database when the client cannot write to it.
+func (c *Client) LoadArrowFile(ctx context.Context, asset *pipeline.Asset, path string, normalize func(string) string) (bool, error) {
+	conn, ok := c.connection.(*EphemeralConnection)
+	if !ok || c.readOnly {
+		return false, nil
+	}
+
+	records, err := openArrowFile(path)
+	if err != nil {
+		return false, err
+	}
+	defer records.Release()
+
+	printer, _ := ctx.Value(executor.KeyPrinter).(io.Writer)
+	records.progress = printer
+
+	if err := c.CreateSchemaIfNotExist(ctx, asset); err != nil {
+		return false, err
+	}
+
+	c.lockIfNeeded()
+	defer c.unlockIfNeeded()
+
+	err = conn.withADBC(ctx, func(adbcConn adbc.Connection) error {
+		return c.loadArrowRecords(ctx, adbcConn, asset, records, normalize)
+	})
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to load the data into '%s'", asset.Name)
+	}
+
+	if printer != nil {
+		_, _ = fmt.Fprintf(printer, "Loaded %d rows into '%s'.\n", records.rows, asset.Name)
+	}
+
+	return true, nil
+}
+
+func (c *Client) loadArrowRecords(ctx context.Context, conn adbc.Connection, asset *pipeline.Asset, records *arrowFileRecords, normalize func(string) string) error {
+	stagingTable := "__bruin_tmp_" + helpers.PrefixGenerator()
+	if err := ingestArrowRecords(ctx, conn, stagingTable, records); err != nil {
+		return errors.Wrap(err, "failed to ingest the Arrow file")
+	}
+	defer func() {
+		_ = execADBCStatement(context.WithoutCancel(ctx), conn, "DROP TABLE IF EXISTS "+stagingTable)
+	}()
+
+	existsQuery, err := c.BuildTableExistsQuery(asset.Name)
+	if err != nil {
+		return err
+	}
+	existing, err := queryADBC(ctx, conn, existsQuery)
+	if err != nil {
+		return errors.Wrap(err, "failed to check if the table exists")
+	}
+	exists := len(existing) > 0 && len(existing[0]) > 0 && fmt.Sprint(existing[0][0]) != "0"
+
+	stagingColumns, err := describeADBCTable(ctx, conn, stagingTable)
+	if err != nil {
+		return err
+	}
+
+	target := arrowTargetAsset(asset, normalize)
+	if !exists {
+		target.Materialization.Strategy = pipeline.MaterializationStrategyCreateReplace
+	}
+
+	fullRefresh, _ := ctx.Value(pipeline.RunConfigFullRefresh).(bool)
+	var targetColumns []string
+	if !replacesTable(target, fullRefresh) {
+		targetColumns, err = alignTargetTable(ctx, conn, target.Name, stagingColumns, normalize)
+		if err != nil {
+			return err
+		}
+	}
+
+	query := buildArrowSelectQuery(stagingTable, stagingColumns, targetColumns, normalize)
+	rendered, err := NewMaterializer(fullRefresh).Render(target, query)
+	if err != nil {
+		return err
+	}
+
+	return execADBCStatement(ctx, conn, rendered)
+}
+
+// arrowTargetAsset returns a copy of the asset with the column names and the incremental
+// key normalized, so that the materialization queries refer to the loaded columns.
+func arrowTargetAsset(asset *pipeline.Asset, normalize func(string) string) *pipeline.Asset {
+	target := *asset
+	target.Columns = make([]pipeline.Column, len(asset.Columns))
+	for i, col := range asset.Columns {
+		col.Name = normalize(col.Name)
+		target.Columns[i] = col
+	}
+	if target.Materialization.IncrementalKey != "" {
+		target.Materialization.IncrementalKey = normalize(target.Materialization.IncrementalKey)
+	}
+
+	return &target
+}
+
+func replacesTable(asset *pipeline.Asset, fullRefresh bool) bool {
+	switch asset.Materialization.Strategy {
+	case pipeline.MaterializationStrategyNone, pipeline.MaterializationStrategyCreateReplace:
+		return true
+	}
+
+	return fullRefresh && (asset.RefreshRestricted == nil || !*asset.RefreshRestricted)
+}
+
+type arrowColumn struct {
+	name       string
+	columnType string
+}
+
+// alignTargetTable adds the columns the existing table does not have yet, the same way
+// ingestr evolves the schema, and returns the columns of the table in their order.
+func alignTargetTable(ctx context.Context, conn adbc.Connection, tableName string, stagingColumns []arrowColumn, normalize func(string) string) ([]string, error) {
+	existing, err := describeADBCTable(ctx, conn, tableName)
+	if err != nil {
+		return nil, err
+	}
+
+	columns := make([]string, 0, len(existing)+len(stagingColumns))
+	seen := make(map[string]bool, len(existing))
+	for _, col := range existing {
+		columns = append(columns, col.name)
+		seen[strings.ToLower(col.name)] = true
+	}
+
+	for _, col := range stagingColumns {
+		name := normalize(col.name)
+		if seen[strings.ToLower(name)] {
+			continue
+		}
+
+		alter := fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", tableName, quoteArrowColumn(name), col.columnType)
+		if err := execADBCStatement(ctx, conn, alter); err != nil {
+			return nil, errors.Wrapf(err, "failed to add the column '%s' to '%s'", name, tableName)
+		}
+		columns = append(columns, name)
+		seen[strings.ToLower(name)] = true
+	}
+
+	return columns, nil
+}
+
+// buildArrowSelectQuery selects the staging table with the normalized column names. When
+// targetColumns is set, the columns follow the order of the existing table and the ones
+// missing from the data are NULL, since the append and delete+insert queries insert by
+// position.
+func buildArrowSelectQuery(stagingTable string, stagingColumns []arrowColumn, targetColumns []string, normalize func(string) string) string {
+	byName := make(map[string]string, len(stagingColumns))
+	expressions := make([]string, 0, len(stagingColumns))
+	for _, col := range stagingColumns {
+		name := normalize(col.name)
+		expression := quoteArrowColumn(col.name) + " AS " + quoteArrowColumn(name)
+		byName[strings.ToLower(name)] = expression
+		expressions = append(expressions, expression)
+	}
+
+	if len(targetColumns) > 0 {
+		expressions = expressions[:0]
+		for _, name := range targetColumns {
+			expression, ok := byName[strings.ToLower(name)]
+			if !ok {
+				expression = "NULL AS " + quoteArrowColumn(name)
+			}
+			expressions = append(expressions, expression)
+		}
+	}
+
+	return fmt.Sprintf("SELECT %s FROM %s", strings.Join(expressions, ", "), stagingTable)
+}
+
+func quoteArrowColumn(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+// ingestArrowRecords bulk-ingests the records into a new temporary table.
+func ingestArrowRecords(ctx context.Context, conn adbc.Connection, tableName string, records *arrowFileRecords) error {
+	stmt, err := conn.NewStatement()
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	options := [][2]string{
+		{adbc.OptionValueIngestTemporary, adbc.OptionValueEnabled},
+		{adbc.OptionKeyIngestTargetTable, tableName},
+		{adbc.OptionKeyIngestMode, adbc.OptionValueIngestModeCreate},
+	}
+	for _, option := range options {
+		if err := stmt.SetOption(option[0], option[1]); err != nil {
+			return err
+		}
+	}
+
+	if err := stmt.BindStream(ctx, records); err != nil {
+		return err
+	}
+
+	_, err = stmt.ExecuteUpdate(ctx)
+	return err
+}
+
+func describeADBCTable(ctx context.Context, conn adbc.Connection, tableName string) ([]arrowColumn, error) {
+	rows, err := queryADBC(ctx, conn, fmt.Sprintf("SELECT column_name, column_type FROM (DESCRIBE %s)", tableName))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to describe the table '%s'", tableName)
+	}
+
+	columns := make([]arrowColumn, len(rows))
+	for i, row := range rows {
+		columns[i] = arrowColumn{name: fmt.Sprint(row[0]), columnType: fmt.Sprint(row[1])}
+	}
+
+	return columns, nil
+}
+
+func queryADBC(ctx context.Context, conn adbc.Connection, queryStr string) ([][]any, error) {
+	stmt, err := conn.NewStatement()
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	if err := stmt.SetSqlQuery(queryStr); err != nil {
+		return nil, err
+	}
+
+	reader, _, err := stmt.ExecuteQuery(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if reader == nil {
+		return nil, nil
+	}
+	defer reader.Release()
+
+	rows, err := bufferArrowReader(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	return rows.data, nil
+}
+
+// withADBC runs fn on a single ADBC connection, for the operations that need to share a
+// session, e.g. the temporary tables.
+func (e *EphemeralConnection) withADBC(ctx context.Context, fn func(conn adbc.Connection) error) error {
+	adb, conn, err := e.openADBC(ctx)
+	if err != nil {
+		return err
+	}
+	defer adb.Close()
+	defer conn.Close()
+
+	return fn(conn)
+}
+
+// arrowProgressInterval is how often the number of rows loaded so far is printed.
+const arrowProgressInterval = 10 * time.Second
+
+// arrowFileRecords streams the record batches of an Arrow IPC file, it implements
+// array.RecordReader so that it can be bound to an ADBC statement.
+type arrowFileRecords struct {
+	refs   atomic.Int64
+	file   *os.File
+	reader *ipc.FileReader
+	record arrow.RecordBatch
+	rows   int64
+	err    error
+
+	// progress receives the number of rows read so far, every arrowProgressInterval.
+	progress   io.Writer
+	lastReport time.Time
+}
+
+func openArrowFile(path string) (*arrowFileRecords, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to open the Arrow file %s", path)
+	}
+
+	reader, err := ipc.NewFileReader(file)
+	if err != nil {
+		file.Close()
+		return nil, errors.Wrapf(err, "failed to read the Arrow file %s", path)
+	}
+
+	records := &arrowFileRecords{file: file, reader: reader, lastReport: time.Now()}
+	records.refs.Store(1)
+
+	return records, nil
+}
+
+func (r *arrowFileRecords) Retain() {
+	r.refs.Add(1)
+}
+
+func (r *arrowFileRecords) Release() {
+	if r.refs.Add(-1) != 0 {
+		return
+	}
+
+	r.record = nil
+	_ = r.reader.Close()
+	_ = r.file.Close()
+}
+
+func (r *arrowFileRecords) Schema() *arrow.Schema {
+	return r.reader.Schema()
+}
+
+func (r *arrowFileRecords) Next() bool {
+	if r.err != nil {
+		return false
+	}
+
+	record, err := r.reader.Read()
+	if errors.Is(err, io.EOF) {
+		r.record = nil
+		return false
+	}
+	if err != nil {
+		r.err = err
+		r.record = nil
+		return false
+	}
+
+	r.record = record
+	r.rows += record.NumRows()
+	if r.progress != nil && time.Since(r.lastReport) >= arrowProgressInterval {
+		_, _ = fmt.Fprintf(r.progress, "Loaded %d rows so far...\n", r.rows)
+		r.lastReport = time.Now()
+	}
+	return true
+}
+
+//nolint:ireturn
+func (r *arrowFileRecords) RecordBatch() arrow.RecordBatch {
+	return r.record
+}
+
+//nolint:ireturn
+func (r *arrowFileRecords) Record() arrow.RecordBatch {
+	return r.record
+}
+
+func (r *arrowFileRecords) Err() error {
+	return r.err
+}
@@ -0,0 +1,351 @@
+//go:build !bruin_no_duckdb
+
+package duck
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/ipc"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/bruin-data/bruin/pkg/query"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeArrowFile(t *testing.T, batches ...[]int64) string {
+	t.Helper()
+
+	schema := arrow.NewSchema([]arrow.Field{{Name: "id", Type: arrow.PrimitiveTypes.Int64}}, nil)
+	path := filepath.Join(t.TempDir(), "data.arrow")
+	file, err := os.Create(path)
+	require.NoError(t, err)
+	defer file.Close()
+
+	writer, err := ipc.NewFileWriter(file, ipc.WithSchema(schema))
+	require.NoError(t, err)
+
+	for _, values := range batches {
+		builder := array.NewRecordBuilder(memory.DefaultAllocator, schema)
+		builder.Field(0).(*array.Int64Builder).AppendValues(values, nil)
+		record := builder.NewRecordBatch()
+		require.NoError(t, writer.Write(record))
+		record.Release()
+		builder.Release()
+	}
+	require.NoError(t, writer.Close())
+
+	return path
+}
+
+func TestOpenArrowFile(t *testing.T) {
+	t.Parallel()
+
+	records, err := openArrowFile(writeArrowFile(t, []int64{1, 2}, []int64{3}))
+	require.NoError(t, err)
+	defer records.Release()
+
+	assert.Equal(t, "id", records.Schema().Field(0).Name)
+
+	var got []int64
+	for records.Next() {
+		got = append(got, records.RecordBatch().Column(0).(*array.Int64).Int64Values()...)
+	}
+	require.NoError(t, records.Err())
+	assert.Equal(t, []int64{1, 2, 3}, got)
+	assert.Equal(t, int64(3), records.rows)
+
+	_, err = openArrowFile(filepath.Join(t.TempDir(), "missing.arrow"))
+	require.ErrorContains(t, err, "failed to open the Arrow file")
+}
+
+func TestArrowFileRecords_ReportsProgress(t *testing.T) {
+	t.Parallel()
+
+	records, err := openArrowFile(writeArrowFile(t, []int64{1, 2}, []int64{3}))
+	require.NoError(t, err)
+	defer records.Release()
+
+	var progress bytes.Buffer
+	records.progress = &progress
+	records.lastReport = time.Time{}
+
+	require.True(t, records.Next())
+	assert.Equal(t, "Loaded 2 rows so far...\n", progress.String())
+
+	// the next batch comes before the interval has passed again
+	require.True(t, records.Next())
+	assert.Equal(t, "Loaded 2 rows so far...\n", progress.String())
+	require.False(t, records.Next())
+	require.NoError(t, records.Err())
+}
+
+func TestBuildArrowSelectQuery(t *testing.T) {
+	t.Parallel()
+
+	staging := []arrowColumn{
+		{name: "ID", columnType: "BIGINT"},
+		{name: "Full Name", columnType: "VARCHAR"},
+	}
+	normalize := func(name string) string {
+		return strings.ReplaceAll(strings.ToLower(name), " ", "_")
+	}
+
+	tests := []struct {
+		name          string
+		targetColumns []string
+		want          string
+	}{
+		{
+			name: "new tables take the columns of the data",
+			want: `SELECT "ID" AS "id", "Full Name" AS "full_name" FROM __bruin_tmp_abcefghi`,
+		},
+		{
+			name:          "existing tables keep their column order",
+			targetColumns: []string{"full_name", "created_at", "id"},
+			want:          `SELECT "Full Name" AS "full_name", NULL AS "created_at", "ID" AS "id" FROM __bruin_tmp_abcefghi`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, buildArrowSelectQuery("__bruin_tmp_abcefghi", staging, tt.targetColumns, normalize))
+		})
+	}
+}
+
+func TestArrowTargetAsset(t *testing.T) {
+	t.Parallel()
+
+	asset := &pipeline.Asset{
+		Name: "raw.users",
+		Materialization: pipeline.Materialization{
+			Type:           pipeline.MaterializationTypeTable,
+			Strategy:       pipeline.MaterializationStrategyDeleteInsert,
+			IncrementalKey: "Updated At",
+		},
+		Columns: []pipeline.Column{{Name: "User ID", PrimaryKey: true}},
+	}
+
+	target := arrowTargetAsset(asset, func(name string) string {
+		return strings.ReplaceAll(strings.ToLower(name), " ", "_")
+	})
+
+	assert.Equal(t, "updated_at", target.Materialization.IncrementalKey)
+	assert.Equal(t, "user_id", target.Columns[0].Name)
+	assert.Equal(t, "User ID", asset.Columns[0].Name)
+	assert.Equal(t, "Updated At", asset.Materialization.IncrementalKey)
+}
+
+func TestReplacesTable(t *testing.T) {
+	t.Parallel()
+
+	restricted := true
+	assert.True(t, replacesTable(&pipeline.Asset{Materialization: pipeline.Materialization{Strategy: pipeline.MaterializationStrategyCreateReplace}}, false))
+	assert.False(t, replacesTable(&pipeline.Asset{Materialization: pipeline.Materialization{Strategy: pipeline.MaterializationStrategyMerge}}, false))
+	assert.True(t, replacesTable(&pipeline.Asset{Materialization: pipeline.Materialization{Strategy: pipeline.MaterializationStrategyAppend}}, true))
+	assert.False(t, replacesTable(&pipeline.Asset{
+		Materialization:   pipeline.Materialization{Strategy: pipeline.MaterializationStrategyAppend},
+		RefreshRestricted: &restricted,
+	}, true))
+}
+
+type arrowUser struct {
+	id    int64
+	name  string
+	score *float64
+}
+
+// writeArrowUsers writes the users to an Arrow IPC file with the column names a pandas
+// dataframe would typically have, the "Score" column is only written when withScore is set.
+func writeArrowUsers(t *testing.T, withScore bool, users ...arrowUser) string {
+	t.Helper()
+
+	fields := []arrow.Field{
+		{Name: "ID", Type: arrow.PrimitiveTypes.Int64},
+		{Name: "Full Name", Type: arrow.BinaryTypes.String},
+	}
+	if withScore {
+		fields = append(fields, arrow.Field{Name: "Score", Type: arrow.PrimitiveTypes.Float64, Nullable: true})
+	}
+	schema := arrow.NewSchema(fields, nil)
+
+	path := filepath.Join(t.TempDir(), "users.arrow")
+	file, err := os.Create(path)
+	require.NoError(t, err)
+	defer file.Close()
+
+	writer, err := ipc.NewFileWriter(file, ipc.WithSchema(schema))
+	require.NoError(t, err)
+
+	builder := array.NewRecordBuilder(memory.DefaultAllocator, schema)
+	defer builder.Release()
+	for _, user := range users {
+		builder.Field(0).(*array.Int64Builder).Append(user.id)
+		builder.Field(1).(*array.StringBuilder).Append(user.name)
+		if !withScore {
+			continue
+		}
+		if user.score == nil {
+			builder.Field(2).AppendNull()
+		} else {
+			builder.Field(2).(*array.Float64Builder).Append(*user.score)
+		}
+	}
+	record := builder.NewRecordBatch()
+	require.NoError(t, writer.Write(record))
+	record.Release()
+	require.NoError(t, writer.Close())
+
+	return path
+}
+
+// newArrowTestClient opens a client on a new DuckDB file, it skips the test when the DuckDB
+// driver is not available.
+func newArrowTestClient(t *testing.T) *Client {
+	t.Helper()
+
+	if runtime.GOOS == "windows" {
+		t.Skip("skipping on Windows due to DuckDB file locking")
+	}
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+	if err := EnsureADBCDriverInstalled(t.Context()); err != nil {
+		t.Skipf("skipping test: ADBC DuckDB driver not available: %v", err)
+	}
+
+	client, err := NewClient(Config{Path: filepath.Join(t.TempDir(), "test.db")})
+	require.NoError(t, err)
+
+	return client
+}
+
+func loadArrowUsers(ctx context.Context, t *testing.T, client *Client, asset *pipeline.Asset, withScore bool, users ...arrowUser) {
+	t.Helper()
+
+	normalize := func(name string) string {
+		return strings.ReplaceAll(strings.ToLower(name), " ", "_")
+	}
+
+	loaded, err := client.LoadArrowFile(ctx, asset, writeArrowUsers(t, withScore, users...), normalize)
+	require.NoError(t, err)
+	require.True(t, loaded)
+}
+
+func selectArrowUsers(ctx context.Context, t *testing.T, client *Client, columns string) []string {
+	t.Helper()
+
+	rows, err := client.Select(ctx, &query.Query{
+		Query: fmt.Sprintf("SELECT concat_ws(':', %s) FROM raw.users ORDER BY ALL", columns),
+	})
+	require.NoError(t, err)
+
+	got := make([]string, len(rows))
+	for i, row := range rows {
+		got[i] = fmt.Sprint(row[0])
+	}
+
+	return got
+}
+
+func TestClient_LoadArrowFile(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name            string
+		materialization pipeline.Materialization
+		columns         []pipeline.Column
+		want            []string
+	}{
+		{
+			name: "append keeps the existing rows",
+			materialization: pipeline.Materialization{
+				Type:     pipeline.MaterializationTypeTable,
+				Strategy: pipeline.MaterializationStrategyAppend,
+			},
+			want: []string{"1:alice", "2:bob", "2:bobby", "3:carol"},
+		},
+		{
+			name: "merge updates the rows with the same primary key",
+			materialization: pipeline.Materialization{
+				Type:     pipeline.MaterializationTypeTable,
+				Strategy: pipeline.MaterializationStrategyMerge,
+			},
+			columns: []pipeline.Column{
+				{Name: "ID", PrimaryKey: true},
+				{Name: "Full Name", UpdateOnMerge: true},
+			},
+			want: []string{"1:alice", "2:bobby", "3:carol"},
+		},
+		{
+			name: "delete+insert replaces the rows with the same incremental key",
+			materialization: pipeline.Materialization{
+				Type:           pipeline.MaterializationTypeTable,
+				Strategy:       pipeline.MaterializationStrategyDeleteInsert,
+				IncrementalKey: "ID",
+			},
+			want: []string{"1:alice", "2:bobby", "3:carol"},
+		},
+		{
+			name: "create+replace keeps only the last load",
+			materialization: pipeline.Materialization{
+				Type:     pipeline.MaterializationTypeTable,
+				Strategy: pipeline.MaterializationStrategyCreateReplace,
+			},
+			want: []string{"2:bobby", "3:carol"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := newArrowTestClient(t)
+			asset := &pipeline.Asset{
+				Name:            "raw.users",
+				Type:            pipeline.AssetTypePython,
+				Materialization: tt.materialization,
+				Columns:         tt.columns,
+			}
+
+			// the first load creates the table, the second one applies the strategy
+			loadArrowUsers(t.Context(), t, client, asset, false, arrowUser{id: 1, name: "alice"}, arrowUser{id: 2, name: "bob"})
+			loadArrowUsers(t.Context(), t, client, asset, false, arrowUser{id: 2, name: "bobby"}, arrowUser{id: 3, name: "carol"})
+
+			assert.Equal(t, tt.want, selectArrowUsers(t.Context(), t, client, "id, full_name"))
+		})
+	}
+}
+
+func TestClient_LoadArrowFile_AddsNewColumns(t *testing.T) {
+	t.Parallel()
+
+	client := newArrowTestClient(t)
+	asset := &pipeline.Asset{
+		Name: "raw.users",
+		Type: pipeline.AssetTypePython,
+		Materialization: pipeline.Materialization{
+			Type:     pipeline.MaterializationTypeTable,
+			Strategy: pipeline.MaterializationStrategyAppend,
+		},
+	}
+
+	score := 4.5
+	loadArrowUsers(t.Context(), t, client, asset, false, arrowUser{id: 1, name: "alice"})
+	loadArrowUsers(t.Context(), t, client, asset, true, arrowUser{id: 2, name: "bob", score: &score})
+
+	assert.Equal(t, []string{"1:alice:none", "2:bob:4.5"}, selectArrowUsers(t.Context(), t, client, "id, full_name, coalesce(CAST(score AS VARCHAR), 'none')"))
+}
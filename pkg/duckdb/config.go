@@ -22,6 +22,12 @@ func (c Config) GetIngestrURI() string {
 	return "duckdb:///" + c.Path
 }
 
+// connectionSetup is implemented by configurations that prepare every new connection with their own
+// statements, e.g. the Iceberg connections that attach their catalog.
+type connectionSetup interface {
+	SetupStatements() ([]string, error)
+}
+
 func (c Config) HasLakehouse() bool {
 	return c.Lakehouse != nil
 }
@@ -897,6 +897,35 @@ ORDER BY ordinal_position;
 	return columns, nil
 }
 
+// BuildTableExistsQuery returns a query that counts the tables or views matching the given name.
+// Unqualified names are resolved against the `main` schema.
+func (c *Client) BuildTableExistsQuery(tableName string) (string, error) {
+	tableComponents := strings.Split(tableName, ".")
+	for _, component := range tableComponents {
+		if component == "" {
+			return "", fmt.Errorf("table name must be in format schema.table or table, '%s' given", tableName)
+		}
+	}
+
+	var schemaName, tableNameOnly string
+	switch len(tableComponents) {
+	case 1:
+		schemaName = "main"
+		tableNameOnly = tableComponents[0]
+	case 2:
+		schemaName = tableComponents[0]
+		tableNameOnly = tableComponents[1]
+	default:
+		return "", fmt.Errorf("table name must be in format schema.table or table, '%s' given", tableName)
+	}
+
+	return fmt.Sprintf(
+		"SELECT COUNT(*) FROM information_schema.tables WHERE table_schema = '%s' AND table_name = '%s'",
+		schemaName,
+		tableNameOnly,
+	), nil
+}
+
 // Close is a no-op since connections are opened and closed per operation.
 func (c *Client) Close() {}
 
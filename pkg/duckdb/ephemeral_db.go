@@ -70,6 +70,19 @@ func (e *EphemeralConnection) openADBC(ctx context.Context) (adbc.Database, adbc
 }
 
 func (e *EphemeralConnection) setupLakehouseADBC(ctx context.Context, conn adbc.Connection) error {
+	if setup, ok := e.config.(connectionSetup); ok {
+		statements, err := setup.SetupStatements()
+		if err != nil {
+			return fmt.Errorf("failed to generate connection setup statements: %w", err)
+		}
+		for _, sqlStr := range statements {
+			if err := execADBCStatement(ctx, conn, sqlStr); err != nil {
+				return fmt.Errorf("failed to execute connection setup statement: %w", err)
+			}
+		}
+		return nil
+	}
+
 	cfg, ok := e.config.(Config)
 	if !ok || !cfg.HasLakehouse() {
 		return nil
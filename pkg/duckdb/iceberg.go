@@ -0,0 +1,91 @@
+package duck
+
+import (
+	"sync"
+
+	"github.com/bruin-data/bruin/pkg/config"
+	"github.com/bruin-data/bruin/pkg/iceberg"
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/pkg/errors"
+)
+
+// IcebergConnections exposes the Iceberg connections as DuckDB clients that attach the catalog, so
+// that `iceberg.sql` assets run on the DuckDB operator. Other connections are returned as they are.
+// The DuckDB client of a connection is built once and shared by the assets and checks using it.
+type IcebergConnections struct {
+	connections config.ConnectionGetter
+	newClient   func(DuckDBConfig) (*Client, error)
+
+	mu      sync.Mutex
+	clients map[string]*Client
+}
+
+var _ config.ConnectionResolver = (*IcebergConnections)(nil)
+
+func NewIcebergConnections(connections config.ConnectionGetter) *IcebergConnections {
+	return &IcebergConnections{
+		connections: connections,
+		newClient:   NewClient,
+		clients:     make(map[string]*Client),
+	}
+}
+
+// GetConnection implements config.ConnectionGetter, callers that need the reason a client could
+// not be built use ResolveConnection.
+func (i *IcebergConnections) GetConnection(name string) any {
+	conn, err := i.ResolveConnection(name)
+	if err != nil {
+		return nil
+	}
+
+	return conn
+}
+
+// ResolveConnection implements config.ConnectionResolver.
+func (i *IcebergConnections) ResolveConnection(name string) (any, error) {
+	conn, err := config.ResolveConnection(i.connections, name)
+	if err != nil {
+		return nil, err
+	}
+
+	icebergClient, ok := conn.(*iceberg.Client)
+	if !ok {
+		return conn, nil
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if client, ok := i.clients[name]; ok {
+		return client, nil
+	}
+
+	client, err := i.newClient(icebergClient.DuckDBConfig())
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to attach the iceberg connection '%s' to DuckDB", name)
+	}
+	i.clients[name] = client
+
+	return client, nil
+}
+
+// NewIcebergMaterializer renders `iceberg.sql` assets with the strategies DuckDB can run against
+// Iceberg tables.
+func NewIcebergMaterializer(fullRefresh bool) *pipeline.Materializer {
+	return &pipeline.Materializer{
+		MaterializationMap: icebergMatMap,
+		FullRefresh:        fullRefresh,
+	}
+}
+
+var icebergMatMap = pipeline.AssetMaterializationMap{
+	pipeline.MaterializationTypeView: {
+		pipeline.MaterializationStrategyNone: errorMaterializer,
+	},
+	pipeline.MaterializationTypeTable: {
+		pipeline.MaterializationStrategyNone:          buildCreateReplaceQuery,
+		pipeline.MaterializationStrategyCreateReplace: buildCreateReplaceQuery,
+		pipeline.MaterializationStrategyAppend:        buildAppendQuery,
+		pipeline.MaterializationStrategyMerge:         buildMergeQuery,
+	},
+}
@@ -0,0 +1,450 @@
+package duck
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+// testRESTCatalog is an in-memory Iceberg REST catalog for the end-to-end tests, it keeps the
+// metadata of the tables in memory and writes each version next to the data files DuckDB writes
+// under the warehouse directory. It implements the endpoints DuckDB's iceberg extension uses.
+type testRESTCatalog struct {
+	warehouse string
+
+	mu         sync.Mutex
+	namespaces map[string]bool
+	tables     map[string]*testTable
+}
+
+type testTable struct {
+	metadata         map[string]any
+	metadataLocation string
+	version          int
+}
+
+type testTableUpdate struct {
+	Action     string            `json:"action"`
+	UUID       string            `json:"uuid"`
+	Version    *int              `json:"format-version"`
+	Schema     map[string]any    `json:"schema"`
+	SchemaID   *int              `json:"schema-id"`
+	Spec       map[string]any    `json:"spec"`
+	SpecID     *int              `json:"spec-id"`
+	SortOrder  map[string]any    `json:"sort-order"`
+	OrderID    *int              `json:"sort-order-id"`
+	Location   string            `json:"location"`
+	Updates    map[string]string `json:"updates"`
+	Removals   []string          `json:"removals"`
+	Snapshot   map[string]any    `json:"snapshot"`
+	RefName    string            `json:"ref-name"`
+	SnapshotID *int64            `json:"snapshot-id"`
+	Type       string            `json:"type"`
+}
+
+type testCommitRequest struct {
+	Identifier *struct {
+		Namespace []string `json:"namespace"`
+		Name      string   `json:"name"`
+	} `json:"identifier"`
+	Updates []testTableUpdate `json:"updates"`
+}
+
+// startTestRESTCatalog serves the catalog on a local port until the test ends.
+func startTestRESTCatalog(t *testing.T, warehouse string) *httptest.Server {
+	t.Helper()
+
+	catalog := &testRESTCatalog{warehouse: warehouse, namespaces: map[string]bool{}, tables: map[string]*testTable{}}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/config", func(w http.ResponseWriter, _ *http.Request) {
+		writeTestJSON(w, map[string]any{"defaults": map[string]string{}, "overrides": map[string]string{}})
+	})
+	mux.HandleFunc("GET /v1/namespaces", catalog.listNamespaces)
+	mux.HandleFunc("POST /v1/namespaces", catalog.createNamespace)
+	mux.HandleFunc("GET /v1/namespaces/{namespace}", catalog.loadNamespace)
+	mux.HandleFunc("HEAD /v1/namespaces/{namespace}", catalog.loadNamespace)
+	mux.HandleFunc("GET /v1/namespaces/{namespace}/tables", catalog.listTables)
+	mux.HandleFunc("POST /v1/namespaces/{namespace}/tables", catalog.createTable)
+	mux.HandleFunc("GET /v1/namespaces/{namespace}/tables/{table}", catalog.loadTable)
+	mux.HandleFunc("HEAD /v1/namespaces/{namespace}/tables/{table}", catalog.loadTable)
+	mux.HandleFunc("POST /v1/namespaces/{namespace}/tables/{table}", catalog.commitTable)
+	mux.HandleFunc("DELETE /v1/namespaces/{namespace}/tables/{table}", catalog.dropTable)
+	mux.HandleFunc("POST /v1/namespaces/{namespace}/tables/{table}/metrics", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("POST /v1/transactions/commit", catalog.commitTransaction)
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func (c *testRESTCatalog) listNamespaces(w http.ResponseWriter, _ *http.Request) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	namespaces := make([][]string, 0, len(c.namespaces))
+	for namespace := range c.namespaces {
+		namespaces = append(namespaces, []string{namespace})
+	}
+	writeTestJSON(w, map[string]any{"namespaces": namespaces})
+}
+
+func (c *testRESTCatalog) createNamespace(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Namespace []string `json:"namespace"`
+	}
+	if err := decodeTestRequest(r, &req); err != nil || len(req.Namespace) != 1 {
+		writeTestError(w, http.StatusBadRequest, "BadRequestException", "expected a single-level namespace")
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.namespaces[req.Namespace[0]] {
+		writeTestError(w, http.StatusConflict, "AlreadyExistsException", "namespace already exists")
+		return
+	}
+	c.namespaces[req.Namespace[0]] = true
+	writeTestJSON(w, map[string]any{"namespace": req.Namespace, "properties": map[string]string{}})
+}
+
+func (c *testRESTCatalog) loadNamespace(w http.ResponseWriter, r *http.Request) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	namespace := r.PathValue("namespace")
+	if !c.namespaces[namespace] {
+		writeTestError(w, http.StatusNotFound, "NoSuchNamespaceException", "namespace does not exist: "+namespace)
+		return
+	}
+	if r.Method == http.MethodHead {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	writeTestJSON(w, map[string]any{"namespace": []string{namespace}, "properties": map[string]string{}})
+}
+
+func (c *testRESTCatalog) listTables(w http.ResponseWriter, r *http.Request) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	namespace := r.PathValue("namespace")
+	identifiers := make([]map[string]any, 0)
+	for key := range c.tables {
+		if tableNamespace, name, _ := strings.Cut(key, "."); tableNamespace == namespace {
+			identifiers = append(identifiers, map[string]any{"namespace": []string{namespace}, "name": name})
+		}
+	}
+	writeTestJSON(w, map[string]any{"identifiers": identifiers})
+}
+
+func (c *testRESTCatalog) createTable(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Name          string            `json:"name"`
+		Location      string            `json:"location"`
+		Schema        map[string]any    `json:"schema"`
+		PartitionSpec map[string]any    `json:"partition-spec"`
+		WriteOrder    map[string]any    `json:"write-order"`
+		StageCreate   bool              `json:"stage-create"`
+		Properties    map[string]string `json:"properties"`
+	}
+	if err := decodeTestRequest(r, &req); err != nil {
+		writeTestError(w, http.StatusBadRequest, "BadRequestException", err.Error())
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	namespace := r.PathValue("namespace")
+	key := namespace + "." + req.Name
+	if _, ok := c.tables[key]; ok {
+		writeTestError(w, http.StatusConflict, "AlreadyExistsException", "table already exists: "+key)
+		return
+	}
+
+	location := req.Location
+	if location == "" {
+		location = filepath.Join(c.warehouse, namespace, req.Name)
+	}
+	if req.PartitionSpec == nil {
+		req.PartitionSpec = map[string]any{"spec-id": 0, "fields": []any{}}
+	}
+	if req.WriteOrder == nil {
+		req.WriteOrder = map[string]any{"order-id": 0, "fields": []any{}}
+	}
+	last := -1
+	table := &testTable{metadata: newTestMetadata()}
+	applyTestUpdates(table.metadata, []testTableUpdate{
+		{Action: "assign-uuid", UUID: uuid.NewString()},
+		{Action: "add-schema", Schema: req.Schema},
+		{Action: "set-current-schema", SchemaID: &last},
+		{Action: "add-spec", Spec: req.PartitionSpec},
+		{Action: "set-default-spec", SpecID: &last},
+		{Action: "add-sort-order", SortOrder: req.WriteOrder},
+		{Action: "set-default-sort-order", OrderID: &last},
+		{Action: "set-location", Location: location},
+		{Action: "set-properties", Updates: req.Properties},
+	})
+
+	// a staged table is stored by the commit that creates it
+	if !req.StageCreate {
+		if err := table.write(); err != nil {
+			writeTestError(w, http.StatusInternalServerError, "ServerError", err.Error())
+			return
+		}
+		c.tables[key] = table
+	}
+	writeTestJSON(w, table.result())
+}
+
+func (c *testRESTCatalog) loadTable(w http.ResponseWriter, r *http.Request) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := r.PathValue("namespace") + "." + r.PathValue("table")
+	table, ok := c.tables[key]
+	if !ok {
+		writeTestError(w, http.StatusNotFound, "NoSuchTableException", "table does not exist: "+key)
+		return
+	}
+	if r.Method == http.MethodHead {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	writeTestJSON(w, table.result())
+}
+
+func (c *testRESTCatalog) commitTable(w http.ResponseWriter, r *http.Request) {
+	var req testCommitRequest
+	if err := decodeTestRequest(r, &req); err != nil {
+		writeTestError(w, http.StatusBadRequest, "BadRequestException", err.Error())
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	table, err := c.commit(r.PathValue("namespace"), r.PathValue("table"), req.Updates)
+	if err != nil {
+		writeTestError(w, http.StatusInternalServerError, "ServerError", err.Error())
+		return
+	}
+	writeTestJSON(w, table.result())
+}
+
+func (c *testRESTCatalog) commitTransaction(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		TableChanges []testCommitRequest `json:"table-changes"`
+	}
+	if err := decodeTestRequest(r, &req); err != nil {
+		writeTestError(w, http.StatusBadRequest, "BadRequestException", err.Error())
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, change := range req.TableChanges {
+		if change.Identifier == nil || len(change.Identifier.Namespace) != 1 {
+			writeTestError(w, http.StatusBadRequest, "BadRequestException", "every table change needs an identifier")
+			return
+		}
+		if _, err := c.commit(change.Identifier.Namespace[0], change.Identifier.Name, change.Updates); err != nil {
+			writeTestError(w, http.StatusInternalServerError, "ServerError", err.Error())
+			return
+		}
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// commit applies the updates to the table, creating it when a staged create is committed.
+func (c *testRESTCatalog) commit(namespace, name string, updates []testTableUpdate) (*testTable, error) {
+	key := namespace + "." + name
+	table, ok := c.tables[key]
+	if !ok {
+		table = &testTable{metadata: newTestMetadata()}
+	}
+
+	applyTestUpdates(table.metadata, updates)
+	if table.metadata["location"] == "" {
+		table.metadata["location"] = filepath.Join(c.warehouse, namespace, name)
+	}
+	if err := table.write(); err != nil {
+		return nil, err
+	}
+	c.tables[key] = table
+
+	return table, nil
+}
+
+func (c *testRESTCatalog) dropTable(w http.ResponseWriter, r *http.Request) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := r.PathValue("namespace") + "." + r.PathValue("table")
+	if _, ok := c.tables[key]; !ok {
+		writeTestError(w, http.StatusNotFound, "NoSuchTableException", "table does not exist: "+key)
+		return
+	}
+	delete(c.tables, key)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (t *testTable) write() error {
+	t.version++
+	location := fmt.Sprintf("%s/metadata/%05d-%s.metadata.json", t.metadata["location"], t.version, uuid.NewString())
+	if err := os.MkdirAll(filepath.Dir(location), 0o755); err != nil {
+		return err
+	}
+
+	content, err := json.Marshal(t.metadata)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(location, content, 0o600); err != nil {
+		return err
+	}
+	t.metadataLocation = location
+
+	return nil
+}
+
+func (t *testTable) result() map[string]any {
+	return map[string]any{"metadata-location": t.metadataLocation, "metadata": t.metadata, "config": map[string]string{}}
+}
+
+func newTestMetadata() map[string]any {
+	return map[string]any{
+		"format-version":        2,
+		"location":              "",
+		"last-sequence-number":  0,
+		"last-updated-ms":       0,
+		"last-column-id":        0,
+		"schemas":               []any{},
+		"current-schema-id":     0,
+		"partition-specs":       []any{},
+		"default-spec-id":       0,
+		"last-partition-id":     999,
+		"sort-orders":           []any{},
+		"default-sort-order-id": 0,
+		"properties":            map[string]string{},
+		"snapshots":             []any{},
+		"snapshot-log":          []any{},
+		"metadata-log":          []any{},
+		"refs":                  map[string]any{},
+	}
+}
+
+// applyTestUpdates applies the metadata updates DuckDB sends, a -1 ID refers to the schema,
+// partition spec or sort order added last.
+func applyTestUpdates(md map[string]any, updates []testTableUpdate) {
+	for _, u := range updates {
+		switch u.Action {
+		case "assign-uuid":
+			md["table-uuid"] = u.UUID
+		case "upgrade-format-version":
+			md["format-version"] = *u.Version
+		case "add-schema":
+			md["schemas"] = append(md["schemas"].([]any), u.Schema)
+			md["last-column-id"] = max(toInt(md["last-column-id"]), maxTestFieldID(u.Schema))
+		case "set-current-schema":
+			md["current-schema-id"] = resolveTestID(*u.SchemaID, md["schemas"].([]any), "schema-id")
+		case "add-spec":
+			md["partition-specs"] = append(md["partition-specs"].([]any), u.Spec)
+		case "set-default-spec":
+			md["default-spec-id"] = resolveTestID(*u.SpecID, md["partition-specs"].([]any), "spec-id")
+		case "add-sort-order":
+			md["sort-orders"] = append(md["sort-orders"].([]any), u.SortOrder)
+		case "set-default-sort-order":
+			md["default-sort-order-id"] = resolveTestID(*u.OrderID, md["sort-orders"].([]any), "order-id")
+		case "set-location":
+			md["location"] = u.Location
+		case "set-properties":
+			properties := md["properties"].(map[string]string)
+			for k, v := range u.Updates {
+				properties[k] = v
+			}
+		case "remove-properties":
+			properties := md["properties"].(map[string]string)
+			for _, k := range u.Removals {
+				delete(properties, k)
+			}
+		case "add-snapshot":
+			md["snapshots"] = append(md["snapshots"].([]any), u.Snapshot)
+			md["last-sequence-number"] = max(toInt(md["last-sequence-number"]), toInt(u.Snapshot["sequence-number"]))
+			md["last-updated-ms"] = toInt(u.Snapshot["timestamp-ms"])
+		case "set-snapshot-ref":
+			md["refs"].(map[string]any)[u.RefName] = map[string]any{"snapshot-id": *u.SnapshotID, "type": u.Type}
+			if u.RefName == "main" {
+				md["current-snapshot-id"] = *u.SnapshotID
+				md["snapshot-log"] = append(md["snapshot-log"].([]any), map[string]any{
+					"snapshot-id": *u.SnapshotID, "timestamp-ms": md["last-updated-ms"],
+				})
+			}
+		case "remove-snapshot-ref":
+			delete(md["refs"].(map[string]any), u.RefName)
+		}
+	}
+}
+
+func resolveTestID(id int, list []any, key string) int64 {
+	if id != -1 || len(list) == 0 {
+		return int64(id)
+	}
+	return toInt(list[len(list)-1].(map[string]any)[key])
+}
+
+func maxTestFieldID(schema map[string]any) int64 {
+	highest := int64(0)
+	fields, _ := schema["fields"].([]any)
+	for _, field := range fields {
+		if f, ok := field.(map[string]any); ok {
+			highest = max(highest, toInt(f["id"]))
+		}
+	}
+	return highest
+}
+
+func toInt(v any) int64 {
+	switch n := v.(type) {
+	case int:
+		return int64(n)
+	case int64:
+		return n
+	case json.Number:
+		i, _ := n.Int64()
+		return i
+	default:
+		return 0
+	}
+}
+
+// decodeTestRequest keeps the numbers of the request exact, snapshot IDs do not fit in a float64.
+func decodeTestRequest(r *http.Request, v any) error {
+	decoder := json.NewDecoder(r.Body)
+	decoder.UseNumber()
+	return decoder.Decode(v)
+}
+
+func writeTestJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeTestError(w http.ResponseWriter, code int, errorType, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(map[string]any{"error": map[string]any{"message": message, "type": errorType, "code": code}})
+}
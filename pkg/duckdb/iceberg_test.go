@@ -0,0 +1,245 @@
+package duck
+
+import (
+	"errors"
+	"net/url"
+	"runtime"
+	"strconv"
+	"testing"
+
+	"github.com/bruin-data/bruin/pkg/config"
+	"github.com/bruin-data/bruin/pkg/iceberg"
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/bruin-data/bruin/pkg/query"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type staticConnections map[string]any
+
+func (s staticConnections) GetConnection(name string) any {
+	return s[name]
+}
+
+func TestIcebergMaterializer_Render(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		strategy    pipeline.MaterializationStrategy
+		matType     pipeline.MaterializationType
+		fullRefresh bool
+		want        string
+		wantErr     string
+	}{
+		{
+			name:    "create+replace recreates the table",
+			matType: pipeline.MaterializationTypeTable,
+			want: `BEGIN TRANSACTION;
+DROP TABLE IF EXISTS analytics.orders; 
+CREATE TABLE analytics.orders AS SELECT 1 AS id;
+COMMIT;`,
+		},
+		{
+			name:     "append inserts the rows",
+			matType:  pipeline.MaterializationTypeTable,
+			strategy: pipeline.MaterializationStrategyAppend,
+			want:     "INSERT INTO analytics.orders SELECT 1 AS id",
+		},
+		{
+			name:        "full refresh turns merge into create+replace",
+			matType:     pipeline.MaterializationTypeTable,
+			strategy:    pipeline.MaterializationStrategyMerge,
+			fullRefresh: true,
+			want: `BEGIN TRANSACTION;
+DROP TABLE IF EXISTS analytics.orders; 
+CREATE TABLE analytics.orders AS SELECT 1 AS id;
+COMMIT;`,
+		},
+		{
+			name:     "strategies DuckDB cannot run on iceberg are rejected",
+			matType:  pipeline.MaterializationTypeTable,
+			strategy: pipeline.MaterializationStrategyDeleteInsert,
+			wantErr:  "unsupported materialization type - strategy combination",
+		},
+		{
+			name:    "views are rejected",
+			matType: pipeline.MaterializationTypeView,
+			wantErr: "is not supported for materialization type view",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			asset := &pipeline.Asset{
+				Name: "analytics.orders",
+				Type: pipeline.AssetTypeIcebergQuery,
+				Materialization: pipeline.Materialization{
+					Type:     tt.matType,
+					Strategy: tt.strategy,
+				},
+				Columns: []pipeline.Column{{Name: "id", PrimaryKey: true}},
+			}
+
+			got, err := NewIcebergMaterializer(tt.fullRefresh).Render(asset, "SELECT 1 AS id")
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestIcebergMaterializer_RenderMerge(t *testing.T) {
+	t.Parallel()
+
+	asset := &pipeline.Asset{
+		Name: "analytics.orders",
+		Type: pipeline.AssetTypeIcebergQuery,
+		Materialization: pipeline.Materialization{
+			Type:     pipeline.MaterializationTypeTable,
+			Strategy: pipeline.MaterializationStrategyMerge,
+		},
+		Columns: []pipeline.Column{
+			{Name: "id", PrimaryKey: true},
+			{Name: "status", UpdateOnMerge: true},
+		},
+	}
+
+	got, err := NewIcebergMaterializer(false).Render(asset, "SELECT 1 AS id, 'paid' AS status")
+	require.NoError(t, err)
+	assert.Contains(t, got, "UPDATE analytics.orders AS target SET status = source.status FROM")
+	assert.Contains(t, got, "INSERT INTO analytics.orders (id, status) SELECT id, status FROM")
+}
+
+func TestIcebergConnections_GetConnectionPassesOtherConnectionsThrough(t *testing.T) {
+	t.Parallel()
+
+	other := &struct{}{}
+	connections := NewIcebergConnections(staticConnections{"duckdb-default": other})
+
+	assert.Same(t, other, connections.GetConnection("duckdb-default"))
+	assert.Nil(t, connections.GetConnection("missing"))
+}
+
+func TestIcebergConnections_ResolveConnectionCachesClients(t *testing.T) {
+	t.Parallel()
+
+	icebergClient, err := iceberg.NewClient(iceberg.Config{})
+	require.NoError(t, err)
+
+	calls := 0
+	connections := NewIcebergConnections(staticConnections{"lake": icebergClient})
+	connections.newClient = func(c DuckDBConfig) (*Client, error) {
+		calls++
+		return &Client{config: c}, nil
+	}
+
+	first, err := connections.ResolveConnection("lake")
+	require.NoError(t, err)
+	second := connections.GetConnection("lake")
+
+	assert.Same(t, first, second)
+	assert.Equal(t, 1, calls)
+}
+
+func TestIcebergConnections_ResolveConnectionReturnsClientErrors(t *testing.T) {
+	t.Parallel()
+
+	icebergClient, err := iceberg.NewClient(iceberg.Config{})
+	require.NoError(t, err)
+
+	connections := NewIcebergConnections(staticConnections{"lake": icebergClient})
+	connections.newClient = func(DuckDBConfig) (*Client, error) {
+		return nil, errors.New("driver not installed")
+	}
+
+	_, err = connections.ResolveConnection("lake")
+	require.EqualError(t, err, "failed to attach the iceberg connection 'lake' to DuckDB: driver not installed")
+	assert.Nil(t, connections.GetConnection("lake"))
+}
+
+// TestIcebergMaterialization_EndToEnd runs the strategies of `iceberg.sql` assets on DuckDB against
+// a local REST catalog and warehouse, and reads the rows back through the catalog.
+func TestIcebergMaterialization_EndToEnd(t *testing.T) {
+	t.Parallel()
+
+	if runtime.GOOS == "windows" {
+		t.Skip("skipping on Windows due to DuckDB file locking")
+	}
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+	if err := EnsureADBCDriverInstalled(t.Context()); err != nil {
+		t.Skipf("skipping test: ADBC DuckDB driver not available: %v", err)
+	}
+
+	warehouse := t.TempDir()
+	server := startTestRESTCatalog(t, warehouse)
+	serverURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	port, err := strconv.Atoi(serverURL.Port())
+	require.NoError(t, err)
+
+	icebergClient, err := iceberg.NewClient(iceberg.Config{
+		Catalog: config.IcebergCatalog{Type: config.IcebergCatalogREST, Host: serverURL.Hostname(), Port: port},
+		Storage: config.IcebergStorage{Type: config.IcebergStorageLocal, Path: warehouse},
+	})
+	require.NoError(t, err)
+
+	resolved, err := NewIcebergConnections(staticConnections{"lake": icebergClient}).ResolveConnection("lake")
+	require.NoError(t, err)
+	client := resolved.(*Client)
+
+	ctx := t.Context()
+	if _, err := client.Select(ctx, &query.Query{Query: "SELECT 1"}); err != nil {
+		t.Skipf("skipping test: the DuckDB iceberg extension could not be loaded: %v", err)
+	}
+
+	run := func(strategy pipeline.MaterializationStrategy, sql string) {
+		t.Helper()
+
+		asset := &pipeline.Asset{
+			Name: "analytics.orders",
+			Type: pipeline.AssetTypeIcebergQuery,
+			Materialization: pipeline.Materialization{
+				Type:     pipeline.MaterializationTypeTable,
+				Strategy: strategy,
+			},
+			Columns: []pipeline.Column{
+				{Name: "id", PrimaryKey: true},
+				{Name: "status", UpdateOnMerge: true},
+			},
+		}
+		require.NoError(t, client.CreateSchemaIfNotExist(ctx, asset))
+
+		materialized, err := NewIcebergMaterializer(false).Render(asset, sql)
+		require.NoError(t, err)
+		require.NoError(t, client.RunQueryWithoutResult(ctx, &query.Query{Query: materialized}))
+	}
+	rows := func() [][]interface{} {
+		t.Helper()
+
+		result, err := client.Select(ctx, &query.Query{Query: "SELECT id::VARCHAR || ':' || status FROM analytics.orders ORDER BY id"})
+		require.NoError(t, err)
+		return result
+	}
+
+	run(pipeline.MaterializationStrategyCreateReplace, "SELECT 1 AS id, 'new' AS status UNION ALL SELECT 2, 'new'")
+	assert.Equal(t, [][]interface{}{{"1:new"}, {"2:new"}}, rows())
+
+	// create+replace drops the rows of the previous run
+	run(pipeline.MaterializationStrategyCreateReplace, "SELECT 1 AS id, 'paid' AS status")
+	assert.Equal(t, [][]interface{}{{"1:paid"}}, rows())
+
+	run(pipeline.MaterializationStrategyAppend, "SELECT 2 AS id, 'new' AS status")
+	assert.Equal(t, [][]interface{}{{"1:paid"}, {"2:new"}}, rows())
+
+	run(pipeline.MaterializationStrategyMerge, "SELECT 2 AS id, 'shipped' AS status UNION ALL SELECT 3, 'new'")
+	assert.Equal(t, [][]interface{}{{"1:paid"}, {"2:shipped"}, {"3:new"}}, rows())
+}
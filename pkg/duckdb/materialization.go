@@ -244,11 +244,14 @@ func buildDDLQuery(asset *pipeline.Asset, query string) (string, error) {
 		if col.Default != "" {
 			def += " DEFAULT " + col.Default
 		}
+		if col.IsNotNull() {
+			def += " NOT NULL"
+		}
 
 		if col.PrimaryKey {
 			primaryKeys = append(primaryKeys, col.Name)
 		}
-		if col.ForeignKey != nil && col.ForeignKey.Table != "" && col.ForeignKey.Column != "" {
+		if col.HasForeignKey() {
 			foreignKeys = append(foreignKeys, fmt.Sprintf("FOREIGN KEY (%s) REFERENCES %s (%s)",
 				col.Name, col.ForeignKey.Table, col.ForeignKey.Column))
 		}
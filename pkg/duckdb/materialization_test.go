@@ -1464,6 +1464,8 @@ func TestBuildSCD2ByTimeFullRefreshQuery(t *testing.T) {
 
 func intp(i int) *int { return &i }
 
+func boolp(value bool) *bool { return &value }
+
 func TestColumnMetadataDDL(t *testing.T) {
 	t.Parallel()
 	asset := &pipeline.Asset{
@@ -1483,3 +1485,18 @@ func TestColumnMetadataDDL(t *testing.T) {
 	assert.Contains(t, render, "COLLATE")
 	assert.Contains(t, render, "REFERENCES")
 }
+
+func TestColumnMetadataDDL_NotNullForeignKey(t *testing.T) {
+	t.Parallel()
+	asset := &pipeline.Asset{
+		Name:            "orders",
+		Materialization: pipeline.Materialization{Type: pipeline.MaterializationTypeTable, Strategy: pipeline.MaterializationStrategyDDL},
+		Columns: []pipeline.Column{
+			{Name: "customer_id", Type: "integer", Nullable: pipeline.DefaultTrueBool{Value: boolp(false)}, ForeignKey: &pipeline.ColumnReference{Table: "customers", Column: "id"}},
+		},
+	}
+	render, err := NewMaterializer(false).Render(asset, "SELECT 1")
+	require.NoError(t, err)
+	assert.Contains(t, render, "customer_id integer NOT NULL")
+	assert.Contains(t, render, "REFERENCES")
+}
@@ -0,0 +1,56 @@
+package duck
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/bruin-data/bruin/pkg/ansisql"
+	"github.com/bruin-data/bruin/pkg/config"
+	"github.com/bruin-data/bruin/pkg/pipeline"
+)
+
+// MetadataDialect pushes asset and column descriptions as DuckDB comments, the current comments
+// are read from duckdb_tables() and duckdb_columns().
+var MetadataDialect = ansisql.MetadataDialect{
+	Platform: "DuckDB",
+	CurrentMetadata: func(ctx context.Context, conn ansisql.MetadataClient, asset *pipeline.Asset) (*ansisql.TableMetadata, error) {
+		return ansisql.QueryTableMetadata(ctx, conn, currentMetadataQuery(asset.Name))
+	},
+	TableCommentStatement: func(asset *pipeline.Asset, comment string) string {
+		return fmt.Sprintf("COMMENT ON TABLE %s IS %s;", asset.Name, ansisql.QuoteLiteral(comment))
+	},
+	ColumnCommentStatement: func(asset *pipeline.Asset, column ansisql.ColumnMetadata, comment string) string {
+		return fmt.Sprintf("COMMENT ON COLUMN %s.%s IS %s;", asset.Name, quoteColumn(column.Name), ansisql.QuoteLiteral(comment))
+	},
+}
+
+func NewMetadataPushOperator(conn config.ConnectionGetter) *ansisql.MetadataPushOperator {
+	return ansisql.NewMetadataPushOperator(conn, MetadataDialect)
+}
+
+func currentMetadataQuery(name string) string {
+	parts := strings.Split(name, ".")
+	database := "current_database()"
+	schema := "main"
+	switch len(parts) {
+	case 2:
+		schema = parts[0]
+	case 3:
+		database = ansisql.QuoteLiteral(parts[0])
+		schema = parts[1]
+	}
+	filter := fmt.Sprintf(
+		"database_name = %s AND schema_name = %s AND table_name = %s",
+		database, ansisql.QuoteLiteral(schema), ansisql.QuoteLiteral(parts[len(parts)-1]),
+	)
+
+	return fmt.Sprintf(
+		"SELECT 'table', table_name, comment FROM duckdb_tables() WHERE %s UNION ALL SELECT 'column', column_name, comment FROM duckdb_columns() WHERE %s",
+		filter, filter,
+	)
+}
+
+func quoteColumn(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
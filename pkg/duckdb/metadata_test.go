@@ -0,0 +1,70 @@
+package duck
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/bruin-data/bruin/pkg/query"
+	"github.com/stretchr/testify/require"
+)
+
+type metadataClient struct {
+	rows    [][]interface{}
+	selects []string
+	queries []string
+}
+
+func (c *metadataClient) Select(ctx context.Context, q *query.Query) ([][]interface{}, error) {
+	c.selects = append(c.selects, q.Query)
+	return c.rows, nil
+}
+
+func (c *metadataClient) RunQueryWithoutResult(ctx context.Context, q *query.Query) error {
+	c.queries = append(c.queries, q.Query)
+	return nil
+}
+
+func TestMetadataDialect_PushMetadata(t *testing.T) {
+	t.Parallel()
+
+	asset := &pipeline.Asset{
+		Name:        "analytics.orders",
+		Description: "All the orders, one row per order",
+		Columns: []pipeline.Column{
+			{Name: "ID", Description: "The order's id"},
+			{Name: "amount", Description: "Order amount"},
+			{Name: "missing", Description: "Not in the table"},
+		},
+	}
+
+	client := &metadataClient{rows: [][]interface{}{
+		{"table", "orders", nil},
+		{"column", "id", nil},
+		{"column", "amount", "Order amount"},
+	}}
+	require.NoError(t, MetadataDialect.PushMetadata(t.Context(), client, asset))
+	require.Equal(t, []string{
+		"SELECT 'table', table_name, comment FROM duckdb_tables() WHERE database_name = current_database() AND schema_name = 'analytics' AND table_name = 'orders' " +
+			"UNION ALL SELECT 'column', column_name, comment FROM duckdb_columns() WHERE database_name = current_database() AND schema_name = 'analytics' AND table_name = 'orders'",
+	}, client.selects)
+	require.Equal(t, []string{
+		"COMMENT ON TABLE analytics.orders IS 'All the orders, one row per order';",
+		`COMMENT ON COLUMN analytics.orders."id" IS 'The order''s id';`,
+	}, client.queries)
+
+	unchanged := &metadataClient{rows: [][]interface{}{
+		{"table", "orders", "All the orders, one row per order"},
+		{"column", "id", "The order's id"},
+		{"column", "amount", "Order amount"},
+	}}
+	require.NoError(t, MetadataDialect.PushMetadata(t.Context(), unchanged, asset))
+	require.Empty(t, unchanged.queries)
+}
+
+func TestCurrentMetadataQuery(t *testing.T) {
+	t.Parallel()
+
+	require.Contains(t, currentMetadataQuery("orders"), "database_name = current_database() AND schema_name = 'main' AND table_name = 'orders'")
+	require.Contains(t, currentMetadataQuery("lake.analytics.orders"), "database_name = 'lake' AND schema_name = 'analytics' AND table_name = 'orders'")
+}
@@ -6,12 +6,15 @@ import (
 	"github.com/bruin-data/bruin/pkg/ansisql"
 	"github.com/bruin-data/bruin/pkg/config"
 	"github.com/bruin-data/bruin/pkg/devenv"
+	"github.com/bruin-data/bruin/pkg/diff"
 	"github.com/bruin-data/bruin/pkg/executor"
+	"github.com/bruin-data/bruin/pkg/microbatch"
 	"github.com/bruin-data/bruin/pkg/pipeline"
 	"github.com/bruin-data/bruin/pkg/query"
 	"github.com/bruin-data/bruin/pkg/scheduler"
 	"github.com/bruin-data/bruin/pkg/sqlparser"
 	"github.com/pkg/errors"
+	"github.com/spf13/afero"
 )
 
 type materializer interface {
@@ -32,11 +35,21 @@ type devEnv interface {
 	RegisterAssetForSchemaCache(ctx context.Context, p *pipeline.Pipeline, a *pipeline.Asset, q *query.Query) error
 }
 
+type schemaEvolver interface {
+	EvolveSchema(ctx context.Context, conn any, asset *pipeline.Asset, assetQuery string, writer interface{}) error
+}
+
+type microbatcher interface {
+	Run(ctx context.Context, asset *pipeline.Asset, runBatch func(ctx context.Context) error) error
+}
+
 type BasicOperator struct {
-	connection   config.ConnectionGetter
-	extractor    query.QueryExtractor
-	materializer materializer
-	devEnv       devEnv
+	connection    config.ConnectionGetter
+	extractor     query.QueryExtractor
+	materializer  materializer
+	devEnv        devEnv
+	schemaEvolver schemaEvolver
+	microbatcher  microbatcher
 }
 
 func NewBasicOperator(conn config.ConnectionGetter, extractor query.QueryExtractor, materializer materializer, parser *sqlparser.SQLParser) *BasicOperator {
@@ -49,6 +62,8 @@ func NewBasicOperator(conn config.ConnectionGetter, extractor query.QueryExtract
 			Conn:    conn,
 			Parser:  parser,
 		},
+		schemaEvolver: ansisql.NewSchemaEvolver(diff.DialectDuckDB, diff.NewDuckDBTypeMapper()),
+		microbatcher:  microbatch.NewRunner(afero.NewOsFs()),
 	}
 }
 
@@ -57,6 +72,16 @@ func (o BasicOperator) Run(ctx context.Context, ti scheduler.TaskInstance) error
 }
 
 func (o BasicOperator) RunTask(ctx context.Context, p *pipeline.Pipeline, t *pipeline.Asset) error {
+	if o.microbatcher != nil && t.Materialization.IsMicrobatched() && !o.isFullRefresh() {
+		return o.microbatcher.Run(ctx, t, func(batchCtx context.Context) error {
+			return o.runTask(batchCtx, p, t)
+		})
+	}
+
+	return o.runTask(ctx, p, t)
+}
+
+func (o BasicOperator) runTask(ctx context.Context, p *pipeline.Pipeline, t *pipeline.Asset) error {
 	extractor, err := o.extractor.CloneForAsset(ctx, p, t)
 	if err != nil {
 		return errors.Wrapf(err, "failed to clone extractor for asset %s", t.Name)
@@ -75,6 +100,7 @@ func (o BasicOperator) RunTask(ctx context.Context, p *pipeline.Pipeline, t *pip
 	}
 
 	q := queries[0]
+	assetQuery := q.String()
 	writer := ctx.Value(executor.KeyPrinter)
 	err = o.materializer.LogIfFullRefreshAndDDL(writer, t)
 	if err != nil {
@@ -105,7 +131,10 @@ func (o BasicOperator) RunTask(ctx context.Context, p *pipeline.Pipeline, t *pip
 		return err
 	}
 
-	rawConn := o.connection.GetConnection(connName)
+	rawConn, err := config.ResolveConnection(o.connection, connName)
+	if err != nil {
+		return err
+	}
 	if rawConn == nil {
 		return config.NewConnectionNotFoundError(ctx, "", connName)
 	}
@@ -124,6 +153,21 @@ func (o BasicOperator) RunTask(ctx context.Context, p *pipeline.Pipeline, t *pip
 
 	defer conn.Close()
 
+	if o.schemaEvolver != nil && t.Materialization.ShouldEvolveSchema() && !o.isFullRefresh() {
+		probeQuery := &query.Query{Query: assetQuery}
+		if o.devEnv != nil {
+			probeQuery, err = o.devEnv.Modify(ctx, p, t, probeQuery)
+			if err != nil {
+				return err
+			}
+		}
+
+		err = o.schemaEvolver.EvolveSchema(ctx, conn, t, probeQuery.Query, writer)
+		if err != nil {
+			return err
+		}
+	}
+
 	var lastQuery *query.Query
 	for _, queryString := range materializedQueries {
 		queryObj := &query.Query{Query: queryString}
@@ -160,6 +204,14 @@ func (o BasicOperator) RunTask(ctx context.Context, p *pipeline.Pipeline, t *pip
 	return nil
 }
 
+func (o BasicOperator) isFullRefresh() bool {
+	fullRefresh, ok := o.materializer.(interface {
+		IsFullRefresh() bool
+	})
+
+	return ok && fullRefresh.IsFullRefresh()
+}
+
 func NewColumnCheckOperator(manager config.ConnectionGetter) *ansisql.ColumnCheckOperator {
 	return ansisql.NewColumnCheckOperator(map[string]ansisql.CheckRunner{
 		"not_null":        ansisql.NewNotNullCheck(manager),
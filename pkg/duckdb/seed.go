@@ -0,0 +1,147 @@
+//go:build !bruin_no_duckdb
+
+package duck
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bruin-data/bruin/pkg/ansisql"
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/bruin-data/bruin/pkg/query"
+	"github.com/bruin-data/bruin/pkg/seed"
+	"github.com/pkg/errors"
+)
+
+// LoadSeed validates the seed rows into a normalized CSV file and replaces the table
+// with it through COPY, inside a single transaction. Only local database files are
+// supported, MotherDuck seeds go through ingestr.
+func (c *Client) LoadSeed(ctx context.Context, table *seed.Table, rows *seed.Rows) error {
+	if _, ok := c.config.(Config); !ok || c.readOnly {
+		return seed.ErrUnsupported
+	}
+
+	path, err := writeSeedCSV(table, rows)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(path)
+
+	if err := c.CreateSchemaIfNotExist(ctx, &pipeline.Asset{Name: table.Name}); err != nil {
+		return err
+	}
+
+	tableName := ansisql.QuoteIdentifierWithDoubleQuotes(table.Name)
+	queries := []string{
+		"BEGIN TRANSACTION",
+		fmt.Sprintf("CREATE OR REPLACE TABLE %s (\n%s\n)", tableName, table.ColumnDefinitions(ansisql.QuoteIdentifierWithDoubleQuotes, seedColumnType)),
+		fmt.Sprintf("COPY %s FROM '%s' (FORMAT csv, HEADER true)", tableName, strings.ReplaceAll(path, "'", "''")),
+		"COMMIT",
+	}
+
+	return c.RunQueryWithoutResult(ctx, &query.Query{Query: strings.Join(queries, ";\n") + ";"})
+}
+
+// writeSeedCSV writes the rows to a temporary CSV file in the formats DuckDB reads
+// without any options, NULLs are empty fields.
+func writeSeedCSV(table *seed.Table, rows *seed.Rows) (string, error) {
+	file, err := os.CreateTemp("", "bruin-seed-*.csv")
+	if err != nil {
+		return "", errors.Wrap(err, "failed to create a temporary file for the seed")
+	}
+
+	err = func() error {
+		writer := csv.NewWriter(file)
+		if err := writer.Write(table.ColumnNames()); err != nil {
+			return err
+		}
+
+		record := make([]string, len(table.Columns))
+		for rows.Next() {
+			values, _ := rows.Values()
+			for i, v := range values {
+				record[i] = formatSeedValue(table.Columns[i], v)
+			}
+			if err := writer.Write(record); err != nil {
+				return err
+			}
+		}
+		if err := rows.Err(); err != nil {
+			return err
+		}
+
+		writer.Flush()
+		return writer.Error()
+	}()
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(file.Name())
+		return "", err
+	}
+
+	return file.Name(), nil
+}
+
+func formatSeedValue(col seed.Column, value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	case time.Time:
+		switch {
+		case col.Kind == seed.KindDate:
+			return v.Format("2006-01-02")
+		case col.TimeZone:
+			return v.Format("2006-01-02 15:04:05.999999Z07:00")
+		default:
+			return v.UTC().Format("2006-01-02 15:04:05.999999")
+		}
+	case string:
+		return v
+	}
+
+	return fmt.Sprint(value)
+}
+
+func seedColumnType(col seed.Column) string {
+	var typ string
+	switch col.Kind {
+	case seed.KindInteger:
+		typ = "BIGINT"
+	case seed.KindFloat:
+		typ = "DOUBLE"
+	case seed.KindDecimal:
+		typ = "DECIMAL(38, 9)"
+		if col.Precision > 0 {
+			typ = fmt.Sprintf("DECIMAL(%d, %d)", col.Precision, col.Scale)
+		}
+	case seed.KindBoolean:
+		typ = "BOOLEAN"
+	case seed.KindDate:
+		typ = "DATE"
+	case seed.KindTimestamp:
+		typ = "TIMESTAMP"
+		if col.TimeZone {
+			typ = "TIMESTAMPTZ"
+		}
+	default:
+		typ = "VARCHAR"
+	}
+
+	if col.NotNull {
+		typ += " NOT NULL"
+	}
+	return typ
+}
@@ -0,0 +1,61 @@
+//go:build !bruin_no_duckdb
+
+package duck
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bruin-data/bruin/pkg/seed"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteSeedCSV(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "seed.csv")
+	require.NoError(t, os.WriteFile(path, []byte(
+		"id,price,amount,active,day,created_at,seen_at,name\n"+
+			"1,1e3,10.50,yes,2024-01-31,2024-01-31 10:00:00,2024-01-31T10:00:00+02:00,\"a, b\"\n"+
+			"2,,,,,,,\n",
+	), 0o600))
+
+	table := &seed.Table{Name: "seeds.countries", Columns: []seed.Column{
+		{Name: "id", Kind: seed.KindInteger},
+		{Name: "price", Kind: seed.KindFloat},
+		{Name: "amount", Kind: seed.KindDecimal},
+		{Name: "active", Kind: seed.KindBoolean},
+		{Name: "day", Kind: seed.KindDate},
+		{Name: "created_at", Kind: seed.KindTimestamp},
+		{Name: "seen_at", Kind: seed.KindTimestamp, TimeZone: true},
+		{Name: "name", Kind: seed.KindText},
+	}}
+
+	rows, err := seed.Open(path, table)
+	require.NoError(t, err)
+	defer rows.Close()
+
+	written, err := writeSeedCSV(table, rows)
+	require.NoError(t, err)
+	defer os.Remove(written)
+
+	content, err := os.ReadFile(written)
+	require.NoError(t, err)
+	assert.Equal(t,
+		"id,price,amount,active,day,created_at,seen_at,name\n"+
+			"1,1000,10.50,true,2024-01-31,2024-01-31 10:00:00,2024-01-31 10:00:00+02:00,\"a, b\"\n"+
+			"2,,,,,,,\n",
+		string(content),
+	)
+}
+
+func TestSeedColumnType(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "DECIMAL(10, 2) NOT NULL", seedColumnType(seed.Column{Kind: seed.KindDecimal, Precision: 10, Scale: 2, NotNull: true}))
+	assert.Equal(t, "DECIMAL(38, 9)", seedColumnType(seed.Column{Kind: seed.KindDecimal}))
+	assert.Equal(t, "TIMESTAMPTZ", seedColumnType(seed.Column{Kind: seed.KindTimestamp, TimeZone: true}))
+	assert.Equal(t, "VARCHAR", seedColumnType(seed.Column{Kind: seed.KindText}))
+}
@@ -0,0 +1,213 @@
+package envclone
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/bruin-data/bruin/pkg/config"
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/bruin-data/bruin/pkg/query"
+	"github.com/pkg/errors"
+)
+
+// Method describes how the data of a table is copied into the developer environment.
+type Method string
+
+const (
+	// MethodClone is a zero-copy clone, the cloned table shares the storage of the source until either is modified.
+	MethodClone Method = "clone"
+	// MethodShallowClone is a Delta shallow clone, the cloned table references the data files of the source.
+	MethodShallowClone Method = "shallow_clone"
+	// MethodCopy copies the rows with `CREATE TABLE AS`, for platforms that have no zero-copy clones.
+	MethodCopy Method = "copy"
+)
+
+// Result is the outcome of seeding a single asset.
+type Result struct {
+	Asset   string `json:"asset"`
+	Source  string `json:"source"`
+	Target  string `json:"target"`
+	Method  Method `json:"method,omitempty"`
+	Skipped string `json:"skipped,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+type queryRunner interface {
+	RunQueryWithoutResult(ctx context.Context, q *query.Query) error
+}
+
+// Cloner seeds the prefixed schemas of a developer environment with the tables built by the source
+// environment. The statements run on the connections of the target environment, since they write
+// into it, and the source tables are read through them as well.
+type Cloner struct {
+	source config.ConnectionAndDetailsGetter
+	target config.ConnectionAndDetailsGetter
+}
+
+func NewCloner(source, target config.ConnectionAndDetailsGetter) *Cloner {
+	return &Cloner{source: source, target: target}
+}
+
+// Clone copies the tables of the given assets from the schemas of the source environment into the
+// schemas of the target environment. A failure on one asset does not stop the others, every asset
+// gets a result.
+func (c *Cloner) Clone(ctx context.Context, p *pipeline.Pipeline, assets []*pipeline.Asset, sourcePrefix, targetPrefix string) []Result {
+	results := make([]Result, 0, len(assets))
+	for _, asset := range assets {
+		results = append(results, c.cloneAsset(ctx, p, asset, sourcePrefix, targetPrefix))
+	}
+
+	return results
+}
+
+func (c *Cloner) cloneAsset(ctx context.Context, p *pipeline.Pipeline, asset *pipeline.Asset, sourcePrefix, targetPrefix string) Result {
+	result := Result{
+		Asset:  asset.Name,
+		Source: pipeline.PrefixSchemaName(asset.Name, sourcePrefix),
+		Target: pipeline.PrefixSchemaName(asset.Name, targetPrefix),
+	}
+
+	if reason := skipReason(asset); reason != "" {
+		result.Skipped = reason
+		return result
+	}
+
+	connName, err := p.GetConnectionNameForAsset(asset)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	if err := c.checkSameLocation(connName); err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	method, statements, err := Statements(c.target.GetConnectionType(connName), result.Source, result.Target)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	result.Method = method
+
+	conn, ok := c.target.GetConnection(connName).(queryRunner)
+	if !ok {
+		result.Error = fmt.Sprintf("connection '%s' cannot run queries", connName)
+		return result
+	}
+
+	for _, statement := range statements {
+		if err := conn.RunQueryWithoutResult(ctx, &query.Query{Query: statement}); err != nil {
+			result.Error = errors.Wrapf(err, "failed to clone '%s' into '%s'", result.Source, result.Target).Error()
+			return result
+		}
+	}
+
+	return result
+}
+
+// checkSameLocation makes sure that the connection points to the same place in both environments.
+// The source tables are read with the target connection, their names would resolve to other
+// tables, or to none at all, if it pointed to another account, project or database.
+func (c *Cloner) checkSameLocation(connName string) error {
+	sourceType := c.source.GetConnectionType(connName)
+	targetType := c.target.GetConnectionType(connName)
+	if targetType == "" {
+		return errors.Errorf("connection '%s' does not exist in the target environment", connName)
+	}
+	if sourceType != targetType {
+		return errors.Errorf("connection '%s' is a '%s' connection in the source environment but a '%s' connection in the target environment", connName, sourceType, targetType)
+	}
+
+	sourceLocation := location(c.source.GetConnectionDetails(connName))
+	targetLocation := location(c.target.GetConnectionDetails(connName))
+	if !strings.EqualFold(sourceLocation, targetLocation) {
+		return errors.Errorf("connection '%s' points to '%s' in the source environment but to '%s' in the target environment, data can only be cloned within the same %s", connName, sourceLocation, targetLocation, locationKind(targetType))
+	}
+
+	return nil
+}
+
+// location returns where the tables of a connection live, e.g. the account and database of a
+// Snowflake connection.
+func location(details any) string {
+	switch d := details.(type) {
+	case *config.SnowflakeConnection:
+		return d.Account + "/" + d.Database
+	case *config.GoogleCloudPlatformConnection:
+		return d.ProjectID
+	case *config.DatabricksConnection:
+		return d.Host + "/" + d.Catalog
+	case *config.PostgresConnection:
+		return fmt.Sprintf("%s:%d/%s", d.Host, d.Port, d.Database)
+	case *config.RedshiftConnection:
+		return fmt.Sprintf("%s:%d/%s", d.Host, d.Port, d.Database)
+	default:
+		return ""
+	}
+}
+
+func locationKind(connectionType string) string {
+	switch connectionType {
+	case "snowflake":
+		return "account and database"
+	case "google_cloud_platform":
+		return "project"
+	case "databricks":
+		return "workspace and catalog"
+	default:
+		return "database"
+	}
+}
+
+func skipReason(asset *pipeline.Asset) string {
+	switch asset.Materialization.Type {
+	case pipeline.MaterializationTypeTable:
+	case pipeline.MaterializationTypeView:
+		return "views are not cloned, run the asset to create it"
+	case pipeline.MaterializationTypeNone:
+		// ingestr assets always load into a table, even without a materialization block.
+		if asset.Type != pipeline.AssetTypeIngestr {
+			return "the asset is not materialized"
+		}
+	}
+
+	if len(strings.Split(asset.Name, ".")) < 2 {
+		return "the asset name has no schema to prefix"
+	}
+
+	return ""
+}
+
+// Statements returns the statements that seed target with the data in source for the given
+// connection type.
+func Statements(connectionType, source, target string) (Method, []string, error) {
+	createSchema := "CREATE SCHEMA IF NOT EXISTS " + schemaOf(target)
+
+	switch connectionType {
+	case "snowflake", "google_cloud_platform":
+		return MethodClone, []string{
+			createSchema,
+			fmt.Sprintf("CREATE OR REPLACE TABLE %s CLONE %s", target, source),
+		}, nil
+	case "databricks":
+		return MethodShallowClone, []string{
+			createSchema,
+			fmt.Sprintf("CREATE OR REPLACE TABLE %s SHALLOW CLONE %s", target, source),
+		}, nil
+	case "postgres", "redshift":
+		return MethodCopy, []string{
+			createSchema,
+			"DROP TABLE IF EXISTS " + target,
+			fmt.Sprintf("CREATE TABLE %s AS SELECT * FROM %s", target, source),
+		}, nil
+	default:
+		return "", nil, errors.Errorf("cloning data is not supported for '%s' connections", connectionType)
+	}
+}
+
+func schemaOf(tableName string) string {
+	parts := strings.Split(tableName, ".")
+	return strings.Join(parts[:len(parts)-1], ".")
+}
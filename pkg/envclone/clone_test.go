@@ -0,0 +1,230 @@
+package envclone
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/bruin-data/bruin/pkg/config"
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/bruin-data/bruin/pkg/query"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingConnection struct {
+	queries []string
+	failOn  string
+}
+
+func (r *recordingConnection) RunQueryWithoutResult(_ context.Context, q *query.Query) error {
+	r.queries = append(r.queries, q.Query)
+	if r.failOn != "" && q.Query == r.failOn {
+		return errors.New("table does not exist")
+	}
+	return nil
+}
+
+type fakeConnections struct {
+	connections map[string]any
+	details     map[string]any
+	types       map[string]string
+}
+
+func (f *fakeConnections) GetConnection(name string) any {
+	return f.connections[name]
+}
+
+func (f *fakeConnections) GetConnectionDetails(name string) any {
+	return f.details[name]
+}
+
+func (f *fakeConnections) GetConnectionType(name string) string {
+	return f.types[name]
+}
+
+func TestStatements(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		connectionType string
+		wantMethod     Method
+		want           []string
+		wantErr        string
+	}{
+		{
+			name:           "snowflake uses a zero-copy clone",
+			connectionType: "snowflake",
+			wantMethod:     MethodClone,
+			want: []string{
+				"CREATE SCHEMA IF NOT EXISTS db.dev_analytics",
+				"CREATE OR REPLACE TABLE db.dev_analytics.users CLONE db.analytics.users",
+			},
+		},
+		{
+			name:           "databricks uses a shallow clone",
+			connectionType: "databricks",
+			wantMethod:     MethodShallowClone,
+			want: []string{
+				"CREATE SCHEMA IF NOT EXISTS db.dev_analytics",
+				"CREATE OR REPLACE TABLE db.dev_analytics.users SHALLOW CLONE db.analytics.users",
+			},
+		},
+		{
+			name:           "postgres copies the rows",
+			connectionType: "postgres",
+			wantMethod:     MethodCopy,
+			want: []string{
+				"CREATE SCHEMA IF NOT EXISTS db.dev_analytics",
+				"DROP TABLE IF EXISTS db.dev_analytics.users",
+				"CREATE TABLE db.dev_analytics.users AS SELECT * FROM db.analytics.users",
+			},
+		},
+		{
+			name:           "unsupported platform",
+			connectionType: "mysql",
+			wantErr:        "cloning data is not supported for 'mysql' connections",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			method, statements, err := Statements(tt.connectionType, "db.analytics.users", "db.dev_analytics.users")
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantMethod, method)
+			assert.Equal(t, tt.want, statements)
+		})
+	}
+}
+
+func TestCloner_Clone(t *testing.T) {
+	t.Parallel()
+
+	bq := &recordingConnection{failOn: "CREATE OR REPLACE TABLE dev_raw.events CLONE raw.events"}
+	connections := &fakeConnections{
+		connections: map[string]any{"gcp": bq},
+		types:       map[string]string{"gcp": "google_cloud_platform"},
+	}
+
+	p := &pipeline.Pipeline{
+		DefaultConnections: map[string]string{"google_cloud_platform": "gcp"},
+	}
+	assets := []*pipeline.Asset{
+		{
+			Name:            "analytics.users",
+			Type:            pipeline.AssetTypeBigqueryQuery,
+			Materialization: pipeline.Materialization{Type: pipeline.MaterializationTypeTable},
+		},
+		{
+			Name:            "analytics.active_users",
+			Type:            pipeline.AssetTypeBigqueryQuery,
+			Materialization: pipeline.Materialization{Type: pipeline.MaterializationTypeView},
+		},
+		{
+			Name:            "raw.events",
+			Type:            pipeline.AssetTypeBigqueryQuery,
+			Materialization: pipeline.Materialization{Type: pipeline.MaterializationTypeTable},
+		},
+	}
+
+	results := NewCloner(connections, connections).Clone(t.Context(), p, assets, "", "dev_")
+	require.Len(t, results, 3)
+
+	assert.Equal(t, Result{Asset: "analytics.users", Source: "analytics.users", Target: "dev_analytics.users", Method: MethodClone}, results[0])
+	assert.Equal(t, "views are not cloned, run the asset to create it", results[1].Skipped)
+	assert.Contains(t, results[2].Error, "failed to clone 'raw.events' into 'dev_raw.events'")
+
+	assert.Equal(t, []string{
+		"CREATE SCHEMA IF NOT EXISTS dev_analytics",
+		"CREATE OR REPLACE TABLE dev_analytics.users CLONE analytics.users",
+		"CREATE SCHEMA IF NOT EXISTS dev_raw",
+		"CREATE OR REPLACE TABLE dev_raw.events CLONE raw.events",
+	}, bq.queries)
+}
+
+func TestCloner_Clone_UsesTheTargetConnections(t *testing.T) {
+	t.Parallel()
+
+	p := &pipeline.Pipeline{
+		DefaultConnections: map[string]string{"snowflake": "sf"},
+	}
+	assets := []*pipeline.Asset{
+		{
+			Name:            "analytics.users",
+			Type:            pipeline.AssetTypeSnowflakeQuery,
+			Materialization: pipeline.Materialization{Type: pipeline.MaterializationTypeTable},
+		},
+	}
+	environment := func(conn *recordingConnection, connType string, details *config.SnowflakeConnection) *fakeConnections {
+		return &fakeConnections{
+			connections: map[string]any{"sf": conn},
+			details:     map[string]any{"sf": details},
+			types:       map[string]string{"sf": connType},
+		}
+	}
+
+	tests := []struct {
+		name          string
+		targetType    string
+		targetDetails *config.SnowflakeConnection
+		wantErr       string
+	}{
+		{
+			name:          "the statements run on the target environment",
+			targetType:    "snowflake",
+			targetDetails: &config.SnowflakeConnection{Account: "ACME", Database: "analytics", Role: "developer"},
+		},
+		{
+			name:          "connections to another database are rejected",
+			targetType:    "snowflake",
+			targetDetails: &config.SnowflakeConnection{Account: "acme", Database: "dev"},
+			wantErr:       "connection 'sf' points to 'acme/ANALYTICS' in the source environment but to 'acme/dev' in the target environment, data can only be cloned within the same account and database",
+		},
+		{
+			name:       "connections missing from the target environment are rejected",
+			targetType: "",
+			wantErr:    "connection 'sf' does not exist in the target environment",
+		},
+		{
+			name:          "connections of another type are rejected",
+			targetType:    "postgres",
+			targetDetails: &config.SnowflakeConnection{Account: "acme", Database: "ANALYTICS"},
+			wantErr:       "connection 'sf' is a 'snowflake' connection in the source environment but a 'postgres' connection in the target environment",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			source := &recordingConnection{}
+			target := &recordingConnection{}
+			results := NewCloner(
+				environment(source, "snowflake", &config.SnowflakeConnection{Account: "acme", Database: "ANALYTICS", Role: "admin"}),
+				environment(target, tt.targetType, tt.targetDetails),
+			).Clone(t.Context(), p, assets, "", "dev_")
+			require.Len(t, results, 1)
+			assert.Empty(t, source.queries)
+
+			if tt.wantErr != "" {
+				assert.Equal(t, tt.wantErr, results[0].Error)
+				assert.Empty(t, target.queries)
+				return
+			}
+
+			assert.Empty(t, results[0].Error)
+			assert.Equal(t, []string{
+				"CREATE SCHEMA IF NOT EXISTS dev_analytics",
+				"CREATE OR REPLACE TABLE dev_analytics.users CLONE analytics.users",
+			}, target.queries)
+		})
+	}
+}
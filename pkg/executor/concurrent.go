@@ -242,8 +242,10 @@ func (w worker) run(ctx context.Context, taskChannel <-chan scheduler.TaskInstan
 		}
 
 		results <- &scheduler.TaskExecutionResult{
-			Instance: task,
-			Error:    err,
+			Instance:  task,
+			Error:     err,
+			StartedAt: start,
+			Duration:  duration,
 		}
 	}
 }
@@ -26,9 +26,10 @@ type Domain struct {
 }
 
 type Attribute struct {
-	Name        string `json:"name" yaml:"name"`
-	Description string `json:"description" yaml:"description"`
-	Type        string `json:"type" yaml:"type"`
+	Name        string   `json:"name" yaml:"name"`
+	Description string   `json:"description" yaml:"description"`
+	Type        string   `json:"type" yaml:"type"`
+	Tags        []string `json:"tags,omitempty" yaml:"tags,omitempty"`
 }
 
 type Entity struct {
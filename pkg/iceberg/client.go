@@ -1,7 +1,7 @@
 package iceberg
 
-// Client is a thin wrapper around Config. Iceberg is only used as an ingestr
-// destination in Bruin, so the client just exposes the ingestr URI.
+// Client is a thin wrapper around Config. Iceberg is an ingestr destination,
+// and `iceberg.sql` assets run on DuckDB through DuckDBConfig.
 type Client struct {
 	config Config
 }
@@ -13,3 +13,8 @@ func NewClient(c Config) (*Client, error) {
 func (c *Client) GetIngestrURI() (string, error) {
 	return c.config.GetIngestrURI()
 }
+
+// DuckDBConfig returns the configuration of the DuckDB database that runs `iceberg.sql` assets.
+func (c *Client) DuckDBConfig() DuckDBConfig {
+	return DuckDBConfig{Config: c.config}
+}
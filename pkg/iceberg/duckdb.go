@@ -0,0 +1,194 @@
+package iceberg
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/bruin-data/bruin/pkg/config"
+)
+
+// DuckDBAlias is the name the Iceberg catalog is attached under when `iceberg.sql` assets run on DuckDB.
+const DuckDBAlias = "iceberg_catalog"
+
+// DuckDBConfig runs queries on an in-memory DuckDB database that attaches the Iceberg catalog with
+// DuckDB's iceberg extension. It satisfies the DuckDB client configuration.
+type DuckDBConfig struct {
+	Config Config
+}
+
+func (c DuckDBConfig) ToDBConnectionURI() string {
+	return ":memory:"
+}
+
+func (c DuckDBConfig) GetIngestrURI() string {
+	uri, _ := c.Config.GetIngestrURI()
+	return uri
+}
+
+// SetupStatements are run on every new DuckDB connection before the queries.
+func (c DuckDBConfig) SetupStatements() ([]string, error) {
+	return c.Config.DuckDBStatements(DuckDBAlias)
+}
+
+// DuckDBStatements returns the statements that attach the catalog to DuckDB under the given alias and
+// make it the default catalog, so that `namespace.table` names resolve to Iceberg tables.
+//
+// DuckDB's iceberg extension only attaches REST catalogs, which includes Glue through its REST
+// endpoint; the other catalog types remain ingestr-only.
+func (c Config) DuckDBStatements(alias string) ([]string, error) {
+	var attach string
+	extensions := []string{"iceberg", "httpfs"}
+	var secrets []string
+
+	switch c.Catalog.Type {
+	case config.IcebergCatalogREST:
+		if c.Catalog.Host == "" {
+			return nil, fmt.Errorf("iceberg: rest catalog requires %q", "host")
+		}
+
+		scheme := "http"
+		if c.Catalog.RestUseSSL != nil && *c.Catalog.RestUseSSL {
+			scheme = "https"
+		}
+		options := []string{"TYPE iceberg", "ENDPOINT " + quoteLiteral(scheme+"://"+hostPort(c.Catalog.Host, c.Catalog.Port))}
+
+		catalogSecret := duckDBSecretName(alias, "catalog")
+		switch {
+		case c.Catalog.Token != "":
+			secrets = append(secrets, createSecret(catalogSecret, "iceberg", [][2]string{{"TOKEN", quoteLiteral(c.Catalog.Token)}}))
+			options = append(options, "SECRET "+catalogSecret)
+		case c.Catalog.Credential != "":
+			clientID, clientSecret, _ := strings.Cut(c.Catalog.Credential, ":")
+			secrets = append(secrets, createSecret(catalogSecret, "iceberg", [][2]string{
+				{"CLIENT_ID", quoteLiteral(clientID)},
+				{"CLIENT_SECRET", quoteLiteral(clientSecret)},
+				{"OAUTH2_SERVER_URI", quoteLiteral(scheme + "://" + hostPort(c.Catalog.Host, c.Catalog.Port) + "/v1/oauth/tokens")},
+			}))
+			options = append(options, "SECRET "+catalogSecret)
+		default:
+			options = append(options, "AUTHORIZATION_TYPE 'none'")
+		}
+
+		attach = fmt.Sprintf("ATTACH %s AS %s (%s)", quoteLiteral(c.warehouse()), alias, strings.Join(options, ", "))
+	case config.IcebergCatalogGlue:
+		if c.Catalog.CatalogID == "" {
+			return nil, fmt.Errorf("iceberg: glue catalog requires %q for iceberg.sql assets", "catalog_id")
+		}
+
+		extensions = append(extensions, "aws")
+		if secret := s3Secret(duckDBSecretName(alias, "glue"), c.Catalog.Region, "", nil, c.Catalog.Auth); secret != "" {
+			secrets = append(secrets, secret)
+		}
+		attach = fmt.Sprintf("ATTACH %s AS %s (TYPE iceberg, ENDPOINT_TYPE 'glue')", quoteLiteral(c.Catalog.CatalogID), alias)
+	case config.IcebergCatalogSQLite, config.IcebergCatalogPostgres, config.IcebergCatalogSQL:
+		return nil, fmt.Errorf(
+			"iceberg: %s catalogs are not supported for iceberg.sql assets, DuckDB can only attach %s and %s catalogs; serve the catalog through a REST catalog and set catalog.type to %q",
+			c.Catalog.Type, config.IcebergCatalogREST, config.IcebergCatalogGlue, config.IcebergCatalogREST,
+		)
+	case "":
+		return nil, fmt.Errorf("iceberg: catalog.type must be provided (supported for iceberg.sql: %s, %s)", config.IcebergCatalogREST, config.IcebergCatalogGlue)
+	default:
+		return nil, fmt.Errorf("iceberg: catalog type %q is not supported for iceberg.sql assets, DuckDB can only attach %s and %s catalogs", c.Catalog.Type, config.IcebergCatalogREST, config.IcebergCatalogGlue)
+	}
+
+	switch c.Storage.Type {
+	case config.IcebergStorageGCS:
+		secrets = append(secrets, createSecret(duckDBSecretName(alias, "storage"), "gcs", [][2]string{{"PROVIDER", "credential_chain"}}))
+	case config.IcebergStorageLocal:
+	default:
+		if secret := s3Secret(duckDBSecretName(alias, "storage"), c.Storage.Region, c.Storage.Endpoint, c.Storage.UseSSL, c.Storage.Auth); secret != "" {
+			extensions = append(extensions, "aws")
+			secrets = append(secrets, secret)
+		}
+	}
+
+	statements := make([]string, 0, len(extensions)*2+len(secrets)+3)
+	seen := make(map[string]bool)
+	for _, extension := range extensions {
+		if seen[extension] {
+			continue
+		}
+		seen[extension] = true
+		statements = append(statements, "INSTALL "+extension, "LOAD "+extension)
+	}
+	statements = append(statements, secrets...)
+	statements = append(statements,
+		attach,
+		// USE needs a schema to switch to, the same way the DuckDB lakehouse attachments do.
+		"CREATE SCHEMA IF NOT EXISTS "+alias+".main",
+		"USE "+alias,
+	)
+
+	return statements, nil
+}
+
+// warehouse is the warehouse name given to a REST catalog.
+func (c Config) warehouse() string {
+	if w, ok := c.Properties["warehouse"]; ok {
+		return w
+	}
+	if c.Storage.Path != "" {
+		return c.Storage.Path
+	}
+	if c.Storage.Bucket != "" {
+		scheme := "s3://"
+		if c.Storage.Type == config.IcebergStorageGCS {
+			scheme = "gs://"
+		}
+		return scheme + strings.TrimSuffix(c.Storage.Bucket+"/"+c.Storage.Prefix, "/")
+	}
+
+	return ""
+}
+
+func s3Secret(name, region, endpoint string, useSSL *bool, auth config.IcebergAuth) string {
+	if !hasAWSCredentials(auth) {
+		return ""
+	}
+
+	options := [][2]string{
+		{"KEY_ID", quoteLiteral(auth.AccessKey)},
+		{"SECRET", quoteLiteral(auth.SecretKey)},
+	}
+	if auth.SessionToken != "" {
+		options = append(options, [2]string{"SESSION_TOKEN", quoteLiteral(auth.SessionToken)})
+	}
+	if region != "" {
+		options = append(options, [2]string{"REGION", quoteLiteral(region)})
+	}
+	if endpoint != "" {
+		// DuckDB takes the endpoint without the scheme, S3-compatible services use path-style URLs.
+		host := endpoint
+		if idx := strings.Index(host, "://"); idx >= 0 {
+			if useSSL == nil {
+				ssl := host[:idx] == "https"
+				useSSL = &ssl
+			}
+			host = host[idx+3:]
+		}
+		options = append(options, [2]string{"ENDPOINT", quoteLiteral(host)}, [2]string{"URL_STYLE", "'path'"})
+	}
+	if useSSL != nil {
+		options = append(options, [2]string{"USE_SSL", strconv.FormatBool(*useSSL)})
+	}
+
+	return createSecret(name, "s3", options)
+}
+
+func createSecret(name, secretType string, options [][2]string) string {
+	parts := []string{"TYPE " + secretType}
+	for _, option := range options {
+		parts = append(parts, option[0]+" "+option[1])
+	}
+
+	return fmt.Sprintf("CREATE OR REPLACE SECRET %s (%s)", name, strings.Join(parts, ", "))
+}
+
+func duckDBSecretName(alias, kind string) string {
+	return "bruin_" + alias + "_" + kind
+}
+
+func quoteLiteral(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
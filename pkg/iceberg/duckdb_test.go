@@ -0,0 +1,117 @@
+package iceberg
+
+import (
+	"testing"
+
+	"github.com/bruin-data/bruin/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfig_DuckDBStatements(t *testing.T) {
+	t.Parallel()
+
+	useSSL := true
+	tests := []struct {
+		name    string
+		config  Config
+		want    []string
+		wantErr string
+	}{
+		{
+			name: "rest catalog without auth on local storage",
+			config: Config{
+				Catalog: config.IcebergCatalog{Type: config.IcebergCatalogREST, Host: "localhost", Port: 8181},
+				Storage: config.IcebergStorage{Type: config.IcebergStorageLocal, Path: "/tmp/warehouse"},
+			},
+			want: []string{
+				"INSTALL iceberg",
+				"LOAD iceberg",
+				"INSTALL httpfs",
+				"LOAD httpfs",
+				"ATTACH '/tmp/warehouse' AS iceberg_catalog (TYPE iceberg, ENDPOINT 'http://localhost:8181', AUTHORIZATION_TYPE 'none')",
+				"CREATE SCHEMA IF NOT EXISTS iceberg_catalog.main",
+				"USE iceberg_catalog",
+			},
+		},
+		{
+			name: "rest catalog with oauth credentials and minio storage",
+			config: Config{
+				Catalog: config.IcebergCatalog{Type: config.IcebergCatalogREST, Host: "polaris.example.com", RestUseSSL: &useSSL, Credential: "client:s3cr'et"},
+				Storage: config.IcebergStorage{
+					Type:     config.IcebergStorageS3,
+					Bucket:   "lake",
+					Prefix:   "wh",
+					Endpoint: "http://minio:9000",
+					Auth:     config.IcebergAuth{AccessKey: "AK", SecretKey: "SK"},
+				},
+			},
+			want: []string{
+				"INSTALL iceberg",
+				"LOAD iceberg",
+				"INSTALL httpfs",
+				"LOAD httpfs",
+				"INSTALL aws",
+				"LOAD aws",
+				"CREATE OR REPLACE SECRET bruin_iceberg_catalog_catalog (TYPE iceberg, CLIENT_ID 'client', CLIENT_SECRET 's3cr''et', OAUTH2_SERVER_URI 'https://polaris.example.com/v1/oauth/tokens')",
+				"CREATE OR REPLACE SECRET bruin_iceberg_catalog_storage (TYPE s3, KEY_ID 'AK', SECRET 'SK', ENDPOINT 'minio:9000', URL_STYLE 'path', USE_SSL false)",
+				"ATTACH 's3://lake/wh' AS iceberg_catalog (TYPE iceberg, ENDPOINT 'https://polaris.example.com', SECRET bruin_iceberg_catalog_catalog)",
+				"CREATE SCHEMA IF NOT EXISTS iceberg_catalog.main",
+				"USE iceberg_catalog",
+			},
+		},
+		{
+			name: "glue catalog",
+			config: Config{
+				Catalog: config.IcebergCatalog{
+					Type:      config.IcebergCatalogGlue,
+					CatalogID: "123456789012",
+					Region:    testAWSRegion,
+					Auth:      config.IcebergAuth{AccessKey: "AKID", SecretKey: "SECRET"},
+				},
+			},
+			want: []string{
+				"INSTALL iceberg",
+				"LOAD iceberg",
+				"INSTALL httpfs",
+				"LOAD httpfs",
+				"INSTALL aws",
+				"LOAD aws",
+				"CREATE OR REPLACE SECRET bruin_iceberg_catalog_glue (TYPE s3, KEY_ID 'AKID', SECRET 'SECRET', REGION 'us-east-1')",
+				"ATTACH '123456789012' AS iceberg_catalog (TYPE iceberg, ENDPOINT_TYPE 'glue')",
+				"CREATE SCHEMA IF NOT EXISTS iceberg_catalog.main",
+				"USE iceberg_catalog",
+			},
+		},
+		{
+			name:    "glue catalog without a catalog id",
+			config:  Config{Catalog: config.IcebergCatalog{Type: config.IcebergCatalogGlue}},
+			wantErr: `iceberg: glue catalog requires "catalog_id" for iceberg.sql assets`,
+		},
+		{
+			name:    "sqlite catalogs cannot be attached",
+			config:  Config{Catalog: config.IcebergCatalog{Type: config.IcebergCatalogSQLite, Path: testSQLitePath}},
+			wantErr: `iceberg: sqlite catalogs are not supported for iceberg.sql assets, DuckDB can only attach rest and glue catalogs; serve the catalog through a REST catalog and set catalog.type to "rest"`,
+		},
+		{
+			name:    "hive catalogs cannot be attached",
+			config:  Config{Catalog: config.IcebergCatalog{Type: config.IcebergCatalogHive, Host: "metastore"}},
+			wantErr: `iceberg: catalog type "hive" is not supported for iceberg.sql assets, DuckDB can only attach rest and glue catalogs`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := tt.config.DuckDBStatements(DuckDBAlias)
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -749,6 +749,11 @@ func (o *SeedOperator) Run(ctx context.Context, ti scheduler.TaskInstance) error
 
 	destTable := o.resolveSeedDestinationTableName(destConnectionName, destURI, asset.Name)
 
+	loaded, err := o.loadSeedNatively(ctx, asset, destConnectionName, destTable, sourceURI, seedFileType)
+	if err != nil || loaded {
+		return err
+	}
+
 	extraPackages = python.AddExtraPackages(destURI, sourceURI, extraPackages)
 
 	baseArgs := []string{
@@ -0,0 +1,170 @@
+package ingestr
+
+import (
+	"context"
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/bruin-data/bruin/pkg/python"
+	"github.com/bruin-data/bruin/pkg/seed"
+	"github.com/pkg/errors"
+)
+
+// loadSeedNatively loads CSV seeds into the destinations that implement seed.Loader
+// without starting ingestr. It returns false when the seed has to go through ingestr:
+// the destination or the file type is not supported, the asset uses a feature only
+// ingestr implements, or `loader: ingestr` is set.
+func (o *SeedOperator) loadSeedNatively(ctx context.Context, asset *pipeline.Asset, destConnectionName, destTable, sourceURI, fileType string) (bool, error) {
+	if loader, ok := asset.Parameters.GetString("loader"); ok && strings.EqualFold(strings.TrimSpace(loader), "ingestr") {
+		return false, nil
+	}
+
+	destConn := o.conn.GetConnection(destConnectionName)
+	loader, ok := destConn.(seed.Loader)
+	if !ok {
+		return false, nil
+	}
+
+	path, remote, ok := csvSeedPath(sourceURI, fileType)
+	if !ok {
+		return false, nil
+	}
+
+	declared, ok := nativeSeedColumns(asset, python.TypeHintOverlayForConnection(destConn), python.TypeWrappersForConnection(destConn))
+	if !ok {
+		return false, nil
+	}
+
+	if remote {
+		downloaded, cleanup, err := seed.Download(ctx, path)
+		if err != nil {
+			return false, err
+		}
+		defer cleanup()
+		path = downloaded
+	}
+
+	table, err := seed.Describe(path, destTable, declared, python.NormalizeColumnName)
+	if err != nil {
+		return false, err
+	}
+
+	rows, err := seed.Open(path, table)
+	if err != nil {
+		return false, err
+	}
+	defer rows.Close()
+
+	err = loader.LoadSeed(ctx, table, rows)
+	if errors.Is(err, seed.ErrUnsupported) {
+		return false, nil
+	}
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to load seed into '%s'", destTable)
+	}
+
+	return true, nil
+}
+
+// csvSeedPath returns the local path or the URL of a CSV seed, ok is false for the
+// other file types.
+func csvSeedPath(sourceURI, fileType string) (path string, remote bool, ok bool) {
+	if after, found := strings.CutPrefix(sourceURI, "csv://"); found {
+		return after, false, true
+	}
+
+	lower := strings.ToLower(sourceURI)
+	if !strings.HasPrefix(lower, "http://") && !strings.HasPrefix(lower, "https://") {
+		return "", false, false
+	}
+
+	ft := strings.ToLower(strings.TrimSpace(fileType))
+	if ft == "" {
+		parsed, err := url.Parse(sourceURI)
+		if err != nil {
+			return "", false, false
+		}
+		ft = strings.TrimPrefix(strings.ToLower(filepath.Ext(parsed.Path)), ".")
+		if _, known := seedFileSchemes[ft]; !known {
+			ft = "csv"
+		}
+	}
+
+	return sourceURI, true, ft == "csv"
+}
+
+// nativeSeedColumns converts the declared columns to seed columns keyed by their
+// normalized name. Declared types go through the same ingestr type hints the ingestr
+// loader uses, ok is false when a column needs a type or a feature only ingestr supports.
+func nativeSeedColumns(asset *pipeline.Asset, overlay map[string]string, wrappers map[string]bool) (map[string]seed.Column, bool) {
+	if mask, ok := asset.Parameters.GetString("mask"); ok && strings.TrimSpace(mask) != "" {
+		return nil, false
+	}
+
+	enforce := true
+	if value, ok := asset.Parameters.GetString("enforce_schema"); ok {
+		enforce = value == "true"
+	}
+
+	declared := make(map[string]seed.Column, len(asset.Columns))
+	for _, col := range asset.Columns {
+		if col.SourceColumn != "" || strings.TrimSpace(col.Mask) != "" {
+			return nil, false
+		}
+
+		c := seed.Column{
+			NotNull:    col.Nullable.Value != nil && !*col.Nullable.Value,
+			PrimaryKey: col.PrimaryKey,
+		}
+
+		if enforce {
+			if hint, known := python.ColumnTypeHint(col, overlay, wrappers); known {
+				if !applySeedTypeHint(&c, hint) {
+					return nil, false
+				}
+			}
+		}
+
+		declared[python.NormalizeColumnName(col.Name)] = c
+	}
+
+	return declared, true
+}
+
+// applySeedTypeHint sets the kind of the column from an ingestr type hint, it returns
+// false for the hints the native loader has no kind for, e.g. binary or json.
+func applySeedTypeHint(col *seed.Column, hint string) bool {
+	base, params, _ := strings.Cut(strings.TrimSuffix(hint, ")"), "(")
+
+	switch base {
+	case "tinyint", "smallint", "int", "bigint":
+		col.Kind = seed.KindInteger
+	case "double":
+		col.Kind = seed.KindFloat
+	case "decimal":
+		col.Kind = seed.KindDecimal
+		if params != "" {
+			precision, scale, _ := strings.Cut(params, ",")
+			col.Precision, _ = strconv.Atoi(precision)
+			col.Scale, _ = strconv.Atoi(scale)
+		}
+	case "bool":
+		col.Kind = seed.KindBoolean
+	case "date":
+		col.Kind = seed.KindDate
+	case "timestamp":
+		col.Kind = seed.KindTimestamp
+		col.TimeZone = true
+	case "timestamp_ntz":
+		col.Kind = seed.KindTimestamp
+	case "text":
+		col.Kind = seed.KindText
+	default:
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,204 @@
+package ingestr
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/bruin-data/bruin/pkg/scheduler"
+	"github.com/bruin-data/bruin/pkg/seed"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type seedLoaderConnection struct {
+	err   error
+	table *seed.Table
+	rows  [][]any
+}
+
+func (c *seedLoaderConnection) GetIngestrURI() (string, error) {
+	return "duckdb:////some/path", nil
+}
+
+func (c *seedLoaderConnection) LoadSeed(_ context.Context, table *seed.Table, rows *seed.Rows) error {
+	if c.err != nil {
+		return c.err
+	}
+
+	c.table = table
+	for rows.Next() {
+		values, _ := rows.Values()
+		c.rows = append(c.rows, values)
+	}
+	return rows.Err()
+}
+
+type seedLoaderFetcher map[string]any
+
+func (f seedLoaderFetcher) GetConnection(name string) any {
+	return f[name]
+}
+
+func TestSeedOperator_LoadsSeedsNatively(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "seed.csv"), []byte("Country Code,Population\nTR,85\nDE,\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "seed.parquet"), []byte("PAR1"), 0o600))
+
+	nullable := false
+	tests := []struct {
+		name       string
+		loaderErr  error
+		asset      *pipeline.Asset
+		wantNative bool
+		wantTable  *seed.Table
+		wantRows   [][]any
+	}{
+		{
+			name: "csv seeds are loaded with the declared types",
+			asset: &pipeline.Asset{
+				Name:       "seeds.countries",
+				Connection: "duck",
+				Parameters: pipeline.ParameterMap{"path": "seed.csv"},
+				Columns: []pipeline.Column{
+					{Name: "Country Code", Type: "varchar", Nullable: pipeline.DefaultTrueBool{Value: &nullable}},
+					{Name: "population", Type: "decimal(10,2)"},
+				},
+			},
+			wantNative: true,
+			wantTable: &seed.Table{Name: "seeds.countries", Columns: []seed.Column{
+				{Name: "country_code", Kind: seed.KindText, NotNull: true},
+				{Name: "population", Kind: seed.KindDecimal, Precision: 10, Scale: 2},
+			}},
+			wantRows: [][]any{{"TR", "85"}, {"DE", nil}},
+		},
+		{
+			name: "types are inferred when the schema is not enforced",
+			asset: &pipeline.Asset{
+				Name:       "countries",
+				Connection: "duck",
+				Parameters: pipeline.ParameterMap{"path": "seed.csv", "enforce_schema": "false"},
+				Columns:    []pipeline.Column{{Name: "population", Type: "varchar"}},
+			},
+			wantNative: true,
+			wantTable: &seed.Table{Name: "countries", Columns: []seed.Column{
+				{Name: "country_code", Kind: seed.KindText},
+				{Name: "population", Kind: seed.KindInteger},
+			}},
+			wantRows: [][]any{{"TR", int64(85)}, {"DE", nil}},
+		},
+		{
+			name: "non csv files go through ingestr",
+			asset: &pipeline.Asset{
+				Name:       "countries",
+				Connection: "duck",
+				Parameters: pipeline.ParameterMap{"path": "seed.parquet"},
+			},
+		},
+		{
+			name: "types without a native kind go through ingestr",
+			asset: &pipeline.Asset{
+				Name:       "countries",
+				Connection: "duck",
+				Parameters: pipeline.ParameterMap{"path": "seed.csv"},
+				Columns:    []pipeline.Column{{Name: "population", Type: "json"}},
+			},
+		},
+		{
+			name: "renamed columns go through ingestr",
+			asset: &pipeline.Asset{
+				Name:       "countries",
+				Connection: "duck",
+				Parameters: pipeline.ParameterMap{"path": "seed.csv"},
+				Columns:    []pipeline.Column{{Name: "code", SourceColumn: "Country Code"}},
+			},
+		},
+		{
+			name: "the loader parameter forces ingestr",
+			asset: &pipeline.Asset{
+				Name:       "countries",
+				Connection: "duck",
+				Parameters: pipeline.ParameterMap{"path": "seed.csv", "loader": "ingestr"},
+			},
+		},
+		{
+			name:      "unsupported destinations go through ingestr",
+			loaderErr: seed.ErrUnsupported,
+			asset: &pipeline.Asset{
+				Name:       "countries",
+				Connection: "duck",
+				Parameters: pipeline.ParameterMap{"path": "seed.csv"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tt.asset.Type = pipeline.AssetTypeDuckDBSeed
+			tt.asset.ExecutableFile.Path = filepath.Join(dir, "seed.asset.yml")
+
+			conn := &seedLoaderConnection{err: tt.loaderErr}
+			runner := new(mockRunner)
+			runner.On("RunIngestr", mock.Anything, mock.Anything, mock.Anything, repo).Return(nil)
+
+			o := &SeedOperator{
+				conn:   seedLoaderFetcher{"duck": conn},
+				finder: &mockFinder{},
+				runner: runner,
+			}
+
+			err := o.Run(t.Context(), &scheduler.AssetInstance{Pipeline: &pipeline.Pipeline{}, Asset: tt.asset})
+			require.NoError(t, err)
+
+			if !tt.wantNative {
+				runner.AssertNumberOfCalls(t, "RunIngestr", 1)
+				return
+			}
+
+			runner.AssertNotCalled(t, "RunIngestr", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			assert.Equal(t, tt.wantTable, conn.table)
+			assert.Equal(t, tt.wantRows, conn.rows)
+		})
+	}
+}
+
+func TestCSVSeedPath(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		sourceURI  string
+		fileType   string
+		wantPath   string
+		wantRemote bool
+		wantOK     bool
+	}{
+		{name: "local csv", sourceURI: "csv:///repo/seed.csv", wantPath: "/repo/seed.csv", wantOK: true},
+		{name: "local parquet", sourceURI: "parquet:///repo/seed.parquet"},
+		{name: "url with csv extension", sourceURI: "https://example.com/seed.csv?raw=1", wantPath: "https://example.com/seed.csv?raw=1", wantRemote: true, wantOK: true},
+		{name: "url without extension", sourceURI: "https://example.com/export", wantPath: "https://example.com/export", wantRemote: true, wantOK: true},
+		{name: "url with parquet extension", sourceURI: "https://example.com/seed.parquet", wantPath: "https://example.com/seed.parquet", wantRemote: true},
+		{name: "file type wins over the extension", sourceURI: "https://example.com/seed.csv", fileType: "json", wantPath: "https://example.com/seed.csv", wantRemote: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			path, remote, ok := csvSeedPath(tt.sourceURI, tt.fileType)
+			assert.Equal(t, tt.wantOK, ok)
+			if !ok {
+				return
+			}
+			assert.Equal(t, tt.wantPath, path)
+			assert.Equal(t, tt.wantRemote, remote)
+		})
+	}
+}
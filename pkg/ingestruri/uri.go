@@ -57,15 +57,9 @@ func ForConnection(ctx context.Context, conn config.ConnectionGetter, role, name
 	return uri, nil
 }
 
-// resolve prefers ConnectionResolver so that a secrets backend can report why a
-// lookup failed. Plain ConnectionGetter implementations collapse every failure
-// into a nil connection.
+// resolve lets a secrets backend report why a lookup failed.
 func resolve(conn config.ConnectionGetter, name string) (any, error) {
-	if resolver, ok := conn.(config.ConnectionResolver); ok {
-		return resolver.ResolveConnection(name)
-	}
-
-	return conn.GetConnection(name), nil
+	return config.ResolveConnection(conn, name)
 }
 
 // Normalize ensures the URI carries the "//" authority separator after its
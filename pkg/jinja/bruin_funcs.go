@@ -36,6 +36,7 @@ const (
 	PlatformFabric     Platform = "fabric"
 	PlatformVertica    Platform = "vertica"
 	PlatformStarRocks  Platform = "starrocks"
+	PlatformSQLite     Platform = "sqlite"
 )
 
 // ---------------------------------------------------------------------------
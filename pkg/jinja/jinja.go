@@ -384,6 +384,7 @@ var assetTypePlatform = map[pipeline.AssetType]Platform{
 	pipeline.AssetTypeDuckDBQuerySensor: PlatformDuckDB,
 	pipeline.AssetTypeDuckDBSource:      PlatformDuckDB,
 	pipeline.AssetTypeMotherduckQuery:   PlatformDuckDB,
+	pipeline.AssetTypeIcebergQuery:      PlatformDuckDB,
 
 	pipeline.AssetTypeDatabricksQuery:       PlatformDatabricks,
 	pipeline.AssetTypeDatabricksSeed:        PlatformDatabricks,
@@ -441,6 +442,9 @@ var assetTypePlatform = map[pipeline.AssetType]Platform{
 	pipeline.AssetTypeVerticaQuerySensor: PlatformVertica,
 	pipeline.AssetTypeVerticaTableSensor: PlatformVertica,
 	pipeline.AssetTypeVerticaSource:      PlatformVertica,
+	pipeline.AssetTypeSQLiteQuery:        PlatformSQLite,
+	pipeline.AssetTypeSQLiteSeed:         PlatformSQLite,
+	pipeline.AssetTypeSQLiteQuerySensor:  PlatformSQLite,
 }
 
 // PlatformForAssetType resolves the SQL generation platform for the given asset type.
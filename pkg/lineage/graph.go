@@ -0,0 +1,344 @@
+package lineage
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/bruin-data/bruin/pkg/pipeline"
+)
+
+type GraphNodeKind string
+
+const (
+	GraphNodeAsset    GraphNodeKind = "asset"
+	GraphNodeColumn   GraphNodeKind = "column"
+	GraphNodeExternal GraphNodeKind = "external"
+)
+
+type GraphEdgeKind string
+
+const (
+	GraphEdgeAsset  GraphEdgeKind = "asset"
+	GraphEdgeColumn GraphEdgeKind = "column"
+	GraphEdgeURI    GraphEdgeKind = "uri"
+)
+
+// GraphNode is an asset, a column of an asset, or a URI dependency outside the pipeline. External
+// nodes that belong to an asset of another pipeline carry the name of that pipeline.
+type GraphNode struct {
+	ID       string             `json:"id"`
+	Kind     GraphNodeKind      `json:"kind"`
+	Label    string             `json:"label"`
+	Type     pipeline.AssetType `json:"type,omitempty"`
+	Platform string             `json:"platform,omitempty"`
+	Tier     int                `json:"tier,omitempty"`
+	Parent   string             `json:"parent,omitempty"`
+	Pipeline string             `json:"pipeline,omitempty"`
+	URI      string             `json:"uri,omitempty"`
+}
+
+// GraphEdge points from the upstream node to the downstream one.
+type GraphEdge struct {
+	From string        `json:"from"`
+	To   string        `json:"to"`
+	Kind GraphEdgeKind `json:"kind"`
+}
+
+// Graph is the lineage of a pipeline as a list of nodes and edges, which can be rendered in the
+// formats external tools understand.
+type Graph struct {
+	Pipeline string       `json:"pipeline"`
+	Nodes    []*GraphNode `json:"nodes"`
+	Edges    []*GraphEdge `json:"edges"`
+
+	nodes map[string]*GraphNode
+	edges map[GraphEdge]bool
+}
+
+// GraphOptions controls what goes into the lineage graph.
+type GraphOptions struct {
+	// Assets limits the graph to the given assets, the whole pipeline is used when it is empty.
+	Assets []*pipeline.Asset
+	// Columns adds the columns of the assets and the edges between them, which requires the
+	// column-level upstreams to be extracted beforehand.
+	Columns bool
+	// URIs adds the URI dependencies of the assets as external nodes, they are resolved to the
+	// assets of OtherPipelines that declare the same URI, and the assets of OtherPipelines that
+	// depend on the URIs of the assets are added as downstream nodes.
+	URIs           bool
+	OtherPipelines []*pipeline.Pipeline
+}
+
+// BuildGraph builds the lineage graph of the pipeline.
+func BuildGraph(p *pipeline.Pipeline, opts GraphOptions) *Graph {
+	g := &Graph{
+		Pipeline: p.Name,
+		Nodes:    make([]*GraphNode, 0),
+		Edges:    make([]*GraphEdge, 0),
+		nodes:    make(map[string]*GraphNode),
+		edges:    make(map[GraphEdge]bool),
+	}
+
+	assets := opts.Assets
+	if len(assets) == 0 {
+		assets = p.Assets
+	}
+	included := make(map[string]*pipeline.Asset, len(assets))
+	for _, asset := range assets {
+		included[strings.ToLower(asset.Name)] = asset
+		g.addNode(&GraphNode{
+			ID:       asset.Name,
+			Kind:     GraphNodeAsset,
+			Label:    asset.Name,
+			Type:     asset.Type,
+			Platform: Platform(asset.Type),
+			Tier:     asset.Tier,
+		})
+	}
+
+	for _, asset := range assets {
+		for _, upstream := range asset.Upstreams {
+			if upstream.Type != "" && upstream.Type != "asset" {
+				continue
+			}
+			if parent, ok := included[strings.ToLower(upstream.Value)]; ok {
+				g.addEdge(parent.Name, asset.Name, GraphEdgeAsset)
+			}
+		}
+	}
+
+	if opts.Columns {
+		for _, asset := range assets {
+			for _, column := range asset.Columns {
+				for _, upstream := range column.Upstreams {
+					if upstream == nil {
+						continue
+					}
+					parent, ok := included[strings.ToLower(upstream.Table)]
+					if !ok {
+						continue
+					}
+					parentColumn := upstream.Column
+					if c := parent.GetColumnWithName(upstream.Column); c != nil {
+						parentColumn = c.Name
+					}
+					from := g.addColumnNode(parent, parentColumn)
+					to := g.addColumnNode(asset, column.Name)
+					g.addEdge(from, to, GraphEdgeColumn)
+				}
+			}
+		}
+	}
+
+	if opts.URIs {
+		g.addURIEdges(p, assets, included, opts.OtherPipelines)
+	}
+
+	return g
+}
+
+func (g *Graph) addURIEdges(p *pipeline.Pipeline, assets []*pipeline.Asset, included map[string]*pipeline.Asset, otherPipelines []*pipeline.Pipeline) {
+	type uriOwner struct {
+		pipeline string
+		asset    *pipeline.Asset
+	}
+	owners := make(map[string]uriOwner)
+	for _, other := range append([]*pipeline.Pipeline{p}, otherPipelines...) {
+		for _, asset := range other.Assets {
+			if asset.URI != "" {
+				if _, ok := owners[asset.URI]; !ok {
+					owners[asset.URI] = uriOwner{pipeline: other.Name, asset: asset}
+				}
+			}
+		}
+	}
+
+	for _, asset := range assets {
+		for _, upstream := range asset.Upstreams {
+			if upstream.Type != "uri" {
+				continue
+			}
+
+			owner, ok := owners[upstream.Value]
+			if ok && owner.pipeline == p.Name && included[strings.ToLower(owner.asset.Name)] == owner.asset {
+				g.addEdge(owner.asset.Name, asset.Name, GraphEdgeURI)
+				continue
+			}
+
+			node := &GraphNode{ID: upstream.Value, Kind: GraphNodeExternal, Label: upstream.Value, URI: upstream.Value}
+			if ok && owner.pipeline != p.Name {
+				node.Label = owner.asset.Name
+				node.Type = owner.asset.Type
+				node.Platform = Platform(owner.asset.Type)
+				node.Pipeline = owner.pipeline
+			}
+			g.addNode(node)
+			g.addEdge(node.ID, asset.Name, GraphEdgeURI)
+		}
+	}
+
+	for _, other := range otherPipelines {
+		if other.Name == p.Name {
+			continue
+		}
+		for _, consumer := range other.Assets {
+			for _, upstream := range consumer.Upstreams {
+				if upstream.Type != "uri" {
+					continue
+				}
+				owner, ok := owners[upstream.Value]
+				if !ok || owner.pipeline != p.Name || included[strings.ToLower(owner.asset.Name)] != owner.asset {
+					continue
+				}
+
+				id := other.Name + "/" + consumer.Name
+				g.addNode(&GraphNode{
+					ID:       id,
+					Kind:     GraphNodeExternal,
+					Label:    consumer.Name,
+					Type:     consumer.Type,
+					Platform: Platform(consumer.Type),
+					Pipeline: other.Name,
+				})
+				g.addEdge(owner.asset.Name, id, GraphEdgeURI)
+			}
+		}
+	}
+}
+
+func (g *Graph) addNode(node *GraphNode) {
+	if _, ok := g.nodes[node.ID]; ok {
+		return
+	}
+	g.nodes[node.ID] = node
+	g.Nodes = append(g.Nodes, node)
+}
+
+func (g *Graph) addColumnNode(asset *pipeline.Asset, column string) string {
+	id := asset.Name + "#" + column
+	g.addNode(&GraphNode{
+		ID:     id,
+		Kind:   GraphNodeColumn,
+		Label:  column,
+		Parent: asset.Name,
+	})
+	return id
+}
+
+func (g *Graph) addEdge(from, to string, kind GraphEdgeKind) {
+	edge := GraphEdge{From: from, To: to, Kind: kind}
+	if from == to || g.edges[edge] {
+		return
+	}
+	g.edges[edge] = true
+	g.Edges = append(g.Edges, &edge)
+}
+
+// columnsOf returns the column nodes of the given asset node.
+func (g *Graph) columnsOf(id string) []*GraphNode {
+	columns := make([]*GraphNode, 0)
+	for _, node := range g.Nodes {
+		if node.Kind == GraphNodeColumn && node.Parent == id {
+			columns = append(columns, node)
+		}
+	}
+	return columns
+}
+
+// ExpandAssets adds the assets up to the given number of hops upstream and downstream of the
+// selected assets, in the order of the pipeline.
+func ExpandAssets(p *pipeline.Pipeline, selected []*pipeline.Asset, depth int) []*pipeline.Asset {
+	distance := make(map[*pipeline.Asset]int, len(selected))
+	queue := make([]*pipeline.Asset, 0, len(selected))
+	for _, asset := range selected {
+		distance[asset] = 0
+		queue = append(queue, asset)
+	}
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		if distance[current] >= depth {
+			continue
+		}
+		for _, neighbour := range append(current.GetUpstream(), current.GetDownstream()...) {
+			if _, ok := distance[neighbour]; ok {
+				continue
+			}
+			distance[neighbour] = distance[current] + 1
+			queue = append(queue, neighbour)
+		}
+	}
+
+	assets := make([]*pipeline.Asset, 0, len(distance))
+	for _, asset := range p.Assets {
+		if _, ok := distance[asset]; ok {
+			assets = append(assets, asset)
+		}
+	}
+	return assets
+}
+
+// Platform returns the platform of an asset type, the part before the first dot, e.g. `bq` for
+// `bq.sql`.
+func Platform(assetType pipeline.AssetType) string {
+	platform, _, _ := strings.Cut(string(assetType), ".")
+	return platform
+}
+
+var platformColors = map[string]string{
+	"bq":         "#aecbfa",
+	"sf":         "#b3e5fc",
+	"pg":         "#c5cae9",
+	"rs":         "#ffccbc",
+	"athena":     "#ffe0b2",
+	"databricks": "#ffcdd2",
+	"duckdb":     "#fff9c4",
+	"ms":         "#d1c4e9",
+	"synapse":    "#d1c4e9",
+	"clickhouse": "#fff59d",
+	"python":     "#c8e6c9",
+	"ingestr":    "#b2dfdb",
+	"tableau":    "#f8bbd0",
+	"quicksight": "#f8bbd0",
+}
+
+const (
+	defaultNodeColor  = "#eeeeee"
+	externalNodeColor = "#ffffff"
+)
+
+func nodeColor(node *GraphNode) string {
+	if node.Kind == GraphNodeExternal && node.Pipeline == "" {
+		return externalNodeColor
+	}
+	if color, ok := platformColors[node.Platform]; ok {
+		return color
+	}
+	return defaultNodeColor
+}
+
+// nodeBorderWidth makes the assets of the most critical tiers stand out.
+func nodeBorderWidth(node *GraphNode) int {
+	switch node.Tier {
+	case 1:
+		return 3
+	case 2:
+		return 2
+	default:
+		return 1
+	}
+}
+
+func sortedPlatforms(g *Graph) []string {
+	seen := make(map[string]bool)
+	platforms := make([]string, 0)
+	for _, node := range g.Nodes {
+		if node.Platform != "" && !seen[node.Platform] {
+			seen[node.Platform] = true
+			platforms = append(platforms, node.Platform)
+		}
+	}
+	sort.Strings(platforms)
+	return platforms
+}
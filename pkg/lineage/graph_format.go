@@ -0,0 +1,318 @@
+package lineage
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// GraphFormats are the formats the lineage graph can be rendered in.
+var GraphFormats = []string{"dot", "mermaid", "graphml", "json"}
+
+// Render renders the graph in one of GraphFormats.
+func (g *Graph) Render(format string) (string, error) {
+	switch format {
+	case "dot":
+		return g.DOT(), nil
+	case "mermaid":
+		return g.Mermaid(), nil
+	case "graphml":
+		return g.GraphML()
+	case "json":
+		js, err := json.Marshal(g)
+		if err != nil {
+			return "", errors.Wrap(err, "failed to marshal the lineage graph to json")
+		}
+		return string(js) + "\n", nil
+	default:
+		return "", errors.Errorf("unknown graph format '%s', possible values are: %s", format, strings.Join(GraphFormats, ", "))
+	}
+}
+
+// renderIDs assigns the identifiers used in the DOT and Mermaid outputs, which are stricter about
+// identifiers than asset names and URIs are.
+func (g *Graph) renderIDs() map[string]string {
+	ids := make(map[string]string, len(g.Nodes))
+	nodeIndex, columnIndex := 0, 0
+	for _, node := range g.Nodes {
+		if node.Kind == GraphNodeColumn {
+			ids[node.ID] = fmt.Sprintf("c%d", columnIndex)
+			columnIndex++
+			continue
+		}
+		ids[node.ID] = fmt.Sprintf("n%d", nodeIndex)
+		nodeIndex++
+	}
+	return ids
+}
+
+func (n *GraphNode) displayLabel() string {
+	switch {
+	case n.Kind == GraphNodeExternal && n.Pipeline != "":
+		return fmt.Sprintf("%s: %s", n.Pipeline, n.Label)
+	case n.Kind == GraphNodeAsset && n.Tier != 0:
+		return fmt.Sprintf("%s (%s, tier %d)", n.Label, n.Type, n.Tier)
+	case n.Kind == GraphNodeAsset:
+		return fmt.Sprintf("%s (%s)", n.Label, n.Type)
+	default:
+		return n.Label
+	}
+}
+
+// DOT renders the graph for Graphviz, the assets whose columns are part of the graph are drawn as
+// clusters that contain the asset and its columns.
+func (g *Graph) DOT() string {
+	ids := g.renderIDs()
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "digraph %s {\n", dotQuote(g.Pipeline))
+	sb.WriteString("  rankdir=LR;\n")
+	sb.WriteString("  node [shape=box, style=\"rounded,filled\", fontname=\"Helvetica\"];\n")
+
+	clusterIndex := 0
+	for _, node := range g.Nodes {
+		if node.Kind == GraphNodeColumn {
+			continue
+		}
+
+		columns := g.columnsOf(node.ID)
+		indent := "  "
+		if len(columns) > 0 {
+			fmt.Fprintf(&sb, "  subgraph cluster_%d {\n", clusterIndex)
+			fmt.Fprintf(&sb, "    label=%s;\n", dotQuote(node.Label))
+			clusterIndex++
+			indent = "    "
+		}
+
+		attributes := []string{
+			"label=" + dotQuote(node.displayLabel()),
+			"fillcolor=" + dotQuote(nodeColor(node)),
+		}
+		if width := nodeBorderWidth(node); width > 1 {
+			attributes = append(attributes, fmt.Sprintf("penwidth=%d", width))
+		}
+		if node.Kind == GraphNodeExternal {
+			attributes = append(attributes, `style="rounded,filled,dashed"`)
+		}
+		fmt.Fprintf(&sb, "%s%s [%s];\n", indent, ids[node.ID], strings.Join(attributes, ", "))
+
+		if len(columns) > 0 {
+			for _, column := range columns {
+				fmt.Fprintf(&sb, "    %s [label=%s, shape=ellipse, fillcolor=\"#ffffff\"];\n", ids[column.ID], dotQuote(column.Label))
+			}
+			sb.WriteString("  }\n")
+		}
+	}
+
+	for _, edge := range g.Edges {
+		switch edge.Kind {
+		case GraphEdgeColumn:
+			fmt.Fprintf(&sb, "  %s -> %s [style=dashed];\n", ids[edge.From], ids[edge.To])
+		case GraphEdgeURI:
+			fmt.Fprintf(&sb, "  %s -> %s [style=dotted];\n", ids[edge.From], ids[edge.To])
+		default:
+			fmt.Fprintf(&sb, "  %s -> %s;\n", ids[edge.From], ids[edge.To])
+		}
+	}
+
+	sb.WriteString("}\n")
+	return sb.String()
+}
+
+func dotQuote(value string) string {
+	return `"` + strings.ReplaceAll(strings.ReplaceAll(value, `\`, `\\`), `"`, `\"`) + `"`
+}
+
+// Mermaid renders the graph as a Mermaid flowchart, styled with a class per platform and tier.
+func (g *Graph) Mermaid() string {
+	ids := g.renderIDs()
+
+	var sb strings.Builder
+	sb.WriteString("flowchart LR\n")
+
+	subgraphIndex := 0
+	for _, node := range g.Nodes {
+		if node.Kind == GraphNodeColumn {
+			continue
+		}
+
+		columns := g.columnsOf(node.ID)
+		indent := "  "
+		if len(columns) > 0 {
+			fmt.Fprintf(&sb, "  subgraph s%d[%s]\n", subgraphIndex, mermaidLabel(node.Label))
+			subgraphIndex++
+			indent = "    "
+		}
+
+		if node.Kind == GraphNodeExternal {
+			fmt.Fprintf(&sb, "%s%s[/%s/]\n", indent, ids[node.ID], mermaidLabel(node.displayLabel()))
+		} else {
+			fmt.Fprintf(&sb, "%s%s[%s]\n", indent, ids[node.ID], mermaidLabel(node.displayLabel()))
+		}
+
+		if len(columns) > 0 {
+			for _, column := range columns {
+				fmt.Fprintf(&sb, "    %s(%s)\n", ids[column.ID], mermaidLabel(column.Label))
+			}
+			sb.WriteString("  end\n")
+		}
+	}
+
+	for _, edge := range g.Edges {
+		switch edge.Kind {
+		case GraphEdgeColumn:
+			fmt.Fprintf(&sb, "  %s -.-> %s\n", ids[edge.From], ids[edge.To])
+		case GraphEdgeURI:
+			fmt.Fprintf(&sb, "  %s ==> %s\n", ids[edge.From], ids[edge.To])
+		default:
+			fmt.Fprintf(&sb, "  %s --> %s\n", ids[edge.From], ids[edge.To])
+		}
+	}
+
+	for _, platform := range sortedPlatforms(g) {
+		members := make([]string, 0)
+		var color string
+		for _, node := range g.Nodes {
+			if node.Platform == platform && node.Kind != GraphNodeColumn {
+				members = append(members, ids[node.ID])
+				color = nodeColor(node)
+			}
+		}
+		fmt.Fprintf(&sb, "  classDef platform_%s fill:%s\n", platform, color)
+		fmt.Fprintf(&sb, "  class %s platform_%s\n", strings.Join(members, ","), platform)
+	}
+
+	external := make([]string, 0)
+	for _, node := range g.Nodes {
+		if node.Kind == GraphNodeExternal && node.Platform == "" {
+			external = append(external, ids[node.ID])
+		}
+	}
+	if len(external) > 0 {
+		fmt.Fprintf(&sb, "  classDef external fill:%s,stroke-dasharray:4 4\n", externalNodeColor)
+		fmt.Fprintf(&sb, "  class %s external\n", strings.Join(external, ","))
+	}
+
+	for _, tier := range []int{1, 2} {
+		members := make([]string, 0)
+		for _, node := range g.Nodes {
+			if node.Kind == GraphNodeAsset && node.Tier == tier {
+				members = append(members, ids[node.ID])
+			}
+		}
+		if len(members) == 0 {
+			continue
+		}
+		fmt.Fprintf(&sb, "  classDef tier%d stroke-width:%dpx\n", tier, nodeBorderWidth(&GraphNode{Tier: tier}))
+		fmt.Fprintf(&sb, "  class %s tier%d\n", strings.Join(members, ","), tier)
+	}
+
+	return sb.String()
+}
+
+type graphML struct {
+	XMLName xml.Name       `xml:"graphml"`
+	XMLNS   string         `xml:"xmlns,attr"`
+	Keys    []graphMLKey   `xml:"key"`
+	Graph   graphMLContent `xml:"graph"`
+}
+
+type graphMLKey struct {
+	ID   string `xml:"id,attr"`
+	For  string `xml:"for,attr"`
+	Name string `xml:"attr.name,attr"`
+	Type string `xml:"attr.type,attr"`
+}
+
+type graphMLContent struct {
+	ID          string        `xml:"id,attr"`
+	EdgeDefault string        `xml:"edgedefault,attr"`
+	Nodes       []graphMLNode `xml:"node"`
+	Edges       []graphMLEdge `xml:"edge"`
+}
+
+type graphMLNode struct {
+	ID   string        `xml:"id,attr"`
+	Data []graphMLData `xml:"data"`
+}
+
+type graphMLEdge struct {
+	Source string        `xml:"source,attr"`
+	Target string        `xml:"target,attr"`
+	Data   []graphMLData `xml:"data"`
+}
+
+type graphMLData struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:",chardata"`
+}
+
+// GraphML renders the graph as GraphML, the attributes of the nodes and the edges are written as
+// data keys so that tools like yEd and Gephi can style and filter them.
+func (g *Graph) GraphML() (string, error) {
+	doc := graphML{
+		XMLNS: "http://graphml.graphdrawing.org/xmlns",
+		Keys: []graphMLKey{
+			{ID: "label", For: "node", Name: "label", Type: "string"},
+			{ID: "kind", For: "node", Name: "kind", Type: "string"},
+			{ID: "type", For: "node", Name: "type", Type: "string"},
+			{ID: "platform", For: "node", Name: "platform", Type: "string"},
+			{ID: "tier", For: "node", Name: "tier", Type: "int"},
+			{ID: "parent", For: "node", Name: "parent", Type: "string"},
+			{ID: "pipeline", For: "node", Name: "pipeline", Type: "string"},
+			{ID: "uri", For: "node", Name: "uri", Type: "string"},
+			{ID: "color", For: "node", Name: "color", Type: "string"},
+			{ID: "edge_kind", For: "edge", Name: "kind", Type: "string"},
+		},
+		Graph: graphMLContent{
+			ID:          g.Pipeline,
+			EdgeDefault: "directed",
+			Nodes:       make([]graphMLNode, 0, len(g.Nodes)),
+			Edges:       make([]graphMLEdge, 0, len(g.Edges)),
+		},
+	}
+
+	for _, node := range g.Nodes {
+		data := []graphMLData{
+			{Key: "label", Value: node.Label},
+			{Key: "kind", Value: string(node.Kind)},
+		}
+		optional := []graphMLData{
+			{Key: "type", Value: string(node.Type)},
+			{Key: "platform", Value: node.Platform},
+			{Key: "parent", Value: node.Parent},
+			{Key: "pipeline", Value: node.Pipeline},
+			{Key: "uri", Value: node.URI},
+		}
+		if node.Tier != 0 {
+			optional = append(optional, graphMLData{Key: "tier", Value: strconv.Itoa(node.Tier)})
+		}
+		if node.Kind != GraphNodeColumn {
+			optional = append(optional, graphMLData{Key: "color", Value: nodeColor(node)})
+		}
+		for _, d := range optional {
+			if d.Value != "" {
+				data = append(data, d)
+			}
+		}
+		doc.Graph.Nodes = append(doc.Graph.Nodes, graphMLNode{ID: node.ID, Data: data})
+	}
+
+	for _, edge := range g.Edges {
+		doc.Graph.Edges = append(doc.Graph.Edges, graphMLEdge{
+			Source: edge.From,
+			Target: edge.To,
+			Data:   []graphMLData{{Key: "edge_kind", Value: string(edge.Kind)}},
+		})
+	}
+
+	out, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return "", errors.Wrap(err, "failed to marshal the lineage graph to GraphML")
+	}
+	return xml.Header + string(out) + "\n", nil
+}
@@ -0,0 +1,180 @@
+package lineage
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func graphTestPipelines() (*pipeline.Pipeline, *pipeline.Pipeline) {
+	raw := &pipeline.Asset{
+		Name:      "raw.orders",
+		Type:      pipeline.AssetTypeIngestr,
+		Columns:   []pipeline.Column{{Name: "id"}},
+		Upstreams: []pipeline.Upstream{{Type: "uri", Value: "bigquery://shared.customers"}, {Type: "uri", Value: "s3://bucket/orders"}},
+	}
+	orders := &pipeline.Asset{
+		Name:      "mart.orders",
+		Type:      pipeline.AssetTypeBigqueryQuery,
+		Tier:      1,
+		URI:       "bigquery://project.mart.orders",
+		Upstreams: []pipeline.Upstream{{Type: "asset", Value: "raw.orders"}},
+		Columns: []pipeline.Column{
+			{Name: "order_id", Upstreams: []*pipeline.UpstreamColumn{{Table: "raw.orders", Column: "ID"}}},
+		},
+	}
+	raw.AddDownstream(orders)
+	orders.AddUpstream(raw)
+
+	sales := &pipeline.Pipeline{Name: "sales", Assets: []*pipeline.Asset{raw, orders}}
+
+	finance := &pipeline.Pipeline{
+		Name: "finance",
+		Assets: []*pipeline.Asset{
+			{Name: "shared.customers", Type: pipeline.AssetTypeSnowflakeQuery, URI: "bigquery://shared.customers"},
+			{Name: "revenue", Type: pipeline.AssetTypeBigqueryQuery, Upstreams: []pipeline.Upstream{{Type: "uri", Value: "bigquery://project.mart.orders"}}},
+		},
+	}
+
+	return sales, finance
+}
+
+func TestBuildGraph(t *testing.T) {
+	t.Parallel()
+
+	sales, finance := graphTestPipelines()
+
+	t.Run("asset edges only", func(t *testing.T) {
+		t.Parallel()
+
+		g := BuildGraph(sales, GraphOptions{})
+		assert.Equal(t, []*GraphNode{
+			{ID: "raw.orders", Kind: GraphNodeAsset, Label: "raw.orders", Type: pipeline.AssetTypeIngestr, Platform: "ingestr"},
+			{ID: "mart.orders", Kind: GraphNodeAsset, Label: "mart.orders", Type: pipeline.AssetTypeBigqueryQuery, Platform: "bq", Tier: 1},
+		}, g.Nodes)
+		assert.Equal(t, []*GraphEdge{{From: "raw.orders", To: "mart.orders", Kind: GraphEdgeAsset}}, g.Edges)
+	})
+
+	t.Run("columns and URIs across pipelines", func(t *testing.T) {
+		t.Parallel()
+
+		g := BuildGraph(sales, GraphOptions{Columns: true, URIs: true, OtherPipelines: []*pipeline.Pipeline{finance}})
+		assert.Equal(t, []*GraphNode{
+			{ID: "raw.orders", Kind: GraphNodeAsset, Label: "raw.orders", Type: pipeline.AssetTypeIngestr, Platform: "ingestr"},
+			{ID: "mart.orders", Kind: GraphNodeAsset, Label: "mart.orders", Type: pipeline.AssetTypeBigqueryQuery, Platform: "bq", Tier: 1},
+			{ID: "raw.orders#id", Kind: GraphNodeColumn, Label: "id", Parent: "raw.orders"},
+			{ID: "mart.orders#order_id", Kind: GraphNodeColumn, Label: "order_id", Parent: "mart.orders"},
+			{ID: "bigquery://shared.customers", Kind: GraphNodeExternal, Label: "shared.customers", Type: pipeline.AssetTypeSnowflakeQuery, Platform: "sf", Pipeline: "finance", URI: "bigquery://shared.customers"},
+			{ID: "s3://bucket/orders", Kind: GraphNodeExternal, Label: "s3://bucket/orders", URI: "s3://bucket/orders"},
+			{ID: "finance/revenue", Kind: GraphNodeExternal, Label: "revenue", Type: pipeline.AssetTypeBigqueryQuery, Platform: "bq", Pipeline: "finance"},
+		}, g.Nodes)
+		assert.Equal(t, []*GraphEdge{
+			{From: "raw.orders", To: "mart.orders", Kind: GraphEdgeAsset},
+			{From: "raw.orders#id", To: "mart.orders#order_id", Kind: GraphEdgeColumn},
+			{From: "bigquery://shared.customers", To: "raw.orders", Kind: GraphEdgeURI},
+			{From: "s3://bucket/orders", To: "raw.orders", Kind: GraphEdgeURI},
+			{From: "mart.orders", To: "finance/revenue", Kind: GraphEdgeURI},
+		}, g.Edges)
+	})
+
+	t.Run("selected assets only", func(t *testing.T) {
+		t.Parallel()
+
+		g := BuildGraph(sales, GraphOptions{Assets: []*pipeline.Asset{sales.Assets[1]}, Columns: true})
+		require.Len(t, g.Nodes, 1)
+		assert.Empty(t, g.Edges)
+	})
+}
+
+func TestExpandAssets(t *testing.T) {
+	t.Parallel()
+
+	a := &pipeline.Asset{Name: "a"}
+	b := &pipeline.Asset{Name: "b"}
+	c := &pipeline.Asset{Name: "c"}
+	a.AddDownstream(b)
+	b.AddUpstream(a)
+	b.AddDownstream(c)
+	c.AddUpstream(b)
+	p := &pipeline.Pipeline{Assets: []*pipeline.Asset{a, b, c}}
+
+	assert.Equal(t, []*pipeline.Asset{c}, ExpandAssets(p, []*pipeline.Asset{c}, 0))
+	assert.Equal(t, []*pipeline.Asset{b, c}, ExpandAssets(p, []*pipeline.Asset{c}, 1))
+	assert.Equal(t, []*pipeline.Asset{a, b, c}, ExpandAssets(p, []*pipeline.Asset{a}, 2))
+}
+
+func TestGraph_Render(t *testing.T) {
+	t.Parallel()
+
+	sales, _ := graphTestPipelines()
+	g := BuildGraph(sales, GraphOptions{Columns: true, URIs: true})
+
+	dot, err := g.Render("dot")
+	require.NoError(t, err)
+	assert.Equal(t, `digraph "sales" {
+  rankdir=LR;
+  node [shape=box, style="rounded,filled", fontname="Helvetica"];
+  subgraph cluster_0 {
+    label="raw.orders";
+    n0 [label="raw.orders (ingestr)", fillcolor="#b2dfdb"];
+    c0 [label="id", shape=ellipse, fillcolor="#ffffff"];
+  }
+  subgraph cluster_1 {
+    label="mart.orders";
+    n1 [label="mart.orders (bq.sql, tier 1)", fillcolor="#aecbfa", penwidth=3];
+    c1 [label="order_id", shape=ellipse, fillcolor="#ffffff"];
+  }
+  n2 [label="bigquery://shared.customers", fillcolor="#ffffff", style="rounded,filled,dashed"];
+  n3 [label="s3://bucket/orders", fillcolor="#ffffff", style="rounded,filled,dashed"];
+  n0 -> n1;
+  c0 -> c1 [style=dashed];
+  n2 -> n0 [style=dotted];
+  n3 -> n0 [style=dotted];
+}
+`, dot)
+
+	mermaid, err := g.Render("mermaid")
+	require.NoError(t, err)
+	assert.Equal(t, `flowchart LR
+  subgraph s0["raw.orders"]
+    n0["raw.orders (ingestr)"]
+    c0("id")
+  end
+  subgraph s1["mart.orders"]
+    n1["mart.orders (bq.sql, tier 1)"]
+    c1("order_id")
+  end
+  n2[/"bigquery://shared.customers"/]
+  n3[/"s3://bucket/orders"/]
+  n0 --> n1
+  c0 -.-> c1
+  n2 ==> n0
+  n3 ==> n0
+  classDef platform_bq fill:#aecbfa
+  class n1 platform_bq
+  classDef platform_ingestr fill:#b2dfdb
+  class n0 platform_ingestr
+  classDef external fill:#ffffff,stroke-dasharray:4 4
+  class n2,n3 external
+  classDef tier1 stroke-width:3px
+  class n1 tier1
+`, mermaid)
+
+	graphml, err := g.Render("graphml")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(graphml, `<?xml version="1.0" encoding="UTF-8"?>`))
+	assert.Contains(t, graphml, `<graph id="sales" edgedefault="directed">`)
+	assert.Contains(t, graphml, `<node id="mart.orders#order_id">`)
+	assert.Contains(t, graphml, `<data key="tier">1</data>`)
+	assert.Contains(t, graphml, `<edge source="raw.orders" target="mart.orders">`)
+
+	js, err := g.Render("json")
+	require.NoError(t, err)
+	assert.Contains(t, js, `{"from":"raw.orders#id","to":"mart.orders#order_id","kind":"column"}`)
+
+	_, err = g.Render("png")
+	require.Error(t, err)
+}
@@ -0,0 +1,302 @@
+package lineage
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/pkg/errors"
+)
+
+// ColumnRef identifies a column of an asset in the pipeline.
+type ColumnRef struct {
+	Asset  string `json:"asset"`
+	Column string `json:"column"`
+}
+
+func (c ColumnRef) String() string {
+	return c.Asset + "." + c.Column
+}
+
+func (c ColumnRef) key() string {
+	return strings.ToLower(c.Asset) + "\x00" + strings.ToLower(c.Column)
+}
+
+// DerivedColumn is a column that is computed, directly or transitively, from the traced column.
+type DerivedColumn struct {
+	ColumnRef
+	// From is the upstream column this column is derived from directly.
+	From ColumnRef `json:"from"`
+	// Depth is the number of hops from the traced column, direct dependents have a depth of 1.
+	Depth int `json:"depth"`
+}
+
+// ImpactedAsset is a downstream asset that reads the traced column or one of its derived columns.
+type ImpactedAsset struct {
+	Name    string             `json:"name"`
+	Type    pipeline.AssetType `json:"type"`
+	Columns []string           `json:"columns"`
+}
+
+// ImpactedCheck is a column or custom check that refers to the traced column or a column derived from it.
+type ImpactedCheck struct {
+	Asset  string `json:"asset"`
+	Column string `json:"column,omitempty"`
+	Name   string `json:"name"`
+	Custom bool   `json:"custom,omitempty"`
+}
+
+// ImpactedBIAsset is a dashboard, dataset or workbook that reads from one of the impacted assets.
+type ImpactedBIAsset struct {
+	Name string             `json:"name"`
+	Type pipeline.AssetType `json:"type"`
+	// Via is the impacted asset the BI asset depends on.
+	Via string `json:"via"`
+}
+
+// ColumnImpact is the report of everything that would break if a column was dropped or renamed.
+type ColumnImpact struct {
+	Column   ColumnRef          `json:"column"`
+	Derived  []*DerivedColumn   `json:"derived_columns"`
+	Assets   []*ImpactedAsset   `json:"assets"`
+	Checks   []*ImpactedCheck   `json:"checks"`
+	BIAssets []*ImpactedBIAsset `json:"bi_assets"`
+}
+
+// DownstreamColumns returns every column in the pipeline that is derived from the given column, in
+// breadth-first order. It relies on the column-level upstreams on the assets, which means
+// ColumnLineage needs to have run for the pipeline first.
+func DownstreamColumns(foundPipeline *pipeline.Pipeline, column ColumnRef) []*DerivedColumn {
+	dependents := make(map[string][]ColumnRef)
+	for _, asset := range foundPipeline.Assets {
+		for _, col := range asset.Columns {
+			for _, upstream := range col.Upstreams {
+				if upstream == nil {
+					continue
+				}
+				from := ColumnRef{Asset: upstream.Table, Column: upstream.Column}
+				dependents[from.key()] = append(dependents[from.key()], ColumnRef{Asset: asset.Name, Column: col.Name})
+			}
+		}
+	}
+
+	derived := make([]*DerivedColumn, 0)
+	visited := map[string]bool{column.key(): true}
+	queue := []*DerivedColumn{{ColumnRef: column}}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, dependent := range dependents[current.key()] {
+			if visited[dependent.key()] {
+				continue
+			}
+			visited[dependent.key()] = true
+
+			next := &DerivedColumn{ColumnRef: dependent, From: current.ColumnRef, Depth: current.Depth + 1}
+			derived = append(derived, next)
+			queue = append(queue, next)
+		}
+	}
+
+	return derived
+}
+
+// AnalyzeColumnImpact builds the report of the derived columns, assets, checks and BI assets that are
+// affected by dropping or renaming the given column.
+func AnalyzeColumnImpact(foundPipeline *pipeline.Pipeline, column ColumnRef) (*ColumnImpact, error) {
+	source := findAsset(foundPipeline, column.Asset)
+	if source == nil {
+		return nil, errors.Errorf("asset '%s' does not exist in the pipeline", column.Asset)
+	}
+	sourceColumn := source.GetColumnWithName(column.Column)
+	if sourceColumn == nil {
+		return nil, errors.Errorf("column '%s' does not exist in asset '%s'", column.Column, source.Name)
+	}
+	column = ColumnRef{Asset: source.Name, Column: sourceColumn.Name}
+
+	report := &ColumnImpact{
+		Column:   column,
+		Derived:  DownstreamColumns(foundPipeline, column),
+		Assets:   make([]*ImpactedAsset, 0),
+		Checks:   make([]*ImpactedCheck, 0),
+		BIAssets: make([]*ImpactedBIAsset, 0),
+	}
+
+	columnsByAsset := map[string][]string{source.Name: {sourceColumn.Name}}
+	impactedAssets := []*pipeline.Asset{source}
+	for _, derived := range report.Derived {
+		asset := findAsset(foundPipeline, derived.Asset)
+		if asset == nil {
+			continue
+		}
+		if _, ok := columnsByAsset[asset.Name]; !ok {
+			impactedAssets = append(impactedAssets, asset)
+			report.Assets = append(report.Assets, &ImpactedAsset{Name: asset.Name, Type: asset.Type})
+		}
+		columnsByAsset[asset.Name] = append(columnsByAsset[asset.Name], derived.Column)
+	}
+	for _, asset := range report.Assets {
+		asset.Columns = columnsByAsset[asset.Name]
+	}
+
+	for _, asset := range impactedAssets {
+		report.Checks = append(report.Checks, impactedChecks(asset, columnsByAsset[asset.Name])...)
+	}
+
+	// the assets furthest downstream are visited first, so that BI assets are reported via the
+	// closest impacted asset they read from.
+	seenBIAssets := make(map[string]bool)
+	for i := len(impactedAssets) - 1; i >= 0; i-- {
+		asset := impactedAssets[i]
+		for _, downstream := range asset.GetFullDownstream() {
+			if !IsBIAsset(downstream) || seenBIAssets[downstream.Name] {
+				continue
+			}
+			seenBIAssets[downstream.Name] = true
+			report.BIAssets = append(report.BIAssets, &ImpactedBIAsset{Name: downstream.Name, Type: downstream.Type, Via: asset.Name})
+		}
+	}
+	sort.SliceStable(report.BIAssets, func(i, j int) bool {
+		return report.BIAssets[i].Name < report.BIAssets[j].Name
+	})
+
+	return report, nil
+}
+
+func findAsset(foundPipeline *pipeline.Pipeline, name string) *pipeline.Asset {
+	for _, asset := range foundPipeline.Assets {
+		if strings.EqualFold(asset.Name, name) {
+			return asset
+		}
+	}
+	return nil
+}
+
+// IsBIAsset reports whether the asset is a Tableau or QuickSight object.
+func IsBIAsset(asset *pipeline.Asset) bool {
+	assetType := string(asset.Type)
+	return assetType == string(pipeline.AssetTypeTableau) || strings.HasPrefix(assetType, string(pipeline.AssetTypeTableau)+".") ||
+		assetType == string(pipeline.AssetTypeQuicksight) || strings.HasPrefix(assetType, string(pipeline.AssetTypeQuicksight)+".")
+}
+
+// impactedChecks returns the checks of the given columns, as well as the custom checks of the asset
+// whose query mentions one of them.
+func impactedChecks(asset *pipeline.Asset, columns []string) []*ImpactedCheck {
+	checks := make([]*ImpactedCheck, 0)
+	for _, name := range columns {
+		column := asset.GetColumnWithName(name)
+		if column == nil {
+			continue
+		}
+		for _, check := range column.Checks {
+			checks = append(checks, &ImpactedCheck{Asset: asset.Name, Column: column.Name, Name: check.Name})
+		}
+	}
+
+	for _, check := range asset.CustomChecks {
+		for _, name := range columns {
+			if mentionsIdentifier(check.Query, name) {
+				checks = append(checks, &ImpactedCheck{Asset: asset.Name, Column: name, Name: check.Name, Custom: true})
+				break
+			}
+		}
+	}
+
+	return checks
+}
+
+func mentionsIdentifier(query, identifier string) bool {
+	pattern := `(?i)(^|[^\w$])` + regexp.QuoteMeta(identifier) + `($|[^\w$])`
+	matched, err := regexp.MatchString(pattern, query)
+	return err == nil && matched
+}
+
+// MermaidDownstream renders the traced column and its derived columns as a Mermaid flowchart, with
+// one subgraph per asset.
+func MermaidDownstream(column ColumnRef, derived []*DerivedColumn) string {
+	var sb strings.Builder
+	sb.WriteString("flowchart LR\n")
+	writeMermaidColumns(&sb, column, derived)
+	return sb.String()
+}
+
+// Mermaid renders the impact report as a Mermaid flowchart: the derived columns grouped by asset,
+// followed by the impacted checks and BI assets.
+func (r *ColumnImpact) Mermaid() string {
+	var sb strings.Builder
+	sb.WriteString("flowchart LR\n")
+	ids := writeMermaidColumns(&sb, r.Column, r.Derived)
+
+	for i, check := range r.Checks {
+		id := fmt.Sprintf("check%d", i)
+		fmt.Fprintf(&sb, "  %s{{%s}}\n", id, mermaidLabel("check: "+check.Name))
+		from, ok := ids[ColumnRef{Asset: check.Asset, Column: check.Column}.key()]
+		if !ok {
+			continue
+		}
+		fmt.Fprintf(&sb, "  %s -.-> %s\n", from, id)
+	}
+
+	for i, asset := range r.BIAssets {
+		id := fmt.Sprintf("bi%d", i)
+		fmt.Fprintf(&sb, "  %s[/%s/]\n", id, mermaidLabel(fmt.Sprintf("%s (%s)", asset.Name, asset.Type)))
+		fmt.Fprintf(&sb, "  %s --> %s\n", mermaidAssetID(asset.Via, ids), id)
+	}
+
+	return sb.String()
+}
+
+// writeMermaidColumns writes the column nodes and the edges between them, and returns the node IDs
+// of the columns as well as the subgraph IDs of the assets, keyed by asset name.
+func writeMermaidColumns(sb *strings.Builder, column ColumnRef, derived []*DerivedColumn) map[string]string {
+	ids := make(map[string]string)
+	assetOrder := make([]string, 0)
+	columnsByAsset := make(map[string][]ColumnRef)
+	for _, col := range append([]ColumnRef{column}, derivedRefs(derived)...) {
+		if _, ok := columnsByAsset[col.Asset]; !ok {
+			assetOrder = append(assetOrder, col.Asset)
+		}
+		columnsByAsset[col.Asset] = append(columnsByAsset[col.Asset], col)
+	}
+
+	nodeIndex := 0
+	for i, asset := range assetOrder {
+		assetID := fmt.Sprintf("asset%d", i)
+		ids[strings.ToLower(asset)] = assetID
+		fmt.Fprintf(sb, "  subgraph %s[%s]\n", assetID, mermaidLabel(asset))
+		for _, col := range columnsByAsset[asset] {
+			id := fmt.Sprintf("col%d", nodeIndex)
+			nodeIndex++
+			ids[col.key()] = id
+			fmt.Fprintf(sb, "    %s[%s]\n", id, mermaidLabel(col.Column))
+		}
+		sb.WriteString("  end\n")
+	}
+
+	for _, d := range derived {
+		fmt.Fprintf(sb, "  %s --> %s\n", ids[d.From.key()], ids[d.key()])
+	}
+
+	return ids
+}
+
+func mermaidAssetID(asset string, ids map[string]string) string {
+	if id, ok := ids[strings.ToLower(asset)]; ok {
+		return id
+	}
+	return mermaidLabel(asset)
+}
+
+func derivedRefs(derived []*DerivedColumn) []ColumnRef {
+	refs := make([]ColumnRef, len(derived))
+	for i, d := range derived {
+		refs[i] = d.ColumnRef
+	}
+	return refs
+}
+
+func mermaidLabel(label string) string {
+	return `"` + strings.ReplaceAll(label, `"`, "#quot;") + `"`
+}
@@ -0,0 +1,166 @@
+package lineage
+
+import (
+	"testing"
+
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func impactTestPipeline() *pipeline.Pipeline {
+	orders := &pipeline.Asset{
+		Name: "orders",
+		Type: pipeline.AssetTypeBigqueryQuery,
+		Columns: []pipeline.Column{
+			{Name: "id"},
+			{Name: "customer_id", Checks: []pipeline.ColumnCheck{{Name: "not_null"}}},
+		},
+	}
+	customerOrders := &pipeline.Asset{
+		Name: "mart.customer_orders",
+		Type: pipeline.AssetTypeBigqueryQuery,
+		Columns: []pipeline.Column{
+			{Name: "customer", Upstreams: []*pipeline.UpstreamColumn{{Table: "orders", Column: "customer_id"}}},
+			{Name: "order_count", Upstreams: []*pipeline.UpstreamColumn{{Table: "orders", Column: "id"}}},
+		},
+		CustomChecks: []pipeline.CustomCheck{
+			{Name: "one row per customer", Query: "SELECT count(*) - count(DISTINCT customer) FROM mart.customer_orders"},
+			{Name: "positive counts", Query: "SELECT count(*) FROM mart.customer_orders WHERE order_count < 0"},
+		},
+	}
+	topCustomers := &pipeline.Asset{
+		Name: "mart.top_customers",
+		Type: pipeline.AssetTypeBigqueryQuery,
+		Columns: []pipeline.Column{
+			{Name: "customer", Upstreams: []*pipeline.UpstreamColumn{{Table: "MART.CUSTOMER_ORDERS", Column: "Customer"}}},
+			{Name: "label", Upstreams: []*pipeline.UpstreamColumn{{Table: "mart.customer_orders", Column: "customer"}, {Table: "orders", Column: "customer_id"}}},
+		},
+	}
+	dashboard := &pipeline.Asset{Name: "sales_dashboard", Type: pipeline.AssetTypeTableauDashboard}
+	unrelated := &pipeline.Asset{Name: "finance_dashboard", Type: pipeline.AssetTypeQuicksightDashboard}
+
+	orders.AddDownstream(customerOrders)
+	orders.AddDownstream(unrelated)
+	customerOrders.AddDownstream(topCustomers)
+	topCustomers.AddDownstream(dashboard)
+
+	return &pipeline.Pipeline{
+		Assets: []*pipeline.Asset{orders, customerOrders, topCustomers, dashboard, unrelated},
+	}
+}
+
+func TestDownstreamColumns(t *testing.T) {
+	t.Parallel()
+
+	derived := DownstreamColumns(impactTestPipeline(), ColumnRef{Asset: "orders", Column: "customer_id"})
+
+	assert.Equal(t, []*DerivedColumn{
+		{
+			ColumnRef: ColumnRef{Asset: "mart.customer_orders", Column: "customer"},
+			From:      ColumnRef{Asset: "orders", Column: "customer_id"},
+			Depth:     1,
+		},
+		{
+			ColumnRef: ColumnRef{Asset: "mart.top_customers", Column: "label"},
+			From:      ColumnRef{Asset: "orders", Column: "customer_id"},
+			Depth:     1,
+		},
+		{
+			ColumnRef: ColumnRef{Asset: "mart.top_customers", Column: "customer"},
+			From:      ColumnRef{Asset: "mart.customer_orders", Column: "customer"},
+			Depth:     2,
+		},
+	}, derived)
+
+	assert.Empty(t, DownstreamColumns(impactTestPipeline(), ColumnRef{Asset: "mart.top_customers", Column: "label"}))
+}
+
+func TestAnalyzeColumnImpact(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		column       ColumnRef
+		wantAssets   []*ImpactedAsset
+		wantChecks   []*ImpactedCheck
+		wantBIAssets []*ImpactedBIAsset
+		wantErr      string
+	}{
+		{
+			name:   "column used across the pipeline",
+			column: ColumnRef{Asset: "ORDERS", Column: "Customer_ID"},
+			wantAssets: []*ImpactedAsset{
+				{Name: "mart.customer_orders", Type: pipeline.AssetTypeBigqueryQuery, Columns: []string{"customer"}},
+				{Name: "mart.top_customers", Type: pipeline.AssetTypeBigqueryQuery, Columns: []string{"label", "customer"}},
+			},
+			wantChecks: []*ImpactedCheck{
+				{Asset: "orders", Column: "customer_id", Name: "not_null"},
+				{Asset: "mart.customer_orders", Column: "customer", Name: "one row per customer", Custom: true},
+			},
+			wantBIAssets: []*ImpactedBIAsset{
+				{Name: "finance_dashboard", Type: pipeline.AssetTypeQuicksightDashboard, Via: "orders"},
+				{Name: "sales_dashboard", Type: pipeline.AssetTypeTableauDashboard, Via: "mart.top_customers"},
+			},
+		},
+		{
+			name:       "column without dependents",
+			column:     ColumnRef{Asset: "mart.top_customers", Column: "label"},
+			wantAssets: []*ImpactedAsset{},
+			wantChecks: []*ImpactedCheck{},
+			wantBIAssets: []*ImpactedBIAsset{
+				{Name: "sales_dashboard", Type: pipeline.AssetTypeTableauDashboard, Via: "mart.top_customers"},
+			},
+		},
+		{
+			name:    "missing asset",
+			column:  ColumnRef{Asset: "missing", Column: "id"},
+			wantErr: "asset 'missing' does not exist in the pipeline",
+		},
+		{
+			name:    "missing column",
+			column:  ColumnRef{Asset: "orders", Column: "missing"},
+			wantErr: "column 'missing' does not exist in asset 'orders'",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			report, err := AnalyzeColumnImpact(impactTestPipeline(), tt.column)
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantAssets, report.Assets)
+			assert.Equal(t, tt.wantChecks, report.Checks)
+			assert.Equal(t, tt.wantBIAssets, report.BIAssets)
+		})
+	}
+}
+
+func TestColumnImpact_Mermaid(t *testing.T) {
+	t.Parallel()
+
+	report, err := AnalyzeColumnImpact(impactTestPipeline(), ColumnRef{Asset: "mart.customer_orders", Column: "customer"})
+	require.NoError(t, err)
+
+	expected := `flowchart LR
+  subgraph asset0["mart.customer_orders"]
+    col0["customer"]
+  end
+  subgraph asset1["mart.top_customers"]
+    col1["customer"]
+    col2["label"]
+  end
+  col0 --> col1
+  col0 --> col2
+  check0{{"check: one row per customer"}}
+  col0 -.-> check0
+  bi0[/"sales_dashboard (tableau.dashboard)"/]
+  asset1 --> bi0
+`
+	assert.Equal(t, expected, report.Mermaid())
+}
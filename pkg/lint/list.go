@@ -24,6 +24,7 @@ func GetRules(fs afero.Fs, finder repoFinder, excludeWarnings bool, parser sqlpa
 		cacheFoundGlossary: cacheFoundGlossary,
 	}
 
+	ownerChecker := &OwnerChecker{fs: fs, finder: finder}
 	yamlFileValidator := WarnRegularYamlFiles{fs: fs}
 	unknownFieldsValidator := validateUnknownYAMLFields{fs: fs}
 
@@ -246,6 +247,27 @@ func GetRules(fs afero.Fs, finder repoFinder, excludeWarnings bool, parser sqlpa
 			AssetValidator:   ValidatePythonAssetMaterialization,
 			ApplicableLevels: []Level{LevelAsset},
 		},
+		&SimpleRule{
+			Identifier:       "python-asset-outputs",
+			Fast:             true,
+			Severity:         ValidatorSeverityCritical,
+			AssetValidator:   ValidatePythonAssetOutputs,
+			ApplicableLevels: []Level{LevelAsset},
+		},
+		&SimpleRule{
+			Identifier:       "pii-column-masking-policy",
+			Fast:             true,
+			Severity:         ValidatorSeverityCritical,
+			AssetValidator:   ValidatePIIColumnsHaveMaskingPolicy,
+			ApplicableLevels: []Level{LevelAsset},
+		},
+		&SimpleRule{
+			Identifier:       "masking-policy-platform",
+			Fast:             true,
+			Severity:         ValidatorSeverityCritical,
+			AssetValidator:   ValidateMaskingPolicyPlatform,
+			ApplicableLevels: []Level{LevelAsset},
+		},
 		&SimpleRule{
 			Identifier:       "script-hooks-unsupported",
 			Fast:             true,
@@ -281,6 +303,13 @@ func GetRules(fs afero.Fs, finder repoFinder, excludeWarnings bool, parser sqlpa
 			AssetValidator:   EnsureAssetTierIsValidForASingleAsset,
 			ApplicableLevels: []Level{LevelAsset},
 		},
+		&SimpleRule{
+			Identifier:       "asset-tier-requires-owner",
+			Fast:             true,
+			Severity:         ValidatorSeverityCritical,
+			AssetValidator:   ownerChecker.EnsureAssetHasOwnerForItsTier,
+			ApplicableLevels: []Level{LevelAsset},
+		},
 		&SimpleRule{
 			Identifier:       "valid-timeout",
 			Fast:             true,
@@ -25,6 +25,7 @@ var (
 	errNoRules       = errors.New("No rules specified")
 	errNoSuchTarget  = errors.New("No such target")
 	errBadName       = errors.New("Only alphanumeric characters and dash allowed")
+	errBadLevel      = errors.New("Level must be either 'error' or 'warning'")
 )
 
 var validRulePattern = regexp.MustCompile(`^[A-Za-z0-9\-]+$`)
@@ -125,6 +126,16 @@ type RuleSet struct {
 	Name     string           `yaml:"name"`
 	Selector []map[string]any `yaml:"selector"`
 	Rules    []string         `yaml:"rules"`
+	Level    string           `yaml:"level"`
+}
+
+// Severity returns the severity of the issues raised by the rules of the set, rules fail the
+// validation unless the set is marked with `level: warning`.
+func (rs *RuleSet) Severity() ValidatorSeverity {
+	if rs.Level == "warning" {
+		return ValidatorSeverityWarning
+	}
+	return ValidatorSeverityCritical
 }
 
 func (rs *RuleSet) validate() error {
@@ -137,6 +148,9 @@ func (rs *RuleSet) validate() error {
 	if len(rs.Rules) == 0 {
 		return errNoRules
 	}
+	if !slices.Contains([]string{"", "error", "warning"}, rs.Level) {
+		return errBadLevel
+	}
 
 	return nil
 }
@@ -187,11 +201,16 @@ func (spec *PolicySpecification) Rules(sqlParser sqlparser.Parser) ([]Rule, erro
 			if !found {
 				return nil, fmt.Errorf("no such rule: %s", ruleName)
 			}
+			severity := ruleSet.Severity()
+			if _, custom := spec.compiledRules[ruleName]; !custom && !parserCanCheck(sqlParser, ruleName) {
+				validators = parserUnavailableValidators(ruleName)
+				severity = ValidatorSeverityWarning
+			}
 			validators = withSelector(ruleSet.Selector, validators)
 			rules = append(rules, &SimpleRule{
 				Identifier:       fmt.Sprintf("policy:%s:%s", ruleSet.Name, ruleName),
 				Fast:             true,
-				Severity:         ValidatorSeverityCritical,
+				Severity:         severity,
 				Validator:        validators.Pipeline,
 				AssetValidator:   validators.Asset,
 				ApplicableLevels: validators.GetApplicableLevels(),
@@ -201,13 +220,47 @@ func (spec *PolicySpecification) Rules(sqlParser sqlparser.Parser) ([]Rule, erro
 	return rules, nil
 }
 
-// we need to pass in the sqlparser to the policy because of the query-matches-columns rule.
+// parserCanCheck reports whether the SQL rules that are built on the parser can be checked with
+// the given one. The query-matches-columns rule predates them and keeps passing silently without
+// the parser.
+func parserCanCheck(sqlParser sqlparser.Parser, ruleName string) bool {
+	if _, found := sqlPatternRules[ruleName]; found {
+		_, ok := sqlParser.(sqlPatternFinder)
+		return ok
+	}
+	return ruleName != "sql-no-undeclared-tables" || sqlParser != nil
+}
+
+// parserUnavailableValidators reports once per pipeline that the rule was not checked, instead of
+// letting it pass silently, e.g. in the checks `bruin run` does before running the assets.
+func parserUnavailableValidators(ruleName string) validators {
+	return validators{
+		Pipeline: func(ctx context.Context, p *pipeline.Pipeline) ([]*Issue, error) {
+			return []*Issue{{
+				Description: fmt.Sprintf("The rule '%s' was not checked, it needs the SQL parser, which is not available here; run 'bruin validate' to check it", ruleName),
+			}}, nil
+		},
+	}
+}
+
+// we need to pass in the sqlparser to the policy because of the query-matches-columns rule and
+// the SQL rules that are built on the parser.
 func (spec *PolicySpecification) getValidators(name string, sqlParser sqlparser.Parser) (validators, bool) {
 	def, found := spec.compiledRules[name]
 	if !found {
 		validators, found := builtinRules[name]
-		if found && name == "query-matches-columns" && sqlParser != nil {
-			validators.Asset = QueryColumnsMatchColumnsPolicy(sqlParser)
+		if found && sqlParser != nil {
+			switch name {
+			case "query-matches-columns":
+				validators.Asset = QueryColumnsMatchColumnsPolicy(sqlParser)
+			case "sql-no-undeclared-tables":
+				validators.Asset = SQLNoUndeclaredTablesPolicy(sqlParser)
+			}
+			if rule, ok := sqlPatternRules[name]; ok {
+				if finder, ok := sqlParser.(sqlPatternFinder); ok {
+					validators.Asset = rule(finder)
+				}
+			}
 		}
 		return validators, found
 	}
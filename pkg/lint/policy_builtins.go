@@ -500,6 +500,24 @@ var builtinRules = map[string]validators{
 	"query-matches-columns": {
 		Asset: noopAssetValidator,
 	},
+	"sql-no-select-star": {
+		Asset: noopAssetValidator,
+	},
+	"sql-no-unfiltered-dml-in-hooks": {
+		Asset: noopAssetValidator,
+	},
+	"sql-no-implicit-cross-join": {
+		Asset: noopAssetValidator,
+	},
+	"sql-no-order-by-without-limit": {
+		Asset: noopAssetValidator,
+	},
+	"sql-sargable-partition-filter": {
+		Asset: noopAssetValidator,
+	},
+	"sql-no-undeclared-tables": {
+		Asset: noopAssetValidator,
+	},
 }
 
 func QueryColumnsMatchColumnsPolicy(parser sqlparser.Parser) func(ctx context.Context, p *pipeline.Pipeline, asset *pipeline.Asset) ([]*Issue, error) {
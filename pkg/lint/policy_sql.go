@@ -0,0 +1,257 @@
+package lint
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/bruin-data/bruin/pkg/jinja"
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/bruin-data/bruin/pkg/sqlparser"
+	"github.com/pkg/errors"
+)
+
+// sqlPatternFinder is the part of the SQL parser the SQL anti-pattern rules are built on, each
+// method runs a parser command that finds one kind of pattern in a query.
+type sqlPatternFinder interface {
+	SelectsStar(sql, dialect string) (bool, error)
+	UnconditionedJoins(sql, dialect string) ([]string, error)
+	UnboundedOrderings(sql, dialect string) (*sqlparser.UnboundedOrderings, error)
+	WrappedFilterColumns(sql, dialect string) ([]string, error)
+	UnfilteredDML(sql, dialect string) ([]string, error)
+}
+
+var _ sqlPatternFinder = (*sqlparser.SQLParser)(nil)
+
+// sqlPatternRules builds the validators of the SQL anti-pattern rules on the given parser.
+var sqlPatternRules = map[string]func(finder sqlPatternFinder) AssetValidator{
+	"sql-no-select-star":             sqlNoSelectStar,
+	"sql-no-unfiltered-dml-in-hooks": sqlNoUnfilteredDMLInHooks,
+	"sql-no-implicit-cross-join":     sqlNoImplicitCrossJoin,
+	"sql-no-order-by-without-limit":  sqlNoOrderByWithoutLimit,
+	"sql-sargable-partition-filter":  sqlSargablePartitionFilter,
+}
+
+func renderAssetSQL(ctx context.Context, p *pipeline.Pipeline, asset *pipeline.Asset) (jinja.RendererInterface, string, bool) {
+	if !asset.IsSQLAsset() {
+		return nil, "", false
+	}
+
+	var renderer jinja.RendererInterface = jinja.NewRendererWithYesterday("your-pipeline-name", "your-run-id")
+	renderer, err := renderer.CloneForAsset(ctx, p, asset)
+	if err != nil {
+		return nil, "", false
+	}
+	rendered, err := renderer.Render(asset.ExecutableFile.Content)
+	if err != nil {
+		return nil, "", false
+	}
+	return renderer, rendered, true
+}
+
+// assetDialect returns the SQL dialect of the asset, or an empty string for standard SQL.
+func assetDialect(asset *pipeline.Asset) string {
+	dialect, err := sqlparser.AssetTypeToDialect(asset.Type)
+	if err != nil {
+		return ""
+	}
+	return dialect
+}
+
+// sqlQueryRule builds a validator that checks the rendered query of SQL assets. Queries the parser
+// cannot parse are not reported, other rules point out broken queries.
+func sqlQueryRule(check func(asset *pipeline.Asset, query, dialect string) ([]*Issue, error)) AssetValidator {
+	return func(ctx context.Context, p *pipeline.Pipeline, asset *pipeline.Asset) ([]*Issue, error) {
+		_, rendered, ok := renderAssetSQL(ctx, p, asset)
+		if !ok || strings.TrimSpace(rendered) == "" {
+			return nil, nil
+		}
+		issues, err := check(asset, rendered, assetDialect(asset))
+		if err != nil { //nolint:nilerr
+			return nil, nil
+		}
+		return issues, nil
+	}
+}
+
+func sqlNoSelectStar(finder sqlPatternFinder) AssetValidator {
+	return sqlQueryRule(func(asset *pipeline.Asset, query, dialect string) ([]*Issue, error) {
+		if asset.Materialization.Type != pipeline.MaterializationTypeTable {
+			return nil, nil
+		}
+		selectsStar, err := finder.SelectsStar(query, dialect)
+		if err != nil || !selectsStar {
+			return nil, err
+		}
+		return []*Issue{{
+			Task:        asset,
+			Description: "The final projection of a table asset must list its columns instead of using 'SELECT *'",
+		}}, nil
+	})
+}
+
+func sqlNoImplicitCrossJoin(finder sqlPatternFinder) AssetValidator {
+	return sqlQueryRule(func(asset *pipeline.Asset, query, dialect string) ([]*Issue, error) {
+		joins, err := finder.UnconditionedJoins(query, dialect)
+		if err != nil || len(joins) == 0 {
+			return nil, err
+		}
+		return []*Issue{{
+			Task:        asset,
+			Description: "The query joins tables with a comma or without a join condition, use an explicit JOIN with a join condition or CROSS JOIN instead: " + strings.Join(joins, ", "),
+			Context:     joins,
+		}}, nil
+	})
+}
+
+func sqlNoOrderByWithoutLimit(finder sqlPatternFinder) AssetValidator {
+	return sqlQueryRule(func(asset *pipeline.Asset, query, dialect string) ([]*Issue, error) {
+		orderings, err := finder.UnboundedOrderings(query, dialect)
+		if err != nil {
+			return nil, err
+		}
+
+		issues := make([]*Issue, 0)
+		if asset.Materialization.Type == pipeline.MaterializationTypeView && orderings.Final {
+			issues = append(issues, &Issue{
+				Task:        asset,
+				Description: "Views must not use ORDER BY without LIMIT, the order is not guaranteed for the queries that read the view",
+			})
+		}
+		if len(orderings.CTEs) > 0 {
+			issues = append(issues, &Issue{
+				Task:        asset,
+				Description: "CTEs must not use ORDER BY without LIMIT, the order is not preserved by the queries that read them: " + strings.Join(orderings.CTEs, ", "),
+				Context:     orderings.CTEs,
+			})
+		}
+		return issues, nil
+	})
+}
+
+var bareIdentifierPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+func sqlSargablePartitionFilter(finder sqlPatternFinder) AssetValidator {
+	return sqlQueryRule(func(asset *pipeline.Asset, query, dialect string) ([]*Issue, error) {
+		column := strings.TrimSpace(asset.Materialization.PartitionBy)
+		if !bareIdentifierPattern.MatchString(column) {
+			return nil, nil
+		}
+		columns, err := finder.WrappedFilterColumns(query, dialect)
+		if err != nil {
+			return nil, err
+		}
+		if !slices.ContainsFunc(columns, func(wrapped string) bool { return strings.EqualFold(wrapped, column) }) {
+			return nil, nil
+		}
+		return []*Issue{{
+			Task:        asset,
+			Description: fmt.Sprintf("The partition column '%s' is wrapped in a function or a cast in a filter, compare the column itself so that partitions can be pruned", column),
+		}}, nil
+	})
+}
+
+func sqlNoUnfilteredDMLInHooks(finder sqlPatternFinder) AssetValidator {
+	return func(ctx context.Context, p *pipeline.Pipeline, asset *pipeline.Asset) ([]*Issue, error) {
+		if !asset.IsSQLAsset() || (len(asset.Hooks.Pre) == 0 && len(asset.Hooks.Post) == 0) {
+			return nil, nil
+		}
+
+		var renderer jinja.RendererInterface = jinja.NewRendererWithYesterday("your-pipeline-name", "your-run-id")
+		renderer, err := renderer.CloneForAsset(ctx, p, asset)
+		if err != nil {
+			return nil, nil //nolint:nilerr
+		}
+
+		issues := make([]*Issue, 0)
+		check := func(kind string, index int, hook pipeline.Hook) {
+			q, err := renderer.Render(hook.Query)
+			if err != nil {
+				q = hook.Query
+			}
+			statements, err := finder.UnfilteredDML(q, assetDialect(asset))
+			if err != nil {
+				return
+			}
+			for _, statement := range statements {
+				issues = append(issues, &Issue{
+					Task:        asset,
+					Description: fmt.Sprintf("The %s hook #%d runs %s without a WHERE clause, which affects every row of the table", kind, index, statement),
+				})
+			}
+		}
+		for i, hook := range asset.Hooks.Pre {
+			check("pre", i+1, hook)
+		}
+		for i, hook := range asset.Hooks.Post {
+			check("post", i+1, hook)
+		}
+		return issues, nil
+	}
+}
+
+// SQLNoUndeclaredTablesPolicy reports the tables referenced in the query of the asset that are
+// neither its upstreams nor declared as external sources through `uri` dependencies.
+func SQLNoUndeclaredTablesPolicy(parser sqlparser.Parser) AssetValidator {
+	return func(ctx context.Context, p *pipeline.Pipeline, asset *pipeline.Asset) ([]*Issue, error) {
+		if parser == nil {
+			return nil, nil
+		}
+
+		dialect, err := sqlparser.AssetTypeToDialect(asset.Type)
+		if err != nil { //nolint:nilerr
+			return nil, nil
+		}
+		_, rendered, ok := renderAssetSQL(ctx, p, asset)
+		if !ok {
+			return nil, nil
+		}
+
+		tables, err := parser.UsedTables(rendered, dialect)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get the tables used by '%s'", asset.Name)
+		}
+
+		declared := []string{asset.Name}
+		for _, upstream := range asset.Upstreams {
+			value := upstream.Value
+			if upstream.Type == "uri" {
+				if _, rest, found := strings.Cut(value, "://"); found {
+					value = rest
+				}
+			}
+			declared = append(declared, value)
+		}
+
+		undeclared := make([]string, 0)
+		for _, table := range tables {
+			if !slices.ContainsFunc(declared, func(name string) bool { return sameTableReference(name, table) }) {
+				undeclared = append(undeclared, table)
+			}
+		}
+		if len(undeclared) == 0 {
+			return nil, nil
+		}
+
+		return []*Issue{{
+			Task:        asset,
+			Description: "The query references tables that are neither upstreams nor declared sources: " + strings.Join(undeclared, ", "),
+			Context:     undeclared,
+		}}, nil
+	}
+}
+
+// sameTableReference compares two table names that may be qualified up to different levels,
+// e.g. `dataset.table` and `project.dataset.table`.
+func sameTableReference(a, b string) bool {
+	aParts := strings.Split(strings.ToLower(a), ".")
+	bParts := strings.Split(strings.ToLower(b), ".")
+	for i := 1; i <= min(len(aParts), len(bParts)); i++ {
+		if strings.Trim(aParts[len(aParts)-i], "`\"") != strings.Trim(bParts[len(bParts)-i], "`\"") {
+			return false
+		}
+	}
+	return true
+}
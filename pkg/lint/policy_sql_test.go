@@ -0,0 +1,267 @@
+package lint_test
+
+import (
+	"testing"
+
+	"github.com/bruin-data/bruin/pkg/lint"
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSQLPolicyRules(t *testing.T) {
+	t.Parallel()
+
+	sqlAsset := func(materialization pipeline.MaterializationType, query string) *pipeline.Asset {
+		return &pipeline.Asset{
+			Name:            "sales.orders",
+			Type:            pipeline.AssetTypeBigqueryQuery,
+			Materialization: pipeline.Materialization{Type: materialization},
+			ExecutableFile:  pipeline.ExecutableFile{Content: query},
+		}
+	}
+	partitioned := func(query string) *pipeline.Asset {
+		asset := sqlAsset(pipeline.MaterializationTypeTable, query)
+		asset.Materialization.PartitionBy = "created_at"
+		return asset
+	}
+	typed := func(assetType pipeline.AssetType, asset *pipeline.Asset) *pipeline.Asset {
+		asset.Type = assetType
+		return asset
+	}
+	withHooks := func(hooks ...string) *pipeline.Asset {
+		asset := sqlAsset(pipeline.MaterializationTypeTable, "SELECT id FROM raw.orders")
+		for _, hook := range hooks {
+			asset.Hooks.Post = append(asset.Hooks.Post, pipeline.Hook{Query: hook})
+		}
+		return asset
+	}
+
+	tests := []struct {
+		name       string
+		rule       string
+		asset      *pipeline.Asset
+		wantIssues int
+	}{
+		{
+			name:       "select star in the final projection of a table",
+			rule:       "sql-no-select-star",
+			asset:      sqlAsset(pipeline.MaterializationTypeTable, "WITH base AS (SELECT * FROM raw.orders) SELECT b.* FROM base b"),
+			wantIssues: 1,
+		},
+		{
+			name:  "select star in a CTE and count(*) are allowed",
+			rule:  "sql-no-select-star",
+			asset: sqlAsset(pipeline.MaterializationTypeTable, "WITH base AS (SELECT * FROM raw.orders) SELECT id, count(*), id * 2 AS double_id FROM base GROUP BY id"),
+		},
+		{
+			name:  "select star with the excluded columns listed is allowed",
+			rule:  "sql-no-select-star",
+			asset: sqlAsset(pipeline.MaterializationTypeTable, "SELECT * EXCEPT (secret) FROM raw.orders"),
+		},
+		{
+			name:  "select star is allowed in views",
+			rule:  "sql-no-select-star",
+			asset: sqlAsset(pipeline.MaterializationTypeView, "SELECT * FROM raw.orders"),
+		},
+		{
+			name:       "comma joins are reported",
+			rule:       "sql-no-implicit-cross-join",
+			asset:      sqlAsset(pipeline.MaterializationTypeTable, "SELECT o.id FROM raw.orders o, raw.customers c WHERE o.customer_id = c.id"),
+			wantIssues: 1,
+		},
+		{
+			name:       "joins without a join condition are reported",
+			rule:       "sql-no-implicit-cross-join",
+			asset:      typed(pipeline.AssetTypePostgresQuery, sqlAsset(pipeline.MaterializationTypeTable, "SELECT o.id FROM raw.orders o JOIN raw.customers c CROSS JOIN raw.dates")),
+			wantIssues: 1,
+		},
+		{
+			name:  "unnest and distinct from are not joins",
+			rule:  "sql-no-implicit-cross-join",
+			asset: sqlAsset(pipeline.MaterializationTypeTable, "SELECT a IS DISTINCT FROM b, EXTRACT(YEAR FROM ts), item FROM raw.orders, UNNEST(items) AS item JOIN raw.c ON a = b"),
+		},
+		{
+			name:       "order by in a view without limit",
+			rule:       "sql-no-order-by-without-limit",
+			asset:      sqlAsset(pipeline.MaterializationTypeView, "SELECT id FROM raw.orders ORDER BY id"),
+			wantIssues: 1,
+		},
+		{
+			name:       "order by in a CTE without limit",
+			rule:       "sql-no-order-by-without-limit",
+			asset:      sqlAsset(pipeline.MaterializationTypeTable, "WITH latest AS (SELECT id FROM raw.orders ORDER BY id), top AS (SELECT id FROM raw.orders ORDER BY id LIMIT 10) SELECT id FROM latest ORDER BY id"),
+			wantIssues: 1,
+		},
+		{
+			name:  "window orderings and limited orderings are allowed",
+			rule:  "sql-no-order-by-without-limit",
+			asset: sqlAsset(pipeline.MaterializationTypeView, "WITH ranked AS (SELECT id, ROW_NUMBER() OVER (ORDER BY ts) AS rn FROM raw.orders) SELECT id FROM ranked ORDER BY id LIMIT 5"),
+		},
+		{
+			name:       "partition column wrapped in a function",
+			rule:       "sql-sargable-partition-filter",
+			asset:      partitioned("SELECT id FROM raw.orders WHERE DATE(created_at) = '2024-01-01'"),
+			wantIssues: 1,
+		},
+		{
+			name:       "partition column cast with ::",
+			rule:       "sql-sargable-partition-filter",
+			asset:      partitioned("SELECT id FROM raw.orders WHERE created_at::date = '2024-01-01'"),
+			wantIssues: 1,
+		},
+		{
+			name:  "partition column compared directly",
+			rule:  "sql-sargable-partition-filter",
+			asset: partitioned("SELECT DATE(created_at) AS day FROM raw.orders WHERE created_at >= TIMESTAMP('2024-01-01') AND id IN (SELECT MAX(created_at) FROM raw.other)"),
+		},
+		{
+			name:       "partition column converted to another time zone",
+			rule:       "sql-sargable-partition-filter",
+			asset:      typed(pipeline.AssetTypeSnowflakeQuery, partitioned("SELECT id FROM raw.orders WHERE created_at AT TIME ZONE 'UTC' >= '2024-01-01'")),
+			wantIssues: 1,
+		},
+		{
+			name:       "backslashes do not escape quotes in postgres strings",
+			rule:       "sql-sargable-partition-filter",
+			asset:      typed(pipeline.AssetTypePostgresQuery, partitioned(`SELECT id FROM raw.orders WHERE path = 'C:\' AND DATE(created_at) = '2024-01-01'`)),
+			wantIssues: 1,
+		},
+		{
+			name:       "bracket quoted partition column in a cast",
+			rule:       "sql-sargable-partition-filter",
+			asset:      typed(pipeline.AssetTypeMsSQLQuery, partitioned("SELECT id FROM raw.orders WHERE CONVERT(date, [created_at]) = '2024-01-01'")),
+			wantIssues: 1,
+		},
+		{
+			name:  "bigquery hash comments are ignored",
+			rule:  "sql-no-select-star",
+			asset: sqlAsset(pipeline.MaterializationTypeTable, "SELECT id # SELECT * FROM raw.orders\nFROM raw.orders"),
+		},
+		{
+			name:  "bigquery triple quoted strings are literals",
+			rule:  "sql-no-select-star",
+			asset: sqlAsset(pipeline.MaterializationTypeTable, `SELECT id, """it's SELECT * FROM x""" AS note FROM raw.orders`),
+		},
+		{
+			name:  "postgres dollar quoted strings are literals",
+			rule:  "sql-no-select-star",
+			asset: typed(pipeline.AssetTypePostgresQuery, sqlAsset(pipeline.MaterializationTypeTable, "SELECT id, $body$ SELECT * FROM x $body$ AS note FROM raw.orders")),
+		},
+		{
+			name:       "delete without where in a hook",
+			rule:       "sql-no-unfiltered-dml-in-hooks",
+			asset:      withHooks("DELETE FROM sales.orders_staging; UPDATE sales.orders SET status = 'done' WHERE id = 1"),
+			wantIssues: 1,
+		},
+		{
+			name:  "filtered dml in hooks",
+			rule:  "sql-no-unfiltered-dml-in-hooks",
+			asset: withHooks("DELETE FROM sales.orders_staging WHERE loaded_at < CURRENT_DATE()", "TRUNCATE TABLE sales.tmp"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			spec := &lint.PolicySpecification{
+				RuleSets: []lint.RuleSet{{Name: "sql", Rules: []string{tt.rule}}},
+			}
+			rules, err := spec.Rules(sharedSQLParser)
+			require.NoError(t, err)
+			require.Len(t, rules, 1)
+
+			issues, err := rules[0].ValidateAsset(t.Context(), &pipeline.Pipeline{}, tt.asset)
+			require.NoError(t, err)
+			assert.Len(t, issues, tt.wantIssues)
+		})
+	}
+}
+
+func TestSQLNoUndeclaredTablesPolicy(t *testing.T) {
+	t.Parallel()
+
+	asset := &pipeline.Asset{
+		Name: "sales.orders",
+		Type: pipeline.AssetTypeBigqueryQuery,
+		ExecutableFile: pipeline.ExecutableFile{
+			Content: "SELECT * FROM raw.orders JOIN raw.customers USING (id) JOIN ext.rates USING (currency)",
+		},
+		Upstreams: []pipeline.Upstream{
+			{Type: "asset", Value: "raw.orders"},
+			{Type: "uri", Value: "bigquery://project.ext.rates"},
+		},
+	}
+
+	parser := new(mockSQLParser)
+	parser.On("UsedTables", mock.Anything, "bigquery").Return([]string{"project.raw.orders", "raw.customers", "ext.rates"}, nil)
+
+	issues, err := lint.SQLNoUndeclaredTablesPolicy(parser)(t.Context(), &pipeline.Pipeline{}, asset)
+	require.NoError(t, err)
+	require.Len(t, issues, 1)
+	assert.Equal(t, []string{"raw.customers"}, issues[0].Context)
+}
+
+func TestParserRulesWithoutParser(t *testing.T) {
+	t.Parallel()
+
+	spec := &lint.PolicySpecification{
+		RuleSets: []lint.RuleSet{{Name: "sql", Rules: []string{"sql-no-undeclared-tables", "sql-no-select-star", "query-matches-columns"}}},
+	}
+
+	rules, err := spec.Rules(nil)
+	require.NoError(t, err)
+	require.Len(t, rules, 3)
+	for i, rule := range []string{"sql-no-undeclared-tables", "sql-no-select-star"} {
+		assert.Equal(t, lint.ValidatorSeverityWarning, rules[i].GetSeverity())
+		assert.Equal(t, []lint.Level{lint.LevelPipeline}, rules[i].GetApplicableLevels())
+
+		issues, err := rules[i].Validate(t.Context(), &pipeline.Pipeline{})
+		require.NoError(t, err)
+		require.Len(t, issues, 1)
+		assert.Equal(t, "The rule '"+rule+"' was not checked, it needs the SQL parser, which is not available here; run 'bruin validate' to check it", issues[0].Description)
+	}
+	// the rules that predate the warning keep passing silently without the parser
+	assert.Equal(t, lint.ValidatorSeverityCritical, rules[2].GetSeverity())
+	assert.Equal(t, []lint.Level{lint.LevelAsset}, rules[2].GetApplicableLevels())
+
+	// parsers that cannot find the SQL patterns only check sql-no-undeclared-tables
+	rules, err = spec.Rules(new(mockSQLParser))
+	require.NoError(t, err)
+	require.Len(t, rules, 3)
+	assert.Equal(t, lint.ValidatorSeverityCritical, rules[0].GetSeverity())
+	assert.Equal(t, []lint.Level{lint.LevelAsset}, rules[0].GetApplicableLevels())
+	assert.Equal(t, lint.ValidatorSeverityWarning, rules[1].GetSeverity())
+
+	rules, err = spec.Rules(sharedSQLParser)
+	require.NoError(t, err)
+	require.Len(t, rules, 3)
+	for _, rule := range rules {
+		assert.Equal(t, lint.ValidatorSeverityCritical, rule.GetSeverity())
+		assert.Equal(t, []lint.Level{lint.LevelAsset}, rule.GetApplicableLevels())
+	}
+}
+
+func TestPolicyRuleSetLevel(t *testing.T) {
+	t.Parallel()
+
+	spec := &lint.PolicySpecification{
+		RuleSets: []lint.RuleSet{
+			{Name: "strict", Rules: []string{"sql-no-select-star"}},
+			{Name: "advisory", Rules: []string{"sql-no-select-star"}, Level: "warning"},
+		},
+	}
+	rules, err := spec.Rules(sharedSQLParser)
+	require.NoError(t, err)
+	require.Len(t, rules, 2)
+	assert.Equal(t, "policy:strict:sql-no-select-star", rules[0].Name())
+	assert.Equal(t, lint.ValidatorSeverityCritical, rules[0].GetSeverity())
+	assert.Equal(t, lint.ValidatorSeverityWarning, rules[1].GetSeverity())
+
+	spec = &lint.PolicySpecification{
+		RuleSets: []lint.RuleSet{{Name: "invalid", Rules: []string{"sql-no-select-star"}, Level: "info"}},
+	}
+	_, err = spec.Rules(sharedSQLParser)
+	require.Error(t, err)
+}
@@ -10,13 +10,16 @@ import (
 	"regexp"
 	"slices"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/bruin-data/bruin/pkg/bigquery"
 	"github.com/bruin-data/bruin/pkg/executor"
+	"github.com/bruin-data/bruin/pkg/git"
 	"github.com/bruin-data/bruin/pkg/glossary"
 	"github.com/bruin-data/bruin/pkg/helpers"
 	"github.com/bruin-data/bruin/pkg/jinja"
+	"github.com/bruin-data/bruin/pkg/owners"
 	"github.com/bruin-data/bruin/pkg/path"
 	"github.com/bruin-data/bruin/pkg/pipeline"
 	"github.com/bruin-data/bruin/pkg/python"
@@ -60,6 +63,7 @@ const (
 	pipelineConcurrencyMustBePositive            = "Pipeline concurrency must be 1 or greater"
 	pipelineMaxActiveStepsMustBePositive         = "Pipeline max_active_steps must be a positive number"
 	assetTierMustBeBetweenOneAndFive             = "Asset tier must be between 1 and 5"
+	assetTierRequiresOwner                       = "Assets of tier %d or lower must have an owner"
 	assetTimeoutMustBeAtLeastOneSecond           = "Asset timeout must be at least 1s"
 	pipelineDefaultTimeoutMustBeAtLeastOneSecond = "Pipeline default timeout must be at least 1s"
 	secretMappingKeyMustExist                    = "Secrets must have a `key` attribute"
@@ -68,6 +72,7 @@ const (
 	materializationPartitionByNotSupportedForViews    = "Materialization partition by is not supported for views because views cannot be partitioned"
 	materializationIncrementalKeyNotSupportedForViews = "Materialization incremental key is not supported for views because views cannot be updated incrementally"
 	materializationClusterByNotSupportedForViews      = "Materialization cluster by is not supported for views because views cannot be clustered"
+	materializationOnSchemaChangeNotSupportedForViews = "Materialization on_schema_change is not supported for views because views are always recreated from the query"
 )
 
 var validIDRegexCompiled = regexp.MustCompile(validIDRegex)
@@ -655,13 +660,18 @@ func ValidateCustomCheckQueryExists(ctx context.Context, p *pipeline.Pipeline, a
 
 func ValidatePythonAssetMaterialization(ctx context.Context, p *pipeline.Pipeline, asset *pipeline.Asset) ([]*Issue, error) {
 	issues := make([]*Issue, 0)
-	if asset.Type != pipeline.AssetTypePython {
+	if asset.Type != pipeline.AssetTypePython && asset.Type != pipeline.AssetTypeR {
 		return issues, nil
 	}
 	if asset.Materialization.Type != pipeline.MaterializationTypeTable {
 		return issues, nil
 	}
 
+	assetTypeLabel := "Python"
+	if asset.Type == pipeline.AssetTypeR {
+		assetTypeLabel = "R"
+	}
+
 	if len(asset.Connection) == 0 {
 		issues = append(issues, &Issue{
 			Task:        asset,
@@ -672,7 +682,7 @@ func ValidatePythonAssetMaterialization(ctx context.Context, p *pipeline.Pipelin
 	if asset.Materialization.IncrementalPredicate != "" {
 		issues = append(issues, &Issue{
 			Task:        asset,
-			Description: "Incremental predicate is not supported for Python assets",
+			Description: fmt.Sprintf("Incremental predicate is not supported for %s assets", assetTypeLabel),
 		})
 	}
 
@@ -683,7 +693,119 @@ func ValidatePythonAssetMaterialization(ctx context.Context, p *pipeline.Pipelin
 	if !python.IsPythonMaterializationStrategySupported(asset.Materialization.Strategy) {
 		issues = append(issues, &Issue{
 			Task:        asset,
-			Description: fmt.Sprintf("Materialization strategy '%s' is not supported for Python assets. Supported strategies are: %s", asset.Materialization.Strategy, python.GetSupportedPythonStrategiesString()),
+			Description: fmt.Sprintf("Materialization strategy '%s' is not supported for %s assets. Supported strategies are: %s", asset.Materialization.Strategy, assetTypeLabel, python.GetSupportedPythonStrategiesString()),
+		})
+	}
+
+	return issues, nil
+}
+
+// ValidatePythonAssetOutputs checks the outputs of multi-output Python assets: only Python assets
+// that do not materialize a table themselves can declare them, and every output needs a unique name.
+func ValidatePythonAssetOutputs(ctx context.Context, p *pipeline.Pipeline, asset *pipeline.Asset) ([]*Issue, error) {
+	issues := make([]*Issue, 0)
+	if len(asset.Outputs) == 0 {
+		return issues, nil
+	}
+
+	if asset.Type != pipeline.AssetTypePython {
+		issues = append(issues, &Issue{
+			Task:        asset,
+			Description: "Outputs are only supported for Python assets",
+		})
+		return issues, nil
+	}
+
+	if asset.Materialization.Type != pipeline.MaterializationTypeNone {
+		issues = append(issues, &Issue{
+			Task:        asset,
+			Description: "A Python asset with outputs cannot have a materialization itself, define the materialization of each output instead",
+		})
+	}
+
+	seen := make(map[string]bool, len(asset.Outputs))
+	for _, output := range asset.Outputs {
+		name := strings.TrimSpace(output.Name)
+		switch {
+		case name == "":
+			issues = append(issues, &Issue{
+				Task:        asset,
+				Description: "Outputs must have a name",
+			})
+		case strings.EqualFold(name, asset.Name):
+			issues = append(issues, &Issue{
+				Task:        asset,
+				Description: fmt.Sprintf("Output '%s' cannot have the same name as the asset that produces it", name),
+			})
+		case seen[strings.ToLower(name)]:
+			issues = append(issues, &Issue{
+				Task:        asset,
+				Description: fmt.Sprintf("Output '%s' is defined more than once", name),
+			})
+		}
+		seen[strings.ToLower(name)] = true
+	}
+
+	return issues, nil
+}
+
+// ValidatePIIColumnsHaveMaskingPolicy requires a masking policy on the columns tagged as pii, either
+// on the column itself or through the glossary attribute it extends, for the platforms that
+// support masking policies.
+func ValidatePIIColumnsHaveMaskingPolicy(ctx context.Context, p *pipeline.Pipeline, asset *pipeline.Asset) ([]*Issue, error) {
+	issues := make([]*Issue, 0)
+
+	if asset.Materialization.Type == pipeline.MaterializationTypeNone {
+		return issues, nil
+	}
+
+	switch asset.Type {
+	case pipeline.AssetTypeSnowflakeQuery, pipeline.AssetTypeBigqueryQuery:
+	default:
+		return issues, nil
+	}
+
+	for _, column := range asset.Columns {
+		if !column.HasTag(pipeline.PIITag) || strings.TrimSpace(column.MaskingPolicy) != "" {
+			continue
+		}
+
+		issues = append(issues, &Issue{
+			Task:        asset,
+			Description: fmt.Sprintf("Column '%s' is tagged as pii but has no masking_policy", column.Name),
+		})
+	}
+
+	return issues, nil
+}
+
+// unsupportedMaskingPolicyAssetTypes are the asset types whose masking policies would not be
+// applied, mapped to the reason given to the user.
+var unsupportedMaskingPolicyAssetTypes = map[pipeline.AssetType]string{
+	pipeline.AssetTypePostgresQuery: "Postgres does not support masking policies",
+	pipeline.AssetTypeRedshiftQuery: "Redshift does not support masking policies",
+	pipeline.AssetTypeSnowflakeSeed: "masking policies are not applied to seed assets",
+	pipeline.AssetTypeBigquerySeed:  "masking policies are not applied to seed assets",
+}
+
+// ValidateMaskingPolicyPlatform rejects the masking policies of the assets that cannot apply them,
+// so that they fail before the table is materialized instead of exposing the column silently.
+func ValidateMaskingPolicyPlatform(ctx context.Context, p *pipeline.Pipeline, asset *pipeline.Asset) ([]*Issue, error) {
+	issues := make([]*Issue, 0)
+
+	reason, ok := unsupportedMaskingPolicyAssetTypes[asset.Type]
+	if !ok {
+		return issues, nil
+	}
+
+	for _, column := range asset.Columns {
+		if strings.TrimSpace(column.MaskingPolicy) == "" {
+			continue
+		}
+
+		issues = append(issues, &Issue{
+			Task:        asset,
+			Description: fmt.Sprintf("Column '%s' has a masking_policy, but %s, remove it or use a row_access_policy instead", column.Name, reason),
 		})
 	}
 
@@ -1437,6 +1559,113 @@ func EnsureAssetNotificationsAreValid(ctx context.Context, p *pipeline.Pipeline,
 	return issues, nil
 }
 
+func validateOnSchemaChange(asset *pipeline.Asset) []*Issue {
+	policy := asset.Materialization.OnSchemaChange
+	if policy == pipeline.MaterializationOnSchemaChangeNone {
+		return nil
+	}
+
+	if !slices.Contains(pipeline.AllAvailableOnSchemaChangePolicies, policy) {
+		return []*Issue{{
+			Task: asset,
+			Description: fmt.Sprintf(
+				"Materialization on_schema_change '%s' is not supported, available values are: %v",
+				policy,
+				pipeline.AllAvailableOnSchemaChangePolicies,
+			),
+		}}
+	}
+
+	if !asset.Materialization.SupportsSchemaEvolution() {
+		return []*Issue{{
+			Task:        asset,
+			Description: "Materialization on_schema_change is only supported with the 'append', 'merge', 'delete+insert' and 'time_interval' strategies.",
+		}}
+	}
+
+	return nil
+}
+
+// microbatchAssetTypes are the asset types whose operators run `time_interval` materializations in
+// batches when `batch_size` is set.
+var microbatchAssetTypes = []pipeline.AssetType{
+	pipeline.AssetTypeBigqueryQuery,
+	pipeline.AssetTypeDuckDBQuery,
+	pipeline.AssetTypePostgresQuery,
+	pipeline.AssetTypeRedshiftQuery,
+	pipeline.AssetTypeSnowflakeQuery,
+}
+
+func validateBatchSize(asset *pipeline.Asset) []*Issue {
+	mat := asset.Materialization
+	if mat.BatchSize == "" && mat.BatchConcurrency == 0 {
+		return nil
+	}
+
+	if mat.BatchSize == "" {
+		return []*Issue{{
+			Task:        asset,
+			Description: "Materialization batch_concurrency requires batch_size to be set",
+		}}
+	}
+
+	issues := make([]*Issue, 0)
+	if mat.Strategy != pipeline.MaterializationStrategyTimeInterval {
+		issues = append(issues, &Issue{
+			Task:        asset,
+			Description: "Materialization batch_size is only supported with the 'time_interval' strategy.",
+		})
+	}
+
+	if !slices.Contains(microbatchAssetTypes, asset.Type) {
+		issues = append(issues, &Issue{
+			Task:        asset,
+			Description: fmt.Sprintf("Materialization batch_size is not supported for asset type '%s'", asset.Type),
+		})
+	}
+
+	size, err := mat.BatchDuration()
+	if err != nil {
+		issues = append(issues, &Issue{
+			Task:        asset,
+			Description: err.Error(),
+		})
+	} else if mat.TimeGranularity == pipeline.MaterializationTimeGranularityDate && size%(24*time.Hour) != 0 {
+		issues = append(issues, &Issue{
+			Task:        asset,
+			Description: fmt.Sprintf("Materialization batch_size '%s' must be a whole number of days when 'time_granularity' is 'date'", mat.BatchSize),
+		})
+	}
+
+	if mat.BatchConcurrency < 0 {
+		issues = append(issues, &Issue{
+			Task:        asset,
+			Description: "Materialization batch_concurrency cannot be negative",
+		})
+	}
+
+	return issues
+}
+
+// insertOverwriteAssetTypes are the asset types whose platforms can replace whole partitions of a
+// table with the `insert_overwrite` strategy.
+var insertOverwriteAssetTypes = []pipeline.AssetType{
+	pipeline.AssetTypeBigqueryQuery,
+	pipeline.AssetTypeClickHouse,
+	pipeline.AssetTypeDatabricksQuery,
+	pipeline.AssetTypeFabricSparkQuery,
+	pipeline.AssetTypeSparkQuery,
+}
+
+// insertOverwriteUnsupportedReasons explain why `insert_overwrite` is rejected on the platforms users
+// most often expect it on, so the issue can point them to an alternative.
+var insertOverwriteUnsupportedReasons = map[pipeline.AssetType]string{
+	// Athena writes Iceberg tables, which have no INSERT OVERWRITE in Athena SQL. Emulating it with a
+	// DELETE followed by an INSERT commits two snapshots, and a failed INSERT leaves the partitions
+	// empty, which is exactly what the strategy promises to avoid.
+	pipeline.AssetTypeAthenaQuery: "Athena has no INSERT OVERWRITE for Iceberg tables and cannot replace a partition atomically, use the 'time_interval' or 'delete+insert' strategy instead",
+}
+
 func EnsureMaterializationValuesAreValidForSingleAsset(ctx context.Context, p *pipeline.Pipeline, asset *pipeline.Asset) ([]*Issue, error) {
 	issues := make([]*Issue, 0)
 	if asset.Type == pipeline.AssetTypePython || asset.Type == pipeline.AssetTypeIngestr {
@@ -1482,6 +1711,20 @@ func EnsureMaterializationValuesAreValidForSingleAsset(ctx context.Context, p *p
 			})
 		}
 
+		if asset.Materialization.OnSchemaChange != pipeline.MaterializationOnSchemaChangeNone {
+			issues = append(issues, &Issue{
+				Task:        asset,
+				Description: materializationOnSchemaChangeNotSupportedForViews,
+			})
+		}
+
+		if asset.Materialization.BatchSize != "" {
+			issues = append(issues, &Issue{
+				Task:        asset,
+				Description: "Materialization batch_size is not supported for views",
+			})
+		}
+
 	case pipeline.MaterializationTypeTable:
 		if asset.Materialization.IncrementalPredicate != "" && asset.Materialization.Strategy != pipeline.MaterializationStrategyMerge {
 			issues = append(issues, &Issue{
@@ -1490,6 +1733,9 @@ func EnsureMaterializationValuesAreValidForSingleAsset(ctx context.Context, p *p
 			})
 		}
 
+		issues = append(issues, validateOnSchemaChange(asset)...)
+		issues = append(issues, validateBatchSize(asset)...)
+
 		if asset.Materialization.Strategy == pipeline.MaterializationStrategyNone {
 			return issues, nil
 		}
@@ -1593,6 +1839,29 @@ func EnsureMaterializationValuesAreValidForSingleAsset(ctx context.Context, p *p
 					Description: "'time_granularity' can be either 'date' or 'timestamp'.",
 				})
 			}
+		case pipeline.MaterializationStrategyInsertOverwrite:
+			if asset.Materialization.PartitionBy == "" {
+				issues = append(issues, &Issue{
+					Task:        asset,
+					Description: "Materialization strategy 'insert_overwrite' requires the 'partition_by' field to be set",
+				})
+			}
+			if asset.Materialization.TimeGranularity != "" && asset.Materialization.TimeGranularity != pipeline.MaterializationTimeGranularityDate && asset.Materialization.TimeGranularity != pipeline.MaterializationTimeGranularityTimestamp {
+				issues = append(issues, &Issue{
+					Task:        asset,
+					Description: "'time_granularity' can be either 'date' or 'timestamp'.",
+				})
+			}
+			if !slices.Contains(insertOverwriteAssetTypes, asset.Type) {
+				description := fmt.Sprintf("Materialization strategy 'insert_overwrite' is not supported for asset type '%s'", asset.Type)
+				if reason, ok := insertOverwriteUnsupportedReasons[asset.Type]; ok {
+					description += ": " + reason
+				}
+				issues = append(issues, &Issue{
+					Task:        asset,
+					Description: description,
+				})
+			}
 		default:
 			issues = append(issues, &Issue{
 				Task: asset,
@@ -2378,6 +2647,65 @@ func EnsureAssetTierIsValidForASingleAsset(ctx context.Context, p *pipeline.Pipe
 	return issues, nil
 }
 
+// OwnerChecker requires the assets up to the tier configured with `require_owner_max_tier` in the
+// owners.yml file of the repository to have an owner.
+type OwnerChecker struct {
+	fs     afero.Fs
+	finder repoFinder
+
+	mu      sync.Mutex
+	configs map[string]*owners.Config
+}
+
+func (o *OwnerChecker) EnsureAssetHasOwnerForItsTier(ctx context.Context, p *pipeline.Pipeline, asset *pipeline.Asset) ([]*Issue, error) {
+	if asset.Tier == 0 || strings.TrimSpace(asset.Owner) != "" {
+		return nil, nil
+	}
+
+	config, err := o.config(p.DefinitionFile.Path)
+	if err != nil {
+		return nil, err
+	}
+	if config.RequireOwnerMaxTier == 0 || asset.Tier > config.RequireOwnerMaxTier {
+		return nil, nil
+	}
+
+	return []*Issue{
+		{
+			Task:        asset,
+			Description: fmt.Sprintf(assetTierRequiresOwner, config.RequireOwnerMaxTier),
+		},
+	}, nil
+}
+
+func (o *OwnerChecker) config(pipelinePath string) (*owners.Config, error) {
+	if o.finder == nil {
+		return &owners.Config{}, nil
+	}
+	repo, err := o.finder.Repo(pipelinePath)
+	if errors.Is(err, git.ErrNoGitRepoFound) {
+		return &owners.Config{}, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to find the repository")
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if config, ok := o.configs[repo.Path]; ok {
+		return config, nil
+	}
+	config, err := owners.ReadConfig(o.fs, repo.Path)
+	if err != nil {
+		return nil, err
+	}
+	if o.configs == nil {
+		o.configs = make(map[string]*owners.Config)
+	}
+	o.configs[repo.Path] = config
+	return config, nil
+}
+
 func EnsureAssetTimeoutIsValidForASingleAsset(ctx context.Context, p *pipeline.Pipeline, asset *pipeline.Asset) ([]*Issue, error) {
 	issues := make([]*Issue, 0)
 	if asset.Timeout != 0 && asset.Timeout.Duration() < time.Second {
@@ -10,8 +10,10 @@ import (
 	"testing"
 	"time"
 
+	"github.com/bruin-data/bruin/pkg/git"
 	"github.com/bruin-data/bruin/pkg/glossary"
 	"github.com/bruin-data/bruin/pkg/jinja"
+	"github.com/bruin-data/bruin/pkg/owners"
 	"github.com/bruin-data/bruin/pkg/path"
 	"github.com/bruin-data/bruin/pkg/pipeline"
 	"github.com/bruin-data/bruin/pkg/query"
@@ -1349,6 +1351,167 @@ func TestEnsureMaterializationValuesAreValid(t *testing.T) {
 			},
 			wantErr: assert.NoError,
 		},
+		{
+			name: "table materialization has append with on_schema_change, all good",
+			assets: []*pipeline.Asset{
+				{
+					Name: "task1",
+					Materialization: pipeline.Materialization{
+						Type:           pipeline.MaterializationTypeTable,
+						Strategy:       pipeline.MaterializationStrategyAppend,
+						OnSchemaChange: pipeline.MaterializationOnSchemaChangeAppendNewColumns,
+					},
+				},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "table materialization has on_schema_change with a non-incremental strategy",
+			assets: []*pipeline.Asset{
+				{
+					Name: "task1",
+					Materialization: pipeline.Materialization{
+						Type:           pipeline.MaterializationTypeTable,
+						Strategy:       pipeline.MaterializationStrategyCreateReplace,
+						OnSchemaChange: pipeline.MaterializationOnSchemaChangeSyncAllColumns,
+					},
+				},
+			},
+			wantErr: assert.NoError,
+			want: []string{
+				"Materialization on_schema_change is only supported with the 'append', 'merge', 'delete+insert' and 'time_interval' strategies.",
+			},
+		},
+		{
+			name: "table materialization has an unknown on_schema_change value",
+			assets: []*pipeline.Asset{
+				{
+					Name: "task1",
+					Materialization: pipeline.Materialization{
+						Type:           pipeline.MaterializationTypeTable,
+						Strategy:       pipeline.MaterializationStrategyAppend,
+						OnSchemaChange: "drop_everything",
+					},
+				},
+			},
+			wantErr: assert.NoError,
+			want: []string{
+				"Materialization on_schema_change 'drop_everything' is not supported, available values are: [fail ignore append_new_columns sync_all_columns]",
+			},
+		},
+		{
+			name: "time_interval with batch_size, all good",
+			assets: []*pipeline.Asset{
+				{
+					Name: "task1",
+					Type: pipeline.AssetTypeDuckDBQuery,
+					Materialization: pipeline.Materialization{
+						Type:             pipeline.MaterializationTypeTable,
+						Strategy:         pipeline.MaterializationStrategyTimeInterval,
+						IncrementalKey:   "dt",
+						TimeGranularity:  pipeline.MaterializationTimeGranularityDate,
+						BatchSize:        "7d",
+						BatchConcurrency: 2,
+					},
+				},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "batch_size with hours on a date granularity",
+			assets: []*pipeline.Asset{
+				{
+					Name: "task1",
+					Type: pipeline.AssetTypeSnowflakeQuery,
+					Materialization: pipeline.Materialization{
+						Type:            pipeline.MaterializationTypeTable,
+						Strategy:        pipeline.MaterializationStrategyTimeInterval,
+						IncrementalKey:  "dt",
+						TimeGranularity: pipeline.MaterializationTimeGranularityDate,
+						BatchSize:       "6h",
+					},
+				},
+			},
+			wantErr: assert.NoError,
+			want: []string{
+				"Materialization batch_size '6h' must be a whole number of days when 'time_granularity' is 'date'",
+			},
+		},
+		{
+			name: "batch_size with a non-time_interval strategy and an invalid value",
+			assets: []*pipeline.Asset{
+				{
+					Name: "task1",
+					Type: pipeline.AssetTypeMsSQLQuery,
+					Materialization: pipeline.Materialization{
+						Type:           pipeline.MaterializationTypeTable,
+						Strategy:       pipeline.MaterializationStrategyDeleteInsert,
+						IncrementalKey: "dt",
+						BatchSize:      "1w",
+					},
+				},
+			},
+			wantErr: assert.NoError,
+			want: []string{
+				"Materialization batch_size is only supported with the 'time_interval' strategy.",
+				"Materialization batch_size is not supported for asset type 'ms.sql'",
+				"invalid batch_size '1w', the unit must be 'h' or 'd'",
+			},
+		},
+		{
+			name: "insert_overwrite with partition_by, all good",
+			assets: []*pipeline.Asset{
+				{
+					Name: "task1",
+					Type: pipeline.AssetTypeBigqueryQuery,
+					Materialization: pipeline.Materialization{
+						Type:            pipeline.MaterializationTypeTable,
+						Strategy:        pipeline.MaterializationStrategyInsertOverwrite,
+						PartitionBy:     "dt",
+						TimeGranularity: pipeline.MaterializationTimeGranularityDate,
+					},
+				},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "insert_overwrite without partition_by on an unsupported platform",
+			assets: []*pipeline.Asset{
+				{
+					Name: "task1",
+					Type: pipeline.AssetTypeAthenaQuery,
+					Materialization: pipeline.Materialization{
+						Type:            pipeline.MaterializationTypeTable,
+						Strategy:        pipeline.MaterializationStrategyInsertOverwrite,
+						TimeGranularity: "month",
+					},
+				},
+			},
+			wantErr: assert.NoError,
+			want: []string{
+				"Materialization strategy 'insert_overwrite' requires the 'partition_by' field to be set",
+				"'time_granularity' can be either 'date' or 'timestamp'.",
+				"Materialization strategy 'insert_overwrite' is not supported for asset type 'athena.sql': Athena has no INSERT OVERWRITE for Iceberg tables and cannot replace a partition atomically, use the 'time_interval' or 'delete+insert' strategy instead",
+			},
+		},
+		{
+			name: "insert_overwrite on a platform without a partition replacement",
+			assets: []*pipeline.Asset{
+				{
+					Name: "task1",
+					Type: pipeline.AssetTypePostgresQuery,
+					Materialization: pipeline.Materialization{
+						Type:        pipeline.MaterializationTypeTable,
+						Strategy:    pipeline.MaterializationStrategyInsertOverwrite,
+						PartitionBy: "dt",
+					},
+				},
+			},
+			wantErr: assert.NoError,
+			want: []string{
+				"Materialization strategy 'insert_overwrite' is not supported for asset type 'pg.sql'",
+			},
+		},
 		{
 			name: "table materialization has incremental key but wrong strategy",
 			assets: []*pipeline.Asset{
@@ -3987,6 +4150,49 @@ func TestEnsureValidPythonAssetMaterialization(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "r asset without a connection and with an incremental predicate",
+			p: &pipeline.Pipeline{
+				Assets: []*pipeline.Asset{
+					{
+						Name: "asset1",
+						Type: pipeline.AssetTypeR,
+						Materialization: pipeline.Materialization{
+							Type:                 pipeline.MaterializationTypeTable,
+							Strategy:             pipeline.MaterializationStrategyMerge,
+							IncrementalPredicate: "target.dt >= '2024-01-01'",
+						},
+					},
+				},
+			},
+			want: []*Issue{
+				{
+					Task: &pipeline.Asset{
+						Name: "asset1",
+						Type: pipeline.AssetTypeR,
+						Materialization: pipeline.Materialization{
+							Type:                 pipeline.MaterializationTypeTable,
+							Strategy:             pipeline.MaterializationStrategyMerge,
+							IncrementalPredicate: "target.dt >= '2024-01-01'",
+						},
+					},
+					Description: "A task with materialization must have a connection defined",
+				},
+				{
+					Task: &pipeline.Asset{
+						Name: "asset1",
+						Type: pipeline.AssetTypeR,
+						Materialization: pipeline.Materialization{
+							Type:                 pipeline.MaterializationTypeTable,
+							Strategy:             pipeline.MaterializationStrategyMerge,
+							IncrementalPredicate: "target.dt >= '2024-01-01'",
+						},
+					},
+					Description: "Incremental predicate is not supported for R assets",
+				},
+			},
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -4004,6 +4210,232 @@ func TestEnsureValidPythonAssetMaterialization(t *testing.T) {
 	}
 }
 
+func TestValidatePythonAssetOutputs(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		asset *pipeline.Asset
+		want  []string
+	}{
+		{
+			name:  "assets without outputs are skipped",
+			asset: &pipeline.Asset{Name: "raw.shop", Type: pipeline.AssetTypePython},
+		},
+		{
+			name: "valid outputs",
+			asset: &pipeline.Asset{
+				Name:    "raw.shop",
+				Type:    pipeline.AssetTypePython,
+				Outputs: []*pipeline.Asset{{Name: "raw.orders"}, {Name: "raw.refunds"}},
+			},
+		},
+		{
+			name: "outputs on a non-python asset",
+			asset: &pipeline.Asset{
+				Name:    "raw.shop",
+				Type:    pipeline.AssetTypeBigqueryQuery,
+				Outputs: []*pipeline.Asset{{Name: "raw.orders"}},
+			},
+			want: []string{"Outputs are only supported for Python assets"},
+		},
+		{
+			name: "materialized asset with outputs",
+			asset: &pipeline.Asset{
+				Name:            "raw.shop",
+				Type:            pipeline.AssetTypePython,
+				Materialization: pipeline.Materialization{Type: pipeline.MaterializationTypeTable},
+				Outputs:         []*pipeline.Asset{{Name: "raw.orders"}},
+			},
+			want: []string{"A Python asset with outputs cannot have a materialization itself, define the materialization of each output instead"},
+		},
+		{
+			name: "invalid output names",
+			asset: &pipeline.Asset{
+				Name: "raw.shop",
+				Type: pipeline.AssetTypePython,
+				Outputs: []*pipeline.Asset{
+					{Name: "raw.orders"},
+					{Name: ""},
+					{Name: "RAW.ORDERS"},
+					{Name: "raw.shop"},
+				},
+			},
+			want: []string{
+				"Outputs must have a name",
+				"Output 'RAW.ORDERS' is defined more than once",
+				"Output 'raw.shop' cannot have the same name as the asset that produces it",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ValidatePythonAssetOutputs(t.Context(), &pipeline.Pipeline{}, tt.asset)
+			require.NoError(t, err)
+
+			descriptions := make([]string, 0, len(got))
+			for _, issue := range got {
+				assert.Equal(t, tt.asset, issue.Task)
+				descriptions = append(descriptions, issue.Description)
+			}
+			if len(tt.want) == 0 {
+				assert.Empty(t, descriptions)
+				return
+			}
+			assert.Equal(t, tt.want, descriptions)
+		})
+	}
+}
+
+func TestValidatePIIColumnsHaveMaskingPolicy(t *testing.T) {
+	t.Parallel()
+
+	table := pipeline.Materialization{Type: pipeline.MaterializationTypeTable}
+	tests := []struct {
+		name  string
+		asset *pipeline.Asset
+		want  []string
+	}{
+		{
+			name: "pii columns with masking policies",
+			asset: &pipeline.Asset{
+				Name:            "analytics.customers",
+				Type:            pipeline.AssetTypeSnowflakeQuery,
+				Materialization: table,
+				Columns: []pipeline.Column{
+					{Name: "id"},
+					{Name: "email", Tags: pipeline.EmptyStringArray{"PII"}, MaskingPolicy: "governance.policies.email_mask"},
+				},
+			},
+		},
+		{
+			name: "pii columns without masking policies",
+			asset: &pipeline.Asset{
+				Name:            "analytics.customers",
+				Type:            pipeline.AssetTypeBigqueryQuery,
+				Materialization: table,
+				Columns: []pipeline.Column{
+					{Name: "email", Tags: pipeline.EmptyStringArray{"pii"}},
+					{Name: "phone", Tags: pipeline.EmptyStringArray{"contact", "pii"}},
+					{Name: "country", Tags: pipeline.EmptyStringArray{"geo"}},
+				},
+			},
+			want: []string{
+				"Column 'email' is tagged as pii but has no masking_policy",
+				"Column 'phone' is tagged as pii but has no masking_policy",
+			},
+		},
+		{
+			name: "assets without materialization are skipped",
+			asset: &pipeline.Asset{
+				Name:    "analytics.customers",
+				Type:    pipeline.AssetTypeSnowflakeQuery,
+				Columns: []pipeline.Column{{Name: "email", Tags: pipeline.EmptyStringArray{"pii"}}},
+			},
+		},
+		{
+			name: "platforms without masking policies are skipped",
+			asset: &pipeline.Asset{
+				Name:            "public.customers",
+				Type:            pipeline.AssetTypePostgresQuery,
+				Materialization: table,
+				Columns:         []pipeline.Column{{Name: "email", Tags: pipeline.EmptyStringArray{"pii"}}},
+			},
+		},
+		{
+			name: "seeds are skipped since their policies are not applied",
+			asset: &pipeline.Asset{
+				Name:            "raw.customers",
+				Type:            pipeline.AssetTypeSnowflakeSeed,
+				Materialization: table,
+				Columns:         []pipeline.Column{{Name: "email", Tags: pipeline.EmptyStringArray{"pii"}}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ValidatePIIColumnsHaveMaskingPolicy(t.Context(), &pipeline.Pipeline{}, tt.asset)
+			require.NoError(t, err)
+
+			descriptions := make([]string, 0, len(got))
+			for _, issue := range got {
+				assert.Equal(t, tt.asset, issue.Task)
+				descriptions = append(descriptions, issue.Description)
+			}
+			if len(tt.want) == 0 {
+				assert.Empty(t, descriptions)
+				return
+			}
+			assert.Equal(t, tt.want, descriptions)
+		})
+	}
+}
+
+func TestValidateMaskingPolicyPlatform(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		asset *pipeline.Asset
+		want  []string
+	}{
+		{
+			name: "snowflake assets can have masking policies",
+			asset: &pipeline.Asset{
+				Name:    "analytics.customers",
+				Type:    pipeline.AssetTypeSnowflakeQuery,
+				Columns: []pipeline.Column{{Name: "email", MaskingPolicy: "governance.policies.email_mask"}},
+			},
+		},
+		{
+			name: "postgres assets cannot have masking policies",
+			asset: &pipeline.Asset{
+				Name: "public.customers",
+				Type: pipeline.AssetTypePostgresQuery,
+				Columns: []pipeline.Column{
+					{Name: "id"},
+					{Name: "email", MaskingPolicy: "email_mask"},
+				},
+			},
+			want: []string{"Column 'email' has a masking_policy, but Postgres does not support masking policies, remove it or use a row_access_policy instead"},
+		},
+		{
+			name: "seed assets cannot have masking policies",
+			asset: &pipeline.Asset{
+				Name:    "raw.customers",
+				Type:    pipeline.AssetTypeBigquerySeed,
+				Columns: []pipeline.Column{{Name: "email", MaskingPolicy: "projects/p/locations/eu/taxonomies/1/policyTags/2"}},
+			},
+			want: []string{"Column 'email' has a masking_policy, but masking policies are not applied to seed assets, remove it or use a row_access_policy instead"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ValidateMaskingPolicyPlatform(t.Context(), &pipeline.Pipeline{}, tt.asset)
+			require.NoError(t, err)
+
+			descriptions := make([]string, 0, len(got))
+			for _, issue := range got {
+				descriptions = append(descriptions, issue.Description)
+			}
+			if len(tt.want) == 0 {
+				assert.Empty(t, descriptions)
+				return
+			}
+			assert.Equal(t, tt.want, descriptions)
+		})
+	}
+}
+
 func TestValidateScriptAssetHooksUnsupported(t *testing.T) {
 	t.Parallel()
 
@@ -4672,6 +5104,84 @@ func TestEnsureAssetTierIsValidForASingleAsset(t *testing.T) {
 	}
 }
 
+type staticRepoFinder struct {
+	path string
+}
+
+func (f staticRepoFinder) Repo(string) (*git.Repo, error) {
+	return &git.Repo{Path: f.path}, nil
+}
+
+func TestOwnerChecker_EnsureAssetHasOwnerForItsTier(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		config *owners.Config
+		asset  *pipeline.Asset
+		want   []*Issue
+	}{
+		{
+			name:   "critical asset without an owner",
+			config: &owners.Config{RequireOwnerMaxTier: 2},
+			asset:  &pipeline.Asset{Name: "mart.orders", Tier: 2},
+			want: []*Issue{
+				{
+					Task:        &pipeline.Asset{Name: "mart.orders", Tier: 2},
+					Description: "Assets of tier 2 or lower must have an owner",
+				},
+			},
+		},
+		{
+			name:   "critical asset with an owner",
+			config: &owners.Config{RequireOwnerMaxTier: 2},
+			asset:  &pipeline.Asset{Name: "mart.orders", Tier: 1, Owner: "jane@example.com"},
+		},
+		{
+			name:   "asset above the required tier",
+			config: &owners.Config{RequireOwnerMaxTier: 2},
+			asset:  &pipeline.Asset{Name: "mart.orders", Tier: 3},
+		},
+		{
+			name:   "asset without a tier",
+			config: &owners.Config{RequireOwnerMaxTier: 2},
+			asset:  &pipeline.Asset{Name: "mart.orders"},
+		},
+		{
+			name:   "rule not configured",
+			config: &owners.Config{},
+			asset:  &pipeline.Asset{Name: "mart.orders", Tier: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			checker := &OwnerChecker{
+				finder:  staticRepoFinder{path: "/repo"},
+				configs: map[string]*owners.Config{"/repo": tt.config},
+			}
+			got, err := checker.EnsureAssetHasOwnerForItsTier(t.Context(), &pipeline.Pipeline{}, tt.asset)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestOwnerChecker_ReadsConfigFromFs(t *testing.T) {
+	t.Parallel()
+
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "/repo/owners.yml", []byte("require_owner_max_tier: 2\n"), 0o600))
+
+	checker := &OwnerChecker{fs: fs, finder: staticRepoFinder{path: "/repo"}}
+	got, err := checker.EnsureAssetHasOwnerForItsTier(t.Context(), &pipeline.Pipeline{}, &pipeline.Asset{Name: "mart.orders", Tier: 1})
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, "Assets of tier 2 or lower must have an owner", got[0].Description)
+}
+
 func TestEnsureAssetTimeoutIsValidForASingleAsset(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,260 @@
+package microbatch
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bruin-data/bruin/pkg/executor"
+	"github.com/bruin-data/bruin/pkg/helpers"
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/pkg/errors"
+	"github.com/spf13/afero"
+	"golang.org/x/sync/errgroup"
+)
+
+// Batch is a single window of a microbatched run. Both ends are inclusive, the same way
+// `start_date` and `end_date` are for a regular run.
+type Batch struct {
+	Start time.Time
+	End   time.Time
+}
+
+func (b Batch) String() string {
+	return fmt.Sprintf("%s - %s", b.Start.Format(time.RFC3339), b.End.Format(time.RFC3339))
+}
+
+// Split splits the inclusive window between start and end into consecutive batches of the given
+// size. Every batch ends one nanosecond before the next one starts, and the last batch is cut at
+// the end of the window.
+func Split(start, end time.Time, size time.Duration) ([]Batch, error) {
+	if size <= 0 {
+		return nil, errors.New("batch size must be positive")
+	}
+	if end.Before(start) {
+		return nil, errors.Errorf("end date %s is before start date %s", end.Format(time.RFC3339), start.Format(time.RFC3339))
+	}
+
+	batches := make([]Batch, 0)
+	for batchStart := start; !batchStart.After(end); batchStart = batchStart.Add(size) {
+		batchEnd := batchStart.Add(size - time.Nanosecond)
+		if batchEnd.After(end) {
+			batchEnd = end
+		}
+		batches = append(batches, Batch{Start: batchStart, End: batchEnd})
+	}
+
+	return batches, nil
+}
+
+// state records the batches that finished successfully for a given asset and window, so that a
+// retry of the same window only runs the batches that did not succeed.
+type state struct {
+	Asset     string      `json:"asset"`
+	Start     time.Time   `json:"start"`
+	End       time.Time   `json:"end"`
+	BatchSize string      `json:"batch_size"`
+	Completed []time.Time `json:"completed"`
+}
+
+func (s *state) matches(asset *pipeline.Asset, start, end time.Time) bool {
+	return s.Asset == asset.Name && s.Start.Equal(start) && s.End.Equal(end) && s.BatchSize == asset.Materialization.BatchSize
+}
+
+func (s *state) isCompleted(batch Batch) bool {
+	for _, completed := range s.Completed {
+		if completed.Equal(batch.Start) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Runner executes `time_interval` assets that have a `batch_size` one batch at a time.
+type Runner struct {
+	fs afero.Fs
+}
+
+func NewRunner(fs afero.Fs) *Runner {
+	return &Runner{fs: fs}
+}
+
+// Run splits the run window of the asset into batches and calls runBatch once per batch with a
+// context where the start and end dates are scoped to the batch. Batches run sequentially unless
+// `batch_concurrency` is greater than one. When a state path is available in the context, the
+// batches that succeeded are recorded there and skipped when the same window is run again.
+func (r *Runner) Run(ctx context.Context, asset *pipeline.Asset, runBatch func(ctx context.Context) error) error {
+	size, err := asset.Materialization.BatchDuration()
+	if err != nil {
+		return err
+	}
+
+	if asset.Materialization.TimeGranularity == pipeline.MaterializationTimeGranularityDate && size%(24*time.Hour) != 0 {
+		return errors.Errorf("batch_size '%s' cannot be used with the 'date' time_granularity, use a whole number of days instead", asset.Materialization.BatchSize)
+	}
+
+	start, end, err := runWindow(ctx, asset)
+	if err != nil {
+		return err
+	}
+
+	batches, err := Split(start, end, size)
+	if err != nil {
+		return err
+	}
+
+	statePath := r.statePath(ctx, asset)
+	runState := r.readState(statePath, asset, start, end)
+
+	writer, _ := ctx.Value(executor.KeyPrinter).(io.Writer)
+	pending := make([]Batch, 0, len(batches))
+	for _, batch := range batches {
+		if runState.isCompleted(batch) {
+			continue
+		}
+		pending = append(pending, batch)
+	}
+
+	if skipped := len(batches) - len(pending); skipped > 0 && writer != nil {
+		fmt.Fprintf(writer, "Skipping %d of %d batches that already succeeded for this window\n", skipped, len(batches))
+	}
+
+	concurrency := asset.Materialization.BatchConcurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	if concurrency > 1 && sequentialAssetTypes[asset.Type] {
+		if writer != nil {
+			fmt.Fprintf(writer, "Ignoring batch_concurrency %d, %s assets run their batches one at a time\n", concurrency, asset.Type)
+		}
+		concurrency = 1
+	}
+
+	var stateLock sync.Mutex
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.SetLimit(concurrency)
+	for i, batch := range pending {
+		group.Go(func() error {
+			// Stop picking up batches once one of them failed, the retry will pick them up.
+			if groupCtx.Err() != nil {
+				return groupCtx.Err()
+			}
+
+			if writer != nil {
+				fmt.Fprintf(writer, "Running batch %d/%d: %s\n", i+1, len(pending), batch)
+			}
+
+			batchCtx := context.WithValue(groupCtx, pipeline.RunConfigStartDate, batch.Start)
+			batchCtx = context.WithValue(batchCtx, pipeline.RunConfigEndDate, batch.End)
+			// The interval modifiers have already been applied to the whole window.
+			batchCtx = context.WithValue(batchCtx, pipeline.RunConfigApplyIntervalModifiers, false)
+
+			if err := runBatch(batchCtx); err != nil {
+				return errors.Wrapf(err, "batch %s failed", batch)
+			}
+
+			stateLock.Lock()
+			defer stateLock.Unlock()
+			runState.Completed = append(runState.Completed, batch.Start)
+
+			return r.writeState(statePath, runState)
+		})
+	}
+
+	if err := group.Wait(); err != nil {
+		return err
+	}
+
+	return r.removeState(statePath)
+}
+
+// sequentialAssetTypes are the asset types whose batches cannot run at the same time: DuckDB
+// allows a single writer per database file, so concurrent batches would only wait for each
+// other's lock, or fail to open the file.
+var sequentialAssetTypes = map[pipeline.AssetType]bool{
+	pipeline.AssetTypeDuckDBQuery: true,
+}
+
+// runWindow returns the window the asset is run for, with the interval modifiers applied.
+func runWindow(ctx context.Context, asset *pipeline.Asset) (time.Time, time.Time, error) {
+	start, ok := ctx.Value(pipeline.RunConfigStartDate).(time.Time)
+	if !ok {
+		return time.Time{}, time.Time{}, errors.New("start date is required to run an asset with batch_size")
+	}
+
+	end, ok := ctx.Value(pipeline.RunConfigEndDate).(time.Time)
+	if !ok {
+		return time.Time{}, time.Time{}, errors.New("end date is required to run an asset with batch_size")
+	}
+
+	applyModifiers, _ := ctx.Value(pipeline.RunConfigApplyIntervalModifiers).(bool)
+	if !applyModifiers {
+		return start, end, nil
+	}
+
+	if asset.IntervalModifiers.Start.Template != "" || asset.IntervalModifiers.End.Template != "" {
+		return time.Time{}, time.Time{}, errors.New("templated interval_modifiers cannot be used together with batch_size")
+	}
+
+	return pipeline.ModifyDate(start, asset.IntervalModifiers.Start), pipeline.ModifyDate(end, asset.IntervalModifiers.End), nil
+}
+
+func (r *Runner) statePath(ctx context.Context, asset *pipeline.Asset) string {
+	dir, ok := ctx.Value(pipeline.RunConfigMicrobatchStatePath).(string)
+	if !ok || dir == "" || r.fs == nil {
+		return ""
+	}
+
+	return filepath.Join(dir, strings.ReplaceAll(asset.Name, string(filepath.Separator), "_")+".json")
+}
+
+func (r *Runner) readState(path string, asset *pipeline.Asset, start, end time.Time) *state {
+	fresh := &state{
+		Asset:     asset.Name,
+		Start:     start,
+		End:       end,
+		BatchSize: asset.Materialization.BatchSize,
+		Completed: make([]time.Time, 0),
+	}
+	if path == "" {
+		return fresh
+	}
+
+	existing := &state{}
+	if err := helpers.ReadJSONToFile(r.fs, path, existing); err != nil {
+		return fresh
+	}
+
+	// A state recorded for a different window or batch size says nothing about this run.
+	if !existing.matches(asset, start, end) {
+		return fresh
+	}
+
+	return existing
+}
+
+func (r *Runner) writeState(path string, s *state) error {
+	if path == "" {
+		return nil
+	}
+
+	return errors.Wrap(helpers.WriteJSONToFile(r.fs, s, path), "failed to record the microbatch state")
+}
+
+func (r *Runner) removeState(path string) error {
+	if path == "" {
+		return nil
+	}
+
+	if err := r.fs.Remove(path); err != nil && !os.IsNotExist(err) {
+		return errors.Wrap(err, "failed to clean up the microbatch state")
+	}
+
+	return nil
+}
@@ -0,0 +1,220 @@
+package microbatch
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bruin-data/bruin/pkg/executor"
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplit(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		end     time.Time
+		size    time.Duration
+		want    []Batch
+		wantErr bool
+	}{
+		{
+			name: "three daily batches",
+			end:  time.Date(2024, 1, 3, 23, 59, 59, 999999999, time.UTC),
+			size: 24 * time.Hour,
+			want: []Batch{
+				{Start: start, End: time.Date(2024, 1, 1, 23, 59, 59, 999999999, time.UTC)},
+				{Start: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), End: time.Date(2024, 1, 2, 23, 59, 59, 999999999, time.UTC)},
+				{Start: time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC), End: time.Date(2024, 1, 3, 23, 59, 59, 999999999, time.UTC)},
+			},
+		},
+		{
+			name: "last batch is cut at the end of the window",
+			end:  time.Date(2024, 1, 2, 11, 59, 59, 0, time.UTC),
+			size: 24 * time.Hour,
+			want: []Batch{
+				{Start: start, End: time.Date(2024, 1, 1, 23, 59, 59, 999999999, time.UTC)},
+				{Start: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), End: time.Date(2024, 1, 2, 11, 59, 59, 0, time.UTC)},
+			},
+		},
+		{
+			name: "window smaller than a batch",
+			end:  time.Date(2024, 1, 1, 5, 0, 0, 0, time.UTC),
+			size: 24 * time.Hour,
+			want: []Batch{{Start: start, End: time.Date(2024, 1, 1, 5, 0, 0, 0, time.UTC)}},
+		},
+		{
+			name:    "end before start",
+			end:     start.Add(-time.Hour),
+			size:    time.Hour,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := Split(start, tt.end, tt.size)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func batchContext(stateDir string) context.Context {
+	ctx := context.WithValue(context.Background(), pipeline.RunConfigStartDate, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	ctx = context.WithValue(ctx, pipeline.RunConfigEndDate, time.Date(2024, 1, 4, 23, 59, 59, 999999999, time.UTC))
+	ctx = context.WithValue(ctx, pipeline.RunConfigApplyIntervalModifiers, true)
+
+	return context.WithValue(ctx, pipeline.RunConfigMicrobatchStatePath, stateDir)
+}
+
+func TestRunner_RetryOnlyRunsFailedBatches(t *testing.T) {
+	t.Parallel()
+
+	fs := afero.NewMemMapFs()
+	runner := NewRunner(fs)
+	asset := &pipeline.Asset{
+		Name: "analytics.events",
+		Materialization: pipeline.Materialization{
+			Type:            pipeline.MaterializationTypeTable,
+			Strategy:        pipeline.MaterializationStrategyTimeInterval,
+			TimeGranularity: pipeline.MaterializationTimeGranularityDate,
+			BatchSize:       "1d",
+		},
+	}
+
+	var seen []time.Time
+	failOn := time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC)
+	err := runner.Run(batchContext("/state"), asset, func(ctx context.Context) error {
+		start := ctx.Value(pipeline.RunConfigStartDate).(time.Time)
+		seen = append(seen, start)
+		assert.False(t, ctx.Value(pipeline.RunConfigApplyIntervalModifiers).(bool))
+		if start.Equal(failOn) {
+			return errors.New("boom")
+		}
+		return nil
+	})
+	require.ErrorContains(t, err, "boom")
+	assert.Len(t, seen, 3)
+
+	exists, err := afero.Exists(fs, "/state/analytics.events.json")
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	seen = nil
+	err = runner.Run(batchContext("/state"), asset, func(ctx context.Context) error {
+		seen = append(seen, ctx.Value(pipeline.RunConfigStartDate).(time.Time))
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []time.Time{failOn, time.Date(2024, 1, 4, 0, 0, 0, 0, time.UTC)}, seen)
+
+	exists, err = afero.Exists(fs, "/state/analytics.events.json")
+	require.NoError(t, err)
+	assert.False(t, exists, "state must be cleaned up once every batch succeeded")
+}
+
+func TestRunner_AppliesIntervalModifiersToTheWholeWindow(t *testing.T) {
+	t.Parallel()
+
+	asset := &pipeline.Asset{
+		Name: "analytics.events",
+		Materialization: pipeline.Materialization{
+			Strategy:         pipeline.MaterializationStrategyTimeInterval,
+			TimeGranularity:  pipeline.MaterializationTimeGranularityTimestamp,
+			BatchSize:        "2d",
+			BatchConcurrency: 2,
+		},
+		IntervalModifiers: pipeline.IntervalModifiers{
+			Start: pipeline.TimeModifier{Days: -2},
+		},
+	}
+
+	var lock sync.Mutex
+	starts := make([]time.Time, 0)
+	err := NewRunner(afero.NewMemMapFs()).Run(batchContext(""), asset, func(ctx context.Context) error {
+		lock.Lock()
+		defer lock.Unlock()
+		starts = append(starts, ctx.Value(pipeline.RunConfigStartDate).(time.Time))
+		return nil
+	})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []time.Time{
+		time.Date(2023, 12, 30, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC),
+	}, starts)
+}
+
+func TestRunner_RejectsSubDayBatchesForDateGranularity(t *testing.T) {
+	t.Parallel()
+
+	asset := &pipeline.Asset{
+		Name: "analytics.events",
+		Materialization: pipeline.Materialization{
+			Strategy:        pipeline.MaterializationStrategyTimeInterval,
+			TimeGranularity: pipeline.MaterializationTimeGranularityDate,
+			BatchSize:       "12h",
+		},
+	}
+
+	var ran atomic.Bool
+	err := NewRunner(afero.NewMemMapFs()).Run(batchContext(""), asset, func(ctx context.Context) error {
+		ran.Store(true)
+		return nil
+	})
+	require.ErrorContains(t, err, "cannot be used with the 'date' time_granularity")
+	assert.False(t, ran.Load(), "no batch should run")
+}
+
+func TestRunner_RunsDuckDBBatchesOneAtATime(t *testing.T) {
+	t.Parallel()
+
+	asset := &pipeline.Asset{
+		Name: "analytics.events",
+		Type: pipeline.AssetTypeDuckDBQuery,
+		Materialization: pipeline.Materialization{
+			Strategy:         pipeline.MaterializationStrategyTimeInterval,
+			BatchSize:        "1d",
+			BatchConcurrency: 4,
+		},
+	}
+
+	var running, maxRunning, batches atomic.Int32
+	var output bytes.Buffer
+	ctx := context.WithValue(batchContext(""), executor.KeyPrinter, &output)
+	err := NewRunner(afero.NewMemMapFs()).Run(ctx, asset, func(ctx context.Context) error {
+		current := running.Add(1)
+		defer running.Add(-1)
+		for {
+			previous := maxRunning.Load()
+			if current <= previous || maxRunning.CompareAndSwap(previous, current) {
+				break
+			}
+		}
+		batches.Add(1)
+		time.Sleep(10 * time.Millisecond)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int32(4), batches.Load())
+	assert.Equal(t, int32(1), maxRunning.Load())
+	assert.Contains(t, output.String(), "Ignoring batch_concurrency 4, duckdb.sql assets run their batches one at a time")
+}
@@ -157,14 +157,18 @@ func buildDDLQuery(asset *pipeline.Asset, _ string) (string, error) {
 
 	columnDefs := make([]string, 0, len(asset.Columns))
 	primaryKeys := make([]string, 0)
+	foreignKeys := make([]string, 0)
 
 	for _, col := range asset.Columns {
 		if col.PrimaryKey {
 			primaryKeys = append(primaryKeys, col.Name)
 		}
+		if col.HasForeignKey() {
+			foreignKeys = append(foreignKeys, fmt.Sprintf("FOREIGN KEY (%s) REFERENCES %s (%s)", col.Name, col.ForeignKey.Table, col.ForeignKey.Column))
+		}
 
 		definition := fmt.Sprintf("%s %s", col.Name, col.Type)
-		if col.Nullable.Value != nil && !*col.Nullable.Value {
+		if col.IsNotNull() {
 			definition += " NOT NULL"
 		}
 
@@ -179,6 +183,7 @@ func buildDDLQuery(asset *pipeline.Asset, _ string) (string, error) {
 	if len(primaryKeys) > 0 {
 		columnDefs = append(columnDefs, fmt.Sprintf("PRIMARY KEY (%s)", strings.Join(primaryKeys, ", ")))
 	}
+	columnDefs = append(columnDefs, foreignKeys...)
 
 	return fmt.Sprintf(
 		"CREATE TABLE IF NOT EXISTS %s (\n%s\n);",
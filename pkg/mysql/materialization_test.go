@@ -266,6 +266,26 @@ COMMIT;$`,
 				"PRIMARY KEY (id)\n" +
 				");",
 		},
+		{
+			name: "ddl builds create table with foreign keys",
+			asset: &pipeline.Asset{
+				Name: "analytics.orders",
+				Materialization: pipeline.Materialization{
+					Type:     pipeline.MaterializationTypeTable,
+					Strategy: pipeline.MaterializationStrategyDDL,
+				},
+				Columns: []pipeline.Column{
+					{Name: "id", Type: "INT", PrimaryKey: true, Nullable: pipeline.DefaultTrueBool{Value: falsePtr}},
+					{Name: "customer_id", Type: "INT", ForeignKey: &pipeline.ColumnReference{Table: "analytics.customers", Column: "id"}},
+				},
+			},
+			wantExact: "CREATE TABLE IF NOT EXISTS analytics.orders (\n" +
+				"id INT NOT NULL,\n" +
+				"customer_id INT,\n" +
+				"PRIMARY KEY (id),\n" +
+				"FOREIGN KEY (customer_id) REFERENCES analytics.customers (id)\n" +
+				");",
+		},
 		{
 			name: "scd2 by time requires incremental key",
 			asset: &pipeline.Asset{
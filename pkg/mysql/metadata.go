@@ -0,0 +1,129 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/bruin-data/bruin/pkg/ansisql"
+	"github.com/bruin-data/bruin/pkg/config"
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/bruin-data/bruin/pkg/query"
+)
+
+// MetadataDialect pushes asset and column descriptions as MySQL comments. MySQL can only change
+// a column comment by redefining the column, the definition is rebuilt from information_schema.
+var MetadataDialect = ansisql.MetadataDialect{
+	Platform:        "MySQL",
+	CurrentMetadata: currentMetadata,
+	TableCommentStatement: func(asset *pipeline.Asset, comment string) string {
+		return fmt.Sprintf("ALTER TABLE %s COMMENT = %s", asset.Name, quoteLiteral(comment))
+	},
+	ColumnCommentStatement: func(asset *pipeline.Asset, column ansisql.ColumnMetadata, comment string) string {
+		if column.Definition == "" {
+			return ""
+		}
+		return fmt.Sprintf(
+			"ALTER TABLE %s MODIFY COLUMN %s %s COMMENT %s",
+			asset.Name, ansisql.QuoteIdentifierWithBackticks(column.Name), column.Definition, quoteLiteral(comment),
+		)
+	},
+}
+
+func NewMetadataPushOperator(conn config.ConnectionGetter) *ansisql.MetadataPushOperator {
+	return ansisql.NewMetadataPushOperator(conn, MetadataDialect)
+}
+
+func currentMetadata(ctx context.Context, conn ansisql.MetadataClient, asset *pipeline.Asset) (*ansisql.TableMetadata, error) {
+	parts := strings.Split(asset.Name, ".")
+	database := "DATABASE()"
+	if len(parts) > 1 {
+		database = quoteLiteral(parts[len(parts)-2])
+	}
+	table := quoteLiteral(parts[len(parts)-1])
+
+	rows, err := conn.Select(ctx, &query.Query{Query: fmt.Sprintf(
+		"SELECT 'table', TABLE_NAME, TABLE_COMMENT, NULL, NULL, NULL, NULL, NULL, NULL FROM information_schema.TABLES WHERE TABLE_SCHEMA = %s AND TABLE_NAME = %s "+
+			"UNION ALL SELECT 'column', COLUMN_NAME, COLUMN_COMMENT, COLUMN_TYPE, IS_NULLABLE, COLUMN_DEFAULT, EXTRA, CHARACTER_SET_NAME, COLLATION_NAME FROM information_schema.COLUMNS WHERE TABLE_SCHEMA = %s AND TABLE_NAME = %s",
+		database, table, database, table,
+	)})
+	if err != nil {
+		return nil, err
+	}
+
+	metadata := &ansisql.TableMetadata{}
+	for _, row := range rows {
+		if len(row) < 9 {
+			continue
+		}
+		switch stringValue(row[0]) {
+		case "table":
+			metadata.Comment = stringValue(row[2])
+		case "column":
+			metadata.Columns = append(metadata.Columns, ansisql.ColumnMetadata{
+				Name:       stringValue(row[1]),
+				Comment:    stringValue(row[2]),
+				Definition: columnDefinition(stringValue(row[3]), stringValue(row[4]), row[5], stringValue(row[6]), stringValue(row[7]), stringValue(row[8])),
+			})
+		}
+	}
+	return metadata, nil
+}
+
+// columnDefinition rebuilds the definition of a column so that it can be modified without
+// changing anything but its comment. Generated columns are not supported and return an empty
+// definition.
+func columnDefinition(columnType, nullable string, columnDefault interface{}, extra, charset, collation string) string {
+	if strings.Contains(strings.ToUpper(extra), "VIRTUAL GENERATED") || strings.Contains(strings.ToUpper(extra), "STORED GENERATED") {
+		return ""
+	}
+
+	definition := columnType
+	if charset != "" {
+		definition += " CHARACTER SET " + charset
+	}
+	if collation != "" {
+		definition += " COLLATE " + collation
+	}
+
+	if nullable == "NO" {
+		definition += " NOT NULL"
+	} else {
+		definition += " NULL"
+	}
+
+	expressionDefault := strings.Contains(strings.ToUpper(extra), "DEFAULT_GENERATED")
+	if columnDefault != nil {
+		value := stringValue(columnDefault)
+		switch {
+		case strings.HasPrefix(strings.ToUpper(value), "CURRENT_TIMESTAMP"):
+			definition += " DEFAULT " + value
+		case expressionDefault:
+			definition += " DEFAULT (" + value + ")"
+		default:
+			definition += " DEFAULT " + quoteLiteral(value)
+		}
+	}
+
+	extra = strings.TrimSpace(strings.ReplaceAll(extra, "DEFAULT_GENERATED", ""))
+	if extra != "" {
+		definition += " " + extra
+	}
+	return definition
+}
+
+// quoteLiteral quotes a MySQL string literal, where backslashes are escape characters.
+func quoteLiteral(value string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(value) + "'"
+}
+
+func stringValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case []byte:
+		return string(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,94 @@
+package mysql
+
+import (
+	"testing"
+
+	"github.com/bruin-data/bruin/pkg/ansisql"
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/stretchr/testify/require"
+)
+
+func TestColumnDefinition(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		columnType    string
+		nullable      string
+		columnDefault interface{}
+		extra         string
+		charset       string
+		collation     string
+		want          string
+	}{
+		{
+			name:       "auto increment primary key",
+			columnType: "int",
+			nullable:   "NO",
+			extra:      "auto_increment",
+			want:       "int NOT NULL auto_increment",
+		},
+		{
+			name:          "string with a literal default keeps its charset",
+			columnType:    "varchar(255)",
+			nullable:      "YES",
+			columnDefault: "it's new",
+			charset:       "utf8mb4",
+			collation:     "utf8mb4_bin",
+			want:          "varchar(255) CHARACTER SET utf8mb4 COLLATE utf8mb4_bin NULL DEFAULT 'it\\'s new'",
+		},
+		{
+			name:          "timestamp defaults are not quoted",
+			columnType:    "timestamp",
+			nullable:      "NO",
+			columnDefault: "CURRENT_TIMESTAMP",
+			extra:         "DEFAULT_GENERATED on update CURRENT_TIMESTAMP",
+			want:          "timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP on update CURRENT_TIMESTAMP",
+		},
+		{
+			name:          "expression defaults are wrapped in parentheses",
+			columnType:    "json",
+			nullable:      "YES",
+			columnDefault: "json_array()",
+			extra:         "DEFAULT_GENERATED",
+			want:          "json NULL DEFAULT (json_array())",
+		},
+		{
+			name:       "generated columns are skipped",
+			columnType: "int",
+			nullable:   "YES",
+			extra:      "VIRTUAL GENERATED",
+			want:       "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			require.Equal(t, tt.want, columnDefinition(tt.columnType, tt.nullable, tt.columnDefault, tt.extra, tt.charset, tt.collation))
+		})
+	}
+}
+
+func TestMetadataDialect_MetadataQueries(t *testing.T) {
+	t.Parallel()
+
+	asset := &pipeline.Asset{
+		Name:        "shop.orders",
+		Description: "Orders",
+		Columns: []pipeline.Column{
+			{Name: "id", Description: "The id"},
+			{Name: "total", Description: "Generated total"},
+		},
+	}
+	current := &ansisql.TableMetadata{
+		Columns: []ansisql.ColumnMetadata{
+			{Name: "id", Definition: "int NOT NULL auto_increment"},
+			{Name: "total"},
+		},
+	}
+
+	require.Equal(t, []string{
+		"ALTER TABLE shop.orders COMMENT = 'Orders'",
+		"ALTER TABLE shop.orders MODIFY COLUMN `id` int NOT NULL auto_increment COMMENT 'The id'",
+	}, MetadataDialect.MetadataQueries(asset, current))
+}
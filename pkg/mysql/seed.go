@@ -0,0 +1,132 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/bruin-data/bruin/pkg/ansisql"
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/bruin-data/bruin/pkg/seed"
+	"github.com/pkg/errors"
+)
+
+// maxPlaceholders is the number of placeholders MySQL accepts in a single statement.
+const maxPlaceholders = 65535
+
+const seedBatchSize = 1000
+
+// LoadSeed loads the seed rows into a staging table with multi-row inserts, then swaps
+// it with the table in a single RENAME TABLE. Vitess and PlanetScale seeds go through ingestr.
+func (c *Client) LoadSeed(ctx context.Context, table *seed.Table, rows *seed.Rows) error {
+	if _, ok := c.config.(*Config); !ok {
+		return seed.ErrUnsupported
+	}
+
+	if err := c.initializeDB(ctx); err != nil {
+		return err
+	}
+
+	if err := c.CreateSchemaIfNotExist(ctx, &pipeline.Asset{Name: table.Name}); err != nil {
+		return err
+	}
+
+	tableName := ansisql.QuoteIdentifierWithBackticks(table.Name)
+	stagingName := ansisql.QuoteIdentifierWithBackticks(seed.StagingTableName(table.Name))
+
+	createQuery := fmt.Sprintf("CREATE TABLE %s (\n%s\n)", stagingName, table.ColumnDefinitions(ansisql.QuoteIdentifierWithBackticks, seedColumnType))
+	if _, err := c.conn.ExecContext(ctx, createQuery); err != nil {
+		return errors.Wrapf(err, "failed to create the staging table for %s", table.Name)
+	}
+	defer func() {
+		_, _ = c.conn.ExecContext(context.WithoutCancel(ctx), "DROP TABLE IF EXISTS "+stagingName)
+	}()
+
+	if err := c.insertSeedRows(ctx, stagingName, table, rows); err != nil {
+		return err
+	}
+
+	// The empty placeholder lets a single RENAME TABLE swap the tables atomically
+	// whether or not the table existed before.
+	oldName := ansisql.QuoteIdentifierWithBackticks(seed.StagingTableName(table.Name) + "_old")
+	queries := []string{
+		fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s LIKE %s", tableName, stagingName),
+		fmt.Sprintf("RENAME TABLE %s TO %s, %s TO %s", tableName, oldName, stagingName, tableName),
+		"DROP TABLE " + oldName,
+	}
+	for _, q := range queries {
+		if _, err := c.conn.ExecContext(ctx, q); err != nil {
+			return errors.Wrapf(err, "failed to replace table %s", table.Name)
+		}
+	}
+
+	return nil
+}
+
+func (c *Client) insertSeedRows(ctx context.Context, tableName string, table *seed.Table, rows *seed.Rows) error {
+	columns := make([]string, len(table.Columns))
+	for i, name := range table.ColumnNames() {
+		columns[i] = ansisql.QuoteIdentifierWithBackticks(name)
+	}
+	insertPrefix := fmt.Sprintf("INSERT INTO %s (%s) VALUES ", tableName, strings.Join(columns, ", "))
+	rowPlaceholders := "(" + strings.TrimSuffix(strings.Repeat("?, ", len(columns)), ", ") + ")"
+	batchSize := min(seedBatchSize, maxPlaceholders/len(columns))
+
+	batch := make([]any, 0, batchSize*len(columns))
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+
+		rowCount := len(batch) / len(columns)
+		q := insertPrefix + strings.TrimSuffix(strings.Repeat(rowPlaceholders+", ", rowCount), ", ")
+		if _, err := c.conn.ExecContext(ctx, q, batch...); err != nil {
+			return errors.Wrapf(err, "failed to insert into table %s", table.Name)
+		}
+		batch = batch[:0]
+		return nil
+	}
+
+	for rows.Next() {
+		values, _ := rows.Values()
+		batch = append(batch, values...)
+		if len(batch) >= batchSize*len(columns) {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	return flush()
+}
+
+func seedColumnType(col seed.Column) string {
+	var typ string
+	switch col.Kind {
+	case seed.KindInteger:
+		typ = "BIGINT"
+	case seed.KindFloat:
+		typ = "DOUBLE"
+	case seed.KindDecimal:
+		typ = "DECIMAL(38, 9)"
+		if col.Precision > 0 {
+			typ = fmt.Sprintf("DECIMAL(%d, %d)", col.Precision, col.Scale)
+		}
+	case seed.KindBoolean:
+		typ = "BOOLEAN"
+	case seed.KindDate:
+		typ = "DATE"
+	case seed.KindTimestamp:
+		typ = "DATETIME(6)"
+	default:
+		typ = "TEXT"
+	}
+
+	if col.NotNull {
+		typ += " NOT NULL"
+	}
+	return typ
+}
@@ -0,0 +1,241 @@
+package odcs
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/bruin-data/bruin/pkg/pipeline"
+)
+
+// SLAMetaPrefix is the prefix of the asset meta keys that hold the SLAs of the asset, e.g.
+// `sla_latency: 4h` is exported as the `latency` SLA property of the contract.
+const SLAMetaPrefix = "sla_"
+
+// libraryRules are the column checks that have an equivalent rule in the ODCS library.
+var libraryRules = map[string]struct {
+	rule      string
+	dimension string
+}{
+	"not_null":        {rule: "nullCheck", dimension: "completeness"},
+	"unique":          {rule: "duplicateCount", dimension: "uniqueness"},
+	"accepted_values": {rule: "validValues", dimension: "conformity"},
+}
+
+// Export builds the contract of an asset from its columns, checks, owner, description and SLAs.
+func Export(asset *pipeline.Asset, p *pipeline.Pipeline) *Contract {
+	contract := &Contract{
+		APIVersion:  APIVersion,
+		Kind:        Kind,
+		ID:          asset.Name,
+		Name:        asset.Name,
+		Version:     "1.0.0",
+		Status:      "active",
+		DataProduct: p.Name,
+		Schema:      []*SchemaObject{exportSchemaObject(asset)},
+	}
+
+	if len(asset.Domains) > 0 {
+		contract.Domain = asset.Domains[0]
+	} else if len(p.Domains) > 0 {
+		contract.Domain = p.Domains[0]
+	}
+	if asset.Owner != "" {
+		contract.Team = []*TeamMember{{Username: asset.Owner, Role: "owner"}}
+	}
+	if server := exportServer(asset, p); server != nil {
+		contract.Servers = []*Server{server}
+	}
+	contract.SLAProperties = exportSLAs(asset, p)
+
+	return contract
+}
+
+func exportSchemaObject(asset *pipeline.Asset) *SchemaObject {
+	obj := &SchemaObject{
+		Name:         tableName(asset.Name),
+		PhysicalName: asset.Name,
+		LogicalType:  "object",
+		PhysicalType: "table",
+		Description:  asset.Description,
+		Tags:         asset.Tags,
+	}
+	if asset.Materialization.Type == pipeline.MaterializationTypeView {
+		obj.PhysicalType = "view"
+	}
+
+	primaryKeyPosition := 0
+	for _, column := range asset.Columns {
+		property := &Property{
+			Name:         column.Name,
+			LogicalType:  logicalType(column.Type),
+			PhysicalType: column.Type,
+			Description:  column.Description,
+			Required:     !column.Nullable.Bool(),
+			PrimaryKey:   column.PrimaryKey,
+			Tags:         column.Tags,
+		}
+		if column.PrimaryKey {
+			primaryKeyPosition++
+			property.PrimaryKeyPosition = primaryKeyPosition
+		}
+		for _, check := range column.Checks {
+			if check.Name == "unique" {
+				property.Unique = true
+			}
+			property.Quality = append(property.Quality, exportColumnCheck(check))
+		}
+		obj.Properties = append(obj.Properties, property)
+	}
+
+	for _, check := range asset.CustomChecks {
+		obj.Quality = append(obj.Quality, exportCustomCheck(check))
+	}
+
+	return obj
+}
+
+func exportColumnCheck(check pipeline.ColumnCheck) *Quality {
+	quality := &Quality{
+		Description: check.Description,
+		Severity:    severity(check.Blocking),
+	}
+
+	library, ok := libraryRules[check.Name]
+	if !ok {
+		value, _ := check.Value.MarshalYAML()
+		quality.Type = qualityTypeCustom
+		quality.Engine = Engine
+		quality.Implementation = map[string]any{"check": check.Name}
+		if value != nil {
+			quality.Implementation["value"] = value
+		}
+		return quality
+	}
+
+	quality.Type = qualityTypeLibrary
+	quality.Rule = library.rule
+	quality.Dimension = library.dimension
+	switch check.Name {
+	case "unique":
+		quality.MustBe = 0
+	case "accepted_values":
+		if check.Value.StringArray != nil {
+			for _, v := range *check.Value.StringArray {
+				quality.ValidValues = append(quality.ValidValues, v)
+			}
+		}
+		if check.Value.IntArray != nil {
+			for _, v := range *check.Value.IntArray {
+				quality.ValidValues = append(quality.ValidValues, v)
+			}
+		}
+	}
+	return quality
+}
+
+// exportCustomCheck turns a custom check into a SQL rule, the checks that compare the number of
+// rows the query returns are wrapped into a query that counts them.
+func exportCustomCheck(check pipeline.CustomCheck) *Quality {
+	quality := &Quality{
+		Name:        check.Name,
+		Description: check.Description,
+		Type:        qualityTypeSQL,
+		Query:       strings.TrimSpace(check.Query),
+		MustBe:      check.Value,
+		Severity:    severity(check.Blocking),
+	}
+	if check.Count != nil {
+		quality.Query = countQuery(check.Query)
+		quality.MustBe = *check.Count
+	}
+	return quality
+}
+
+func countQuery(query string) string {
+	return fmt.Sprintf("SELECT COUNT(*) FROM (%s) AS t", strings.TrimSuffix(strings.TrimSpace(query), ";"))
+}
+
+func severity(blocking pipeline.DefaultTrueBool) string {
+	if blocking.Bool() {
+		return severityError
+	}
+	return severityWarning
+}
+
+func exportServer(asset *pipeline.Asset, p *pipeline.Pipeline) *Server {
+	platform := asset.TablePlatform()
+	if platform == "" {
+		return nil
+	}
+	connection, err := p.GetConnectionNameForAsset(asset)
+	if err != nil {
+		return nil
+	}
+
+	server := &Server{Server: connection, Type: serverType(platform)}
+	parts := strings.Split(asset.Name, ".")
+	var database, schema string
+	switch len(parts) {
+	case 3:
+		database, schema = parts[0], parts[1]
+	case 2:
+		schema = parts[0]
+	}
+	if server.Type == "bigquery" {
+		server.Project, server.Dataset = database, schema
+	} else {
+		server.Database, server.Schema = database, schema
+	}
+	return server
+}
+
+var durationPattern = regexp.MustCompile(`^(\d+(?:\.\d+)?)\s*([a-zA-Z]+)$`)
+
+// exportSLAs reads the SLAs from the `sla_` meta keys of the asset, the schedule of the pipeline is
+// the frequency of the data unless the asset sets one.
+func exportSLAs(asset *pipeline.Asset, p *pipeline.Pipeline) []*SLAProperty {
+	keys := make([]string, 0)
+	for key := range asset.Meta {
+		if strings.HasPrefix(key, SLAMetaPrefix) && len(key) > len(SLAMetaPrefix) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	slas := make([]*SLAProperty, 0, len(keys)+1)
+	hasFrequency := false
+	for _, key := range keys {
+		property := strings.TrimPrefix(key, SLAMetaPrefix)
+		hasFrequency = hasFrequency || property == "frequency"
+		slas = append(slas, parseSLA(property, asset.Meta[key]))
+	}
+	if !hasFrequency && p.Schedule != "" {
+		slas = append(slas, &SLAProperty{Property: "frequency", Value: string(p.Schedule)})
+	}
+	if len(slas) == 0 {
+		return nil
+	}
+	return slas
+}
+
+// parseSLA splits durations such as `4h` into the value and the unit of the SLA property, the other
+// values are kept as they are.
+func parseSLA(property, value string) *SLAProperty {
+	value = strings.TrimSpace(value)
+	if match := durationPattern.FindStringSubmatch(value); match != nil {
+		if i, err := strconv.Atoi(match[1]); err == nil {
+			return &SLAProperty{Property: property, Value: i, Unit: match[2]}
+		}
+		if f, err := strconv.ParseFloat(match[1], 64); err == nil {
+			return &SLAProperty{Property: property, Value: f, Unit: match[2]}
+		}
+	}
+	return &SLAProperty{Property: property, Value: value}
+}
+
+func tableName(assetName string) string {
+	return assetName[strings.LastIndex(assetName, ".")+1:]
+}
@@ -0,0 +1,388 @@
+package odcs
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/pkg/errors"
+)
+
+// sourceTypes are the asset types the assets of a contract are created with, per server type.
+var sourceTypes = map[string]pipeline.AssetType{
+	"athena":     pipeline.AssetTypeAthenaSource,
+	"bigquery":   pipeline.AssetTypeBigquerySource,
+	"clickhouse": pipeline.AssetTypeClickHouseSource,
+	"databricks": pipeline.AssetTypeDatabricksSource,
+	"duckdb":     pipeline.AssetTypeDuckDBSource,
+	"oracle":     pipeline.AssetTypeOracleSource,
+	"postgres":   pipeline.AssetTypePostgresSource,
+	"postgresql": pipeline.AssetTypePostgresSource,
+	"redshift":   pipeline.AssetTypeRedshiftSource,
+	"snowflake":  pipeline.AssetTypeSnowflakeSource,
+	"sqlserver":  pipeline.AssetTypeMsSQLSource,
+	"synapse":    pipeline.AssetTypeSynapseSource,
+	"vertica":    pipeline.AssetTypeVerticaSource,
+}
+
+type ImportOptions struct {
+	// AssetType is the type of the assets that are created for the tables of the contract that
+	// have no asset yet, it is inferred from the type of the first server of the contract if empty.
+	AssetType pipeline.AssetType
+}
+
+type ImportedAsset struct {
+	Asset   *pipeline.Asset
+	Created bool
+}
+
+type ImportResult struct {
+	Assets []*ImportedAsset
+	// Warnings are the parts of the contract that have no equivalent in Bruin and were left out.
+	Warnings []string
+}
+
+// Import creates or patches the assets of the pipeline from the tables of the contract. The fields
+// the contract sets overwrite the ones of the asset, the checks are matched by their name and the
+// columns by their name regardless of the case, everything else on the asset is kept.
+func Import(contract *Contract, p *pipeline.Pipeline, opts ImportOptions) (*ImportResult, error) {
+	if len(contract.Schema) == 0 {
+		return nil, errors.New("the contract does not define any tables in its schema")
+	}
+
+	result := &ImportResult{Assets: make([]*ImportedAsset, 0, len(contract.Schema))}
+	for _, obj := range contract.Schema {
+		name := obj.PhysicalName
+		if name == "" {
+			name = obj.Name
+		}
+		if name == "" {
+			return nil, errors.New("the tables of the contract need a name or a physicalName")
+		}
+
+		imported := &ImportedAsset{Asset: findAsset(p, name)}
+		if imported.Asset == nil {
+			asset, err := newAsset(name, contract, p, opts)
+			if err != nil {
+				return nil, err
+			}
+			imported.Asset = asset
+			imported.Created = true
+		}
+
+		im := &importer{asset: imported.Asset, result: result}
+		im.patchAsset(contract, obj, p)
+		result.Assets = append(result.Assets, imported)
+	}
+	return result, nil
+}
+
+func findAsset(p *pipeline.Pipeline, name string) *pipeline.Asset {
+	for _, asset := range p.Assets {
+		if strings.EqualFold(asset.Name, name) {
+			return asset
+		}
+	}
+	return nil
+}
+
+func newAsset(name string, contract *Contract, p *pipeline.Pipeline, opts ImportOptions) (*pipeline.Asset, error) {
+	assetType := opts.AssetType
+	if assetType == "" && len(contract.Servers) > 0 {
+		assetType = sourceTypes[strings.ToLower(contract.Servers[0].Type)]
+	}
+	if assetType == "" {
+		return nil, errors.Errorf("cannot create the asset '%s', the contract has no server of a known type to infer the asset type from, set the type of the asset to create", name)
+	}
+
+	parts := strings.Split(strings.ToLower(name), ".")
+	fileName := parts[len(parts)-1] + ".asset.yml"
+	dir := filepath.Join(filepath.Dir(p.DefinitionFile.Path), "assets")
+	if len(parts) > 1 {
+		dir = filepath.Join(dir, parts[len(parts)-2])
+	}
+
+	return &pipeline.Asset{
+		Name: name,
+		Type: assetType,
+		ExecutableFile: pipeline.ExecutableFile{
+			Name: fileName,
+			Path: filepath.Join(dir, fileName),
+		},
+	}, nil
+}
+
+type importer struct {
+	asset  *pipeline.Asset
+	result *ImportResult
+}
+
+func (im *importer) warnf(format string, args ...any) {
+	im.result.Warnings = append(im.result.Warnings, fmt.Sprintf(format, args...))
+}
+
+func (im *importer) patchAsset(contract *Contract, obj *SchemaObject, p *pipeline.Pipeline) {
+	asset := im.asset
+
+	description := obj.Description
+	if description == "" && len(contract.Schema) == 1 && contract.Description != nil {
+		description = contract.Description.Purpose
+	}
+	if description != "" {
+		asset.Description = description
+	}
+	if owner := contractOwner(contract.Team); owner != "" {
+		asset.Owner = owner
+	}
+	if contract.Domain != "" && !slices.Contains(asset.Domains, contract.Domain) {
+		asset.Domains = append(asset.Domains, contract.Domain)
+	}
+	asset.Tags = union(asset.Tags, obj.Tags)
+
+	for _, property := range obj.Properties {
+		im.patchColumn(property)
+	}
+
+	for _, quality := range obj.Quality {
+		im.patchCustomCheck(quality)
+	}
+
+	for _, sla := range contract.SLAProperties {
+		if sla.Property == "" || sla.Element != "" {
+			continue
+		}
+		value := slaValue(sla)
+		if sla.Property == "frequency" && value == string(p.Schedule) {
+			continue
+		}
+		if asset.Meta == nil {
+			asset.Meta = pipeline.EmptyStringMap{}
+		}
+		asset.Meta[SLAMetaPrefix+sla.Property] = value
+	}
+}
+
+func (im *importer) patchColumn(property *Property) {
+	asset := im.asset
+
+	index := slices.IndexFunc(asset.Columns, func(c pipeline.Column) bool {
+		return strings.EqualFold(c.Name, property.Name)
+	})
+	if index == -1 {
+		asset.Columns = append(asset.Columns, pipeline.Column{Name: property.Name})
+		index = len(asset.Columns) - 1
+	}
+	column := &asset.Columns[index]
+
+	if property.PhysicalType != "" {
+		column.Type = property.PhysicalType
+	}
+	if property.Description != "" {
+		column.Description = property.Description
+	}
+	if property.PrimaryKey {
+		column.PrimaryKey = true
+	}
+	if property.Required {
+		notNullable := false
+		column.Nullable = pipeline.DefaultTrueBool{Value: &notNullable}
+	}
+	column.Tags = union(column.Tags, property.Tags)
+
+	for _, quality := range property.Quality {
+		name, value, ok := im.columnCheck(column.Name, quality)
+		if !ok {
+			continue
+		}
+		im.upsertColumnCheck(column, name, value, blocking(quality.Severity), quality.Description)
+	}
+
+	hasUnique := slices.ContainsFunc(column.Checks, func(c pipeline.ColumnCheck) bool { return c.Name == "unique" })
+	if property.Unique && !hasUnique {
+		im.upsertColumnCheck(column, "unique", pipeline.ColumnCheckValue{}, nil, "")
+	}
+}
+
+// columnCheck maps a quality rule of a property to the column check it describes.
+func (im *importer) columnCheck(column string, quality *Quality) (string, pipeline.ColumnCheckValue, bool) {
+	switch {
+	case quality.Type == qualityTypeLibrary && quality.Rule == "nullCheck":
+		return "not_null", pipeline.ColumnCheckValue{}, true
+	case quality.Type == qualityTypeLibrary && (quality.Rule == "duplicateCount" || quality.Rule == "uniqueCheck"):
+		return "unique", pipeline.ColumnCheckValue{}, true
+	case quality.Type == qualityTypeLibrary && quality.Rule == "validValues":
+		value, err := checkValue(quality.ValidValues)
+		if err != nil {
+			im.warnf("the valid values of column '%s.%s' are neither strings nor integers, the rule is left out", im.asset.Name, column)
+			return "", value, false
+		}
+		return "accepted_values", value, true
+	case quality.Type == qualityTypeCustom && strings.EqualFold(quality.Engine, Engine):
+		name, _ := quality.Implementation["check"].(string)
+		if name == "" {
+			im.warnf("the %s rule of column '%s.%s' does not name a check in its implementation, the rule is left out", Engine, im.asset.Name, column)
+			return "", pipeline.ColumnCheckValue{}, false
+		}
+		value, err := checkValue(quality.Implementation["value"])
+		if err != nil {
+			im.warnf("the value of check '%s' of column '%s.%s' is not supported, the rule is left out", name, im.asset.Name, column)
+			return "", value, false
+		}
+		return name, value, true
+	default:
+		im.warnf("the %s rule of column '%s.%s' has no equivalent Bruin check, it is left out", qualityLabel(quality), im.asset.Name, column)
+		return "", pipeline.ColumnCheckValue{}, false
+	}
+}
+
+func (im *importer) upsertColumnCheck(column *pipeline.Column, name string, value pipeline.ColumnCheckValue, blocking *bool, description string) {
+	for i := range column.Checks {
+		if column.Checks[i].Name != name {
+			continue
+		}
+		column.Checks[i].Value = value
+		column.Checks[i].Blocking = pipeline.DefaultTrueBool{Value: blocking}
+		if description != "" {
+			column.Checks[i].Description = description
+		}
+		return
+	}
+	column.Checks = append(column.Checks, pipeline.NewColumnCheck(im.asset.Name, column.Name, name, value, blocking, description))
+}
+
+// patchCustomCheck turns a SQL rule of a table into a custom check that expects the query to return
+// the value the rule requires.
+func (im *importer) patchCustomCheck(quality *Quality) {
+	if quality.Type != qualityTypeSQL || quality.Query == "" {
+		im.warnf("the %s rule of table '%s' has no equivalent Bruin check, it is left out", qualityLabel(quality), im.asset.Name)
+		return
+	}
+	if quality.Name == "" {
+		im.warnf("a SQL rule of table '%s' has no name, it is left out", im.asset.Name)
+		return
+	}
+	value, ok := integer(quality.MustBe)
+	if !ok {
+		im.warnf("the SQL rule '%s' of table '%s' does not require an integer with mustBe, it is left out", quality.Name, im.asset.Name)
+		return
+	}
+
+	check := pipeline.CustomCheck{
+		Name:        quality.Name,
+		Description: quality.Description,
+		Query:       quality.Query,
+		Value:       value,
+		Blocking:    pipeline.DefaultTrueBool{Value: blocking(quality.Severity)},
+	}
+	for i := range im.asset.CustomChecks {
+		if im.asset.CustomChecks[i].Name == check.Name {
+			// the checks that count the rows of their query are exported with the query wrapped
+			// into a count, they keep their own query when the contract did not change it.
+			if existing := im.asset.CustomChecks[i]; existing.Count != nil && check.Query == countQuery(existing.Query) {
+				count := check.Value
+				check.Query = existing.Query
+				check.Count = &count
+				check.Value = existing.Value
+			}
+			check.ID = im.asset.CustomChecks[i].ID
+			check.Retries = im.asset.CustomChecks[i].Retries
+			check.Notifications = im.asset.CustomChecks[i].Notifications
+			if check.Description == "" {
+				check.Description = im.asset.CustomChecks[i].Description
+			}
+			im.asset.CustomChecks[i] = check
+			return
+		}
+	}
+	im.asset.CustomChecks = append(im.asset.CustomChecks, check)
+}
+
+// contractOwner returns the member of the team with the owner role, or the first member.
+func contractOwner(team []*TeamMember) string {
+	for _, member := range team {
+		if strings.EqualFold(member.Role, "owner") {
+			return member.Username
+		}
+	}
+	if len(team) > 0 {
+		return team[0].Username
+	}
+	return ""
+}
+
+// blocking returns the blocking flag of a check with the given severity, leaving it to the
+// default for errors.
+func blocking(severity string) *bool {
+	switch strings.ToLower(severity) {
+	case severityWarning, "info":
+		nonBlocking := false
+		return &nonBlocking
+	default:
+		return nil
+	}
+}
+
+func checkValue(value any) (pipeline.ColumnCheckValue, error) {
+	var checkValue pipeline.ColumnCheckValue
+	if value == nil {
+		return checkValue, nil
+	}
+	js, err := json.Marshal(value)
+	if err != nil {
+		return checkValue, err
+	}
+	err = checkValue.UnmarshalJSON(js)
+	return checkValue, err
+}
+
+func integer(value any) (int64, bool) {
+	switch v := value.(type) {
+	case int:
+		return int64(v), true
+	case int64:
+		return v, true
+	case uint64:
+		if v > math.MaxInt64 {
+			return 0, false
+		}
+		return int64(v), true
+	case float64:
+		if v != math.Trunc(v) {
+			return 0, false
+		}
+		return int64(v), true
+	default:
+		return 0, false
+	}
+}
+
+func slaValue(sla *SLAProperty) string {
+	value := fmt.Sprint(sla.Value)
+	if sla.Unit != "" {
+		value += sla.Unit
+	}
+	return value
+}
+
+func qualityLabel(quality *Quality) string {
+	if quality.Rule != "" {
+		return quality.Type + " '" + quality.Rule + "'"
+	}
+	if quality.Name != "" {
+		return quality.Type + " '" + quality.Name + "'"
+	}
+	return quality.Type
+}
+
+func union(existing pipeline.EmptyStringArray, values []string) pipeline.EmptyStringArray {
+	for _, value := range values {
+		if !slices.Contains(existing, value) {
+			existing = append(existing, value)
+		}
+	}
+	return existing
+}
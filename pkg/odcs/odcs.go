@@ -0,0 +1,209 @@
+// Package odcs converts Bruin assets to and from data contracts in the Open Data Contract
+// Standard (ODCS), https://bitol-io.github.io/open-data-contract-standard.
+package odcs
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	APIVersion = "v3.0.2"
+	Kind       = "DataContract"
+
+	// Engine is the engine of the custom quality rules that carry a Bruin check that has no ODCS
+	// equivalent, so that importing the contract restores the check.
+	Engine = "bruin"
+)
+
+type Contract struct {
+	APIVersion    string          `yaml:"apiVersion"`
+	Kind          string          `yaml:"kind"`
+	ID            string          `yaml:"id"`
+	Name          string          `yaml:"name,omitempty"`
+	Version       string          `yaml:"version"`
+	Status        string          `yaml:"status"`
+	Domain        string          `yaml:"domain,omitempty"`
+	DataProduct   string          `yaml:"dataProduct,omitempty"`
+	Description   *Description    `yaml:"description,omitempty"`
+	Tags          []string        `yaml:"tags,omitempty"`
+	Servers       []*Server       `yaml:"servers,omitempty"`
+	Schema        []*SchemaObject `yaml:"schema,omitempty"`
+	Team          []*TeamMember   `yaml:"team,omitempty"`
+	SLAProperties []*SLAProperty  `yaml:"slaProperties,omitempty"`
+}
+
+type Description struct {
+	Purpose     string `yaml:"purpose,omitempty"`
+	Usage       string `yaml:"usage,omitempty"`
+	Limitations string `yaml:"limitations,omitempty"`
+}
+
+type Server struct {
+	Server   string `yaml:"server"`
+	Type     string `yaml:"type"`
+	Project  string `yaml:"project,omitempty"`
+	Dataset  string `yaml:"dataset,omitempty"`
+	Database string `yaml:"database,omitempty"`
+	Schema   string `yaml:"schema,omitempty"`
+}
+
+// SchemaObject is a table of the contract.
+type SchemaObject struct {
+	Name         string      `yaml:"name"`
+	PhysicalName string      `yaml:"physicalName,omitempty"`
+	LogicalType  string      `yaml:"logicalType,omitempty"`
+	PhysicalType string      `yaml:"physicalType,omitempty"`
+	Description  string      `yaml:"description,omitempty"`
+	Tags         []string    `yaml:"tags,omitempty"`
+	Properties   []*Property `yaml:"properties,omitempty"`
+	Quality      []*Quality  `yaml:"quality,omitempty"`
+}
+
+// Property is a column of a table.
+type Property struct {
+	Name               string     `yaml:"name"`
+	LogicalType        string     `yaml:"logicalType,omitempty"`
+	PhysicalType       string     `yaml:"physicalType,omitempty"`
+	Description        string     `yaml:"description,omitempty"`
+	Required           bool       `yaml:"required,omitempty"`
+	Unique             bool       `yaml:"unique,omitempty"`
+	PrimaryKey         bool       `yaml:"primaryKey,omitempty"`
+	PrimaryKeyPosition int        `yaml:"primaryKeyPosition,omitempty"`
+	Tags               []string   `yaml:"tags,omitempty"`
+	Quality            []*Quality `yaml:"quality,omitempty"`
+}
+
+// Quality is a data quality rule, either a rule from the ODCS library, a SQL query whose result is
+// compared to a value, or a custom rule that is run by a given engine.
+type Quality struct {
+	Name           string         `yaml:"name,omitempty"`
+	Description    string         `yaml:"description,omitempty"`
+	Type           string         `yaml:"type"`
+	Rule           string         `yaml:"rule,omitempty"`
+	Dimension      string         `yaml:"dimension,omitempty"`
+	ValidValues    []any          `yaml:"validValues,omitempty"`
+	Query          string         `yaml:"query,omitempty"`
+	MustBe         any            `yaml:"mustBe,omitempty"`
+	Engine         string         `yaml:"engine,omitempty"`
+	Implementation map[string]any `yaml:"implementation,omitempty"`
+	Severity       string         `yaml:"severity,omitempty"`
+}
+
+type TeamMember struct {
+	Username string `yaml:"username"`
+	Role     string `yaml:"role,omitempty"`
+}
+
+type SLAProperty struct {
+	Property string `yaml:"property"`
+	Value    any    `yaml:"value"`
+	Unit     string `yaml:"unit,omitempty"`
+	Element  string `yaml:"element,omitempty"`
+}
+
+const (
+	qualityTypeLibrary = "library"
+	qualityTypeSQL     = "sql"
+	qualityTypeCustom  = "custom"
+
+	severityError   = "error"
+	severityWarning = "warning"
+)
+
+// Parse reads a contract and makes sure it is an ODCS v3 data contract.
+func Parse(content []byte) (*Contract, error) {
+	var contract Contract
+	if err := yaml.Unmarshal(content, &contract); err != nil {
+		return nil, errors.Wrap(err, "failed to parse the contract")
+	}
+	if contract.Kind != Kind {
+		return nil, errors.Errorf("unsupported contract kind '%s', only '%s' contracts are supported", contract.Kind, Kind)
+	}
+	if !strings.HasPrefix(contract.APIVersion, "v3.") {
+		return nil, errors.Errorf("unsupported contract version '%s', only ODCS v3 contracts are supported", contract.APIVersion)
+	}
+	return &contract, nil
+}
+
+// Marshal renders the contract as YAML.
+func Marshal(contract *Contract) ([]byte, error) {
+	buf := new(bytes.Buffer)
+	enc := yaml.NewEncoder(buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(contract); err != nil {
+		return nil, errors.Wrap(err, "failed to marshal the contract")
+	}
+	if err := enc.Close(); err != nil {
+		return nil, errors.Wrap(err, "failed to marshal the contract")
+	}
+	return buf.Bytes(), nil
+}
+
+// serverTypes maps the connection types to the server types of ODCS, the connection types that are
+// missing here are used as they are.
+var serverTypes = map[string]string{
+	"google_cloud_platform": "bigquery",
+	"mssql":                 "sqlserver",
+}
+
+func serverType(connectionType string) string {
+	if t, ok := serverTypes[connectionType]; ok {
+		return t
+	}
+	return connectionType
+}
+
+// integerTypes are the names of the integer types of the platforms, without their parameters.
+var integerTypes = map[string]bool{
+	"int": true, "integer": true, "tinyint": true, "smallint": true, "mediumint": true, "bigint": true,
+	"byteint": true, "hugeint": true, "int2": true, "int4": true, "int8": true, "int16": true,
+	"int32": true, "int64": true, "int128": true, "int256": true, "uint8": true, "uint16": true,
+	"uint32": true, "uint64": true, "uint128": true, "uint256": true, "utinyint": true,
+	"usmallint": true, "uinteger": true, "ubigint": true, "uhugeint": true, "serial": true,
+	"smallserial": true, "bigserial": true, "serial2": true, "serial4": true, "serial8": true,
+}
+
+// baseTypeName strips the parameters and modifiers off a lowercase type, e.g. `int(11) unsigned`,
+// and unwraps the ClickHouse `nullable` and `lowcardinality` types.
+func baseTypeName(t string) string {
+	for _, wrapper := range []string{"nullable(", "lowcardinality("} {
+		if strings.HasPrefix(t, wrapper) && strings.HasSuffix(t, ")") {
+			return baseTypeName(strings.TrimSpace(t[len(wrapper) : len(t)-1]))
+		}
+	}
+	if i := strings.IndexAny(t, "( "); i >= 0 {
+		t = t[:i]
+	}
+	return t
+}
+
+// logicalType maps a native column type to the logical types of ODCS.
+func logicalType(physicalType string) string {
+	t := strings.ToLower(strings.TrimSpace(physicalType))
+	if t == "" {
+		return ""
+	}
+	switch {
+	case strings.HasPrefix(t, "array") || strings.HasSuffix(t, "[]"):
+		return "array"
+	case strings.HasPrefix(t, "struct") || strings.HasPrefix(t, "record") || strings.HasPrefix(t, "map") ||
+		strings.HasPrefix(t, "json") || strings.HasPrefix(t, "variant") || strings.HasPrefix(t, "object"):
+		return "object"
+	case strings.HasPrefix(t, "bool"):
+		return "boolean"
+	case integerTypes[baseTypeName(t)]:
+		return "integer"
+	case strings.HasPrefix(t, "numeric") || strings.HasPrefix(t, "bignumeric") || strings.HasPrefix(t, "decimal") ||
+		strings.HasPrefix(t, "number") || strings.HasPrefix(t, "float") || strings.HasPrefix(t, "double") ||
+		strings.HasPrefix(t, "real") || strings.HasPrefix(t, "money"):
+		return "number"
+	case strings.HasPrefix(t, "date") || strings.HasPrefix(t, "time"):
+		return "date"
+	default:
+		return "string"
+	}
+}
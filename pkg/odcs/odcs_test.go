@@ -0,0 +1,272 @@
+package odcs
+
+import (
+	"testing"
+
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func contractTestPipeline() *pipeline.Pipeline {
+	minAmount := 0
+	nonBlocking := false
+	notNullable := false
+	rowCount := int64(1)
+	return &pipeline.Pipeline{
+		Name:           "sales",
+		Schedule:       "daily",
+		DefinitionFile: pipeline.DefinitionFile{Path: "/repo/sales/pipeline.yml"},
+		Assets: []*pipeline.Asset{
+			{
+				Name:        "mart.orders",
+				Type:        pipeline.AssetTypeBigqueryQuery,
+				Description: "Orders of the shop.",
+				Owner:       "jane@example.com",
+				Tags:        []string{"finance"},
+				Domains:     []string{"commerce"},
+				Meta:        map[string]string{"sla_latency": "4h", "team": "data"},
+				Columns: []pipeline.Column{
+					{
+						Name:       "order_id",
+						Type:       "INT64",
+						PrimaryKey: true,
+						Nullable:   pipeline.DefaultTrueBool{Value: &notNullable},
+						Checks: []pipeline.ColumnCheck{
+							{Name: "not_null"},
+							{Name: "unique"},
+						},
+					},
+					{
+						Name: "status",
+						Type: "STRING",
+						Checks: []pipeline.ColumnCheck{
+							{Name: "accepted_values", Value: pipeline.ColumnCheckValue{StringArray: &[]string{"open", "closed"}}},
+						},
+					},
+					{
+						Name: "amount",
+						Type: "NUMERIC",
+						Checks: []pipeline.ColumnCheck{
+							{Name: "min", Value: pipeline.ColumnCheckValue{Int: &minAmount}, Blocking: pipeline.DefaultTrueBool{Value: &nonBlocking}},
+						},
+					},
+				},
+				CustomChecks: []pipeline.CustomCheck{
+					{Name: "has_rows", Query: "SELECT COUNT(*) > 0 FROM mart.orders", Value: 1},
+					{Name: "no_orphans", Query: "SELECT * FROM mart.orders WHERE customer_id IS NULL", Count: &rowCount},
+				},
+			},
+		},
+	}
+}
+
+func TestExport(t *testing.T) {
+	t.Parallel()
+
+	p := contractTestPipeline()
+	contract := Export(p.Assets[0], p)
+
+	assert.Equal(t, "mart.orders", contract.ID)
+	assert.Equal(t, "commerce", contract.Domain)
+	assert.Equal(t, "sales", contract.DataProduct)
+	assert.Equal(t, []*TeamMember{{Username: "jane@example.com", Role: "owner"}}, contract.Team)
+	assert.Equal(t, []*Server{{Server: "gcp-default", Type: "bigquery", Dataset: "mart"}}, contract.Servers)
+	assert.Equal(t, []*SLAProperty{
+		{Property: "latency", Value: 4, Unit: "h"},
+		{Property: "frequency", Value: "daily"},
+	}, contract.SLAProperties)
+
+	require.Len(t, contract.Schema, 1)
+	obj := contract.Schema[0]
+	assert.Equal(t, "orders", obj.Name)
+	assert.Equal(t, "mart.orders", obj.PhysicalName)
+	require.Len(t, obj.Properties, 3)
+
+	orderID := obj.Properties[0]
+	assert.Equal(t, "integer", orderID.LogicalType)
+	assert.True(t, orderID.Required)
+	assert.True(t, orderID.Unique)
+	assert.Equal(t, 1, orderID.PrimaryKeyPosition)
+	assert.Equal(t, []*Quality{
+		{Type: "library", Rule: "nullCheck", Dimension: "completeness", Severity: "error"},
+		{Type: "library", Rule: "duplicateCount", Dimension: "uniqueness", MustBe: 0, Severity: "error"},
+	}, orderID.Quality)
+
+	assert.Equal(t, []any{"open", "closed"}, obj.Properties[1].Quality[0].ValidValues)
+	assert.Equal(t, "number", obj.Properties[2].LogicalType)
+	assert.Equal(t, &Quality{
+		Type:           "custom",
+		Engine:         "bruin",
+		Implementation: map[string]any{"check": "min", "value": &[]int{0}[0]},
+		Severity:       "warning",
+	}, obj.Properties[2].Quality[0])
+
+	assert.Equal(t, []*Quality{
+		{Name: "has_rows", Type: "sql", Query: "SELECT COUNT(*) > 0 FROM mart.orders", MustBe: int64(1), Severity: "error"},
+		{Name: "no_orphans", Type: "sql", Query: "SELECT COUNT(*) FROM (SELECT * FROM mart.orders WHERE customer_id IS NULL) AS t", MustBe: int64(1), Severity: "error"},
+	}, obj.Quality)
+}
+
+func TestLogicalType(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"INT64":                          "integer",
+		"int(11) unsigned":               "integer",
+		"BIGSERIAL":                      "integer",
+		"Nullable(UInt32)":               "integer",
+		"LowCardinality(Nullable(Int8))": "integer",
+		"interval":                       "string",
+		"point":                          "string",
+		"varchar(255)":                   "string",
+		"NUMERIC(10, 2)":                 "number",
+		"timestamp with time zone":       "date",
+		"ARRAY<INT64>":                   "array",
+		"boolean":                        "boolean",
+		"":                               "",
+	}
+	for physicalType, want := range tests {
+		assert.Equal(t, want, logicalType(physicalType), physicalType)
+	}
+}
+
+func TestImport_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	p := contractTestPipeline()
+	content, err := Marshal(Export(p.Assets[0], p))
+	require.NoError(t, err)
+	contract, err := Parse(content)
+	require.NoError(t, err)
+
+	target := &pipeline.Pipeline{Name: "sales", Schedule: "daily", DefinitionFile: pipeline.DefinitionFile{Path: "/repo/sales/pipeline.yml"}}
+	result, err := Import(contract, target, ImportOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, result.Warnings)
+	require.Len(t, result.Assets, 1)
+	assert.True(t, result.Assets[0].Created)
+
+	asset := result.Assets[0].Asset
+	assert.Equal(t, "/repo/sales/assets/mart/orders.asset.yml", asset.ExecutableFile.Path)
+	assert.Equal(t, pipeline.AssetTypeBigquerySource, asset.Type)
+	assert.Equal(t, "Orders of the shop.", asset.Description)
+	assert.Equal(t, "jane@example.com", asset.Owner)
+	assert.Equal(t, pipeline.EmptyStringArray{"commerce"}, asset.Domains)
+	assert.Equal(t, pipeline.EmptyStringMap{"sla_latency": "4h"}, asset.Meta)
+
+	original := p.Assets[0]
+	require.Len(t, asset.Columns, 3)
+	for i, column := range asset.Columns {
+		assert.Equal(t, original.Columns[i].Name, column.Name)
+		assert.Equal(t, original.Columns[i].Type, column.Type)
+		assert.Equal(t, original.Columns[i].PrimaryKey, column.PrimaryKey)
+		assert.Equal(t, original.Columns[i].Nullable.Bool(), column.Nullable.Bool())
+		require.Len(t, column.Checks, len(original.Columns[i].Checks))
+		for j, check := range column.Checks {
+			assert.Equal(t, original.Columns[i].Checks[j].Name, check.Name)
+			assert.Equal(t, original.Columns[i].Checks[j].Value.ToString(), check.Value.ToString())
+			assert.Equal(t, original.Columns[i].Checks[j].Blocking.Bool(), check.Blocking.Bool())
+		}
+	}
+
+	require.Len(t, asset.CustomChecks, 2)
+	assert.Equal(t, "has_rows", asset.CustomChecks[0].Name)
+	assert.Equal(t, int64(1), asset.CustomChecks[0].Value)
+}
+
+func TestImport_PatchesExistingAsset(t *testing.T) {
+	t.Parallel()
+
+	contract, err := Parse([]byte(`
+apiVersion: v3.0.2
+kind: DataContract
+id: orders
+version: 1.0.0
+status: active
+team:
+  - username: analyst@example.com
+    role: consumer
+  - username: john@example.com
+    role: owner
+schema:
+  - name: orders
+    physicalName: MART.ORDERS
+    tags: [pii]
+    properties:
+      - name: ORDER_ID
+        description: The identifier of the order.
+        quality:
+          - type: library
+            rule: nullCheck
+            severity: warning
+      - name: created_at
+        physicalType: TIMESTAMP
+        required: true
+        quality:
+          - type: text
+            description: Should be in the past.
+    quality:
+      - type: library
+        rule: rowCount
+        mustBeGreaterThan: 0
+      - name: has_rows
+        type: sql
+        query: SELECT COUNT(*) FROM mart.orders
+        mustBe: 10
+      - name: no_orphans
+        type: sql
+        query: SELECT COUNT(*) FROM (SELECT * FROM mart.orders WHERE customer_id IS NULL) AS t
+        mustBe: 3
+`))
+	require.NoError(t, err)
+
+	p := contractTestPipeline()
+	result, err := Import(contract, p, ImportOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"the text rule of column 'mart.orders.created_at' has no equivalent Bruin check, it is left out",
+		"the library 'rowCount' rule of table 'mart.orders' has no equivalent Bruin check, it is left out",
+	}, result.Warnings)
+	require.Len(t, result.Assets, 1)
+	assert.False(t, result.Assets[0].Created)
+
+	asset := p.Assets[0]
+	assert.Same(t, asset, result.Assets[0].Asset)
+	assert.Equal(t, "john@example.com", asset.Owner)
+	assert.Equal(t, "Orders of the shop.", asset.Description)
+	assert.Equal(t, pipeline.EmptyStringArray{"finance", "pii"}, asset.Tags)
+
+	require.Len(t, asset.Columns, 4)
+	assert.Equal(t, "order_id", asset.Columns[0].Name)
+	assert.Equal(t, "The identifier of the order.", asset.Columns[0].Description)
+	require.Len(t, asset.Columns[0].Checks, 2)
+	assert.False(t, asset.Columns[0].Checks[0].Blocking.Bool())
+
+	assert.Equal(t, "created_at", asset.Columns[3].Name)
+	assert.Equal(t, "TIMESTAMP", asset.Columns[3].Type)
+	assert.False(t, asset.Columns[3].Nullable.Bool())
+
+	require.Len(t, asset.CustomChecks, 2)
+	assert.Equal(t, "SELECT COUNT(*) FROM mart.orders", asset.CustomChecks[0].Query)
+	assert.Equal(t, int64(10), asset.CustomChecks[0].Value)
+	assert.Equal(t, "SELECT * FROM mart.orders WHERE customer_id IS NULL", asset.CustomChecks[1].Query)
+	assert.Equal(t, int64(3), *asset.CustomChecks[1].Count)
+}
+
+func TestImport_Errors(t *testing.T) {
+	t.Parallel()
+
+	_, err := Parse([]byte("apiVersion: v2.2.2\nkind: DataContract\n"))
+	require.EqualError(t, err, "unsupported contract version 'v2.2.2', only ODCS v3 contracts are supported")
+
+	_, err = Parse([]byte("apiVersion: v3.0.2\nkind: DataProduct\n"))
+	require.EqualError(t, err, "unsupported contract kind 'DataProduct', only 'DataContract' contracts are supported")
+
+	_, err = Import(&Contract{Schema: []*SchemaObject{{Name: "raw.customers"}}}, contractTestPipeline(), ImportOptions{})
+	require.EqualError(t, err, "cannot create the asset 'raw.customers', the contract has no server of a known type to infer the asset type from, set the type of the asset to create")
+
+	result, err := Import(&Contract{Schema: []*SchemaObject{{Name: "customers"}}}, contractTestPipeline(), ImportOptions{AssetType: pipeline.AssetTypeSnowflakeSource})
+	require.NoError(t, err)
+	assert.Equal(t, "/repo/sales/assets/customers.asset.yml", result.Assets[0].Asset.ExecutableFile.Path)
+}
@@ -0,0 +1,311 @@
+package orphans
+
+import (
+	"context"
+	"path"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/bruin-data/bruin/pkg/config"
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/bruin-data/bruin/pkg/query"
+	"github.com/pkg/errors"
+)
+
+// Object is a table or view found in one of the schemas the pipeline writes to.
+type Object struct {
+	Connection string `json:"connection"`
+	Database   string `json:"database,omitempty"`
+	Schema     string `json:"schema"`
+	Name       string `json:"name"`
+}
+
+// QualifiedName returns the name the object is referenced with in queries.
+func (o Object) QualifiedName() string {
+	if o.Database != "" {
+		return o.Database + "." + o.Schema + "." + o.Name
+	}
+
+	return o.Schema + "." + o.Name
+}
+
+// Report is the outcome of looking for orphaned objects.
+type Report struct {
+	Orphans []Object `json:"orphans"`
+	// Skipped lists the connections whose tables cannot be listed, their schemas are not inspected.
+	Skipped []string `json:"skipped_connections,omitempty"`
+}
+
+// schemaTablesLister lists the tables of a whole database grouped by schema, e.g. Postgres and Snowflake.
+type schemaTablesLister interface {
+	GetTablesWithSchemas(ctx context.Context, databaseName string) (map[string][]string, error)
+}
+
+// tablesLister lists the tables of a single schema, e.g. BigQuery datasets or Databricks schemas.
+type tablesLister interface {
+	GetTables(ctx context.Context, databaseName string) ([]string, error)
+}
+
+// schemasLister lists the schemas a tablesLister lists the tables of, e.g. BigQuery datasets.
+type schemasLister interface {
+	GetDatabases(ctx context.Context) ([]string, error)
+}
+
+type queryRunner interface {
+	RunQueryWithoutResult(ctx context.Context, q *query.Query) error
+}
+
+// Finder looks for tables and views that no asset of the pipeline produces anymore.
+type Finder struct {
+	connections config.ConnectionGetter
+	// databaseName returns the database a connection points to, used to list the tables of
+	// connections that list whole databases.
+	databaseName func(connection string) string
+	// identifierQuotes returns the characters a connection quotes identifiers with.
+	identifierQuotes func(connection string) (string, string)
+}
+
+func NewFinder(connections config.ConnectionGetter, databaseName func(connection string) string, identifierQuotes func(connection string) (string, string)) *Finder {
+	return &Finder{connections: connections, databaseName: databaseName, identifierQuotes: identifierQuotes}
+}
+
+// schemaKey identifies a schema the pipeline writes to within a connection, the schema is
+// lowercased to match the listed objects against the assets.
+type schemaKey struct {
+	connection string
+	database   string
+	schema     string
+}
+
+// Find lists the tables and views in the schemas the pipeline assets write to, and returns the ones
+// that do not belong to any asset. The assets of the other pipelines of the repository are taken
+// into account too, so that the tables of pipelines sharing a schema are not reported. Asset names
+// are prefixed with the given schema prefix, and the objects matching any of the allowlist
+// patterns are never reported.
+func (f *Finder) Find(ctx context.Context, p *pipeline.Pipeline, otherPipelines []*pipeline.Pipeline, schemaPrefix string, allowlist []string) (*Report, error) {
+	for _, pattern := range allowlist {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, errors.Wrapf(err, "invalid allowlist pattern '%s'", pattern)
+		}
+	}
+
+	expected := make(map[schemaKey]map[string]bool)
+	// schemas keeps the casing of the schema names, which are listed and dropped as they are.
+	schemas := make(map[schemaKey]string)
+	skipped := make(map[string]bool)
+	for _, asset := range p.Assets {
+		key, schema, table, connName, ok := f.assetLocation(p, asset, schemaPrefix)
+		if !ok {
+			if connName != "" {
+				skipped[connName] = true
+			}
+			continue
+		}
+
+		if expected[key] == nil {
+			expected[key] = make(map[string]bool)
+			schemas[key] = schema
+		}
+		expected[key][table] = true
+	}
+
+	// the tables of the other pipelines are only looked up, their schemas are not inspected.
+	owned := make(map[schemaKey]map[string]bool)
+	for _, other := range otherPipelines {
+		for _, asset := range other.Assets {
+			key, _, table, _, ok := f.assetLocation(other, asset, schemaPrefix)
+			if !ok || expected[key] == nil {
+				continue
+			}
+			if owned[key] == nil {
+				owned[key] = make(map[string]bool)
+			}
+			owned[key][table] = true
+		}
+	}
+
+	objects, err := f.listObjects(ctx, schemas)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{Orphans: make([]Object, 0)}
+	for _, object := range objects {
+		key := schemaKey{connection: object.Connection, database: object.Database, schema: strings.ToLower(object.Schema)}
+		name := strings.ToLower(object.Name)
+		if expected[key][name] || owned[key][name] || isAllowed(object, allowlist) {
+			continue
+		}
+		report.Orphans = append(report.Orphans, object)
+	}
+
+	for connName := range skipped {
+		report.Skipped = append(report.Skipped, connName)
+	}
+	sort.Strings(report.Skipped)
+
+	return report, nil
+}
+
+// assetLocation returns the schema key, the schema as it is written and the lowercased table name
+// the asset writes to. It returns false for the assets without a schema, and with the connection
+// name for the connections whose tables cannot be listed.
+func (f *Finder) assetLocation(p *pipeline.Pipeline, asset *pipeline.Asset, schemaPrefix string) (schemaKey, string, string, string, bool) {
+	parts := strings.Split(pipeline.PrefixSchemaName(asset.Name, schemaPrefix), ".")
+	if len(parts) != 2 && len(parts) != 3 {
+		return schemaKey{}, "", "", "", false
+	}
+
+	connName, err := p.GetConnectionNameForAsset(asset)
+	if err != nil {
+		return schemaKey{}, "", "", "", false
+	}
+
+	schema := parts[len(parts)-2]
+	key := schemaKey{connection: connName, schema: strings.ToLower(schema)}
+	switch f.connections.GetConnection(connName).(type) {
+	case schemaTablesLister:
+		// the database keeps its casing, the metadata queries compare it as is.
+		key.database = f.databaseName(connName)
+		if len(parts) == 3 {
+			key.database = parts[0]
+		}
+	case tablesLister:
+	default:
+		return schemaKey{}, "", "", connName, false
+	}
+
+	return key, schema, strings.ToLower(parts[len(parts)-1]), connName, true
+}
+
+// listObjects lists the objects of the given schemas. Schemas that do not exist have no objects,
+// e.g. the prefixed schemas of a developer environment that were never built.
+func (f *Finder) listObjects(ctx context.Context, schemas map[schemaKey]string) ([]Object, error) {
+	keys := make([]schemaKey, 0, len(schemas))
+	for key := range schemas {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].connection != keys[j].connection {
+			return keys[i].connection < keys[j].connection
+		}
+		if keys[i].database != keys[j].database {
+			return keys[i].database < keys[j].database
+		}
+		return keys[i].schema < keys[j].schema
+	})
+
+	objects := make([]Object, 0)
+	// databases that list all their schemas at once, and the schemas of a connection, are fetched a
+	// single time.
+	databaseTables := make(map[string]map[string][]string)
+	connectionSchemas := make(map[string][]string)
+	for _, key := range keys {
+		ctx := query.WithQueryType(ctx, query.QueryTypeSchema)
+		switch conn := f.connections.GetConnection(key.connection).(type) {
+		case schemaTablesLister:
+			cacheKey := key.connection + "." + key.database
+			tables, ok := databaseTables[cacheKey]
+			if !ok {
+				var err error
+				tables, err = conn.GetTablesWithSchemas(ctx, key.database)
+				if err != nil {
+					return nil, errors.Wrapf(err, "failed to list the tables of database '%s' on connection '%s'", key.database, key.connection)
+				}
+				databaseTables[cacheKey] = tables
+			}
+
+			for schema, names := range tables {
+				if !strings.EqualFold(schema, key.schema) {
+					continue
+				}
+				for _, name := range names {
+					objects = append(objects, Object{Connection: key.connection, Database: key.database, Schema: schema, Name: name})
+				}
+			}
+		case tablesLister:
+			schema := schemas[key]
+			if lister, ok := conn.(schemasLister); ok {
+				existing, ok := connectionSchemas[key.connection]
+				if !ok {
+					var err error
+					existing, err = lister.GetDatabases(ctx)
+					if err != nil {
+						return nil, errors.Wrapf(err, "failed to list the schemas of connection '%s'", key.connection)
+					}
+					connectionSchemas[key.connection] = existing
+				}
+				if !slices.ContainsFunc(existing, func(s string) bool { return strings.EqualFold(s, schema) }) {
+					continue
+				}
+			}
+
+			names, err := conn.GetTables(ctx, schema)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to list the tables of schema '%s' on connection '%s'", schema, key.connection)
+			}
+			for _, name := range names {
+				objects = append(objects, Object{Connection: key.connection, Schema: schema, Name: name})
+			}
+		}
+	}
+
+	sort.SliceStable(objects, func(i, j int) bool {
+		if objects[i].Connection != objects[j].Connection {
+			return objects[i].Connection < objects[j].Connection
+		}
+		return objects[i].QualifiedName() < objects[j].QualifiedName()
+	})
+
+	return objects, nil
+}
+
+// isAllowed checks the object against the allowlist patterns, which match either `schema.name` or
+// `database.schema.name`, case-insensitively.
+func isAllowed(object Object, allowlist []string) bool {
+	names := []string{strings.ToLower(object.Schema + "." + object.Name)}
+	if object.Database != "" {
+		names = append(names, strings.ToLower(object.QualifiedName()))
+	}
+
+	for _, pattern := range allowlist {
+		for _, name := range names {
+			if matched, _ := path.Match(strings.ToLower(pattern), name); matched {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// Drop drops the given object. Listing does not tell tables and views apart, therefore a view is
+// dropped with `DROP VIEW` after `DROP TABLE` fails on it. The names are quoted as listed, so that
+// they keep their casing and special characters.
+func (f *Finder) Drop(ctx context.Context, object Object) error {
+	conn, ok := f.connections.GetConnection(object.Connection).(queryRunner)
+	if !ok {
+		return errors.Errorf("connection '%s' cannot run queries", object.Connection)
+	}
+
+	open, closing := f.identifierQuotes(object.Connection)
+	parts := []string{object.Schema, object.Name}
+	if object.Database != "" {
+		parts = append([]string{object.Database}, parts...)
+	}
+	for i, part := range parts {
+		parts[i] = open + strings.ReplaceAll(part, closing, closing+closing) + closing
+	}
+	name := strings.Join(parts, ".")
+	tableErr := conn.RunQueryWithoutResult(ctx, &query.Query{Query: "DROP TABLE IF EXISTS " + name})
+	if tableErr == nil {
+		return nil
+	}
+
+	if err := conn.RunQueryWithoutResult(ctx, &query.Query{Query: "DROP VIEW IF EXISTS " + name}); err != nil {
+		return errors.Wrapf(tableErr, "failed to drop '%s'", name)
+	}
+
+	return nil
+}
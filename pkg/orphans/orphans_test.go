@@ -0,0 +1,239 @@
+package orphans
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/bruin-data/bruin/pkg/query"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type databaseConnection struct {
+	tables  map[string][]string
+	queries []string
+	failOn  string
+}
+
+func (d *databaseConnection) GetTablesWithSchemas(_ context.Context, databaseName string) (map[string][]string, error) {
+	if databaseName != "ANALYTICS" {
+		return nil, errors.New("unexpected database " + databaseName)
+	}
+	return d.tables, nil
+}
+
+func (d *databaseConnection) RunQueryWithoutResult(_ context.Context, q *query.Query) error {
+	d.queries = append(d.queries, q.Query)
+	if q.Query == d.failOn {
+		return errors.New("not a table")
+	}
+	return nil
+}
+
+type schemaConnection struct {
+	tables map[string][]string
+}
+
+func (s *schemaConnection) GetTables(_ context.Context, schema string) ([]string, error) {
+	tables, ok := s.tables[schema]
+	if !ok {
+		return nil, errors.New("dataset '" + schema + "' does not exist")
+	}
+	return tables, nil
+}
+
+// datasetConnection lists its datasets too, the way the BigQuery and Databricks clients do.
+type datasetConnection struct {
+	schemaConnection
+	listed []string
+}
+
+func (d *datasetConnection) GetDatabases(context.Context) ([]string, error) {
+	datasets := make([]string, 0, len(d.tables))
+	for dataset := range d.tables {
+		datasets = append(datasets, dataset)
+	}
+	return datasets, nil
+}
+
+func (d *datasetConnection) GetTables(ctx context.Context, schema string) ([]string, error) {
+	d.listed = append(d.listed, schema)
+	return d.schemaConnection.GetTables(ctx, schema)
+}
+
+type fakeConnections map[string]any
+
+func (f fakeConnections) GetConnection(name string) any {
+	return f[name]
+}
+
+func TestFinder_Find(t *testing.T) {
+	t.Parallel()
+
+	sf := &databaseConnection{tables: map[string][]string{
+		"DEV_MART":  {"ORDERS", "ORDERS_OLD", "BACKUP_2024"},
+		"DEV_RAW":   {"EVENTS"},
+		"UNRELATED": {"SOMETHING"},
+	}}
+	bq := &schemaConnection{tables: map[string][]string{
+		"dev_staging": {"users", "users_v1"},
+	}}
+	connections := fakeConnections{"sf": sf, "bq": bq, "mysql": struct{}{}}
+
+	p := &pipeline.Pipeline{
+		DefaultConnections: map[string]string{
+			"snowflake":             "sf",
+			"google_cloud_platform": "bq",
+			"mysql":                 "mysql",
+		},
+		Assets: []*pipeline.Asset{
+			{Name: "mart.orders", Type: pipeline.AssetTypeSnowflakeQuery},
+			{Name: "raw.events", Type: pipeline.AssetTypeSnowflakeQuery},
+			{Name: "staging.users", Type: pipeline.AssetTypeBigqueryQuery},
+			{Name: "staging.missing", Type: pipeline.AssetTypeBigqueryQuery},
+			{Name: "app.users", Type: pipeline.AssetTypeMySQLQuery},
+			{Name: "no_schema", Type: pipeline.AssetTypeSnowflakeQuery},
+		},
+	}
+
+	finder := NewFinder(connections, func(string) string { return "ANALYTICS" }, doubleQuotes)
+	report, err := finder.Find(t.Context(), p, nil, "dev_", []string{"dev_mart.backup_*"})
+	require.NoError(t, err)
+
+	assert.Equal(t, []Object{
+		{Connection: "bq", Schema: "dev_staging", Name: "users_v1"},
+		{Connection: "sf", Database: "ANALYTICS", Schema: "DEV_MART", Name: "ORDERS_OLD"},
+	}, report.Orphans)
+	assert.Equal(t, []string{"mysql"}, report.Skipped)
+}
+
+func doubleQuotes(string) (string, string) {
+	return `"`, `"`
+}
+
+func TestFinder_FindKeepsTablesOfOtherPipelines(t *testing.T) {
+	t.Parallel()
+
+	sf := &databaseConnection{tables: map[string][]string{
+		"MART": {"ORDERS", "REFUNDS", "ORDERS_OLD"},
+		"RAW":  {"EVENTS"},
+	}}
+	connections := fakeConnections{"sf": sf, "sf-finance": sf}
+
+	p := &pipeline.Pipeline{
+		DefaultConnections: map[string]string{"snowflake": "sf"},
+		Assets:             []*pipeline.Asset{{Name: "mart.orders", Type: pipeline.AssetTypeSnowflakeQuery}},
+	}
+	finance := &pipeline.Pipeline{
+		DefaultConnections: map[string]string{"snowflake": "sf"},
+		Assets: []*pipeline.Asset{
+			{Name: "mart.refunds", Type: pipeline.AssetTypeSnowflakeQuery},
+			{Name: "raw.events", Type: pipeline.AssetTypeSnowflakeQuery},
+		},
+	}
+	// the same table on another connection belongs to another database
+	otherConnection := &pipeline.Pipeline{
+		DefaultConnections: map[string]string{"snowflake": "sf-finance"},
+		Assets:             []*pipeline.Asset{{Name: "mart.orders_old", Type: pipeline.AssetTypeSnowflakeQuery}},
+	}
+
+	finder := NewFinder(connections, func(string) string { return "ANALYTICS" }, doubleQuotes)
+	report, err := finder.Find(t.Context(), p, []*pipeline.Pipeline{finance, otherConnection}, "", nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, []Object{
+		{Connection: "sf", Database: "ANALYTICS", Schema: "MART", Name: "ORDERS_OLD"},
+	}, report.Orphans)
+}
+
+func TestFinder_FindReportsListingErrors(t *testing.T) {
+	t.Parallel()
+
+	connections := fakeConnections{"bq": &schemaConnection{tables: map[string][]string{}}}
+	p := &pipeline.Pipeline{
+		DefaultConnections: map[string]string{"google_cloud_platform": "bq"},
+		Assets:             []*pipeline.Asset{{Name: "never_built.table", Type: pipeline.AssetTypeBigqueryQuery}},
+	}
+
+	_, err := NewFinder(connections, nil, doubleQuotes).Find(t.Context(), p, nil, "", nil)
+	require.EqualError(t, err, "failed to list the tables of schema 'never_built' on connection 'bq': dataset 'never_built' does not exist")
+}
+
+func TestFinder_FindTreatsMissingSchemasAsEmpty(t *testing.T) {
+	t.Parallel()
+
+	bq := &datasetConnection{schemaConnection: schemaConnection{tables: map[string][]string{
+		"dev_staging": {"users", "users_v1"},
+	}}}
+	sf := &databaseConnection{tables: map[string][]string{"DEV_MART": {"ORDERS"}}}
+	connections := fakeConnections{"bq": bq, "sf": sf}
+	p := &pipeline.Pipeline{
+		DefaultConnections: map[string]string{"google_cloud_platform": "bq", "snowflake": "sf"},
+		Assets: []*pipeline.Asset{
+			{Name: "staging.users", Type: pipeline.AssetTypeBigqueryQuery},
+			{Name: "never_built.table", Type: pipeline.AssetTypeBigqueryQuery},
+			{Name: "never_built.table", Type: pipeline.AssetTypeSnowflakeQuery},
+		},
+	}
+
+	report, err := NewFinder(connections, func(string) string { return "ANALYTICS" }, doubleQuotes).Find(t.Context(), p, nil, "dev_", nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, []Object{{Connection: "bq", Schema: "dev_staging", Name: "users_v1"}}, report.Orphans)
+	assert.Equal(t, []string{"dev_staging"}, bq.listed)
+}
+
+func TestFinder_FindKeepsTheCasingOfSchemas(t *testing.T) {
+	t.Parallel()
+
+	bq := &datasetConnection{schemaConnection: schemaConnection{tables: map[string][]string{
+		"dev_Staging": {"Users", "users_v1"},
+	}}}
+	connections := fakeConnections{"bq": bq}
+	p := &pipeline.Pipeline{
+		DefaultConnections: map[string]string{"google_cloud_platform": "bq"},
+		Assets:             []*pipeline.Asset{{Name: "Staging.users", Type: pipeline.AssetTypeBigqueryQuery}},
+	}
+
+	finder := NewFinder(connections, nil, func(string) (string, string) { return "`", "`" })
+	report, err := finder.Find(t.Context(), p, nil, "dev_", nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"dev_Staging"}, bq.listed)
+	assert.Equal(t, []Object{{Connection: "bq", Schema: "dev_Staging", Name: "users_v1"}}, report.Orphans)
+}
+
+func TestFinder_FindRejectsInvalidPatterns(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewFinder(fakeConnections{}, nil, doubleQuotes).Find(t.Context(), &pipeline.Pipeline{}, nil, "", []string{"raw.["})
+	require.ErrorContains(t, err, "invalid allowlist pattern 'raw.['")
+}
+
+func TestFinder_Drop(t *testing.T) {
+	t.Parallel()
+
+	sf := &databaseConnection{failOn: `DROP TABLE IF EXISTS "analytics"."dev_mart"."orders_view"`}
+	finder := NewFinder(fakeConnections{"sf": sf}, nil, doubleQuotes)
+
+	require.NoError(t, finder.Drop(t.Context(), Object{Connection: "sf", Database: "analytics", Schema: "dev_mart", Name: "orders_old"}))
+	require.NoError(t, finder.Drop(t.Context(), Object{Connection: "sf", Database: "analytics", Schema: "dev_mart", Name: "orders_view"}))
+
+	assert.Equal(t, []string{
+		`DROP TABLE IF EXISTS "analytics"."dev_mart"."orders_old"`,
+		`DROP TABLE IF EXISTS "analytics"."dev_mart"."orders_view"`,
+		`DROP VIEW IF EXISTS "analytics"."dev_mart"."orders_view"`,
+	}, sf.queries)
+}
+
+func TestFinder_DropQuotesWithTheConnectionDialect(t *testing.T) {
+	t.Parallel()
+
+	bq := &databaseConnection{}
+	finder := NewFinder(fakeConnections{"bq": bq}, nil, func(string) (string, string) { return "`", "`" })
+
+	require.NoError(t, finder.Drop(t.Context(), Object{Connection: "bq", Schema: "staging", Name: "users-v1`old"}))
+	assert.Equal(t, []string{"DROP TABLE IF EXISTS `staging`.`users-v1``old`"}, bq.queries)
+}
@@ -0,0 +1,158 @@
+package owners
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/bruin-data/bruin/pkg/pipeline"
+)
+
+// CodeownersPath is the path of the CODEOWNERS file in the repository.
+const CodeownersPath = ".github/CODEOWNERS"
+
+const (
+	sectionStart  = "# BEGIN bruin owners"
+	sectionEnd    = "# END bruin owners"
+	sectionHeader = "# Generated by `bruin owners codeowners` from the owners of the assets, do not edit by hand."
+)
+
+type CodeownersResult struct {
+	// Entries are the lines of the CODEOWNERS section, one per asset file.
+	Entries []string
+	// Warnings are the owners that could not be mapped to a handle, their assets are left out.
+	Warnings []string
+}
+
+// BuildCodeowners maps the files of the assets to the handles of their owners.
+func BuildCodeowners(repoPath string, pipelines []*pipeline.Pipeline, config *Config) *CodeownersResult {
+	result := &CodeownersResult{Entries: make([]string, 0)}
+	handles := make(map[string][]string)
+	unmapped := make(map[string][]string)
+
+	for _, p := range pipelines {
+		for _, asset := range p.Assets {
+			// outputs are defined in the file of the asset that produces them.
+			if asset.OutputOf != "" {
+				continue
+			}
+			owner := strings.TrimSpace(asset.Owner)
+			if owner == "" {
+				continue
+			}
+			handle, ok := config.Handle(owner)
+			if !ok {
+				unmapped[owner] = append(unmapped[owner], asset.Name)
+				continue
+			}
+
+			for _, path := range assetFiles(asset) {
+				path = "/" + relativePath(repoPath, path)
+				if !slices.Contains(handles[path], handle) {
+					handles[path] = append(handles[path], handle)
+				}
+			}
+		}
+	}
+
+	paths := make([]string, 0, len(handles))
+	for path := range handles {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		result.Entries = append(result.Entries, escapePath(path)+" "+strings.Join(handles[path], " "))
+	}
+
+	owners := make([]string, 0, len(unmapped))
+	for owner := range unmapped {
+		owners = append(owners, owner)
+	}
+	sort.Strings(owners)
+	for _, owner := range owners {
+		result.Warnings = append(result.Warnings, fmt.Sprintf("owner '%s' has no handle, add it to the handles in owners.yml, its assets are left out: %s", owner, strings.Join(unmapped[owner], ", ")))
+	}
+
+	return result
+}
+
+func assetFiles(asset *pipeline.Asset) []string {
+	files := make([]string, 0, 2)
+	if asset.DefinitionFile.Path != "" {
+		files = append(files, asset.DefinitionFile.Path)
+	}
+	if asset.ExecutableFile.Path != "" && asset.ExecutableFile.Path != asset.DefinitionFile.Path {
+		files = append(files, asset.ExecutableFile.Path)
+	}
+	return files
+}
+
+func escapePath(path string) string {
+	return strings.ReplaceAll(path, " ", `\ `)
+}
+
+// UpdateCodeowners replaces the section Bruin manages in the CODEOWNERS file with the entries, the
+// section is appended to the end of the file if it has none yet. The rest of the file is kept.
+func UpdateCodeowners(existing string, entries []string) string {
+	section := make([]string, 0, len(entries)+3)
+	section = append(section, sectionStart, sectionHeader)
+	section = append(section, entries...)
+	section = append(section, sectionEnd)
+
+	lines, start, end := splitSection(existing)
+	if start == -1 {
+		if len(lines) > 0 && lines[len(lines)-1] != "" {
+			lines = append(lines, "")
+		}
+		lines = append(lines, section...)
+	} else {
+		lines = slices.Concat(lines[:start], section, lines[end+1:])
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// DiffCodeowners compares the section Bruin manages in the CODEOWNERS file with the entries, it
+// returns the entries the file is missing and the ones in the file that are not expected anymore.
+func DiffCodeowners(existing string, entries []string) (missing, stale []string) {
+	current := make([]string, 0)
+	lines, start, end := splitSection(existing)
+	if start != -1 {
+		for _, line := range lines[start+1 : end] {
+			if line = strings.TrimSpace(line); line != "" && !strings.HasPrefix(line, "#") {
+				current = append(current, line)
+			}
+		}
+	}
+
+	for _, entry := range entries {
+		if !slices.Contains(current, entry) {
+			missing = append(missing, entry)
+		}
+	}
+	for _, line := range current {
+		if !slices.Contains(entries, line) {
+			stale = append(stale, line)
+		}
+	}
+	return missing, stale
+}
+
+// splitSection returns the lines of the file and the indexes of the start and end markers of the
+// section Bruin manages, or -1 if the file has no complete section.
+func splitSection(content string) ([]string, int, int) {
+	content = strings.TrimRight(content, "\n")
+	if content == "" {
+		return []string{}, -1, -1
+	}
+	lines := strings.Split(content, "\n")
+	start := slices.Index(lines, sectionStart)
+	if start == -1 {
+		return lines, -1, -1
+	}
+	end := slices.Index(lines[start:], sectionEnd)
+	if end == -1 {
+		return lines, -1, -1
+	}
+	return lines, start, start + end
+}
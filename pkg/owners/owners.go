@@ -0,0 +1,82 @@
+// Package owners reports the ownership of the assets and maps their owners to the code owners of
+// their files.
+package owners
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/afero"
+	"gopkg.in/yaml.v3"
+)
+
+// FileNames are the names of the ownership configuration at the root of the repository.
+var FileNames = []string{"owners.yml", "owners.yaml"}
+
+// CriticalMaxTier is the highest tier that is considered critical, i.e. tier 1 and 2 assets.
+const CriticalMaxTier = 2
+
+type Config struct {
+	// Handles maps the owners of the assets to their GitHub handles, e.g. `jane@example.com: "@jane"`
+	// or `finance: "@acme/finance"`. The owners that are already handles or email addresses do not
+	// need to be mapped.
+	Handles map[string]string `yaml:"handles"`
+	// RequireOwnerMaxTier makes the assets of this tier or a lower one require an owner, e.g. 2
+	// requires an owner for the tier 1 and tier 2 assets. It is not enforced when it is 0.
+	RequireOwnerMaxTier int `yaml:"require_owner_max_tier"`
+}
+
+// ReadConfig reads the ownership configuration at the root of the repository, the configuration is
+// empty if the repository has none.
+func ReadConfig(fs afero.Fs, repoPath string) (*Config, error) {
+	for _, fileName := range FileNames {
+		content, err := afero.ReadFile(fs, filepath.Join(repoPath, fileName))
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+			return nil, errors.Wrapf(err, "failed to read %s", fileName)
+		}
+
+		var config Config
+		if err := yaml.Unmarshal(content, &config); err != nil {
+			return nil, errors.Wrapf(err, "failed to parse %s", fileName)
+		}
+		if err := config.validate(); err != nil {
+			return nil, errors.Wrapf(err, "invalid %s", fileName)
+		}
+		return &config, nil
+	}
+	return &Config{}, nil
+}
+
+func (c *Config) validate() error {
+	if c.RequireOwnerMaxTier < 0 || c.RequireOwnerMaxTier > 5 {
+		return errors.Errorf("require_owner_max_tier must be between 1 and 5, or 0 to not require owners, got %d", c.RequireOwnerMaxTier)
+	}
+	for owner, handle := range c.Handles {
+		if !isHandle(handle) {
+			return errors.Errorf("the handle of owner '%s' must be a GitHub user or team starting with '@', or an email address, got '%s'", owner, handle)
+		}
+	}
+	return nil
+}
+
+// Handle returns the code owner of an owner, either its mapped handle or the owner itself when it
+// is already a handle or an email address.
+func (c *Config) Handle(owner string) (string, bool) {
+	owner = strings.TrimSpace(owner)
+	if handle, ok := c.Handles[owner]; ok {
+		return handle, true
+	}
+	if isHandle(owner) {
+		return owner, true
+	}
+	return "", false
+}
+
+func isHandle(value string) bool {
+	return strings.Contains(value, "@") && !strings.ContainsAny(value, " \t")
+}
@@ -0,0 +1,175 @@
+package owners
+
+import (
+	"testing"
+
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func ownersTestPipelines() []*pipeline.Pipeline {
+	return []*pipeline.Pipeline{
+		{
+			Name: "sales",
+			Assets: []*pipeline.Asset{
+				{
+					Name:           "mart.orders",
+					Owner:          "jane@example.com",
+					Tier:           1,
+					Domains:        []string{"finance"},
+					DefinitionFile: pipeline.TaskDefinitionFile{Path: "/repo/sales/assets/orders.sql"},
+					ExecutableFile: pipeline.ExecutableFile{Path: "/repo/sales/assets/orders.sql"},
+				},
+				{
+					Name:           "mart.refunds",
+					Owner:          "finance",
+					Tier:           2,
+					Domains:        []string{"finance"},
+					DefinitionFile: pipeline.TaskDefinitionFile{Path: "/repo/sales/assets/refunds.asset.yml"},
+					ExecutableFile: pipeline.ExecutableFile{Path: "/repo/sales/assets/refunds.py"},
+				},
+				{
+					Name:           "raw.orders",
+					Tier:           1,
+					Domains:        []string{"finance"},
+					DefinitionFile: pipeline.TaskDefinitionFile{Path: "/repo/sales/assets/raw orders.sql"},
+				},
+			},
+		},
+		{
+			Name: "marketing",
+			Assets: []*pipeline.Asset{
+				{
+					Name:           "mart.campaigns",
+					Owner:          "marketing-team",
+					DefinitionFile: pipeline.TaskDefinitionFile{Path: "/repo/marketing/assets/campaigns.sql"},
+				},
+				{
+					Name:           "raw.clicks",
+					DefinitionFile: pipeline.TaskDefinitionFile{Path: "/repo/marketing/assets/clicks.sql"},
+				},
+			},
+		},
+	}
+}
+
+func TestBuildReport(t *testing.T) {
+	t.Parallel()
+
+	report := BuildReport("/repo", ownersTestPipelines())
+
+	assert.Equal(t, 5, report.Assets)
+	assert.Equal(t, []*AssetRef{
+		{Name: "raw.orders", Pipeline: "sales", Tier: 1, Path: "sales/assets/raw orders.sql"},
+		{Name: "raw.clicks", Pipeline: "marketing", Path: "marketing/assets/clicks.sql"},
+	}, report.Unowned)
+	assert.Equal(t, []*DomainOwnership{
+		{
+			Domain:  "finance",
+			Assets:  3,
+			Unowned: 1,
+			Owners:  []*OwnerCount{{Owner: "finance", Assets: 1}, {Owner: "jane@example.com", Assets: 1}},
+		},
+		{
+			Domain:  "",
+			Assets:  2,
+			Unowned: 1,
+			Owners:  []*OwnerCount{{Owner: "marketing-team", Assets: 1}},
+		},
+	}, report.Domains)
+	assert.Equal(t, []*OwnerCount{{Owner: "finance", Assets: 1}, {Owner: "jane@example.com", Assets: 1}}, report.Critical)
+	assert.Equal(t, 1, report.UnownedCritical)
+}
+
+func TestBuildCodeowners(t *testing.T) {
+	t.Parallel()
+
+	result := BuildCodeowners("/repo", ownersTestPipelines(), &Config{
+		Handles: map[string]string{"finance": "@acme/finance"},
+	})
+
+	assert.Equal(t, []string{
+		"/sales/assets/orders.sql jane@example.com",
+		"/sales/assets/refunds.asset.yml @acme/finance",
+		"/sales/assets/refunds.py @acme/finance",
+	}, result.Entries)
+	assert.Equal(t, []string{
+		"owner 'marketing-team' has no handle, add it to the handles in owners.yml, its assets are left out: mart.campaigns",
+	}, result.Warnings)
+}
+
+func TestUpdateCodeowners(t *testing.T) {
+	t.Parallel()
+
+	entries := []string{"/sales/assets/orders.sql @jane"}
+
+	created := UpdateCodeowners("", entries)
+	assert.Equal(t, "# BEGIN bruin owners\n"+sectionHeader+"\n/sales/assets/orders.sql @jane\n# END bruin owners\n", created)
+
+	appended := UpdateCodeowners("* @acme/admins\n", entries)
+	assert.Equal(t, "* @acme/admins\n\n"+created, appended)
+
+	replaced := UpdateCodeowners(appended+"/docs/ @acme/writers\n", []string{"/sales/assets/orders.sql @acme/finance"})
+	assert.Equal(t, "* @acme/admins\n\n# BEGIN bruin owners\n"+sectionHeader+"\n/sales/assets/orders.sql @acme/finance\n# END bruin owners\n/docs/ @acme/writers\n", replaced)
+
+	missing, stale := DiffCodeowners(appended, entries)
+	assert.Empty(t, missing)
+	assert.Empty(t, stale)
+
+	missing, stale = DiffCodeowners(replaced, []string{"/sales/assets/refunds.sql @acme/finance"})
+	assert.Equal(t, []string{"/sales/assets/refunds.sql @acme/finance"}, missing)
+	assert.Equal(t, []string{"/sales/assets/orders.sql @acme/finance"}, stale)
+
+	missing, _ = DiffCodeowners("* @acme/admins\n", entries)
+	assert.Equal(t, entries, missing)
+}
+
+func TestReadConfig(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		content string
+		want    *Config
+		wantErr string
+	}{
+		{
+			name:    "handles and tier",
+			content: "handles:\n  finance: \"@acme/finance\"\nrequire_owner_max_tier: 2\n",
+			want:    &Config{Handles: map[string]string{"finance": "@acme/finance"}, RequireOwnerMaxTier: 2},
+		},
+		{
+			name:    "invalid handle",
+			content: "handles:\n  finance: acme finance\n",
+			wantErr: "invalid owners.yml: the handle of owner 'finance' must be a GitHub user or team starting with '@', or an email address, got 'acme finance'",
+		},
+		{
+			name:    "invalid tier",
+			content: "require_owner_max_tier: 7\n",
+			wantErr: "invalid owners.yml: require_owner_max_tier must be between 1 and 5, or 0 to not require owners, got 7",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			fs := afero.NewMemMapFs()
+			require.NoError(t, afero.WriteFile(fs, "/repo/owners.yml", []byte(tt.content), 0o600))
+
+			got, err := ReadConfig(fs, "/repo")
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	config, err := ReadConfig(afero.NewMemMapFs(), "/repo")
+	require.NoError(t, err)
+	assert.Equal(t, &Config{}, config)
+}
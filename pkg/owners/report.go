@@ -0,0 +1,155 @@
+package owners
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/bruin-data/bruin/pkg/pipeline"
+)
+
+type AssetRef struct {
+	Name     string `json:"name"`
+	Pipeline string `json:"pipeline"`
+	Tier     int    `json:"tier,omitempty"`
+	Path     string `json:"path"`
+}
+
+type OwnerCount struct {
+	Owner  string `json:"owner"`
+	Assets int    `json:"assets"`
+}
+
+type DomainOwnership struct {
+	Domain  string        `json:"domain"`
+	Assets  int           `json:"assets"`
+	Unowned int           `json:"unowned"`
+	Owners  []*OwnerCount `json:"owners"`
+}
+
+type Report struct {
+	Assets int `json:"assets"`
+	// Unowned are the assets without an owner, the critical ones first.
+	Unowned []*AssetRef `json:"unowned"`
+	// Domains is the ownership of the assets per domain, the assets without a domain are reported
+	// under an empty domain.
+	Domains []*DomainOwnership `json:"domains"`
+	// Critical is the number of tier 1 and tier 2 assets per owner.
+	Critical        []*OwnerCount `json:"critical"`
+	UnownedCritical int           `json:"unowned_critical"`
+}
+
+func isCritical(asset *pipeline.Asset) bool {
+	return asset.Tier > 0 && asset.Tier <= CriticalMaxTier
+}
+
+// BuildReport reports the assets without owners, the ownership per domain and the critical assets
+// per owner. The paths of the assets are relative to the repository.
+func BuildReport(repoPath string, pipelines []*pipeline.Pipeline) *Report {
+	report := &Report{
+		Unowned:  make([]*AssetRef, 0),
+		Domains:  make([]*DomainOwnership, 0),
+		Critical: make([]*OwnerCount, 0),
+	}
+
+	domains := make(map[string]*DomainOwnership)
+	domainOwners := make(map[string]map[string]int)
+	critical := make(map[string]int)
+
+	for _, p := range pipelines {
+		for _, asset := range p.Assets {
+			report.Assets++
+			owner := strings.TrimSpace(asset.Owner)
+
+			if owner == "" {
+				report.Unowned = append(report.Unowned, &AssetRef{
+					Name:     asset.Name,
+					Pipeline: p.Name,
+					Tier:     asset.Tier,
+					Path:     relativePath(repoPath, asset.DefinitionFile.Path),
+				})
+			}
+
+			if isCritical(asset) {
+				if owner == "" {
+					report.UnownedCritical++
+				} else {
+					critical[owner]++
+				}
+			}
+
+			assetDomains := []string(asset.Domains)
+			if len(assetDomains) == 0 {
+				assetDomains = []string{""}
+			}
+			for _, domain := range assetDomains {
+				d, ok := domains[domain]
+				if !ok {
+					d = &DomainOwnership{Domain: domain}
+					domains[domain] = d
+					domainOwners[domain] = make(map[string]int)
+				}
+				d.Assets++
+				if owner == "" {
+					d.Unowned++
+				} else {
+					domainOwners[domain][owner]++
+				}
+			}
+		}
+	}
+
+	sort.SliceStable(report.Unowned, func(i, j int) bool {
+		a, b := report.Unowned[i], report.Unowned[j]
+		if tierOrder(a.Tier) != tierOrder(b.Tier) {
+			return tierOrder(a.Tier) < tierOrder(b.Tier)
+		}
+		return a.Name < b.Name
+	})
+
+	for domain, d := range domains {
+		d.Owners = sortedCounts(domainOwners[domain])
+		report.Domains = append(report.Domains, d)
+	}
+	sort.Slice(report.Domains, func(i, j int) bool {
+		a, b := report.Domains[i], report.Domains[j]
+		// the assets without a domain come last
+		if (a.Domain == "") != (b.Domain == "") {
+			return b.Domain == ""
+		}
+		return a.Domain < b.Domain
+	})
+
+	report.Critical = sortedCounts(critical)
+	return report
+}
+
+// tierOrder sorts the assets without a tier after the ones with a tier.
+func tierOrder(tier int) int {
+	if tier == 0 {
+		return 6
+	}
+	return tier
+}
+
+func sortedCounts(counts map[string]int) []*OwnerCount {
+	sorted := make([]*OwnerCount, 0, len(counts))
+	for owner, count := range counts {
+		sorted = append(sorted, &OwnerCount{Owner: owner, Assets: count})
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Assets != sorted[j].Assets {
+			return sorted[i].Assets > sorted[j].Assets
+		}
+		return sorted[i].Owner < sorted[j].Owner
+	})
+	return sorted
+}
+
+func relativePath(repoPath, path string) string {
+	rel, err := filepath.Rel(repoPath, path)
+	if err != nil {
+		return path
+	}
+	return filepath.ToSlash(rel)
+}
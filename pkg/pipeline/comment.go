@@ -391,6 +391,19 @@ func commentRowsToTask(commentRows []string) (*Asset, error) {
 			case "incremental_predicate":
 				task.Materialization.IncrementalPredicate = value
 				continue
+			case "on_schema_change":
+				task.Materialization.OnSchemaChange = MaterializationOnSchemaChange(strings.ToLower(value))
+				continue
+			case "batch_size":
+				task.Materialization.BatchSize = strings.ToLower(value)
+				continue
+			case "batch_concurrency":
+				concurrency, err := strconv.Atoi(value)
+				if err != nil {
+					return nil, errors.Wrapf(err, "invalid batch_concurrency '%s'", value)
+				}
+				task.Materialization.BatchConcurrency = concurrency
+				continue
 			case "cluster_by":
 				values := strings.Split(value, ",")
 				for _, v := range values {
@@ -454,6 +467,8 @@ func handleColumnEntry(columnFields []string, task *Asset, value string) error {
 		task.Columns[columnIndex].Type = strings.ToLower(strings.TrimSpace(value))
 	case "mask":
 		task.Columns[columnIndex].Mask = strings.TrimSpace(value)
+	case "masking_policy":
+		task.Columns[columnIndex].MaskingPolicy = strings.TrimSpace(value)
 	case "primary_key":
 		boolValue, err := strconv.ParseBool(value)
 		if err != nil {
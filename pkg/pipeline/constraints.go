@@ -0,0 +1,17 @@
+package pipeline
+
+// IsNotNull reports whether the column is explicitly defined with `nullable: false`.
+func (c *Column) IsNotNull() bool {
+	return c.Nullable.Value != nil && !*c.Nullable.Value
+}
+
+// IsExplicitlyNullable reports whether the column is explicitly defined with `nullable: true`,
+// as opposed to being nullable only because the flag is not set.
+func (c *Column) IsExplicitlyNullable() bool {
+	return c.Nullable.Value != nil && *c.Nullable.Value
+}
+
+// HasForeignKey reports whether the column references a column of another table.
+func (c *Column) HasForeignKey() bool {
+	return c.ForeignKey != nil && c.ForeignKey.Table != "" && c.ForeignKey.Column != ""
+}
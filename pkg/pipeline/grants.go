@@ -0,0 +1,140 @@
+package pipeline
+
+import (
+	"slices"
+	"sort"
+	"strings"
+)
+
+// Grants maps a privilege, e.g. `select`, to the roles, users or groups it is granted to on the
+// table an asset materializes.
+type Grants map[string][]string
+
+// IsEmpty reports whether there is at least one grantee to grant a privilege to.
+func (g Grants) IsEmpty() bool {
+	for _, grantees := range g {
+		if len(grantees) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Privileges returns the privileges that have grantees, upper-cased and sorted so that the
+// statements generated from them are stable.
+func (g Grants) Privileges() []string {
+	privileges := make([]string, 0, len(g))
+	seen := make(map[string]bool, len(g))
+	for privilege, grantees := range g {
+		normalized := NormalizePrivilege(privilege)
+		if normalized == "" || len(grantees) == 0 || seen[normalized] {
+			continue
+		}
+		seen[normalized] = true
+		privileges = append(privileges, normalized)
+	}
+	sort.Strings(privileges)
+	return privileges
+}
+
+// Grantees returns the grantees of the given privilege regardless of how it is spelled in the
+// asset definition, in the order they were defined and without duplicates.
+func (g Grants) Grantees(privilege string) []string {
+	privilege = NormalizePrivilege(privilege)
+	keys := make([]string, 0, len(g))
+	for key := range g {
+		if NormalizePrivilege(key) == privilege {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	var grantees []string
+	seen := make(map[string]bool)
+	for _, key := range keys {
+		for _, grantee := range g[key] {
+			grantee = strings.TrimSpace(grantee)
+			normalized := NormalizeGrantee(grantee)
+			if normalized == "" || seen[normalized] {
+				continue
+			}
+			seen[normalized] = true
+			grantees = append(grantees, grantee)
+		}
+	}
+	return grantees
+}
+
+// Missing returns the grants in g that are not part of current. Grants are only ever added, the
+// privileges that are granted outside of the asset definition are left untouched.
+func (g Grants) Missing(current Grants) Grants {
+	existing := make(map[string]bool)
+	for _, privilege := range current.Privileges() {
+		for _, grantee := range current.Grantees(privilege) {
+			existing[privilege+"\x00"+NormalizeGrantee(grantee)] = true
+		}
+	}
+
+	missing := Grants{}
+	for _, privilege := range g.Privileges() {
+		for _, grantee := range g.Grantees(privilege) {
+			if !existing[privilege+"\x00"+NormalizeGrantee(grantee)] {
+				missing[privilege] = append(missing[privilege], grantee)
+			}
+		}
+	}
+	return missing
+}
+
+// Clone returns a deep copy of the grants.
+func (g Grants) Clone() Grants {
+	if g == nil {
+		return nil
+	}
+	clone := make(Grants, len(g))
+	for privilege, grantees := range g {
+		clone[privilege] = slices.Clone(grantees)
+	}
+	return clone
+}
+
+// NormalizePrivilege upper-cases the privilege and collapses the whitespace in it, e.g.
+// `all  privileges` becomes `ALL PRIVILEGES`.
+func NormalizePrivilege(privilege string) string {
+	return strings.ToUpper(strings.Join(strings.Fields(privilege), " "))
+}
+
+// NormalizeGrantee returns the form of the grantee that is used to compare it with the grantees
+// reported by the platforms: lower-cased, unquoted and without the grantee type keyword in front
+// of it, e.g. `role`, `group` or Snowflake's `database role`.
+func NormalizeGrantee(grantee string) string {
+	grantee = strings.ToLower(strings.TrimSpace(grantee))
+	for _, keyword := range []string{"database role ", "application role ", "role ", "user ", "group "} {
+		if strings.HasPrefix(grantee, keyword) {
+			grantee = strings.TrimSpace(strings.TrimPrefix(grantee, keyword))
+			break
+		}
+	}
+	return strings.Trim(grantee, "\"`'")
+}
+
+func mergeGrantDefaults(target *Grants, defaults Grants) {
+	if len(defaults) == 0 {
+		return
+	}
+	defined := make(map[string]bool, len(*target))
+	for privilege := range *target {
+		defined[NormalizePrivilege(privilege)] = true
+	}
+	merged := target.Clone()
+	if merged == nil {
+		merged = Grants{}
+	}
+	for privilege, grantees := range defaults {
+		if defined[NormalizePrivilege(privilege)] {
+			continue
+		}
+		merged[privilege] = slices.Clone(grantees)
+	}
+	*target = merged
+}
@@ -0,0 +1,90 @@
+package pipeline
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGrants_Missing(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		grants  Grants
+		current Grants
+		want    Grants
+	}{
+		{
+			name:    "nothing granted yet",
+			grants:  Grants{"select": {"role_bi", "role_analyst"}},
+			current: Grants{},
+			want:    Grants{"SELECT": {"role_bi", "role_analyst"}},
+		},
+		{
+			name:    "grants are matched regardless of case and quotes",
+			grants:  Grants{"select": {"ROLE_BI", "role_analyst"}, "insert": {"role_etl"}},
+			current: Grants{"SELECT": {`"role_bi"`}, "INSERT": {"ROLE_ETL"}},
+			want:    Grants{"SELECT": {"role_analyst"}},
+		},
+		{
+			name:    "grantee type keywords are ignored",
+			grants:  Grants{"select": {"GROUP analysts", "role_bi"}},
+			current: Grants{"select": {"analysts", "role_bi"}},
+			want:    Grants{},
+		},
+		{
+			name:    "snowflake database and application roles are matched by name",
+			grants:  Grants{"select": {"DATABASE ROLE analytics.readers", "application role app.viewer"}},
+			current: Grants{"SELECT": {"ANALYTICS.READERS", "APP.VIEWER"}},
+			want:    Grants{},
+		},
+		{
+			name:    "grants outside of the definition are kept as they are",
+			grants:  Grants{"select": {"role_bi"}},
+			current: Grants{"select": {"role_bi", "role_admin"}, "delete": {"role_admin"}},
+			want:    Grants{},
+		},
+		{
+			name:    "duplicate grantees and empty privileges are dropped",
+			grants:  Grants{"select": {"role_bi", "role_bi", " "}, "update": {}},
+			current: nil,
+			want:    Grants{"SELECT": {"role_bi"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, tt.grants.Missing(tt.current))
+		})
+	}
+}
+
+func TestGrants_Privileges(t *testing.T) {
+	t.Parallel()
+
+	grants := Grants{"select": {"role_bi"}, "all  privileges": {"role_admin"}, "Select": {"role_analyst"}, "delete": nil}
+
+	assert.Equal(t, []string{"ALL PRIVILEGES", "SELECT"}, grants.Privileges())
+	assert.Equal(t, []string{"role_analyst", "role_bi"}, grants.Grantees("SELECT"))
+	assert.True(t, Grants{"select": nil}.IsEmpty())
+	assert.False(t, grants.IsEmpty())
+}
+
+func TestMergeGrantDefaults(t *testing.T) {
+	t.Parallel()
+
+	defaults := Grants{"select": {"role_bi"}, "insert": {"role_etl"}}
+
+	var empty Grants
+	mergeGrantDefaults(&empty, defaults)
+	assert.Equal(t, defaults, empty)
+
+	asset := Grants{"SELECT": {"role_finance"}}
+	mergeGrantDefaults(&asset, defaults)
+	assert.Equal(t, Grants{"SELECT": {"role_finance"}, "insert": {"role_etl"}}, asset)
+
+	// the defaults must not be shared with the asset
+	empty["select"][0] = "changed"
+	assert.Equal(t, []string{"role_bi"}, defaults["select"])
+}
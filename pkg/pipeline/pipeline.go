@@ -7,6 +7,7 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"maps"
 	"math"
 	"path/filepath"
 	"reflect"
@@ -74,6 +75,7 @@ const (
 	AssetTypeGCSPrefixSensorLegacy     = AssetType("gcs.sensor.object_sensor_with_prefix")
 	AssetTypeGoodData                  = AssetType("gooddata")
 	AssetTypeIceberg                   = AssetType("ingestr.iceberg") // ingestr-only mapping key (not an executable asset type)
+	AssetTypeIcebergQuery              = AssetType("iceberg.sql")
 	AssetTypeGoogleSheets              = AssetType("gsheets")
 	AssetTypeGrafana                   = AssetType("grafana")
 	AssetTypeIngestr                   = AssetType("ingestr")
@@ -157,6 +159,9 @@ const (
 	AssetTypeSparkSeed                 = AssetType("spark.seed")
 	AssetTypeSparkSource               = AssetType("spark.source")
 	AssetTypeSparkTableSensor          = AssetType("spark.sensor.table")
+	AssetTypeSQLiteQuery               = AssetType("sqlite.sql")
+	AssetTypeSQLiteQuerySensor         = AssetType("sqlite.sensor.query")
+	AssetTypeSQLiteSeed                = AssetType("sqlite.seed")
 	AssetTypeVerticaQuery              = AssetType("vertica.sql")
 	AssetTypeVerticaQuerySensor        = AssetType("vertica.sensor.query")
 	AssetTypeVerticaSeed               = AssetType("vertica.seed")
@@ -169,6 +174,7 @@ const (
 	RunConfigRunID                     = RunConfig("run-id")
 	RunConfigStartDate                 = RunConfig("start-date")
 	RunConfigExecutionDate             = RunConfig("execution-date")
+	RunConfigMicrobatchStatePath       = RunConfig("microbatch-state-path")
 )
 
 var defaultMapping = map[string]string{
@@ -606,6 +612,7 @@ const (
 type (
 	MaterializationStrategy        string
 	MaterializationTimeGranularity string
+	MaterializationOnSchemaChange  string
 )
 
 const (
@@ -616,6 +623,7 @@ const (
 	MaterializationStrategyAppend             MaterializationStrategy        = "append"
 	MaterializationStrategyMerge              MaterializationStrategy        = "merge"
 	MaterializationStrategyTimeInterval       MaterializationStrategy        = "time_interval"
+	MaterializationStrategyInsertOverwrite    MaterializationStrategy        = "insert_overwrite"
 	MaterializationStrategyDDL                MaterializationStrategy        = "ddl"
 	MaterializationTimeGranularityDate        MaterializationTimeGranularity = "date"
 	MaterializationTimeGranularityTimestamp   MaterializationTimeGranularity = "timestamp"
@@ -626,6 +634,21 @@ const (
 	MaterializationStrategyDataVaultSatellite MaterializationStrategy        = "datavault_satellite"
 )
 
+const (
+	MaterializationOnSchemaChangeNone             MaterializationOnSchemaChange = ""
+	MaterializationOnSchemaChangeFail             MaterializationOnSchemaChange = "fail"
+	MaterializationOnSchemaChangeIgnore           MaterializationOnSchemaChange = "ignore"
+	MaterializationOnSchemaChangeAppendNewColumns MaterializationOnSchemaChange = "append_new_columns"
+	MaterializationOnSchemaChangeSyncAllColumns   MaterializationOnSchemaChange = "sync_all_columns"
+)
+
+var AllAvailableOnSchemaChangePolicies = []MaterializationOnSchemaChange{
+	MaterializationOnSchemaChangeFail,
+	MaterializationOnSchemaChangeIgnore,
+	MaterializationOnSchemaChangeAppendNewColumns,
+	MaterializationOnSchemaChangeSyncAllColumns,
+}
+
 // IsDataVault reports whether the strategy is one of the Data Vault loading strategies.
 // These carry their own full-refresh handling, so they must never be silently swapped
 // for another strategy on a platform that does not implement them.
@@ -645,6 +668,7 @@ var AllAvailableMaterializationStrategies = []MaterializationStrategy{
 	MaterializationStrategyAppend,
 	MaterializationStrategyMerge,
 	MaterializationStrategyTimeInterval,
+	MaterializationStrategyInsertOverwrite,
 	MaterializationStrategyDDL,
 	MaterializationStrategySCD2ByTime,
 	MaterializationStrategySCD2ByColumn,
@@ -661,14 +685,86 @@ type Materialization struct {
 	IncrementalKey       string                         `json:"incremental_key" yaml:"incremental_key,omitempty" mapstructure:"incremental_key"`
 	IncrementalPredicate string                         `json:"incremental_predicate" yaml:"incremental_predicate,omitempty" mapstructure:"incremental_predicate"`
 	TimeGranularity      MaterializationTimeGranularity `json:"time_granularity" yaml:"time_granularity,omitempty" mapstructure:"time_granularity"`
+	OnSchemaChange       MaterializationOnSchemaChange  `json:"on_schema_change,omitempty" yaml:"on_schema_change,omitempty" mapstructure:"on_schema_change"`
+	BatchSize            string                         `json:"batch_size,omitempty" yaml:"batch_size,omitempty" mapstructure:"batch_size"`
+	BatchConcurrency     int                            `json:"batch_concurrency,omitempty" yaml:"batch_concurrency,omitempty" mapstructure:"batch_concurrency"`
 }
 
 func (m Materialization) IsSCD2() bool {
 	return m.Strategy == MaterializationStrategySCD2ByColumn || m.Strategy == MaterializationStrategySCD2ByTime
 }
 
+// SupportsSchemaEvolution reports whether the strategy writes into an existing table, which is
+// the only case where `on_schema_change` has an effect.
+func (m Materialization) SupportsSchemaEvolution() bool {
+	if m.Type != MaterializationTypeTable {
+		return false
+	}
+
+	switch m.Strategy {
+	case MaterializationStrategyAppend, MaterializationStrategyMerge, MaterializationStrategyDeleteInsert, MaterializationStrategyTimeInterval:
+		return true
+	default:
+		return false
+	}
+}
+
+// ShouldEvolveSchema reports whether the existing table needs to be compared against the query
+// output before the incremental statement runs.
+func (m Materialization) ShouldEvolveSchema() bool {
+	if !m.SupportsSchemaEvolution() {
+		return false
+	}
+
+	return m.OnSchemaChange != MaterializationOnSchemaChangeNone && m.OnSchemaChange != MaterializationOnSchemaChangeIgnore
+}
+
+// UsesIntervalDates reports whether the materialized query references the interval dates, which
+// means it has to be rendered once more after materialization. This is the case for `time_interval`
+// and for `insert_overwrite` when the partitions are picked from the interval instead of the
+// query result.
+func (m Materialization) UsesIntervalDates() bool {
+	if m.Strategy == MaterializationStrategyTimeInterval {
+		return true
+	}
+
+	return m.Strategy == MaterializationStrategyInsertOverwrite && m.TimeGranularity != ""
+}
+
+// IsMicrobatched reports whether a `time_interval` materialization splits its window into
+// consecutive batches instead of processing it with a single statement.
+func (m Materialization) IsMicrobatched() bool {
+	return m.Strategy == MaterializationStrategyTimeInterval && m.BatchSize != ""
+}
+
+// BatchDuration parses `batch_size`, which is a positive integer followed by `h` (hours) or
+// `d` (days), e.g. `1d` or `6h`.
+func (m Materialization) BatchDuration() (time.Duration, error) {
+	size := strings.ToLower(strings.TrimSpace(m.BatchSize))
+	if len(size) < 2 {
+		return 0, fmt.Errorf("invalid batch_size '%s', expected a number followed by 'h' or 'd', e.g. '1d'", m.BatchSize)
+	}
+
+	var unit time.Duration
+	switch size[len(size)-1] {
+	case 'h':
+		unit = time.Hour
+	case 'd':
+		unit = 24 * time.Hour
+	default:
+		return 0, fmt.Errorf("invalid batch_size '%s', the unit must be 'h' or 'd'", m.BatchSize)
+	}
+
+	count, err := strconv.Atoi(size[:len(size)-1])
+	if err != nil || count <= 0 {
+		return 0, fmt.Errorf("invalid batch_size '%s', expected a positive number followed by 'h' or 'd', e.g. '1d'", m.BatchSize)
+	}
+
+	return time.Duration(count) * unit, nil
+}
+
 func (m Materialization) MarshalJSON() ([]byte, error) {
-	if m.Type == "" && m.Strategy == "" && m.PartitionBy == "" && len(m.ClusterBy) == 0 && m.IncrementalKey == "" && m.IncrementalPredicate == "" && m.TimeGranularity == "" {
+	if m.Type == "" && m.Strategy == "" && m.PartitionBy == "" && len(m.ClusterBy) == 0 && m.IncrementalKey == "" && m.IncrementalPredicate == "" && m.TimeGranularity == "" && m.OnSchemaChange == "" && m.BatchSize == "" && m.BatchConcurrency == 0 {
 		return []byte("null"), nil
 	}
 
@@ -852,6 +948,7 @@ type Column struct {
 	SourceColumn    string            `json:"source_column" yaml:"source_column,omitempty" mapstructure:"source_column"`
 	Type            string            `json:"type" yaml:"type,omitempty" mapstructure:"type"`
 	Mask            string            `json:"mask,omitempty" yaml:"mask,omitempty" mapstructure:"mask"`
+	MaskingPolicy   string            `json:"masking_policy,omitempty" yaml:"masking_policy,omitempty" mapstructure:"masking_policy"`
 	Description     string            `json:"description" yaml:"description,omitempty" mapstructure:"description"`
 	Tags            EmptyStringArray  `json:"tags" yaml:"tags,omitempty" mapstructure:"tags"`
 	PrimaryKey      bool              `json:"primary_key" yaml:"primary_key,omitempty" mapstructure:"primary_key"`
@@ -977,6 +1074,7 @@ var AssetTypeConnectionMapping = map[AssetType]string{
 	AssetTypeAthenaSource:              "athena",
 	AssetTypeDuckDBQuery:               "duckdb",
 	AssetTypeIceberg:                   "iceberg",
+	AssetTypeIcebergQuery:              "iceberg",
 	AssetTypeDuckDBSeed:                "duckdb",
 	AssetTypeDuckDBQuerySensor:         "duckdb",
 	AssetTypeDuckDBSource:              "duckdb",
@@ -1014,6 +1112,9 @@ var AssetTypeConnectionMapping = map[AssetType]string{
 	AssetTypeVerticaQuerySensor:        "vertica",
 	AssetTypeVerticaTableSensor:        "vertica",
 	AssetTypeVerticaSource:             "vertica",
+	AssetTypeSQLiteQuery:               "sqlite",
+	AssetTypeSQLiteSeed:                "sqlite",
+	AssetTypeSQLiteQuerySensor:         "sqlite",
 	AssetTypeQuicksightDataset:         "quicksight",
 	AssetTypeQuicksightDashboard:       "quicksight",
 }
@@ -1062,6 +1163,7 @@ var IngestrTypeConnectionMapping = map[string]AssetType{
 	"gsheets":       AssetTypeGoogleSheets,
 	"vertica":       AssetTypeVerticaQuery,
 	"iceberg":       AssetTypeIceberg,
+	"sqlite":        AssetTypeSQLiteQuery,
 }
 
 type SecretMapping struct {
@@ -1345,6 +1447,17 @@ type Asset struct { //nolint:recvcheck
 	RetriesDelay      *int               `json:"retries_delay,omitempty" yaml:"-" mapstructure:"-"`
 	RefreshRestricted *bool              `json:"refresh_restricted,omitempty" yaml:"refresh_restricted,omitempty" mapstructure:"refresh_restricted"`
 	Notifications     *Notifications     `json:"notifications,omitempty" yaml:"notifications,omitempty" mapstructure:"notifications"`
+	Grants            Grants             `json:"grants,omitempty" yaml:"grants,omitempty" mapstructure:"grants"`
+	RowAccessPolicy   *RowAccessPolicy   `json:"row_access_policy,omitempty" yaml:"row_access_policy,omitempty" mapstructure:"row_access_policy"`
+
+	// Outputs are the tables a multi-output Python asset materializes, see OutputAssets.
+	Outputs []*Asset `json:"outputs,omitempty" yaml:"outputs,omitempty" mapstructure:"outputs"`
+	// OutputOf is the name of the asset that produces this output, it is only set on the
+	// assets created from Outputs.
+	OutputOf string `json:"output_of,omitempty" yaml:"-" mapstructure:"-"`
+	// OutputName is the name of the output in the definition of the asset that produces it, the
+	// key its data is returned under. Unlike Name, it is not changed by the schema prefix.
+	OutputName string `json:"-" yaml:"-" mapstructure:"-"`
 
 	upstream   []*Asset
 	downstream []*Asset
@@ -1524,6 +1637,49 @@ func (a *Asset) AddUpstream(asset *Asset) {
 	})
 }
 
+// OutputAssets returns a separate asset for each of the outputs of a multi-output Python
+// asset. The outputs depend on the asset that produces them, so that downstream assets,
+// checks and lineage can refer to a single output, and they inherit its connection,
+// owner, tags, parameters and scheduling settings unless they define their own.
+func (a *Asset) OutputAssets() []*Asset {
+	outputs := make([]*Asset, 0, len(a.Outputs))
+	for _, definition := range a.Outputs {
+		output := *definition
+		output.ID = hash(output.Name)
+		output.Type = a.Type
+		output.OutputOf = a.Name
+		output.OutputName = definition.Name
+		output.Outputs = nil
+		output.ExecutableFile = a.ExecutableFile
+		output.DefinitionFile = a.DefinitionFile
+		output.Upstreams = []Upstream{{Type: selectorAssetDependencyType, Value: a.Name, Mode: UpstreamModeFull}}
+		output.Enabled = a.Enabled
+		output.Image = a.Image
+		output.Instance = a.Instance
+		output.StartDate = a.StartDate
+		output.IntervalModifiers = a.IntervalModifiers
+		applyStringDefault(&output.Connection, a.Connection)
+		applyStringDefault(&output.Owner, a.Owner)
+		if len(output.Tags) == 0 {
+			output.Tags = slices.Clone(a.Tags)
+		}
+		if len(output.Domains) == 0 {
+			output.Domains = slices.Clone(a.Domains)
+		}
+
+		parameters := make(ParameterMap, len(a.Parameters)+len(definition.Parameters))
+		maps.Copy(parameters, a.Parameters)
+		maps.Copy(parameters, definition.Parameters)
+		output.Parameters = parameters
+
+		output.upstream = make([]*Asset, 0)
+		output.downstream = make([]*Asset, 0)
+		outputs = append(outputs, &output)
+	}
+
+	return outputs
+}
+
 // prefixSchemaComponent applies the dev-environment schema prefix to the schema
 // component of a (possibly multi-part) table name. The schema is always the
 // component immediately before the table, so for `schema.table` it prefixes the
@@ -1541,12 +1697,25 @@ func prefixSchemaComponent(name, prefix string) string {
 	return strings.Join(nameParts, ".")
 }
 
+// PrefixSchemaName returns the name of the table in a developer environment with the given schema
+// prefix, e.g. `analytics.users` becomes `dev_analytics.users`.
+func PrefixSchemaName(name, prefix string) string {
+	if prefix == "" {
+		return name
+	}
+
+	return prefixSchemaComponent(name, prefix)
+}
+
 func (a *Asset) PrefixSchema(prefix string) {
 	if prefix == "" {
 		return
 	}
 
 	a.Name = prefixSchemaComponent(a.Name, prefix)
+	if a.OutputOf != "" {
+		a.OutputOf = prefixSchemaComponent(a.OutputOf, prefix)
+	}
 	for i := range a.Columns {
 		if a.Columns[i].ForeignKey == nil {
 			continue
@@ -1794,6 +1963,8 @@ func (a *Asset) EnrichFromEntityAttributes(entities []*glossary.Entity) error {
 		if c.Description == "" {
 			a.Columns[i].Description = attr.Description
 		}
+
+		appendMissingStringValues(&a.Columns[i].Tags, EmptyStringArray(attr.Tags))
 	}
 
 	return nil
@@ -1844,6 +2015,12 @@ func (a *Asset) GetNameIfItWasSetFromItsPath(foundPipeline *Pipeline) (string, e
 }
 
 func (a Asset) Persist(fs afero.Fs, pipeline ...*Pipeline) error {
+	// outputs are defined in the file of the asset that produces them, which is
+	// persisted on its own.
+	if a.OutputOf != "" {
+		return nil
+	}
+
 	// Save original values
 	originalParams := a.Parameters
 	originalBigQuery := a.BigQuery
@@ -2181,12 +2358,44 @@ func LoadMacrosFromPath(macrosPath string, fs afero.Fs) ([]Macro, error) {
 }
 
 type MetadataPush struct {
-	Global   bool `json:"-"`
-	BigQuery bool `json:"bigquery" yaml:"bigquery" mapstructure:"bigquery"`
+	Global     bool `json:"-"`
+	BigQuery   bool `json:"bigquery" yaml:"bigquery" mapstructure:"bigquery"`
+	Snowflake  bool `json:"snowflake,omitempty" yaml:"snowflake,omitempty" mapstructure:"snowflake"`
+	DuckDB     bool `json:"duckdb,omitempty" yaml:"duckdb,omitempty" mapstructure:"duckdb"`
+	ClickHouse bool `json:"clickhouse,omitempty" yaml:"clickhouse,omitempty" mapstructure:"clickhouse"`
+	MySQL      bool `json:"mysql,omitempty" yaml:"mysql,omitempty" mapstructure:"mysql"`
+	Databricks bool `json:"databricks,omitempty" yaml:"databricks,omitempty" mapstructure:"databricks"`
 }
 
 func (mp *MetadataPush) HasAnyEnabled() bool {
-	return mp.BigQuery || mp.Global
+	return mp.BigQuery || mp.Snowflake || mp.DuckDB || mp.ClickHouse || mp.MySQL || mp.Databricks || mp.Global
+}
+
+// IsEnabledFor reports whether the metadata of assets of the given type should be pushed. Assets
+// on platforms without their own key, e.g. Postgres, Python and ingestr assets, are pushed when
+// metadata push is enabled for any platform. The `bigquery` key also enables Snowflake, since it
+// did so before Snowflake had its own key.
+func (mp *MetadataPush) IsEnabledFor(assetType AssetType) bool {
+	if mp.Global {
+		return true
+	}
+
+	switch AssetTypeConnectionMapping[assetType] {
+	case "google_cloud_platform":
+		return mp.BigQuery
+	case "snowflake":
+		return mp.Snowflake || mp.BigQuery
+	case "duckdb":
+		return mp.DuckDB
+	case "clickhouse":
+		return mp.ClickHouse
+	case "mysql":
+		return mp.MySQL
+	case "databricks":
+		return mp.Databricks
+	default:
+		return mp.HasAnyEnabled()
+	}
 }
 
 type Macro string
@@ -2355,6 +2564,7 @@ type DefaultValues struct {
 	Timeout           DurationSeconds        `json:"timeout,omitempty" yaml:"timeout,omitempty" mapstructure:"timeout"`
 	RefreshRestricted *bool                  `json:"refresh_restricted,omitempty" yaml:"refresh_restricted,omitempty" mapstructure:"refresh_restricted"`
 	Notifications     *Notifications         `json:"notifications,omitempty" yaml:"notifications,omitempty" mapstructure:"notifications"`
+	Grants            Grants                 `json:"grants,omitempty" yaml:"grants,omitempty" mapstructure:"grants"`
 }
 
 func (d *DefaultValues) UnmarshalYAML(value *yaml.Node) error {
@@ -2401,6 +2611,7 @@ func (d *DefaultValues) UnmarshalYAML(value *yaml.Node) error {
 		Timeout:           asset.Timeout,
 		RefreshRestricted: asset.RefreshRestricted,
 		Notifications:     asset.Notifications,
+		Grants:            asset.Grants,
 	}
 
 	return nil
@@ -2447,6 +2658,12 @@ func (p *Pipeline) GetAllConnectionNamesForAsset(asset *Asset) ([]string, error)
 		connectionNames := assetSecretConnectionNames(asset)
 		if asset.Connection != "" {
 			connectionNames = append(connectionNames, asset.Connection)
+		} else if asset.Materialization.Type != "" {
+			conn, err := p.GetConnectionNameForAsset(asset)
+			if err != nil {
+				return []string{}, err
+			}
+			connectionNames = append(connectionNames, conn)
 		}
 		return connectionNames, nil
 	} else if assetType == AssetTypeIngestr {
@@ -2559,7 +2776,7 @@ func (p *Pipeline) GetConnectionNameForAsset(asset *Asset) (string, error) {
 		if !ok {
 			return "", errors.Errorf("connection type could not be inferred for destination '%s', please specify a `connection` key in the asset", ingestrDest)
 		}
-	case AssetTypePython, AssetTypeEmpty:
+	case AssetTypePython, AssetTypeR, AssetTypeEmpty:
 		assetType = p.GetMajorityAssetTypesFromSQLAssets(AssetTypeBigqueryQuery)
 	default:
 		// For all other asset types, use the asset type directly for connection mapping lookup.
@@ -2614,6 +2831,7 @@ func (p *Pipeline) GetMajorityAssetTypesFromSQLAssets(defaultIfNone AssetType) A
 		AssetTypeAthenaQuery:       0,
 		AssetTypeDuckDBQuery:       0,
 		AssetTypeMotherduckQuery:   0,
+		AssetTypeIcebergQuery:      0,
 		AssetTypeClickHouse:        0,
 		AssetTypeTrinoQuery:        0,
 		AssetTypeDremioQuery:       0,
@@ -2622,6 +2840,7 @@ func (p *Pipeline) GetMajorityAssetTypesFromSQLAssets(defaultIfNone AssetType) A
 		AssetTypeOracleQuery:       0,
 		AssetTypeDorisQuery:        0,
 		AssetTypeStarRocksQuery:    0,
+		AssetTypeSQLiteQuery:       0,
 	}
 	maxTasks := 0
 	maxTaskType := defaultIfNone
@@ -2978,25 +3197,36 @@ func (b *Builder) CreatePipelineFromPath(ctx context.Context, pathToPipeline str
 			continue
 		}
 
-		task := result.task
-		if config.isMutate {
-			task, err = b.MutateAsset(ctx, task, pipeline)
-			if err != nil {
+		assets := []*Asset{result.task}
+		if len(result.task.Outputs) > 0 {
+			// the outputs depend on the asset that produces them, therefore its name needs to
+			// be known before they are created.
+			if _, err := b.SetNameFromPath(ctx, result.task, pipeline); err != nil {
 				return nil, err
 			}
+			assets = append(assets, result.task.OutputAssets()...)
 		}
 
-		task.upstream = make([]*Asset, 0)
-		task.downstream = make([]*Asset, 0)
+		for _, task := range assets {
+			if config.isMutate {
+				task, err = b.MutateAsset(ctx, task, pipeline)
+				if err != nil {
+					return nil, err
+				}
+			}
 
-		pipeline.Assets = append(pipeline.Assets, task)
+			task.upstream = make([]*Asset, 0)
+			task.downstream = make([]*Asset, 0)
 
-		if _, ok := pipeline.TasksByType[task.Type]; !ok {
-			pipeline.TasksByType[task.Type] = make([]*Asset, 0)
-		}
+			pipeline.Assets = append(pipeline.Assets, task)
 
-		pipeline.TasksByType[task.Type] = append(pipeline.TasksByType[task.Type], task)
-		pipeline.tasksByName[task.Name] = task
+			if _, ok := pipeline.TasksByType[task.Type]; !ok {
+				pipeline.TasksByType[task.Type] = make([]*Asset, 0)
+			}
+
+			pipeline.TasksByType[task.Type] = append(pipeline.TasksByType[task.Type], task)
+			pipeline.tasksByName[task.Name] = task
+		}
 	}
 	var entities []*glossary.Entity
 	if b.GlossaryReader != nil {
@@ -3286,6 +3516,7 @@ func (b *Builder) SetupDefaultsFromPipeline(ctx context.Context, asset *Asset, f
 	asset.Upstreams = appendMissingUpstreams(asset.Upstreams, defaults.Upstreams)
 	mergeColumnDefaults(asset, defaults.Columns)
 	mergeCustomCheckDefaults(asset, defaults.CustomChecks)
+	mergeGrantDefaults(&asset.Grants, defaults.Grants)
 
 	// merge secrets from the default values to asset secrets
 	existingSecrets := make(map[string]bool)
@@ -3460,6 +3691,15 @@ func mergeMaterializationDefaults(target *Materialization, defaults Materializat
 	if target.TimeGranularity == "" {
 		target.TimeGranularity = defaults.TimeGranularity
 	}
+	if target.OnSchemaChange == "" {
+		target.OnSchemaChange = defaults.OnSchemaChange
+	}
+	if target.BatchSize == "" {
+		target.BatchSize = defaults.BatchSize
+	}
+	if target.BatchConcurrency == 0 {
+		target.BatchConcurrency = defaults.BatchConcurrency
+	}
 }
 
 func appendMissingUpstreams(target []Upstream, defaults []Upstream) []Upstream {
@@ -3527,6 +3767,7 @@ func mergeColumnDefault(target *Column, defaults Column, assetName string) {
 	applyStringDefault(&target.SourceColumn, defaults.SourceColumn)
 	applyStringDefault(&target.Type, defaults.Type)
 	applyStringDefault(&target.Mask, defaults.Mask)
+	applyStringDefault(&target.MaskingPolicy, defaults.MaskingPolicy)
 	applyStringDefault(&target.Description, defaults.Description)
 	appendMissingStringValues(&target.Tags, defaults.Tags)
 	if !target.PrimaryKey && defaults.PrimaryKey {
@@ -4092,6 +4333,24 @@ func (a *Asset) IsSQLAsset() bool {
 	return IsSQLAssetType(a.Type)
 }
 
+// TablePlatform returns the connection type of the table the asset produces, e.g.
+// `google_cloud_platform`, or an empty string for the assets that do not produce one, e.g. sensors
+// and Python scripts. The ingestr assets produce their table on the platform of their destination.
+func (a *Asset) TablePlatform() string {
+	assetType := a.Type
+	if assetType == AssetTypeIngestr {
+		destination, ok := a.Parameters.GetString("destination")
+		if !ok {
+			return ""
+		}
+		assetType = IngestrTypeConnectionMapping[destination]
+	}
+	if strings.Contains(string(assetType), ".sensor.") {
+		return ""
+	}
+	return AssetTypeConnectionMapping[assetType]
+}
+
 func IsSQLAssetType(t AssetType) bool {
 	switch t {
 	case AssetTypeBigqueryQuery,
@@ -4109,6 +4368,7 @@ func IsSQLAssetType(t AssetType) bool {
 		AssetTypeAthenaQuery,
 		AssetTypeDuckDBQuery,
 		AssetTypeMotherduckQuery,
+		AssetTypeIcebergQuery,
 		AssetTypeClickHouse,
 		AssetTypeDorisQuery,
 		AssetTypeStarRocksQuery,
@@ -4116,7 +4376,8 @@ func IsSQLAssetType(t AssetType) bool {
 		AssetTypeDremioQuery,
 		AssetTypeSailQuery,
 		AssetTypeSparkQuery,
-		AssetTypeOracleQuery:
+		AssetTypeOracleQuery,
+		AssetTypeSQLiteQuery:
 		return true
 	default:
 		return false
@@ -94,6 +94,41 @@ func TestIsSQLAssetTypeCoversQueryAssets(t *testing.T) {
 	}
 }
 
+func TestAsset_TablePlatform(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		asset *pipeline.Asset
+		want  string
+	}{
+		{
+			name:  "sql assets produce a table on their platform",
+			asset: &pipeline.Asset{Type: pipeline.AssetTypeBigqueryQuery},
+			want:  "google_cloud_platform",
+		},
+		{
+			name:  "ingestr assets produce a table on their destination",
+			asset: &pipeline.Asset{Type: pipeline.AssetTypeIngestr, Parameters: pipeline.ParameterMap{"destination": "snowflake"}},
+			want:  "snowflake",
+		},
+		{
+			name:  "ingestr assets without a destination produce no table",
+			asset: &pipeline.Asset{Type: pipeline.AssetTypeIngestr},
+		},
+		{
+			name:  "sensors produce no table",
+			asset: &pipeline.Asset{Type: pipeline.AssetTypeBigqueryTableSensor},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, tt.asset.TablePlatform())
+		})
+	}
+}
+
 func TestGetMajorityAssetTypesFromSQLAssetsCoversQueryAssets(t *testing.T) {
 	t.Parallel()
 
@@ -753,6 +788,85 @@ func TestPipeline_GetAssetByPath(t *testing.T) {
 	assert.Equal(t, "task1", asset.Name)
 }
 
+func TestBuilder_CreatePipelineFromPath_PythonOutputs(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	files := map[string]string{
+		"pipeline.yml": "name: shop\n",
+		"assets/shop.py": `""" @bruin
+name: raw.shop
+connection: duckdb-default
+tags: [shop]
+outputs:
+  - name: raw.orders
+    materialization:
+      type: table
+    columns:
+      - name: id
+        checks:
+          - name: not_null
+  - name: raw.refunds
+    connection: duckdb-other
+    materialization:
+      type: table
+      strategy: append
+@bruin """
+
+def materialize():
+    return {}
+`,
+		"assets/report.sql": `/* @bruin
+name: analytics.report
+type: duckdb.sql
+depends:
+  - raw.orders
+@bruin */
+
+SELECT * FROM raw.orders
+`,
+	}
+	for name, content := range files {
+		require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600))
+	}
+
+	fs := afero.NewOsFs()
+	config := pipeline.BuilderConfig{
+		PipelineFileName:    []string{"pipeline.yml"},
+		TasksDirectoryNames: []string{"assets"},
+		TasksFileSuffixes:   []string{"asset.yml"},
+	}
+	builder := pipeline.NewBuilder(config, pipeline.CreateTaskFromYamlDefinition(fs), pipeline.CreateTaskFromFileComments(fs), fs, nil, nil)
+	p, err := builder.CreatePipelineFromPath(t.Context(), dir, pipeline.WithMutate())
+	require.NoError(t, err)
+	require.Len(t, p.Assets, 4)
+
+	shop := p.GetAssetByName("raw.shop")
+	orders := p.GetAssetByName("raw.orders")
+	refunds := p.GetAssetByName("raw.refunds")
+	report := p.GetAssetByName("analytics.report")
+	require.NotNil(t, shop)
+	require.NotNil(t, orders)
+	require.NotNil(t, refunds)
+	require.NotNil(t, report)
+
+	assert.Equal(t, pipeline.AssetTypePython, orders.Type)
+	assert.Equal(t, "raw.shop", orders.OutputOf)
+	assert.Equal(t, "duckdb-default", orders.Connection)
+	assert.Equal(t, "duckdb-other", refunds.Connection)
+	assert.Equal(t, pipeline.EmptyStringArray{"shop"}, orders.Tags)
+	assert.Equal(t, pipeline.MaterializationStrategyAppend, refunds.Materialization.Strategy)
+	assert.Equal(t, shop.ExecutableFile.Path, orders.ExecutableFile.Path)
+	require.Len(t, orders.Columns, 1)
+	assert.Equal(t, "not_null", orders.Columns[0].Checks[0].Name)
+
+	assert.ElementsMatch(t, []*pipeline.Asset{orders, refunds}, shop.GetDownstream())
+	assert.Equal(t, []*pipeline.Asset{shop}, orders.GetUpstream())
+	assert.Equal(t, []*pipeline.Asset{orders}, report.GetUpstream())
+	assert.ElementsMatch(t, []*pipeline.Asset{shop, orders}, report.GetFullUpstream())
+}
+
 func TestPipeline_GetConnectionNameForAsset(t *testing.T) {
 	t.Parallel()
 
@@ -1250,6 +1364,11 @@ func TestAsset_Persist(t *testing.T) {
 			assetPath:    path.AbsPathForTests(t, "testdata/persist/symbolic_upstream.sql"),
 			expectedPath: path.AbsPathForTests(t, "testdata/persist/symbolic_upstream.expected.sql"),
 		},
+		{
+			name:         "python outputs are kept",
+			assetPath:    path.AbsPathForTests(t, "testdata/persist/multi_output.py"),
+			expectedPath: path.AbsPathForTests(t, "testdata/persist/multi_output.expected.py"),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1279,6 +1398,26 @@ func TestAsset_Persist(t *testing.T) {
 	}
 }
 
+func TestAsset_Persist_OutputsRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	original, err := cmd.DefaultPipelineBuilder.CreateAssetFromFile(path.AbsPathForTests(t, "testdata/persist/multi_output.py"), nil)
+	require.NoError(t, err)
+	content, err := original.FormatContent()
+	require.NoError(t, err)
+
+	persistedPath := filepath.Join(t.TempDir(), "multi_output.py")
+	require.NoError(t, os.WriteFile(persistedPath, content, 0o600))
+	persisted, err := cmd.DefaultPipelineBuilder.CreateAssetFromFile(persistedPath, nil)
+	require.NoError(t, err)
+
+	require.Len(t, persisted.Outputs, 2)
+	assert.Equal(t, original.Outputs, persisted.Outputs)
+	assert.Equal(t, pipeline.Grants{"select": {"analyst"}}, persisted.Outputs[0].Grants)
+	assert.Equal(t, "archive", persisted.Outputs[1].Connection)
+	assert.Len(t, persisted.OutputAssets(), 2)
+}
+
 func TestAsset_Persist_TagsRemoval(t *testing.T) {
 	t.Parallel()
 
@@ -2883,6 +3022,12 @@ func TestDefaultValues_CoversAssetConfigFields(t *testing.T) {
 		// Unit tests are inherently per-asset (they pin one asset's logic against
 		// specific inputs), so they are not inherited from pipeline-level defaults.
 		"UnitTests": true,
+		// Outputs are the tables of a single multi-output Python asset.
+		"Outputs":    true,
+		"OutputOf":   true,
+		"OutputName": true,
+		// Row access policies filter on the columns of a specific table.
+		"RowAccessPolicy": true,
 	}
 
 	for i := range assetType.NumField() {
@@ -3859,3 +4004,32 @@ func TestPipeline_GetAllConnectionNamesForAsset_ConnectionlessIngestr(t *testing
 	require.NoError(t, err)
 	assert.ElementsMatch(t, []string{"duck", "my-sf"}, names)
 }
+
+func TestMetadataPush_IsEnabledFor(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		push      pipeline.MetadataPush
+		assetType pipeline.AssetType
+		want      bool
+	}{
+		{name: "disabled", push: pipeline.MetadataPush{}, assetType: pipeline.AssetTypeSnowflakeQuery, want: false},
+		{name: "global flag enables every platform", push: pipeline.MetadataPush{Global: true}, assetType: pipeline.AssetTypeMySQLQuery, want: true},
+		{name: "platform key enables its assets", push: pipeline.MetadataPush{DuckDB: true}, assetType: pipeline.AssetTypeDuckDBSeed, want: true},
+		{name: "platform key does not enable other platforms", push: pipeline.MetadataPush{Snowflake: true}, assetType: pipeline.AssetTypeBigqueryQuery, want: false},
+		{name: "snowflake key does not enable duckdb", push: pipeline.MetadataPush{Snowflake: true}, assetType: pipeline.AssetTypeDuckDBQuery, want: false},
+		{name: "legacy bigquery key enables snowflake", push: pipeline.MetadataPush{BigQuery: true}, assetType: pipeline.AssetTypeSnowflakeQuery, want: true},
+		{name: "legacy bigquery key does not enable new platforms", push: pipeline.MetadataPush{BigQuery: true}, assetType: pipeline.AssetTypeDuckDBQuery, want: false},
+		{name: "databricks", push: pipeline.MetadataPush{Databricks: true}, assetType: pipeline.AssetTypeDatabricksQuery, want: true},
+		{name: "clickhouse", push: pipeline.MetadataPush{ClickHouse: true}, assetType: pipeline.AssetTypeClickHouse, want: true},
+		{name: "platforms without a key follow any key", push: pipeline.MetadataPush{BigQuery: true}, assetType: pipeline.AssetTypePostgresQuery, want: true},
+		{name: "python assets follow any key", push: pipeline.MetadataPush{Snowflake: true}, assetType: pipeline.AssetTypePython, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, tt.push.IsEnabledFor(tt.assetType))
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package pipeline
+
+import (
+	"strings"
+)
+
+// PIITag is the tag that marks a column, or the glossary attribute it extends, as personally
+// identifiable information.
+const PIITag = "pii"
+
+// RowAccessPolicy restricts the rows of the asset's table that a user can see.
+//
+// On Snowflake, Name is an existing row access policy that is attached to the table with the
+// columns in On as its arguments. On BigQuery and Postgres the policy is created by Bruin:
+// Filter is the row filter and Grantees are the principals or roles the policy applies to.
+type RowAccessPolicy struct {
+	Name     string   `json:"name,omitempty" yaml:"name,omitempty" mapstructure:"name"`
+	On       []string `json:"on,omitempty" yaml:"on,omitempty" mapstructure:"on"`
+	Filter   string   `json:"filter,omitempty" yaml:"filter,omitempty" mapstructure:"filter"`
+	Grantees []string `json:"grantees,omitempty" yaml:"grantees,omitempty" mapstructure:"grantees"`
+}
+
+// PolicyName returns the name of the policy, defaulting to `<table>_row_access` for the
+// platforms where Bruin creates the policy itself.
+func (p *RowAccessPolicy) PolicyName(assetName string) string {
+	if p.Name != "" {
+		return p.Name
+	}
+	parts := strings.Split(assetName, ".")
+	return parts[len(parts)-1] + "_row_access"
+}
+
+// HasTag reports whether the column is tagged with the given tag, ignoring case.
+func (c *Column) HasTag(tag string) bool {
+	for _, t := range c.Tags {
+		if strings.EqualFold(strings.TrimSpace(t), tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// HasPolicies reports whether the asset defines a row access policy or a masking policy on
+// any of its columns.
+func (a *Asset) HasPolicies() bool {
+	if a.RowAccessPolicy != nil {
+		return true
+	}
+	for _, column := range a.Columns {
+		if column.MaskingPolicy != "" {
+			return true
+		}
+	}
+	return false
+}
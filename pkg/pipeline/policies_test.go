@@ -0,0 +1,40 @@
+package pipeline
+
+import (
+	"testing"
+
+	"github.com/bruin-data/bruin/pkg/glossary"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAsset_EnrichFromEntityAttributes_Tags(t *testing.T) {
+	t.Parallel()
+
+	asset := &Asset{
+		Columns: []Column{
+			{EntityAttribute: &EntityAttribute{Entity: "Customer", Attribute: "Email"}, Tags: EmptyStringArray{"contact"}},
+			{Name: "id"},
+		},
+	}
+
+	err := asset.EnrichFromEntityAttributes([]*glossary.Entity{
+		{
+			Name: "Customer",
+			Attributes: map[string]*glossary.Attribute{
+				"Email": {Name: "email", Type: "string", Tags: []string{"pii", "contact"}},
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "email", asset.Columns[0].Name)
+	require.Equal(t, EmptyStringArray{"contact", "pii"}, asset.Columns[0].Tags)
+	require.True(t, asset.Columns[0].HasTag(PIITag))
+	require.False(t, asset.Columns[1].HasTag(PIITag))
+}
+
+func TestRowAccessPolicy_PolicyName(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, "orders_row_access", (&RowAccessPolicy{}).PolicyName("analytics.orders"))
+	require.Equal(t, "eu_only", (&RowAccessPolicy{Name: "eu_only"}).PolicyName("analytics.orders"))
+}
@@ -0,0 +1,35 @@
+"""@bruin
+
+name: raw.shop
+connection: warehouse
+outputs:
+  - name: raw.orders
+    description: Orders of the shop
+    materialization:
+      type: table
+      strategy: merge
+    columns:
+      - name: id
+        type: integer
+        primary_key: true
+        checks:
+          - name: not_null
+    grants:
+      select:
+        - analyst
+  - name: raw.refunds
+    connection: archive
+    tags:
+      - finance
+    materialization:
+      type: table
+    owner: finance@example.com
+    custom_checks:
+      - name: has refunds
+        value: 0
+        query: SELECT COUNT(*) > 0 FROM raw.refunds
+
+@bruin"""
+
+def materialize():
+    return {"raw.orders": None, "raw.refunds": None}
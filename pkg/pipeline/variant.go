@@ -314,6 +314,7 @@ func assetFromDefaultValues(dv *DefaultValues) *Asset {
 		Timeout:           dv.Timeout,
 		RefreshRestricted: dv.RefreshRestricted,
 		Notifications:     dv.Notifications,
+		Grants:            dv.Grants,
 	}
 }
 
@@ -355,6 +356,7 @@ func copyAssetToDefaultValues(dv *DefaultValues, asset *Asset) {
 	dv.Timeout = asset.Timeout
 	dv.RefreshRestricted = asset.RefreshRestricted
 	dv.Notifications = asset.Notifications
+	dv.Grants = asset.Grants
 }
 
 func renderAssetStrings(render RenderFunc, a *Asset) error {
@@ -366,6 +368,9 @@ func renderAssetStrings(render RenderFunc, a *Asset) error {
 	if a.Name, err = maybeRender(render, fmt.Sprintf("asset[%s].name", originalName), a.Name); err != nil {
 		return err
 	}
+	if a.OutputOf, err = maybeRender(render, fmt.Sprintf("asset[%s].output_of", originalName), a.OutputOf); err != nil {
+		return err
+	}
 	if a.URI, err = maybeRender(render, fmt.Sprintf("asset[%s].uri", originalName), a.URI); err != nil {
 		return err
 	}
@@ -407,6 +412,32 @@ func renderAssetStrings(render RenderFunc, a *Asset) error {
 			return err
 		}
 	}
+	if a.RowAccessPolicy != nil {
+		policy := a.RowAccessPolicy
+		if policy.Name, err = maybeRender(render, fmt.Sprintf("asset[%s].row_access_policy.name", originalName), policy.Name); err != nil {
+			return err
+		}
+		if policy.Filter, err = maybeRender(render, fmt.Sprintf("asset[%s].row_access_policy.filter", originalName), policy.Filter); err != nil {
+			return err
+		}
+		for i, column := range policy.On {
+			if policy.On[i], err = maybeRender(render, fmt.Sprintf("asset[%s].row_access_policy.on[%d]", originalName, i), column); err != nil {
+				return err
+			}
+		}
+		for i, grantee := range policy.Grantees {
+			if policy.Grantees[i], err = maybeRender(render, fmt.Sprintf("asset[%s].row_access_policy.grantees[%d]", originalName, i), grantee); err != nil {
+				return err
+			}
+		}
+	}
+	for privilege, grantees := range a.Grants {
+		for i, grantee := range grantees {
+			if grantees[i], err = maybeRender(render, fmt.Sprintf("asset[%s].grants[%s][%d]", originalName, privilege, i), grantee); err != nil {
+				return err
+			}
+		}
+	}
 	// Asset.Parameters is intentionally NOT rendered here. Parameter values
 	// frequently embed runtime variables (e.g. "{{ start_date }}") which the
 	// per-asset renderer resolves at execution time with the full Jinja context.
@@ -505,6 +536,9 @@ func renderAssetStrings(render RenderFunc, a *Asset) error {
 		if c.Mask, err = maybeRender(render, fmt.Sprintf("asset[%s].columns[%d].mask", originalName, i), c.Mask); err != nil {
 			return err
 		}
+		if c.MaskingPolicy, err = maybeRender(render, fmt.Sprintf("asset[%s].columns[%d].masking_policy", originalName, i), c.MaskingPolicy); err != nil {
+			return err
+		}
 		if c.Description, err = maybeRender(render, fmt.Sprintf("asset[%s].columns[%d].description", originalName, i), c.Description); err != nil {
 			return err
 		}
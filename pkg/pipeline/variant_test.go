@@ -453,16 +453,21 @@ func TestVariantVisitorCoversStringFields(t *testing.T) {
 		"Pipeline.Assets[].DefinitionFile.Type":    true,
 		"Pipeline.Assets[].Hooks.Pre[].Query":      true,
 		"Pipeline.Assets[].Hooks.Post[].Query":     true,
+		"Pipeline.Assets[].OutputName":             true, // matched against the names in Outputs, which are not rendered
 
 		// Enum-typed strings on Asset.
 		"Pipeline.Assets[].Materialization.Type":                 true,
 		"Pipeline.Assets[].Materialization.Strategy":             true,
 		"Pipeline.Assets[].Materialization.TimeGranularity":      true,
+		"Pipeline.Assets[].Materialization.OnSchemaChange":       true,
+		"Pipeline.Assets[].Materialization.BatchSize":            true,
 		"Pipeline.Assets[].Upstreams[].Type":                     true,
 		"Pipeline.Assets[].Upstreams[].Mode":                     true,
 		"Pipeline.DefaultValues.Materialization.Type":            true,
 		"Pipeline.DefaultValues.Materialization.Strategy":        true,
 		"Pipeline.DefaultValues.Materialization.TimeGranularity": true,
+		"Pipeline.DefaultValues.Materialization.OnSchemaChange":  true,
+		"Pipeline.DefaultValues.Materialization.BatchSize":       true,
 		"Pipeline.DefaultValues.Upstreams[].Type":                true,
 		"Pipeline.DefaultValues.Upstreams[].Mode":                true,
 
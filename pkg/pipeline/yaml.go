@@ -218,6 +218,9 @@ type materialization struct {
 	IncrementalKey       string    `yaml:"incremental_key"`
 	IncrementalPredicate string    `yaml:"incremental_predicate"`
 	TimeGranularity      string    `yaml:"time_granularity,omitempty"`
+	OnSchemaChange       string    `yaml:"on_schema_change,omitempty"`
+	BatchSize            string    `yaml:"batch_size,omitempty"`
+	BatchConcurrency     int       `yaml:"batch_concurrency,omitempty"`
 }
 
 type columnCheckValue struct {
@@ -292,6 +295,7 @@ type column struct {
 	SourceColumn  string            `yaml:"source_column"`
 	Type          string            `yaml:"type"`
 	Mask          string            `yaml:"mask"`
+	MaskingPolicy string            `yaml:"masking_policy"`
 	Description   string            `yaml:"description"`
 	Tests         []columnCheck     `yaml:"checks"`
 	PrimaryKey    bool              `yaml:"primary_key"`
@@ -447,6 +451,25 @@ type taskDefinition struct {
 	RefreshRestricted     *bool             `yaml:"refresh_restricted,omitempty"`
 	FullRefreshRestricted *bool             `yaml:"full_refresh_restricted,omitempty"`
 	Notifications         Notifications     `yaml:"notifications"`
+	Outputs               []assetOutput     `yaml:"outputs"`
+	Grants                Grants            `yaml:"grants"`
+	RowAccessPolicy       *RowAccessPolicy  `yaml:"row_access_policy"`
+}
+
+// assetOutput is a single table materialized by a multi-output Python asset.
+type assetOutput struct {
+	Name            string            `yaml:"name"`
+	Description     string            `yaml:"description"`
+	Connection      string            `yaml:"connection"`
+	Materialization materialization   `yaml:"materialization"`
+	Parameters      ParameterMap      `yaml:"parameters"`
+	Owner           string            `yaml:"owner"`
+	Tags            []string          `yaml:"tags"`
+	Domains         []string          `yaml:"domains"`
+	Meta            map[string]string `yaml:"meta"`
+	Columns         []column          `yaml:"columns"`
+	CustomChecks    []customCheck     `yaml:"custom_checks"`
+	Grants          Grants            `yaml:"grants"`
 }
 
 func (d taskDefinition) refreshRestricted() *bool {
@@ -529,6 +552,9 @@ func taskDefinitionToAsset(definition taskDefinition) (*Asset, error) {
 		IncrementalKey:       definition.Materialization.IncrementalKey,
 		IncrementalPredicate: definition.Materialization.IncrementalPredicate,
 		TimeGranularity:      MaterializationTimeGranularity(strings.ToLower(definition.Materialization.TimeGranularity)),
+		OnSchemaChange:       MaterializationOnSchemaChange(strings.ToLower(definition.Materialization.OnSchemaChange)),
+		BatchSize:            strings.ToLower(definition.Materialization.BatchSize),
+		BatchConcurrency:     definition.Materialization.BatchConcurrency,
 	}
 
 	columns := make([]Column, len(definition.Columns))
@@ -588,6 +614,7 @@ func taskDefinitionToAsset(definition taskDefinition) (*Asset, error) {
 			SourceColumn:    column.SourceColumn,
 			Type:            strings.TrimSpace(column.Type),
 			Mask:            strings.TrimSpace(column.Mask),
+			MaskingPolicy:   strings.TrimSpace(column.MaskingPolicy),
 			Description:     column.Description,
 			Checks:          tests,
 			PrimaryKey:      column.PrimaryKey,
@@ -680,6 +707,8 @@ func taskDefinitionToAsset(definition taskDefinition) (*Asset, error) {
 		RerunCooldown:     definition.RerunCooldown,
 		RefreshRestricted: definition.refreshRestricted(),
 		Notifications:     notificationsOrNil(definition.Notifications),
+		Grants:            definition.Grants,
+		RowAccessPolicy:   definition.RowAccessPolicy,
 	}
 
 	for index, check := range definition.CustomChecks {
@@ -728,6 +757,28 @@ func taskDefinitionToAsset(definition taskDefinition) (*Asset, error) {
 		task.Secrets[index] = mapping
 	}
 
+	for _, output := range definition.Outputs {
+		outputAsset, err := taskDefinitionToAsset(taskDefinition{
+			Name:            output.Name,
+			Description:     output.Description,
+			Connection:      output.Connection,
+			Materialization: output.Materialization,
+			Parameters:      output.Parameters,
+			Owner:           output.Owner,
+			Tags:            output.Tags,
+			Domains:         output.Domains,
+			Meta:            output.Meta,
+			Columns:         output.Columns,
+			CustomChecks:    output.CustomChecks,
+			Grants:          output.Grants,
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid output '%s'", output.Name)
+		}
+
+		task.Outputs = append(task.Outputs, outputAsset)
+	}
+
 	return &task, nil
 }
 
@@ -10,6 +10,7 @@ import (
 	"github.com/bruin-data/bruin/pkg/pipeline"
 	"github.com/spf13/afero"
 	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
 )
 
 func TestCreateTaskFromYamlDefinition(t *testing.T) {
@@ -440,6 +441,53 @@ bigquery:
 	require.NoError(t, pipeline.ValidateAssetYAML(fs, "bigquery.sql", pipeline.CommentTask))
 }
 
+func TestConvertYamlToTask_Outputs(t *testing.T) {
+	t.Parallel()
+
+	definition := strings.TrimSpace(`
+name: raw.shop
+parameters:
+  loader: ingestr
+outputs:
+  - name: raw.orders
+    materialization:
+      type: table
+      strategy: merge
+    parameters:
+      loader: native
+    columns:
+      - name: id
+        primary_key: true
+    custom_checks:
+      - name: has rows
+        query: SELECT count(*) > 0 FROM raw.orders
+  - name: raw.refunds
+    materialization:
+      type: table
+`)
+	task, err := pipeline.ConvertYamlToTask([]byte(definition))
+	require.NoError(t, err)
+	require.Len(t, task.Outputs, 2)
+
+	orders := task.Outputs[0]
+	require.Equal(t, "raw.orders", orders.Name)
+	require.Equal(t, pipeline.MaterializationStrategyMerge, orders.Materialization.Strategy)
+	require.True(t, orders.Columns[0].PrimaryKey)
+	require.Len(t, orders.CustomChecks, 1)
+
+	outputs := task.OutputAssets()
+	require.Len(t, outputs, 2)
+	require.Equal(t, "raw.shop", outputs[0].OutputOf)
+	require.Equal(t, []pipeline.Upstream{{Type: "asset", Value: "raw.shop", Mode: pipeline.UpstreamModeFull}}, outputs[0].Upstreams)
+	require.Equal(t, pipeline.ParameterMap{"loader": "native"}, outputs[0].Parameters)
+	require.Equal(t, pipeline.ParameterMap{"loader": "ingestr"}, outputs[1].Parameters)
+
+	fs := afero.NewMemMapFs()
+	content := "\"\"\" @bruin\n" + definition + "\n@bruin \"\"\"\n\ndef materialize():\n    return {}\n"
+	require.NoError(t, afero.WriteFile(fs, "shop.py", []byte(content), 0o644))
+	require.NoError(t, pipeline.ValidateAssetYAML(fs, "shop.py", pipeline.CommentTask))
+}
+
 func TestConvertYamlToTask_Timeout(t *testing.T) {
 	t.Parallel()
 
@@ -894,3 +942,53 @@ hooks:
 		})
 	}
 }
+
+func TestConvertYamlToTask_Grants(t *testing.T) {
+	t.Parallel()
+
+	definition := strings.TrimSpace(`
+name: analytics.orders
+type: sf.sql
+materialization:
+  type: table
+grants:
+  select:
+    - role_bi
+    - role_analyst
+  insert: [role_etl]
+`)
+	task, err := pipeline.ConvertYamlToTask([]byte(definition))
+	require.NoError(t, err)
+	require.Equal(t, pipeline.Grants{
+		"select": {"role_bi", "role_analyst"},
+		"insert": {"role_etl"},
+	}, task.Grants)
+
+	var defaults pipeline.DefaultValues
+	require.NoError(t, yaml.Unmarshal([]byte("grants:\n  select: [role_reader]\n"), &defaults))
+	require.Equal(t, pipeline.Grants{"select": {"role_reader"}}, defaults.Grants)
+}
+
+func TestConvertYamlToTask_Policies(t *testing.T) {
+	t.Parallel()
+
+	definition := strings.TrimSpace(`
+name: analytics.customers
+type: sf.sql
+materialization:
+  type: table
+row_access_policy:
+  name: governance.policies.region_access
+  on: [region]
+columns:
+  - name: email
+    tags: [pii]
+    masking_policy: governance.policies.email_mask
+`)
+	task, err := pipeline.ConvertYamlToTask([]byte(definition))
+	require.NoError(t, err)
+	require.Equal(t, &pipeline.RowAccessPolicy{Name: "governance.policies.region_access", On: []string{"region"}}, task.RowAccessPolicy)
+	require.Equal(t, "governance.policies.email_mask", task.Columns[0].MaskingPolicy)
+	require.True(t, task.Columns[0].HasTag(pipeline.PIITag))
+	require.True(t, task.HasPolicies())
+}
@@ -0,0 +1,42 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/bruin-data/bruin/pkg/ansisql"
+	"github.com/bruin-data/bruin/pkg/pipeline"
+)
+
+// ConstraintDialect migrates the primary key, not-null and foreign key constraints of Postgres
+// tables, the current constraints are read from information_schema.
+var ConstraintDialect = ansisql.ConstraintDialect{
+	CurrentConstraints: func(ctx context.Context, conn ansisql.MetadataClient, asset *pipeline.Asset) (*ansisql.TableConstraints, error) {
+		schema, table := ansisql.SplitTableName(asset.Name, "public")
+		return ansisql.QueryTableConstraints(ctx, conn, ansisql.InformationSchemaConstraintsQuery(
+			"information_schema", strings.ToLower(schema), strings.ToLower(table),
+		))
+	},
+	AddPrimaryKey: func(asset *pipeline.Asset, columns []string) string {
+		return fmt.Sprintf("ALTER TABLE %s ADD PRIMARY KEY (%s);", QuoteIdentifier(asset.Name), strings.Join(quoteNames(columns), ", "))
+	},
+	DropPrimaryKey: func(asset *pipeline.Asset, name string) string {
+		return fmt.Sprintf("ALTER TABLE %s DROP CONSTRAINT %s;", QuoteIdentifier(asset.Name), QuoteIdentifier(name))
+	},
+	SetNotNull: func(asset *pipeline.Asset, column string) string {
+		return fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s SET NOT NULL;", QuoteIdentifier(asset.Name), QuoteIdentifier(column))
+	},
+	DropNotNull: func(asset *pipeline.Asset, column string) string {
+		return fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s DROP NOT NULL;", QuoteIdentifier(asset.Name), QuoteIdentifier(column))
+	},
+	AddForeignKey: func(asset *pipeline.Asset, key ansisql.ForeignKey) string {
+		return fmt.Sprintf(
+			"ALTER TABLE %s ADD FOREIGN KEY (%s) REFERENCES %s (%s);",
+			QuoteIdentifier(asset.Name), QuoteIdentifier(key.Column), QuoteIdentifier(key.Table), QuoteIdentifier(key.RefColumn),
+		)
+	},
+	DropForeignKey: func(asset *pipeline.Asset, key ansisql.ForeignKey) string {
+		return fmt.Sprintf("ALTER TABLE %s DROP CONSTRAINT %s;", QuoteIdentifier(asset.Name), QuoteIdentifier(key.Name))
+	},
+}
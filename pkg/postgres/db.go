@@ -36,6 +36,7 @@ type PgConfig interface {
 type connection interface {
 	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
 	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+	Begin(ctx context.Context) (pgx.Tx, error)
 }
 
 func NewClient(ctx context.Context, c PgConfig) (*Client, error) {
@@ -0,0 +1,43 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/bruin-data/bruin/pkg/ansisql"
+	"github.com/bruin-data/bruin/pkg/pipeline"
+)
+
+// GrantDialect applies the grants of Postgres and Redshift assets, the current grants are read
+// from information_schema.table_privileges.
+var GrantDialect = ansisql.GrantDialect{
+	CurrentGrantsQuery: func(asset *pipeline.Asset) string {
+		schema, table := ansisql.SplitTableName(asset.Name, "public")
+		return fmt.Sprintf(
+			"SELECT privilege_type, grantee FROM information_schema.table_privileges WHERE table_schema = %s AND table_name = %s",
+			ansisql.QuoteLiteral(strings.ToLower(schema)),
+			ansisql.QuoteLiteral(strings.ToLower(table)),
+		)
+	},
+	PrivilegeColumn: 0,
+	GranteeColumn:   1,
+	GrantStatement: func(asset *pipeline.Asset, privilege, grantee string) string {
+		return fmt.Sprintf("GRANT %s ON %s TO %s;", privilege, asset.Name, grantee)
+	},
+	// information_schema lists the table privileges ALL PRIVILEGES stands for one by one. Postgres
+	// 17 adds MAINTAIN to them, which is not required so that older versions match too, and
+	// Redshift only reports the privileges it shares with every Postgres version.
+	AllPrivileges: func(asset *pipeline.Asset) []string {
+		if asset.Type == pipeline.AssetTypeRedshiftQuery {
+			return []string{"SELECT", "INSERT", "UPDATE", "DELETE", "REFERENCES"}
+		}
+		return []string{"SELECT", "INSERT", "UPDATE", "DELETE", "TRUNCATE", "REFERENCES", "TRIGGER"}
+	},
+}
+
+// ApplyGrants applies the grants of an asset whose table was loaded without running SQL through
+// the operator, e.g. a materialized Python asset.
+func (c *Client) ApplyGrants(ctx context.Context, asset *pipeline.Asset) error {
+	return GrantDialect.ApplyGrants(ctx, c, asset)
+}
@@ -310,11 +310,14 @@ func buildDDLQuery(asset *pipeline.Asset, query string) (string, error) {
 		if col.Default != "" {
 			def += " DEFAULT " + col.Default
 		}
+		if col.IsNotNull() {
+			def += " NOT NULL"
+		}
 
 		if col.PrimaryKey {
 			primaryKeys = append(primaryKeys, quotedColName)
 		}
-		if col.ForeignKey != nil && col.ForeignKey.Table != "" && col.ForeignKey.Column != "" {
+		if col.HasForeignKey() {
 			foreignKeys = append(foreignKeys, fmt.Sprintf(
 				"foreign key (%s) references %s (%s)",
 				quotedColName, QuoteIdentifier(col.ForeignKey.Table), QuoteIdentifier(col.ForeignKey.Column),
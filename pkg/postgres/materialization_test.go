@@ -478,6 +478,20 @@ WHEN NOT MATCHED THEN INSERT\("id", "col_a"\) VALUES\("id", "col_a"\);$`,
 			},
 			want: `CREATE TABLE IF NOT EXISTS "orders" \(\s*"amount" numeric\(10, 2\) DEFAULT 0,\s*"name" varchar\(255\) COLLATE "en_US",\s*"customer_id" int,\s*foreign key \("customer_id"\) references "customers" \("id"\)\s*\)`,
 		},
+		{
+			name: "table with a not null foreign key",
+			task: &pipeline.Asset{
+				Name: "orders",
+				Materialization: pipeline.Materialization{
+					Type:     pipeline.MaterializationTypeTable,
+					Strategy: pipeline.MaterializationStrategyDDL,
+				},
+				Columns: []pipeline.Column{
+					{Name: "customer_id", Type: "int", Nullable: pipeline.DefaultTrueBool{Value: boolp(false)}, ForeignKey: &pipeline.ColumnReference{Table: "customers", Column: "id"}},
+				},
+			},
+			want: `CREATE TABLE IF NOT EXISTS "orders" \(\s*"customer_id" int NOT NULL,\s*foreign key \("customer_id"\) references "customers" \("id"\)\s*\)`,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1874,3 +1888,5 @@ func TestBuildRedshiftSCD2ByColumnQuery(t *testing.T) {
 }
 
 func intp(i int) *int { return &i }
+
+func boolp(value bool) *bool { return &value }
@@ -7,13 +7,16 @@ import (
 	"github.com/bruin-data/bruin/pkg/ansisql"
 	"github.com/bruin-data/bruin/pkg/config"
 	"github.com/bruin-data/bruin/pkg/devenv"
+	"github.com/bruin-data/bruin/pkg/diff"
 	"github.com/bruin-data/bruin/pkg/executor"
+	"github.com/bruin-data/bruin/pkg/microbatch"
 	"github.com/bruin-data/bruin/pkg/pipeline"
 	"github.com/bruin-data/bruin/pkg/query"
 	"github.com/bruin-data/bruin/pkg/scd2migration"
 	"github.com/bruin-data/bruin/pkg/scheduler"
 	"github.com/bruin-data/bruin/pkg/sqlparser"
 	"github.com/pkg/errors"
+	"github.com/spf13/afero"
 )
 
 type materializer interface {
@@ -37,11 +40,21 @@ type devEnv interface {
 	RegisterAssetForSchemaCache(ctx context.Context, p *pipeline.Pipeline, a *pipeline.Asset, q *query.Query) error
 }
 
+type schemaEvolver interface {
+	EvolveSchema(ctx context.Context, conn any, asset *pipeline.Asset, assetQuery string, writer interface{}) error
+}
+
+type microbatcher interface {
+	Run(ctx context.Context, asset *pipeline.Asset, runBatch func(ctx context.Context) error) error
+}
+
 type BasicOperator struct {
-	connection   config.ConnectionGetter
-	extractor    query.QueryExtractor
-	materializer materializer
-	devEnv       devEnv
+	connection    config.ConnectionGetter
+	extractor     query.QueryExtractor
+	materializer  materializer
+	devEnv        devEnv
+	schemaEvolver schemaEvolver
+	microbatcher  microbatcher
 }
 
 func NewBasicOperator(conn config.ConnectionGetter, extractor query.QueryExtractor, materializer materializer, parser *sqlparser.SQLParser) *BasicOperator {
@@ -54,6 +67,8 @@ func NewBasicOperator(conn config.ConnectionGetter, extractor query.QueryExtract
 			Conn:    conn,
 			Parser:  parser,
 		},
+		schemaEvolver: ansisql.NewSchemaEvolver(diff.DialectPostgreSQL, diff.NewPostgresTypeMapper()),
+		microbatcher:  microbatch.NewRunner(afero.NewOsFs()),
 	}
 }
 
@@ -62,6 +77,16 @@ func (o BasicOperator) Run(ctx context.Context, ti scheduler.TaskInstance) error
 }
 
 func (o BasicOperator) RunTask(ctx context.Context, p *pipeline.Pipeline, t *pipeline.Asset) error {
+	if o.microbatcher != nil && t.Materialization.IsMicrobatched() && !o.materializer.IsFullRefresh() {
+		return o.microbatcher.Run(ctx, t, func(batchCtx context.Context) error {
+			return o.runTask(batchCtx, p, t)
+		})
+	}
+
+	return o.runTask(ctx, p, t)
+}
+
+func (o BasicOperator) runTask(ctx context.Context, p *pipeline.Pipeline, t *pipeline.Asset) error {
 	extractor, err := o.extractor.CloneForAsset(ctx, p, t)
 	if err != nil {
 		return errors.Wrapf(err, "failed to clone extractor for asset %s", t.Name)
@@ -82,7 +107,8 @@ func (o BasicOperator) RunTask(ctx context.Context, p *pipeline.Pipeline, t *pip
 	}
 
 	q := queries[0]
-	materialized, err := o.materializer.Render(t, q.String())
+	assetQuery := q.String()
+	materialized, err := o.materializer.Render(t, assetQuery)
 	if err != nil {
 		return err
 	}
@@ -132,9 +158,29 @@ func (o BasicOperator) RunTask(ctx context.Context, p *pipeline.Pipeline, t *pip
 		}
 	}
 
+	if o.schemaEvolver != nil && t.Materialization.ShouldEvolveSchema() && !o.materializer.IsFullRefresh() {
+		probeQuery := &query.Query{Query: assetQuery}
+		if o.devEnv != nil {
+			probeQuery, err = o.devEnv.Modify(ctx, p, t, probeQuery)
+			if err != nil {
+				return err
+			}
+		}
+
+		err = o.schemaEvolver.EvolveSchema(ctx, conn, t, probeQuery.Query, writer)
+		if err != nil {
+			return err
+		}
+	}
+
 	if o.devEnv == nil {
 		ansisql.LogQueryIfVerbose(ctx, writer, q.Query)
-		return conn.RunQueryWithoutResult(ctx, q)
+		err = conn.RunQueryWithoutResult(ctx, q)
+		if err != nil {
+			return err
+		}
+
+		return applyGovernance(ctx, conn, t)
 	}
 
 	q, err = o.devEnv.Modify(ctx, p, t, q)
@@ -154,7 +200,16 @@ func (o BasicOperator) RunTask(ctx context.Context, p *pipeline.Pipeline, t *pip
 		return errors.Wrap(err, "cannot register asset for schema cache")
 	}
 
-	return nil
+	return applyGovernance(ctx, conn, t)
+}
+
+// applyGovernance applies the grants and policies of the asset after it is materialized.
+func applyGovernance(ctx context.Context, conn PgClient, t *pipeline.Asset) error {
+	if err := GrantDialect.ApplyGrants(ctx, conn, t); err != nil {
+		return err
+	}
+
+	return ApplyPolicies(ctx, conn, t)
 }
 
 func NewColumnCheckOperator(manager config.ConnectionGetter) *ansisql.ColumnCheckOperator {
@@ -0,0 +1,63 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/bruin-data/bruin/pkg/ansisql"
+	"github.com/bruin-data/bruin/pkg/executor"
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/bruin-data/bruin/pkg/query"
+	"github.com/pkg/errors"
+)
+
+// ApplyPolicies enables row level security on the materialized table and (re)creates the asset's
+// row access policy in a single transaction, so the table is never left without the policy.
+// Postgres has no column masking policies, the masking-policy-platform lint rule rejects them.
+func ApplyPolicies(ctx context.Context, conn PgClient, asset *pipeline.Asset) error {
+	if asset.RowAccessPolicy == nil || asset.Materialization.Type == pipeline.MaterializationTypeNone {
+		return nil
+	}
+
+	q, err := policyQuery(asset)
+	if err != nil {
+		return err
+	}
+
+	ansisql.LogQueryIfVerbose(ctx, ctx.Value(executor.KeyPrinter), q)
+	if err := conn.RunQueryWithoutResult(ctx, &query.Query{Query: q}); err != nil {
+		return errors.Wrapf(err, "failed to apply the row access policy on '%s'", asset.Name)
+	}
+	return nil
+}
+
+func policyQuery(asset *pipeline.Asset) (string, error) {
+	if asset.Type == pipeline.AssetTypeRedshiftQuery {
+		return "", errors.New("row access policies are not supported for Redshift assets")
+	}
+
+	if asset.Materialization.Type != pipeline.MaterializationTypeTable {
+		return "", errors.New("row access policies are only supported for table materializations on Postgres")
+	}
+
+	policy := asset.RowAccessPolicy
+	if strings.TrimSpace(policy.Filter) == "" {
+		return "", errors.New("row_access_policy.filter is required for Postgres assets")
+	}
+
+	name := policy.PolicyName(asset.Name)
+	create := fmt.Sprintf("CREATE POLICY %s ON %s", name, asset.Name)
+	if len(policy.Grantees) > 0 {
+		create += " TO " + strings.Join(policy.Grantees, ", ")
+	}
+	create += fmt.Sprintf(" USING (%s);", policy.Filter)
+
+	return strings.Join([]string{
+		"BEGIN TRANSACTION;",
+		fmt.Sprintf("ALTER TABLE %s ENABLE ROW LEVEL SECURITY;", asset.Name),
+		fmt.Sprintf("DROP POLICY IF EXISTS %s ON %s;", name, asset.Name),
+		create,
+		"COMMIT;",
+	}, "\n"), nil
+}
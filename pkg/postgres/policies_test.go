@@ -0,0 +1,71 @@
+package postgres
+
+import (
+	"testing"
+
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPolicyQuery(t *testing.T) {
+	t.Parallel()
+
+	table := pipeline.Materialization{Type: pipeline.MaterializationTypeTable}
+	tests := []struct {
+		name    string
+		asset   *pipeline.Asset
+		want    string
+		wantErr string
+	}{
+		{
+			name: "row access policy is recreated in a transaction",
+			asset: &pipeline.Asset{
+				Name:            "public.orders",
+				Type:            pipeline.AssetTypePostgresQuery,
+				Materialization: table,
+				RowAccessPolicy: &pipeline.RowAccessPolicy{
+					Filter:   "region = current_setting('app.region')",
+					Grantees: []string{"role_bi", "role_analyst"},
+				},
+			},
+			want: "BEGIN TRANSACTION;\n" +
+				"ALTER TABLE public.orders ENABLE ROW LEVEL SECURITY;\n" +
+				"DROP POLICY IF EXISTS orders_row_access ON public.orders;\n" +
+				"CREATE POLICY orders_row_access ON public.orders TO role_bi, role_analyst USING (region = current_setting('app.region'));\n" +
+				"COMMIT;",
+		},
+		{
+			name: "a filter is required",
+			asset: &pipeline.Asset{
+				Name:            "public.orders",
+				Type:            pipeline.AssetTypePostgresQuery,
+				Materialization: table,
+				RowAccessPolicy: &pipeline.RowAccessPolicy{Name: "orders_policy"},
+			},
+			wantErr: "row_access_policy.filter is required for Postgres assets",
+		},
+		{
+			name: "redshift is not supported",
+			asset: &pipeline.Asset{
+				Name:            "public.orders",
+				Type:            pipeline.AssetTypeRedshiftQuery,
+				Materialization: table,
+				RowAccessPolicy: &pipeline.RowAccessPolicy{Filter: "true"},
+			},
+			wantErr: "row access policies are not supported for Redshift assets",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := policyQuery(tt.asset)
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/bruin-data/bruin/pkg/seed"
+	"github.com/jackc/pgx/v5"
+	"github.com/pkg/errors"
+)
+
+// LoadSeed replaces the table with the seed rows in a single transaction, the rows are
+// streamed with COPY. Redshift does not support COPY FROM STDIN, its seeds go through ingestr.
+func (c *Client) LoadSeed(ctx context.Context, table *seed.Table, rows *seed.Rows) error {
+	if _, ok := c.config.(Config); !ok {
+		return seed.ErrUnsupported
+	}
+
+	if err := c.CreateSchemaIfNotExist(ctx, &pipeline.Asset{Name: table.Name}); err != nil {
+		return err
+	}
+
+	tx, err := c.connection.Begin(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to start a transaction")
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	tableName := QuoteIdentifier(table.Name)
+	if _, err := tx.Exec(ctx, "DROP TABLE IF EXISTS "+tableName); err != nil {
+		return errors.Wrapf(err, "failed to drop table %s", table.Name)
+	}
+
+	createQuery := fmt.Sprintf("CREATE TABLE %s (\n%s\n)", tableName, table.ColumnDefinitions(QuoteIdentifier, seedColumnType))
+	if _, err := tx.Exec(ctx, createQuery); err != nil {
+		return errors.Wrapf(err, "failed to create table %s", table.Name)
+	}
+
+	if _, err := tx.CopyFrom(ctx, pgx.Identifier(strings.Split(table.Name, ".")), table.ColumnNames(), rows); err != nil {
+		if rowsErr := rows.Err(); rowsErr != nil {
+			return rowsErr
+		}
+		return errors.Wrapf(err, "failed to copy the seed into table %s", table.Name)
+	}
+
+	return errors.Wrap(tx.Commit(ctx), "failed to commit the seed")
+}
+
+func seedColumnType(col seed.Column) string {
+	var typ string
+	switch col.Kind {
+	case seed.KindInteger:
+		typ = "BIGINT"
+	case seed.KindFloat:
+		typ = "DOUBLE PRECISION"
+	case seed.KindDecimal:
+		typ = "NUMERIC"
+		if col.Precision > 0 {
+			typ = fmt.Sprintf("NUMERIC(%d, %d)", col.Precision, col.Scale)
+		}
+	case seed.KindBoolean:
+		typ = "BOOLEAN"
+	case seed.KindDate:
+		typ = "DATE"
+	case seed.KindTimestamp:
+		typ = "TIMESTAMP"
+		if col.TimeZone {
+			typ = "TIMESTAMPTZ"
+		}
+	default:
+		typ = "TEXT"
+	}
+
+	if col.NotNull {
+		typ += " NOT NULL"
+	}
+	return typ
+}
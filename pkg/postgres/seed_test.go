@@ -0,0 +1,62 @@
+package postgres
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync"
+	"testing"
+
+	"github.com/bruin-data/bruin/pkg/ansisql"
+	"github.com/bruin-data/bruin/pkg/seed"
+	"github.com/jackc/pgx/v5"
+	"github.com/pashagolub/pgxmock/v3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_LoadSeed(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "seed.csv")
+	require.NoError(t, os.WriteFile(path, []byte("id,name\n1,Turkey\n"), 0o600))
+	table := &seed.Table{Name: "seeds.countries", Columns: []seed.Column{
+		{Name: "id", Kind: seed.KindInteger, NotNull: true},
+		{Name: "name", Kind: seed.KindText},
+	}}
+
+	t.Run("replaces the table in a transaction", func(t *testing.T) {
+		t.Parallel()
+
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		mock.ExpectExec(regexp.QuoteMeta(`CREATE SCHEMA IF NOT EXISTS "seeds"`)).WillReturnResult(pgxmock.NewResult("CREATE", 0))
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`DROP TABLE IF EXISTS "seeds"."countries"`)).WillReturnResult(pgxmock.NewResult("DROP", 0))
+		mock.ExpectExec(regexp.QuoteMeta("CREATE TABLE \"seeds\".\"countries\" (\n\"id\" BIGINT NOT NULL,\n\"name\" TEXT\n)")).WillReturnResult(pgxmock.NewResult("CREATE", 0))
+		mock.ExpectCopyFrom(pgx.Identifier{"seeds", "countries"}, []string{"id", "name"}).WillReturnResult(1)
+		mock.ExpectCommit()
+
+		client := Client{connection: mock, config: Config{}, schemaCreator: ansisql.NewSchemaCreator(), schemaCache: &sync.Map{}}
+
+		rows, err := seed.Open(path, table)
+		require.NoError(t, err)
+		defer rows.Close()
+
+		require.NoError(t, client.LoadSeed(t.Context(), table, rows))
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("redshift is not supported", func(t *testing.T) {
+		t.Parallel()
+
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		client := Client{connection: mock, config: RedShiftConfig{}}
+		require.ErrorIs(t, client.LoadSeed(t.Context(), table, nil), seed.ErrUnsupported)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
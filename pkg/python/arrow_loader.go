@@ -0,0 +1,65 @@
+package python
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/bruin-data/bruin/pkg/pipeline"
+)
+
+// ArrowLoader is implemented by the connections that can load the Arrow file produced by
+// a materialized Python asset without starting ingestr, e.g. DuckDB through ADBC.
+// LoadArrowFile returns false when it cannot load the asset, before it touches the
+// destination, so that the caller can fall back to ingestr.
+type ArrowLoader interface {
+	LoadArrowFile(ctx context.Context, asset *pipeline.Asset, path string, normalize func(string) string) (bool, error)
+}
+
+// GrantApplier is implemented by the connections that can apply the `grants` of an asset to the
+// table a materialized Python asset loaded.
+type GrantApplier interface {
+	ApplyGrants(ctx context.Context, asset *pipeline.Asset) error
+}
+
+// ingestrOnlyArrowParameters are the asset parameters that configure ingestr itself, the
+// assets using them keep going through ingestr.
+var ingestrOnlyArrowParameters = []string{"mask", "schema_contract", "schema_naming", "loader_file_format", "staging_bucket", "staging_dataset"}
+
+// loadArrowFileNatively loads the Arrow file into the destination when it implements
+// ArrowLoader. It returns false when the asset has to go through ingestr: the destination
+// or the strategy is not supported, the asset uses a feature only ingestr implements, or
+// `loader: ingestr` is set.
+func loadArrowFileNatively(ctx context.Context, asset *pipeline.Asset, destConn any, path string) (bool, error) {
+	loader, ok := destConn.(ArrowLoader)
+	if !ok || !canLoadArrowNatively(asset) {
+		return false, nil
+	}
+
+	return loader.LoadArrowFile(ctx, asset, path, NormalizeColumnName)
+}
+
+func canLoadArrowNatively(asset *pipeline.Asset) bool {
+	if value, ok := asset.Parameters.GetString("loader"); ok && strings.EqualFold(strings.TrimSpace(value), "ingestr") {
+		return false
+	}
+
+	if value, ok := asset.Parameters.GetString("enforce_schema"); ok && value == "true" {
+		return false
+	}
+
+	for _, param := range ingestrOnlyArrowParameters {
+		if value, ok := asset.Parameters.GetString(param); ok && strings.TrimSpace(value) != "" {
+			return false
+		}
+	}
+
+	for _, col := range asset.Columns {
+		if strings.TrimSpace(col.Mask) != "" {
+			return false
+		}
+	}
+
+	return asset.Materialization.Strategy == pipeline.MaterializationStrategyNone ||
+		slices.Contains(SupportedPythonMaterializationStrategies, asset.Materialization.Strategy)
+}
@@ -0,0 +1,203 @@
+package python
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/bruin-data/bruin/pkg/git"
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeArrowLoader struct {
+	fakeIngestrConnection
+	loaded bool
+	calls  *[]string
+}
+
+func (f fakeArrowLoader) LoadArrowFile(ctx context.Context, asset *pipeline.Asset, path string, normalize func(string) string) (bool, error) {
+	*f.calls = append(*f.calls, asset.Name+" "+normalize("Full Name"))
+	return f.loaded, nil
+}
+
+func (f fakeArrowLoader) ApplyGrants(ctx context.Context, asset *pipeline.Asset) error {
+	*f.calls = append(*f.calls, "grant "+strings.Join(asset.Grants.Grantees("select"), ",")+" on "+asset.Name)
+	return nil
+}
+
+func TestCanLoadArrowNatively(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		asset *pipeline.Asset
+		want  bool
+	}{
+		{
+			name:  "supported strategies are loaded natively",
+			asset: &pipeline.Asset{Materialization: pipeline.Materialization{Type: "table", Strategy: pipeline.MaterializationStrategyMerge}},
+			want:  true,
+		},
+		{
+			name:  "the default strategy is loaded natively",
+			asset: &pipeline.Asset{Materialization: pipeline.Materialization{Type: "table"}},
+			want:  true,
+		},
+		{
+			name:  "unsupported strategies go through ingestr",
+			asset: &pipeline.Asset{Materialization: pipeline.Materialization{Type: "table", Strategy: pipeline.MaterializationStrategyTimeInterval}},
+		},
+		{
+			name: "the ingestr loader can be forced",
+			asset: &pipeline.Asset{
+				Materialization: pipeline.Materialization{Type: "table"},
+				Parameters:      pipeline.ParameterMap{"loader": "ingestr"},
+			},
+		},
+		{
+			name: "enforced schemas go through ingestr",
+			asset: &pipeline.Asset{
+				Materialization: pipeline.Materialization{Type: "table"},
+				Parameters:      pipeline.ParameterMap{"enforce_schema": "true"},
+			},
+		},
+		{
+			name: "masked columns go through ingestr",
+			asset: &pipeline.Asset{
+				Materialization: pipeline.Materialization{Type: "table"},
+				Columns:         []pipeline.Column{{Name: "email", Mask: "hash"}},
+			},
+		},
+		{
+			name: "ingestr specific parameters go through ingestr",
+			asset: &pipeline.Asset{
+				Materialization: pipeline.Materialization{Type: "table"},
+				Parameters:      pipeline.ParameterMap{"schema_contract": "freeze"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, canLoadArrowNatively(tt.asset))
+		})
+	}
+}
+
+func Test_uvPythonRunner_RunWithMaterialization_LoadsArrowFileNatively(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		loaded      bool
+		wantIngestr bool
+	}{
+		{
+			name:   "ingestr is skipped when the destination loads the file",
+			loaded: true,
+		},
+		{
+			name:        "ingestr is used when the destination declines the file",
+			loaded:      false,
+			wantIngestr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			repo := &git.Repo{Path: t.TempDir()}
+			cmd := new(mockCmd)
+			cmd.On("Run", mock.Anything, repo, mock.MatchedBy(func(c *CommandInstance) bool {
+				return c.Name == "~/.bruin/uv" && len(c.Args) > 0 && c.Args[0] == "run"
+			})).Run(func(args mock.Arguments) {
+				command := args.Get(2).(*CommandInstance)
+				script, err := os.ReadFile(command.Args[len(command.Args)-1])
+				require.NoError(t, err)
+				require.NoError(t, os.WriteFile(extractArrowPathFromScript(t, string(script)), []byte("arrow"), 0o600))
+			}).Return(nil)
+
+			ingestrInst := new(mockIngestrInstaller)
+			if tt.wantIngestr {
+				cmd.On("Run", mock.Anything, repo, mock.MatchedBy(func(c *CommandInstance) bool {
+					return len(c.Args) > 0 && c.Args[0] == "ingest"
+				})).Return(nil)
+				ingestrInst.On("EnsureIngestrInstalled", mock.Anything, IngestrVersionV1).
+					Return("~/.bruin/ingestr/"+IngestrVersionV1+"/ingestr", nil)
+			}
+
+			inst := new(mockUvInstaller)
+			inst.On("EnsureUvInstalled", mock.Anything).Return("~/.bruin/uv", nil)
+
+			var calls []string
+			runner := &UvPythonRunner{
+				Cmd:              cmd,
+				UvInstaller:      inst,
+				IngestrInstaller: ingestrInst,
+				conn: fakeConnectionGetter{
+					"dest": fakeArrowLoader{
+						fakeIngestrConnection: fakeIngestrConnection{uri: "duckdb:///tmp/db.duckdb"},
+						loaded:                tt.loaded,
+						calls:                 &calls,
+					},
+				},
+			}
+
+			err := runner.Run(t.Context(), &executionContext{
+				repo:     repo,
+				module:   "path.to.module",
+				pipeline: &pipeline.Pipeline{},
+				asset: &pipeline.Asset{
+					Name:       "main.asset_data",
+					Type:       pipeline.AssetTypePython,
+					Connection: "dest",
+					Materialization: pipeline.Materialization{
+						Type:     "table",
+						Strategy: pipeline.MaterializationStrategyAppend,
+					},
+				},
+			})
+
+			require.NoError(t, err)
+			assert.Equal(t, []string{"main.asset_data full_name"}, calls)
+			cmd.AssertExpectations(t)
+			ingestrInst.AssertExpectations(t)
+			if !tt.wantIngestr {
+				ingestrInst.AssertNotCalled(t, "EnsureIngestrInstalled", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestUvPythonRunner_MaterializeArrowFile(t *testing.T) {
+	t.Parallel()
+
+	var calls []string
+	runner := NewUvPythonRunner(fakeConnectionGetter{
+		"dest": fakeArrowLoader{
+			fakeIngestrConnection: fakeIngestrConnection{uri: "duckdb:///tmp/db.duckdb"},
+			loaded:                true,
+			calls:                 &calls,
+		},
+	})
+
+	err := runner.MaterializeArrowFile(t.Context(), &git.Repo{Path: t.TempDir()}, &pipeline.Pipeline{}, &pipeline.Asset{
+		Name:       "main.r_asset",
+		Type:       pipeline.AssetTypeR,
+		Connection: "dest",
+		Materialization: pipeline.Materialization{
+			Type:     "table",
+			Strategy: pipeline.MaterializationStrategyMerge,
+		},
+	}, "data.arrow")
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"main.r_asset full_name"}, calls)
+}
@@ -315,24 +315,12 @@ func ColumnHints(cols []pipeline.Column, normaliseNames bool, overlay map[string
 	mapping := MergeTypeHints(TypeHintMapping, overlay)
 	hints := make([]string, 0)
 	for _, col := range cols {
-		hint, typeKnown, inlineParams := resolveColumnTypeHint(col.Type, mapping, wrappers)
+		hint, typeKnown := columnTypeHint(col, mapping, wrappers)
 
 		if !typeKnown && col.SourceColumn == "" {
 			continue
 		}
 
-		// Append size params (inline wins over length/precision/scale fields; invalid
-		// sizes stay unbounded). Only decimal uses precision/scale; the rest take a length.
-		if typeKnown && ingestrSizedTypes[hint] {
-			if hint == decimalHint {
-				if params := decimalParams(inlineParams, col.Precision, col.Scale); params != "" {
-					hint = fmt.Sprintf("%s(%s)", hint, params)
-				}
-			} else if length := sizedStringLength(inlineParams, col.Length); length != "" {
-				hint = fmt.Sprintf("%s(%s)", hint, length)
-			}
-		}
-
 		name := col.Name
 		if normaliseNames {
 			name = NormalizeColumnName(name)
@@ -350,6 +338,33 @@ func ColumnHints(cols []pipeline.Column, normaliseNames bool, overlay map[string
 	return strings.Join(hints, ",")
 }
 
+// ColumnTypeHint returns the ingestr type hint of a single column including its size
+// params, e.g. "decimal(10,2)". known is false when the declared type has no hint.
+func ColumnTypeHint(col pipeline.Column, overlay map[string]string, wrappers map[string]bool) (hint string, known bool) {
+	return columnTypeHint(col, MergeTypeHints(TypeHintMapping, overlay), wrappers)
+}
+
+func columnTypeHint(col pipeline.Column, mapping map[string]string, wrappers map[string]bool) (string, bool) {
+	hint, typeKnown, inlineParams := resolveColumnTypeHint(col.Type, mapping, wrappers)
+	if !typeKnown {
+		return "", false
+	}
+
+	// Append size params (inline wins over length/precision/scale fields; invalid
+	// sizes stay unbounded). Only decimal uses precision/scale; the rest take a length.
+	if ingestrSizedTypes[hint] {
+		if hint == decimalHint {
+			if params := decimalParams(inlineParams, col.Precision, col.Scale); params != "" {
+				hint = fmt.Sprintf("%s(%s)", hint, params)
+			}
+		} else if length := sizedStringLength(inlineParams, col.Length); length != "" {
+			hint = fmt.Sprintf("%s(%s)", hint, length)
+		}
+	}
+
+	return hint, true
+}
+
 // splitParenType splits a type such as "varchar(100)" into base ("varchar") and inner
 // ("100"), returning ok=false when there is no trailing parenthesised section.
 func splitParenType(typ string) (base, inner string, ok bool) {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 
 	"github.com/bruin-data/bruin/pkg/config"
 	"github.com/bruin-data/bruin/pkg/env"
@@ -52,17 +53,10 @@ type LocalOperator struct {
 }
 
 func NewLocalOperator(config config.ConnectionAndDetailsGetter, envVariables map[string]string) *LocalOperator {
-	cmdRunner := &CommandRunner{}
-
 	return &LocalOperator{
-		repoFinder: &git.RepoFinder{},
-		module:     &ModulePathFinder{},
-		runner: &UvPythonRunner{
-			Cmd:              cmdRunner,
-			UvInstaller:      &UvChecker{},
-			IngestrInstaller: &IngestrChecker{},
-			conn:             config,
-		},
+		repoFinder:   &git.RepoFinder{},
+		module:       &ModulePathFinder{},
+		runner:       NewUvPythonRunner(config),
 		envVariables: envVariables,
 		config:       config,
 	}
@@ -78,6 +72,14 @@ func (o *LocalOperator) Run(ctx context.Context, ti scheduler.TaskInstance) erro
 }
 
 func (o *LocalOperator) RunTask(ctx context.Context, p *pipeline.Pipeline, t *pipeline.Asset) error {
+	// the outputs of a multi-output asset are loaded when the asset that produces them runs.
+	if t.OutputOf != "" {
+		if printer, ok := ctx.Value(executor.KeyPrinter).(io.Writer); ok {
+			_, _ = fmt.Fprintf(printer, "'%s' is loaded by '%s', skipping\n", t.Name, t.OutputOf)
+		}
+		return nil
+	}
+
 	repo, err := o.repoFinder.Repo(t.ExecutableFile.Path)
 	if err != nil {
 		return errors.Wrap(err, "failed to find repo to run Python")
@@ -337,3 +337,23 @@ func TestLocalOperator_RunTask(t *testing.T) {
 		})
 	}
 }
+
+func TestLocalOperator_RunTask_SkipsOutputs(t *testing.T) {
+	t.Parallel()
+
+	finder := new(mockRepoFinder)
+	runner := new(mockRunner)
+	o := &LocalOperator{
+		repoFinder: finder,
+		runner:     runner,
+	}
+
+	err := o.RunTask(t.Context(), &pipeline.Pipeline{}, &pipeline.Asset{
+		Name:     "raw.orders",
+		OutputOf: "raw.shop",
+	})
+
+	assert.NoError(t, err)
+	finder.AssertNotCalled(t, "Repo", mock.Anything)
+	runner.AssertNotCalled(t, "Run", mock.Anything, mock.Anything)
+}
@@ -2,6 +2,7 @@ package python
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"os"
@@ -226,6 +227,39 @@ type UvPythonRunner struct {
 	binaryFullPath   string
 }
 
+// NewUvPythonRunner returns a runner that installs its dependencies on demand and resolves
+// the destinations through the given connections.
+func NewUvPythonRunner(conn config.ConnectionGetter) *UvPythonRunner {
+	return &UvPythonRunner{
+		Cmd:              &CommandRunner{},
+		UvInstaller:      &UvChecker{},
+		IngestrInstaller: &IngestrChecker{},
+		conn:             conn,
+	}
+}
+
+// MaterializeArrowFile loads an Arrow IPC file into the destination of the asset with its
+// materialization strategy and applies its grants, the same way the data returned by Python
+// assets is loaded. It allows the other script assets, e.g. R, to materialize their results.
+func (u *UvPythonRunner) MaterializeArrowFile(ctx context.Context, repo *git.Repo, p *pipeline.Pipeline, asset *pipeline.Asset, arrowFilePath string) error {
+	var output io.Writer = os.Stdout
+	if printer, ok := ctx.Value(executor.KeyPrinter).(io.Writer); ok {
+		output = printer
+	}
+
+	return u.materializeArrowFile(ctx, repo, p, asset, arrowFilePath, output)
+}
+
+// materializeArrowFile loads the Arrow file into the destination of the asset and then applies
+// the grants of the loaded table.
+func (u *UvPythonRunner) materializeArrowFile(ctx context.Context, repo *git.Repo, p *pipeline.Pipeline, asset *pipeline.Asset, arrowFilePath string, output io.Writer) error {
+	if err := u.uploadArrowFile(ctx, repo, p, asset, arrowFilePath, output); err != nil {
+		return err
+	}
+
+	return u.applyGrants(ctx, p, asset)
+}
+
 func (u *UvPythonRunner) Run(ctx context.Context, execCtx *executionContext) error {
 	binaryFullPath, err := u.UvInstaller.EnsureUvInstalled(ctx)
 	if err != nil {
@@ -249,7 +283,7 @@ func (u *UvPythonRunner) Run(ctx context.Context, execCtx *executionContext) err
 		pythonVersion = resolved
 	}
 
-	if execCtx.asset.Materialization.Type == "" {
+	if execCtx.asset.Materialization.Type == "" && len(execCtx.asset.Outputs) == 0 {
 		return u.runWithNoMaterialization(ctx, execCtx, pythonVersion)
 	}
 
@@ -357,21 +391,43 @@ func (u *UvPythonRunner) calculateModuleFromProjectRoot(execCtx *executionContex
 }
 
 func (u *UvPythonRunner) runWithMaterialization(ctx context.Context, execCtx *executionContext, pythonVersion string) error {
-	asset := execCtx.asset
-	mat := asset.Materialization
-
-	if mat.Type != "table" {
-		return errors.New("only table materialization is supported for Python assets")
+	targets, err := materializationTargets(execCtx)
+	if err != nil {
+		return err
 	}
+	for i, target := range targets {
+		mat := target.asset.Materialization
+		if mat.Type != "table" {
+			return errors.New("only table materialization is supported for Python assets")
+		}
 
-	if mat.IncrementalPredicate != "" {
-		return errors.New("incremental_predicate is not supported for Python assets")
-	}
+		if mat.IncrementalPredicate != "" {
+			return errors.New("incremental_predicate is not supported for Python assets")
+		}
 
-	arrowFilePath := filepath.Join(os.TempDir(), fmt.Sprintf("asset_data_%d.arrow", time.Now().UnixNano()))
-	defer func(name string) {
-		_ = os.Remove(name)
-	}(arrowFilePath)
+		targets[i].path = filepath.Join(os.TempDir(), fmt.Sprintf("asset_data_%d_%d.arrow", time.Now().UnixNano(), i))
+		defer func(name string) {
+			_ = os.Remove(name)
+		}(targets[i].path)
+	}
+
+	// multi-output assets return a dict of output names to data, each of them is written
+	// to its own Arrow file.
+	arrowFilePath := targets[0].path
+	arrowOutputs := "None"
+	if len(execCtx.asset.Outputs) > 0 {
+		arrowFilePath = ""
+		outputPaths := make(map[string]string, len(targets))
+		for _, target := range targets {
+			outputPaths[target.key] = target.path
+		}
+
+		encoded, err := json.Marshal(outputPaths)
+		if err != nil {
+			return errors.Wrap(err, "failed to encode the asset outputs")
+		}
+		arrowOutputs = string(encoded)
+	}
 
 	tempPyScript, err := os.CreateTemp("", "bruin-arrow-*.py")
 	if err != nil {
@@ -397,6 +453,7 @@ func (u *UvPythonRunner) runWithMaterialization(ctx context.Context, execCtx *ex
 	arrowScript := strings.ReplaceAll(PythonArrowTemplate, "$REPO_ROOT", strings.ReplaceAll(rootPath, "\\", "\\\\"))
 	arrowScript = strings.ReplaceAll(arrowScript, "$MODULE_PATH", modulePath)
 	arrowScript = strings.ReplaceAll(arrowScript, "$ARROW_FILE_PATH", strings.ReplaceAll(arrowFilePath, "\\", "\\\\"))
+	arrowScript = strings.ReplaceAll(arrowScript, "$ARROW_OUTPUTS", arrowOutputs)
 
 	// For pyproject-based execution, strip inline script metadata (PEP 723) so that uv
 	// stays in project mode and uses pyproject.toml dependencies. Without this, uv enters
@@ -446,13 +503,111 @@ func (u *UvPythonRunner) runWithMaterialization(ctx context.Context, execCtx *ex
 		output = ctx.Value(executor.KeyPrinter).(io.Writer)
 	}
 
-	// Check if the arrow file was created (materialize() may return None)
-	if _, err := os.Stat(arrowFilePath); os.IsNotExist(err) {
-		_, _ = output.Write([]byte("WARNING: materialize() returned None, skipping materialization\n"))
+	for _, target := range targets {
+		// Check if the arrow file was created (materialize() may return None)
+		if _, err := os.Stat(target.path); os.IsNotExist(err) {
+			if target.key != "" {
+				_, _ = fmt.Fprintf(output, "WARNING: materialize() returned no data for output '%s', skipping materialization\n", target.key)
+				continue
+			}
+			_, _ = output.Write([]byte("WARNING: materialize() returned None, skipping materialization\n"))
+			continue
+		}
+
+		if target.key != "" {
+			_, _ = fmt.Fprintf(output, "Successfully collected the data for output '%s', uploading to the destination...\n", target.key)
+		} else {
+			_, _ = output.Write([]byte("Successfully collected the data from the asset, uploading to the destination...\n"))
+		}
+
+		if err := u.materializeArrowFile(ctx, execCtx.repo, execCtx.pipeline, target.asset, target.path, output); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// arrowTarget is a table loaded from the Arrow file the asset code writes. key is the name
+// of the output in the dict returned by materialize(), it is empty for single-output assets.
+type arrowTarget struct {
+	key   string
+	asset *pipeline.Asset
+	path  string
+}
+
+// materializationTargets returns the tables a materialized Python asset loads. The outputs of
+// a multi-output asset are taken from the pipeline, so that any change made to them while
+// building it, e.g. the schema prefix of developer environments, applies here as well. Building
+// them again from the definitions would lose those changes and load the data into the wrong tables.
+// The outputs are matched by the name in the definition, since the names in the pipeline may be
+// prefixed.
+func materializationTargets(execCtx *executionContext) ([]arrowTarget, error) {
+	asset := execCtx.asset
+	if len(asset.Outputs) == 0 {
+		return []arrowTarget{{asset: asset}}, nil
+	}
+
+	nodes := make(map[string]*pipeline.Asset, len(asset.Outputs))
+	if execCtx.pipeline != nil {
+		for _, candidate := range execCtx.pipeline.Assets {
+			if candidate.OutputOf == asset.Name {
+				nodes[candidate.OutputName] = candidate
+			}
+		}
+	}
+
+	targets := make([]arrowTarget, len(asset.Outputs))
+	for i, output := range asset.Outputs {
+		node, ok := nodes[output.Name]
+		if !ok {
+			return nil, errors.Errorf("asset '%s' defines the output '%s', but the pipeline does not contain it", asset.Name, output.Name)
+		}
+		targets[i] = arrowTarget{key: output.Name, asset: node}
+	}
+
+	return targets, nil
+}
+
+// applyGrants applies the grants of a materialized table once its data is loaded, on the
+// destinations that support grants. Grants are ignored on the other platforms, the same way they
+// are for SQL assets.
+func (u *UvPythonRunner) applyGrants(ctx context.Context, p *pipeline.Pipeline, asset *pipeline.Asset) error {
+	if asset.Grants.IsEmpty() {
 		return nil
 	}
 
-	_, _ = output.Write([]byte("Successfully collected the data from the asset, uploading to the destination...\n"))
+	destConnectionName, err := p.GetConnectionNameForAsset(asset)
+	if err != nil {
+		return err
+	}
+
+	applier, ok := u.conn.GetConnection(destConnectionName).(GrantApplier)
+	if !ok {
+		return nil
+	}
+
+	return applier.ApplyGrants(ctx, asset)
+}
+
+// uploadArrowFile loads the Arrow file into the destination of the asset, natively when the
+// destination supports it and through ingestr otherwise.
+func (u *UvPythonRunner) uploadArrowFile(ctx context.Context, repo *git.Repo, p *pipeline.Pipeline, asset *pipeline.Asset, arrowFilePath string, output io.Writer) error {
+	mat := asset.Materialization
+	destConnectionName, err := p.GetConnectionNameForAsset(asset)
+	if err != nil {
+		return err
+	}
+
+	destConn := u.conn.GetConnection(destConnectionName)
+	loaded, err := loadArrowFileNatively(ctx, asset, destConn, arrowFilePath)
+	if err != nil {
+		return errors.Wrap(err, "failed to load the data into the destination")
+	}
+	if loaded {
+		_, _ = output.Write([]byte("Successfully loaded the data from the asset into the destination.\n"))
+		return nil
+	}
 
 	if len(asset.Parameters) == 0 {
 		asset.Parameters = make(pipeline.ParameterMap)
@@ -469,12 +624,6 @@ func (u *UvPythonRunner) runWithMaterialization(ctx context.Context, execCtx *ex
 		asset.Parameters["incremental_key"] = mat.IncrementalKey
 	}
 
-	destConnectionName, err := execCtx.pipeline.GetConnectionNameForAsset(asset)
-	if err != nil {
-		return err
-	}
-
-	destConn := u.conn.GetConnection(destConnectionName)
 	// build ingestr flags
 	cmdArgs, err := ConsolidatedParameters(ctx, asset, []string{
 		"ingest",
@@ -483,7 +632,7 @@ func (u *UvPythonRunner) runWithMaterialization(ctx context.Context, execCtx *ex
 		"--source-table",
 		"asset_data",
 		"--dest-table",
-		execCtx.asset.Name,
+		asset.Name,
 		"--yes",
 		"--progress",
 		"log",
@@ -536,7 +685,7 @@ func (u *UvPythonRunner) runWithMaterialization(ctx context.Context, execCtx *ex
 		_, _ = output.Write([]byte("Running CommandInstance: " + ingestrCommand.Name + " " + strings.Join(ingestrCommand.Args, " ") + "\n"))
 	}
 
-	err = u.Cmd.Run(ingestrCtx, execCtx.repo, ingestrCommand)
+	err = u.Cmd.Run(ingestrCtx, repo, ingestrCommand)
 	if err != nil {
 		if logBuffer != nil {
 			logBuffer.flushTo(output)
@@ -682,16 +831,20 @@ const PythonArrowTemplate = `
 # ///
 
 import sys
+import time
 import importlib.util
 from pathlib import Path
 
+# how often the number of rows collected so far is printed while a generator is consumed
+PROGRESS_INTERVAL_SECONDS = 10
+
 def import_module_from_path(module_path: str, module_name: str):
     project_root = str(Path(module_path))
     sys.path.insert(0, project_root)
 
     return importlib.import_module(module_name)
 
-def convert_and_write(df):
+def convert_and_write(df, path):
     if df is None:
         return  # Go-side will detect missing arrow file and log a warning
 
@@ -723,6 +876,29 @@ def convert_and_write(df):
         type_name = type(obj).__name__
         return 'pandas' in type_module and type_name == 'DataFrame'
 
+    def to_arrow_table(obj):
+        # DataFrames and record batches are converted one at a time, so a generator
+        # yielding them only ever holds a single chunk in memory.
+        if isinstance(obj, pa.Table):
+            return obj
+        if isinstance(obj, pa.RecordBatch):
+            return pa.Table.from_batches([obj])
+        if is_polars_dataframe(obj):
+            return obj.to_arrow()
+        if is_pandas_dataframe(obj):
+            return pa.Table.from_pandas(obj)
+        return None
+
+    def conform_to_schema(table, schema):
+        # Each chunk infers its own types, e.g. a pandas integer column with a missing
+        # value becomes a double, so cast it to the schema of the first chunk if possible.
+        if sorted(table.schema.names) != sorted(schema.names):
+            raise TypeError("All yielded pyarrow Tables must have the same schema.")
+        try:
+            return table.select(schema.names).cast(schema)
+        except (pa.ArrowInvalid, pa.ArrowNotImplementedError, ValueError) as e:
+            raise TypeError(f"All yielded pyarrow Tables must have the same schema: {e}")
+
     def write_arrow_tables(tables):
         iterator = iter(tables)
         try:
@@ -733,23 +909,89 @@ def convert_and_write(df):
         if not isinstance(first_table, pa.Table):
             raise TypeError(f"Unsupported return type: {type(first_table)}")
 
-        with pa.OSFile("$ARROW_FILE_PATH", 'wb') as f:
+        rows = first_table.num_rows
+        batches = 1
+        last_report = time.monotonic()
+        with pa.OSFile(path, 'wb') as f:
             writer = ipc.new_file(f, first_table.schema)
             writer.write_table(first_table)
             for next_table in iterator:
                 if not isinstance(next_table, pa.Table):
                     raise TypeError(f"Unsupported yielded type: {type(next_table)}. expected pyarrow.Table.")
                 if not next_table.schema.equals(first_table.schema):
-                    raise TypeError("All yielded pyarrow Tables must have the same schema.")
+                    next_table = conform_to_schema(next_table, first_table.schema)
                 writer.write_table(next_table)
+
+                rows += next_table.num_rows
+                batches += 1
+                if time.monotonic() - last_report >= PROGRESS_INTERVAL_SECONDS:
+                    print(f"Collected {rows:,} rows so far...", flush=True)
+                    last_report = time.monotonic()
             writer.close()
 
+        if batches > 1:
+            print(f"Collected {rows:,} rows in {batches:,} batches.", flush=True)
+
+    # Generators, other iterables and lists of tables are written the same way.
+    # Each yielded value is written as its own Arrow batch, as-is, so a
+    # generator never has to hold the full dataset in memory. A value can be:
+    #   1. an individual dict:        yield {"col": val}         -> a one-row batch
+    #   2. a batch (list of dicts):   yield [{"col": val}, ...]  -> one batch per page
+    #   3. a pyarrow Table:           yield pa.table(...)         -> written directly
+    #   4. a pyarrow RecordBatch or a pandas/polars DataFrame     -> converted, then written
+    # The batch granularity is whatever materialize() yields; we do not
+    # re-chunk. This mirrors how yielded pyarrow Tables are handled.
+    #
+    # The Arrow IPC file has a single schema fixed when the first batch is
+    # written, so every yielded batch must share one schema. If each yield
+    # inferred its own schema, a None in one yield would produce a 'null'-typed
+    # column that mismatches a typed value in the next yield (a common
+    # database-cursor pattern: "for row in cursor: yield row"). To handle this
+    # we buffer only the leading rows until their inferred schema has no
+    # null-typed columns, lock that schema, then stream every later batch
+    # against it so nullable values and missing optional keys conform instead
+    # of raising. The buffer is just the warm-up window; if a column stays
+    # entirely None we cannot infer its type and fall back to buffering it.
+    def rows_to_tables(items):
+        locked_schema = None
+        pending = []
+        for item in items:
+            table_item = to_arrow_table(item)
+            if table_item is not None:
+                item = table_item
+                # A yielded pa.Table carries an explicit schema, so use it to
+                # flush any buffered rows (whose own inference may have left
+                # null-typed columns) and to lock the schema for later rows.
+                # This keeps the table and the surrounding dict batches in
+                # agreement, whether the table comes before or after them.
+                if pending:
+                    yield pa.Table.from_pylist(pending, schema=item.schema)
+                    pending = []
+                if locked_schema is None:
+                    locked_schema = item.schema
+                yield item
+                continue
+            rows = item if isinstance(item, (list, tuple)) else [item]
+            if not rows:  # skip empty pages so we never emit a zero-row batch
+                continue
+            if locked_schema is not None:
+                yield pa.Table.from_pylist(list(rows), schema=locked_schema)
+                continue
+            pending.extend(rows)
+            table = pa.Table.from_pylist(pending)
+            if not any(pa.types.is_null(field.type) for field in table.schema):
+                locked_schema = table.schema
+                yield table
+                pending = []
+        if pending:  # a column stayed all-None through the end of the stream
+            yield pa.Table.from_pylist(pending)
+
     # Polars can write Arrow IPC directly, avoiding the extra PyArrow writer
     # memory peak seen when converting through a PyArrow Table first.
     if is_polars_dataframe(df):
         if pl is None:
             raise TypeError(f"Unsupported return type: {type(df)}. polars DataFrame detected but polars cannot be imported.")
-        df.write_ipc("$ARROW_FILE_PATH")
+        df.write_ipc(path)
         return
 
     if is_pandas_dataframe(df):
@@ -759,65 +1001,18 @@ def convert_and_write(df):
     elif isinstance(df, pa.Table):
         write_arrow_tables([df])
         return
+    elif isinstance(df, pa.RecordBatch):
+        write_arrow_tables([to_arrow_table(df)])
+        return
     elif isinstance(df, (list, tuple)):
         if not df:
             return
-        if isinstance(df[0], pa.Table):
-            write_arrow_tables(df)
+        if to_arrow_table(df[0]) is not None:
+            write_arrow_tables(rows_to_tables(df))
             return
         table = pa.Table.from_pylist(list(df))
     elif hasattr(df, '__iter__') and not isinstance(df, (str, bytes)):
         # Handle generators and other iterables (but not strings/bytes).
-        # Each yielded value is written as its own Arrow batch, as-is, so a
-        # generator never has to hold the full dataset in memory. A value can be:
-        #   1. an individual dict:        yield {"col": val}         -> a one-row batch
-        #   2. a batch (list of dicts):   yield [{"col": val}, ...]  -> one batch per page
-        #   3. a pyarrow Table:           yield pa.table(...)         -> written directly
-        # The batch granularity is whatever materialize() yields; we do not
-        # re-chunk. This mirrors how yielded pyarrow Tables are handled.
-        #
-        # The Arrow IPC file has a single schema fixed when the first batch is
-        # written, so every yielded batch must share one schema. If each yield
-        # inferred its own schema, a None in one yield would produce a 'null'-typed
-        # column that mismatches a typed value in the next yield (a common
-        # database-cursor pattern: "for row in cursor: yield row"). To handle this
-        # we buffer only the leading rows until their inferred schema has no
-        # null-typed columns, lock that schema, then stream every later batch
-        # against it so nullable values and missing optional keys conform instead
-        # of raising. The buffer is just the warm-up window; if a column stays
-        # entirely None we cannot infer its type and fall back to buffering it.
-        def rows_to_tables(items):
-            locked_schema = None
-            pending = []
-            for item in items:
-                if isinstance(item, pa.Table):
-                    # A yielded pa.Table carries an explicit schema, so use it to
-                    # flush any buffered rows (whose own inference may have left
-                    # null-typed columns) and to lock the schema for later rows.
-                    # This keeps the table and the surrounding dict batches in
-                    # agreement, whether the table comes before or after them.
-                    if pending:
-                        yield pa.Table.from_pylist(pending, schema=item.schema)
-                        pending = []
-                    if locked_schema is None:
-                        locked_schema = item.schema
-                    yield item
-                    continue
-                rows = item if isinstance(item, (list, tuple)) else [item]
-                if not rows:  # skip empty pages so we never emit a zero-row batch
-                    continue
-                if locked_schema is not None:
-                    yield pa.Table.from_pylist(list(rows), schema=locked_schema)
-                    continue
-                pending.extend(rows)
-                table = pa.Table.from_pylist(pending)
-                if not any(pa.types.is_null(field.type) for field in table.schema):
-                    locked_schema = table.schema
-                    yield table
-                    pending = []
-            if pending:  # a column stayed all-None through the end of the stream
-                yield pa.Table.from_pylist(pending)
-
         write_arrow_tables(rows_to_tables(df))
         return
     else:
@@ -825,8 +1020,23 @@ def convert_and_write(df):
 
     write_arrow_tables([table])
 
+# the Arrow file of each output, keyed by the output name, for assets with multiple outputs
+ARROW_OUTPUTS = $ARROW_OUTPUTS
+
 module = import_module_from_path("$REPO_ROOT", "$MODULE_PATH")
-convert_and_write(module.materialize())
+result = module.materialize()
+if ARROW_OUTPUTS is None:
+    convert_and_write(result, "$ARROW_FILE_PATH")
+else:
+    if result is None:
+        result = {}
+    if not isinstance(result, dict):
+        raise TypeError(f"materialize() must return a dict of output names to data for assets with outputs, got {type(result)}")
+    unknown = [name for name in result if name not in ARROW_OUTPUTS]
+    if unknown:
+        raise ValueError(f"materialize() returned data for undeclared outputs: {', '.join(unknown)}. Declared outputs are: {', '.join(ARROW_OUTPUTS)}")
+    for name, output_path in ARROW_OUTPUTS.items():
+        convert_and_write(result.get(name), output_path)
 `
 
 type SqlfluffRunner struct {
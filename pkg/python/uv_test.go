@@ -1,12 +1,18 @@
 package python
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"fmt"
 	"os"
+	"os/exec"
+	"path/filepath"
 	"regexp"
 	"strings"
 	"testing"
 
+	"github.com/bruin-data/bruin/pkg/executor"
 	"github.com/bruin-data/bruin/pkg/git"
 	"github.com/bruin-data/bruin/pkg/pipeline"
 	"github.com/stretchr/testify/assert"
@@ -228,10 +234,219 @@ func Test_uvPythonRunner_RunWithMaterialization_RejectsIncrementalPredicate(t *t
 	require.ErrorContains(t, err, "incremental_predicate is not supported for Python assets")
 }
 
+func Test_uvPythonRunner_RunWithMaterialization_LoadsEachOutput(t *testing.T) {
+	t.Parallel()
+
+	repo := &git.Repo{Path: t.TempDir()}
+	cmd := new(mockCmd)
+	cmd.On("Run", mock.Anything, repo, mock.MatchedBy(func(c *CommandInstance) bool {
+		return c.Name == "~/.bruin/uv" && len(c.Args) > 0 && c.Args[0] == "run"
+	})).Run(func(args mock.Arguments) {
+		command := args.Get(2).(*CommandInstance)
+		script, err := os.ReadFile(command.Args[len(command.Args)-1])
+		require.NoError(t, err)
+
+		matches := regexp.MustCompile(`(?m)^ARROW_OUTPUTS = (.+)$`).FindStringSubmatch(string(script))
+		require.Len(t, matches, 2)
+		var outputs map[string]string
+		require.NoError(t, json.Unmarshal([]byte(matches[1]), &outputs))
+		require.Len(t, outputs, 2)
+
+		// refunds returned no data, it is skipped
+		require.NoError(t, os.WriteFile(outputs["raw.orders"], []byte("arrow"), 0o600))
+	}).Return(nil)
+
+	inst := new(mockUvInstaller)
+	inst.On("EnsureUvInstalled", mock.Anything).Return("~/.bruin/uv", nil)
+
+	parent := &pipeline.Asset{
+		Name:       "raw.shop",
+		Type:       pipeline.AssetTypePython,
+		Connection: "dest",
+		Outputs: []*pipeline.Asset{
+			{Name: "raw.orders", Materialization: pipeline.Materialization{Type: "table"}},
+			{Name: "raw.refunds", Materialization: pipeline.Materialization{Type: "table"}},
+		},
+	}
+	outputs := parent.OutputAssets()
+	for _, asset := range append([]*pipeline.Asset{parent}, outputs...) {
+		asset.PrefixSchema("dev_")
+	}
+
+	var calls []string
+	runner := &UvPythonRunner{
+		Cmd:         cmd,
+		UvInstaller: inst,
+		conn: fakeConnectionGetter{
+			"dest": fakeArrowLoader{
+				fakeIngestrConnection: fakeIngestrConnection{uri: "duckdb:///tmp/db.duckdb"},
+				loaded:                true,
+				calls:                 &calls,
+			},
+		},
+	}
+
+	err := runner.Run(t.Context(), &executionContext{
+		repo:     repo,
+		module:   "path.to.module",
+		pipeline: &pipeline.Pipeline{Assets: append([]*pipeline.Asset{parent}, outputs...)},
+		asset:    parent,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"dev_raw.orders full_name"}, calls)
+	cmd.AssertExpectations(t)
+}
+
+func Test_uvPythonRunner_RunWithMaterialization_AppliesOutputGrants(t *testing.T) {
+	t.Parallel()
+
+	repo := &git.Repo{Path: t.TempDir()}
+	cmd := new(mockCmd)
+	cmd.On("Run", mock.Anything, repo, mock.MatchedBy(func(c *CommandInstance) bool {
+		return c.Name == "~/.bruin/uv" && len(c.Args) > 0 && c.Args[0] == "run"
+	})).Run(func(args mock.Arguments) {
+		command := args.Get(2).(*CommandInstance)
+		script, err := os.ReadFile(command.Args[len(command.Args)-1])
+		require.NoError(t, err)
+
+		matches := regexp.MustCompile(`(?m)^ARROW_OUTPUTS = (.+)$`).FindStringSubmatch(string(script))
+		require.Len(t, matches, 2)
+		var outputs map[string]string
+		require.NoError(t, json.Unmarshal([]byte(matches[1]), &outputs))
+		for _, path := range outputs {
+			require.NoError(t, os.WriteFile(path, []byte("arrow"), 0o600))
+		}
+	}).Return(nil)
+
+	inst := new(mockUvInstaller)
+	inst.On("EnsureUvInstalled", mock.Anything).Return("~/.bruin/uv", nil)
+
+	parent := &pipeline.Asset{
+		Name:       "raw.shop",
+		Type:       pipeline.AssetTypePython,
+		Connection: "dest",
+		Outputs: []*pipeline.Asset{
+			{Name: "raw.orders", Materialization: pipeline.Materialization{Type: "table"}, Grants: pipeline.Grants{"select": {"analyst"}}},
+			{Name: "raw.refunds", Materialization: pipeline.Materialization{Type: "table"}},
+		},
+	}
+	outputs := parent.OutputAssets()
+
+	var calls []string
+	runner := &UvPythonRunner{
+		Cmd:         cmd,
+		UvInstaller: inst,
+		conn: fakeConnectionGetter{
+			"dest": fakeArrowLoader{
+				fakeIngestrConnection: fakeIngestrConnection{uri: "duckdb:///tmp/db.duckdb"},
+				loaded:                true,
+				calls:                 &calls,
+			},
+		},
+	}
+
+	err := runner.Run(t.Context(), &executionContext{
+		repo:     repo,
+		module:   "path.to.module",
+		pipeline: &pipeline.Pipeline{Assets: append([]*pipeline.Asset{parent}, outputs...)},
+		asset:    parent,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"raw.orders full_name",
+		"grant analyst on raw.orders",
+		"raw.refunds full_name",
+	}, calls)
+}
+
+func Test_uvPythonRunner_MaterializeArrowFile_AppliesGrants(t *testing.T) {
+	t.Parallel()
+
+	asset := &pipeline.Asset{
+		Name:            "raw.scores",
+		Type:            pipeline.AssetTypeR,
+		Connection:      "dest",
+		Materialization: pipeline.Materialization{Type: "table"},
+		Grants:          pipeline.Grants{"select": {"analyst"}},
+	}
+
+	var calls []string
+	runner := &UvPythonRunner{
+		conn: fakeConnectionGetter{
+			"dest": fakeArrowLoader{
+				fakeIngestrConnection: fakeIngestrConnection{uri: "duckdb:///tmp/db.duckdb"},
+				loaded:                true,
+				calls:                 &calls,
+			},
+		},
+	}
+
+	var output bytes.Buffer
+	ctx := context.WithValue(t.Context(), executor.KeyPrinter, &output)
+	err := runner.MaterializeArrowFile(ctx, &git.Repo{Path: t.TempDir()}, &pipeline.Pipeline{}, asset, filepath.Join(t.TempDir(), "data.arrow"))
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"raw.scores full_name", "grant analyst on raw.scores"}, calls)
+}
+
+func Test_materializationTargets_RequiresTheOutputAssets(t *testing.T) {
+	t.Parallel()
+
+	parent := &pipeline.Asset{
+		Name: "raw.shop",
+		Type: pipeline.AssetTypePython,
+		Outputs: []*pipeline.Asset{
+			{Name: "raw.orders", Materialization: pipeline.Materialization{Type: "table"}},
+			{Name: "raw.refunds", Materialization: pipeline.Materialization{Type: "table"}},
+		},
+	}
+	outputs := parent.OutputAssets()
+
+	_, err := materializationTargets(&executionContext{
+		pipeline: &pipeline.Pipeline{Assets: []*pipeline.Asset{parent, outputs[0]}},
+		asset:    parent,
+	})
+
+	require.EqualError(t, err, "asset 'raw.shop' defines the output 'raw.refunds', but the pipeline does not contain it")
+}
+
+func Test_materializationTargets_MatchesOutputsByName(t *testing.T) {
+	t.Parallel()
+
+	parent := &pipeline.Asset{
+		Name: "raw.shop",
+		Type: pipeline.AssetTypePython,
+		Outputs: []*pipeline.Asset{
+			{Name: "raw.orders", Materialization: pipeline.Materialization{Type: "table"}},
+			{Name: "raw.refunds", Materialization: pipeline.Materialization{Type: "table"}},
+		},
+	}
+	outputs := parent.OutputAssets()
+	for _, asset := range append([]*pipeline.Asset{parent}, outputs...) {
+		asset.PrefixSchema("dev_")
+	}
+
+	// the pipeline lists the assets sorted or in the order they were found, not in the order of
+	// the outputs
+	targets, err := materializationTargets(&executionContext{
+		pipeline: &pipeline.Pipeline{Assets: []*pipeline.Asset{outputs[1], parent, outputs[0]}},
+		asset:    parent,
+	})
+
+	require.NoError(t, err)
+	require.Len(t, targets, 2)
+	assert.Equal(t, "raw.orders", targets[0].key)
+	assert.Equal(t, "dev_raw.orders", targets[0].asset.Name)
+	assert.Equal(t, "raw.refunds", targets[1].key)
+	assert.Equal(t, "dev_raw.refunds", targets[1].asset.Name)
+}
+
 func extractArrowPathFromScript(t *testing.T, script string) string {
 	t.Helper()
 
-	matches := regexp.MustCompile(`pa\.OSFile\("([^"]+)", 'wb'\)`).FindStringSubmatch(script)
+	matches := regexp.MustCompile(`convert_and_write\(result, "([^"]+)"\)`).FindStringSubmatch(script)
 	require.Len(t, matches, 2)
 	return strings.ReplaceAll(matches[1], `\\`, `\`)
 }
@@ -249,11 +464,11 @@ func TestPythonArrowTemplate_UsesNativePolarsIPC(t *testing.T) {
 	t.Parallel()
 
 	polarsCheckIndex := strings.Index(PythonArrowTemplate, "if is_polars_dataframe(df):")
-	polarsWriteIndex := strings.Index(PythonArrowTemplate, `df.write_ipc("$ARROW_FILE_PATH")`)
+	polarsWriteIndex := strings.Index(PythonArrowTemplate, "df.write_ipc(path)")
 	pandasCheckIndex := strings.Index(PythonArrowTemplate, "if is_pandas_dataframe(df):")
 	arrowTableIndex := strings.Index(PythonArrowTemplate, "elif isinstance(df, pa.Table):")
 	arrowTablesWriterIndex := strings.Index(PythonArrowTemplate, "def write_arrow_tables(tables):")
-	pyarrowWriteIndex := strings.Index(PythonArrowTemplate, `with pa.OSFile("$ARROW_FILE_PATH", 'wb') as f:`)
+	pyarrowWriteIndex := strings.Index(PythonArrowTemplate, "with pa.OSFile(path, 'wb') as f:")
 
 	require.NotEqual(t, -1, polarsCheckIndex)
 	require.NotEqual(t, -1, polarsWriteIndex)
@@ -295,6 +510,97 @@ func TestPythonArrowTemplate_PreservesNoDataAndIterableHandling(t *testing.T) {
 	assert.NotContains(t, PythonArrowTemplate, "rows = [first_row, *iterator]")
 }
 
+// runPythonArrowTemplate renders the template for a module with the given materialize
+// function, runs it and returns the number of rows and record batches of the Arrow file.
+func runPythonArrowTemplate(t *testing.T, python, materialize string) (int, int) {
+	t.Helper()
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "chunks.py"), []byte(materialize), 0o600))
+
+	arrowFile := filepath.Join(dir, "output.arrow")
+	script := strings.ReplaceAll(PythonArrowTemplate, "$REPO_ROOT", dir)
+	script = strings.ReplaceAll(script, "$MODULE_PATH", "chunks")
+	script = strings.ReplaceAll(script, "$ARROW_FILE_PATH", arrowFile)
+	script = strings.ReplaceAll(script, "$ARROW_OUTPUTS", "None")
+	scriptPath := filepath.Join(dir, "main.py")
+	require.NoError(t, os.WriteFile(scriptPath, []byte(script), 0o600))
+
+	output, err := exec.Command(python, scriptPath).CombinedOutput()
+	require.NoError(t, err, string(output))
+
+	output, err = exec.Command(python, "-c", `import sys, pyarrow.ipc as ipc
+reader = ipc.open_file(sys.argv[1])
+print(reader.read_all().num_rows, reader.num_record_batches)`, arrowFile).CombinedOutput()
+	require.NoError(t, err, string(output))
+
+	var rows, batches int
+	_, err = fmt.Sscan(string(output), &rows, &batches)
+	require.NoError(t, err)
+	return rows, batches
+}
+
+func TestPythonArrowTemplate_WritesChunks(t *testing.T) {
+	t.Parallel()
+
+	python, err := exec.LookPath("python3")
+	if err != nil || exec.Command(python, "-c", "import pyarrow, pandas").Run() != nil {
+		t.Skip("python3 with pyarrow and pandas is not available")
+	}
+
+	tests := []struct {
+		name        string
+		materialize string
+		wantRows    int
+		wantBatches int
+	}{
+		{
+			name: "list of tables and dataframes",
+			materialize: `import pyarrow as pa
+import pandas as pd
+
+def materialize():
+    return [pa.table({"id": [1, 2]}), pd.DataFrame({"id": [3]})]
+`,
+			wantRows:    3,
+			wantBatches: 2,
+		},
+		{
+			name: "generator of dataframes and record batches",
+			materialize: `import pyarrow as pa
+import pandas as pd
+
+def materialize():
+    yield pd.DataFrame({"id": [1, 2]})
+    yield pa.record_batch({"id": [3, 4]})
+    yield pd.DataFrame({"id": [5.0, None]})
+`,
+			wantRows:    6,
+			wantBatches: 3,
+		},
+		{
+			name: "single dataframe",
+			materialize: `import pandas as pd
+
+def materialize():
+    return pd.DataFrame({"id": [1, 2, 3], "name": ["a", "b", "c"]})
+`,
+			wantRows:    3,
+			wantBatches: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			rows, batches := runPythonArrowTemplate(t, python, tt.materialize)
+			assert.Equal(t, tt.wantRows, rows)
+			assert.Equal(t, tt.wantBatches, batches)
+		})
+	}
+}
+
 func Test_uvPythonRunner_Run(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,100 @@
+package r
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/bruin-data/bruin/pkg/git"
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/pkg/errors"
+)
+
+// arrowMaterializer loads the Arrow file written by the asset into its destination.
+type arrowMaterializer interface {
+	MaterializeArrowFile(ctx context.Context, repo *git.Repo, p *pipeline.Pipeline, asset *pipeline.Asset, arrowFilePath string) error
+}
+
+// rArrowTemplate sources the asset script into its own environment, calls its `materialize`
+// function and writes the returned data.frame to an Arrow IPC file that is then loaded the
+// same way the data returned by Python assets is.
+const rArrowTemplate = `
+bruin_asset <- new.env()
+source("$SCRIPT_PATH", local = bruin_asset)
+
+if (!exists("materialize", envir = bruin_asset, inherits = FALSE) || !is.function(bruin_asset$materialize)) {
+  stop("R assets with materialization must define a 'materialize' function that returns a data.frame")
+}
+
+bruin_result <- bruin_asset$materialize()
+if (is.null(bruin_result)) {
+  quit(save = "no", status = 0)
+}
+
+if (!is.data.frame(bruin_result)) {
+  stop(paste0("materialize() must return a data.frame, got: ", paste(class(bruin_result), collapse = ", ")))
+}
+
+if (!requireNamespace("arrow", quietly = TRUE)) {
+  stop("the 'arrow' package is required to materialize R assets, install it with install.packages(\"arrow\") or add it to renv.lock")
+}
+
+arrow::write_ipc_file(bruin_result, "$ARROW_FILE_PATH")
+`
+
+func renderArrowScript(scriptPath, arrowFilePath string) string {
+	script := strings.ReplaceAll(rArrowTemplate, "$SCRIPT_PATH", escapeRString(scriptPath))
+	return strings.ReplaceAll(script, "$ARROW_FILE_PATH", escapeRString(arrowFilePath))
+}
+
+func escapeRString(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value)
+}
+
+func (l *localRRunner) runWithMaterialization(ctx context.Context, execCtx *executionContext) error {
+	asset := execCtx.asset
+	if asset.Materialization.Type != pipeline.MaterializationTypeTable {
+		return errors.New("only table materialization is supported for R assets")
+	}
+
+	if asset.Materialization.IncrementalPredicate != "" {
+		return errors.New("incremental_predicate is not supported for R assets")
+	}
+
+	arrowFilePath := filepath.Join(os.TempDir(), fmt.Sprintf("r_asset_data_%d.arrow", time.Now().UnixNano()))
+	defer func(name string) {
+		_ = os.Remove(name)
+	}(arrowFilePath)
+
+	tempScript, err := os.CreateTemp("", "bruin-arrow-*.R")
+	if err != nil {
+		return errors.Wrap(err, "failed to create temp file")
+	}
+	defer func(name string) {
+		_ = os.Remove(name)
+	}(tempScript.Name())
+
+	_, err = io.WriteString(tempScript, renderArrowScript(asset.ExecutableFile.Path, arrowFilePath))
+	_ = tempScript.Close()
+	if err != nil {
+		return errors.Wrap(err, "failed to write to temp file")
+	}
+
+	if err := l.runScript(ctx, execCtx, tempScript.Name()); err != nil {
+		return err
+	}
+
+	// materialize() may return NULL
+	if _, err := os.Stat(arrowFilePath); os.IsNotExist(err) {
+		log(ctx, "WARNING: materialize() returned NULL, skipping materialization")
+		return nil
+	}
+
+	log(ctx, "Successfully collected the data from the asset, uploading to the destination...")
+
+	return l.materializer.MaterializeArrowFile(ctx, execCtx.repo, execCtx.pipeline, asset, arrowFilePath)
+}
@@ -0,0 +1,212 @@
+package r
+
+import (
+	"context"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/bruin-data/bruin/pkg/git"
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type mockCmd struct {
+	mock.Mock
+}
+
+func (m *mockCmd) Run(ctx context.Context, repo *git.Repo, command *CommandInstance) error {
+	args := m.Called(ctx, repo, command)
+	return args.Error(0)
+}
+
+type mockRenvInstaller struct {
+	mock.Mock
+}
+
+func (m *mockRenvInstaller) EnsureRenvExists(ctx context.Context, repo *git.Repo, renvLock string) error {
+	return m.Called(ctx, repo, renvLock).Error(0)
+}
+
+type mockArrowMaterializer struct {
+	mock.Mock
+}
+
+func (m *mockArrowMaterializer) MaterializeArrowFile(ctx context.Context, repo *git.Repo, p *pipeline.Pipeline, asset *pipeline.Asset, arrowFilePath string) error {
+	return m.Called(ctx, repo, p, asset, arrowFilePath).Error(0)
+}
+
+func TestRenderArrowScript(t *testing.T) {
+	t.Parallel()
+
+	script := renderArrowScript(`C:\assets\my "asset".R`, "/tmp/r_asset_data_1.arrow")
+
+	assert.Contains(t, script, `source("C:\\assets\\my \"asset\".R", local = bruin_asset)`)
+	assert.Contains(t, script, `arrow::write_ipc_file(bruin_result, "/tmp/r_asset_data_1.arrow")`)
+	assert.NotContains(t, script, "$SCRIPT_PATH")
+	assert.NotContains(t, script, "$ARROW_FILE_PATH")
+}
+
+var arrowFilePathPattern = regexp.MustCompile(`write_ipc_file\(bruin_result, "(.*)"\)`)
+
+// writeArrowFileFromScript plays the part of Rscript: it reads the rendered script the command
+// runs and creates the Arrow file it would write, returning the path of that file.
+func writeArrowFileFromScript(t *testing.T, command *CommandInstance) string {
+	t.Helper()
+
+	require.Len(t, command.Args, 1)
+	script, err := os.ReadFile(command.Args[0])
+	require.NoError(t, err)
+
+	matches := arrowFilePathPattern.FindStringSubmatch(string(script))
+	require.Len(t, matches, 2)
+	path := strings.ReplaceAll(matches[1], `\\`, `\`)
+
+	require.NoError(t, os.WriteFile(path, []byte("arrow"), 0o600))
+	return path
+}
+
+func TestLocalRRunner_runWithMaterialization(t *testing.T) {
+	t.Parallel()
+
+	repo := &git.Repo{Path: "/repo"}
+	p := &pipeline.Pipeline{Name: "pipeline"}
+	tableAsset := func() *pipeline.Asset {
+		return &pipeline.Asset{
+			Name:            "raw.users",
+			Type:            pipeline.AssetTypeR,
+			ExecutableFile:  pipeline.ExecutableFile{Path: "/repo/assets/users.R"},
+			Materialization: pipeline.Materialization{Type: pipeline.MaterializationTypeTable, Strategy: pipeline.MaterializationStrategyAppend},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		asset    func() *pipeline.Asset
+		renvLock string
+		setup    func(t *testing.T, cmd *mockCmd, renv *mockRenvInstaller, materializer *mockArrowMaterializer, written *string)
+		wantErr  string
+	}{
+		{
+			name: "views are rejected",
+			asset: func() *pipeline.Asset {
+				asset := tableAsset()
+				asset.Materialization.Type = pipeline.MaterializationTypeView
+				return asset
+			},
+			wantErr: "only table materialization is supported for R assets",
+		},
+		{
+			name: "incremental predicates are rejected",
+			asset: func() *pipeline.Asset {
+				asset := tableAsset()
+				asset.Materialization.IncrementalPredicate = "dt > '2024-01-01'"
+				return asset
+			},
+			wantErr: "incremental_predicate is not supported for R assets",
+		},
+		{
+			name:  "the Arrow file written by the script is materialized",
+			asset: tableAsset,
+			setup: func(t *testing.T, cmd *mockCmd, renv *mockRenvInstaller, materializer *mockArrowMaterializer, written *string) {
+				cmd.On("Run", mock.Anything, repo, mock.MatchedBy(func(c *CommandInstance) bool {
+					return c.Name == "Rscript" && c.EnvVars["BRUIN_ASSET"] == "raw.users"
+				})).
+					Run(func(args mock.Arguments) {
+						*written = writeArrowFileFromScript(t, args.Get(2).(*CommandInstance))
+					}).
+					Return(nil)
+				materializer.On("MaterializeArrowFile", mock.Anything, repo, p, mock.Anything, mock.MatchedBy(func(path string) bool {
+					return path == *written
+				})).Return(nil)
+			},
+		},
+		{
+			name:     "renv is set up before the script runs",
+			asset:    tableAsset,
+			renvLock: "/repo/renv.lock",
+			setup: func(t *testing.T, cmd *mockCmd, renv *mockRenvInstaller, materializer *mockArrowMaterializer, written *string) {
+				renv.On("EnsureRenvExists", mock.Anything, repo, "/repo/renv.lock").Return(nil)
+				cmd.On("Run", mock.Anything, repo, mock.Anything).
+					Run(func(args mock.Arguments) {
+						*written = writeArrowFileFromScript(t, args.Get(2).(*CommandInstance))
+					}).
+					Return(nil)
+				materializer.On("MaterializeArrowFile", mock.Anything, repo, p, mock.Anything, mock.Anything).Return(nil)
+			},
+		},
+		{
+			name:  "nothing is materialized when materialize() returns NULL",
+			asset: tableAsset,
+			setup: func(t *testing.T, cmd *mockCmd, renv *mockRenvInstaller, materializer *mockArrowMaterializer, written *string) {
+				cmd.On("Run", mock.Anything, repo, mock.Anything).Return(nil)
+			},
+		},
+		{
+			name:  "script errors are returned without materializing",
+			asset: tableAsset,
+			setup: func(t *testing.T, cmd *mockCmd, renv *mockRenvInstaller, materializer *mockArrowMaterializer, written *string) {
+				cmd.On("Run", mock.Anything, repo, mock.Anything).Return(assert.AnError)
+			},
+			wantErr: assert.AnError.Error(),
+		},
+		{
+			name:  "materialization errors are returned",
+			asset: tableAsset,
+			setup: func(t *testing.T, cmd *mockCmd, renv *mockRenvInstaller, materializer *mockArrowMaterializer, written *string) {
+				cmd.On("Run", mock.Anything, repo, mock.Anything).
+					Run(func(args mock.Arguments) {
+						*written = writeArrowFileFromScript(t, args.Get(2).(*CommandInstance))
+					}).
+					Return(nil)
+				materializer.On("MaterializeArrowFile", mock.Anything, repo, p, mock.Anything, mock.Anything).Return(assert.AnError)
+			},
+			wantErr: assert.AnError.Error(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cmd := new(mockCmd)
+			renv := new(mockRenvInstaller)
+			materializer := new(mockArrowMaterializer)
+			var written string
+			if tt.setup != nil {
+				tt.setup(t, cmd, renv, materializer, &written)
+			}
+
+			runner := &localRRunner{
+				cmd:           cmd,
+				renvInstaller: renv,
+				materializer:  materializer,
+				pathToRscript: "Rscript",
+			}
+			err := runner.runWithMaterialization(t.Context(), &executionContext{
+				repo:         repo,
+				renvLock:     tt.renvLock,
+				envVariables: map[string]string{"BRUIN_ASSET": "raw.users"},
+				pipeline:     p,
+				asset:        tt.asset(),
+			})
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+
+			cmd.AssertExpectations(t)
+			renv.AssertExpectations(t)
+			materializer.AssertExpectations(t)
+
+			// the Arrow file is removed once it is loaded
+			if written != "" {
+				assert.NoFileExists(t, written)
+			}
+		})
+	}
+}
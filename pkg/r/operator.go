@@ -13,6 +13,7 @@ import (
 	"github.com/bruin-data/bruin/pkg/git"
 	logger2 "github.com/bruin-data/bruin/pkg/logger"
 	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/bruin-data/bruin/pkg/python"
 	"github.com/bruin-data/bruin/pkg/scheduler"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
@@ -68,6 +69,7 @@ After installation, make sure 'Rscript' is available in your PATH.`)
 		runner: &localRRunner{
 			cmd:           cmdRunner,
 			renvInstaller: &RenvInstaller{cmd: cmdRunner},
+			materializer:  python.NewUvPythonRunner(config),
 			pathToRscript: pathToRscript,
 		},
 		envVariables: envVariables,
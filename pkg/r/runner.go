@@ -12,6 +12,7 @@ import (
 	"github.com/bruin-data/bruin/pkg/executor"
 	"github.com/bruin-data/bruin/pkg/git"
 	"github.com/bruin-data/bruin/pkg/logger"
+	"github.com/bruin-data/bruin/pkg/pipeline"
 	"github.com/pkg/errors"
 	"golang.org/x/sync/errgroup"
 )
@@ -27,6 +28,7 @@ type renvInstaller interface {
 type localRRunner struct {
 	cmd           cmd
 	renvInstaller renvInstaller
+	materializer  arrowMaterializer
 	pathToRscript string
 }
 
@@ -44,8 +46,14 @@ func log(ctx context.Context, message string) {
 }
 
 func (l *localRRunner) Run(ctx context.Context, execCtx *executionContext) error {
-	scriptPath := execCtx.asset.ExecutableFile.Path
+	if execCtx.asset.Materialization.Type != pipeline.MaterializationTypeNone {
+		return l.runWithMaterialization(ctx, execCtx)
+	}
+
+	return l.runScript(ctx, execCtx, execCtx.asset.ExecutableFile.Path)
+}
 
+func (l *localRRunner) runScript(ctx context.Context, execCtx *executionContext, scriptPath string) error {
 	// If there's no renv.lock, just run the R script directly
 	if execCtx.renvLock == "" {
 		log(ctx, "No renv.lock found, executing R script directly...")
@@ -155,6 +155,18 @@ type RunConfig struct {
 type PipelineAssetState struct {
 	Name   string `json:"name"`
 	Status string `json:"status"`
+	// StartedAt and DurationMs describe the execution of the asset itself, without its checks.
+	StartedAt  *time.Time            `json:"started_at,omitempty"`
+	DurationMs int64                 `json:"duration_ms,omitempty"`
+	Checks     []*PipelineCheckState `json:"checks,omitempty"`
+}
+
+// PipelineCheckState is the outcome of a column or custom check in a run, custom checks have no
+// column.
+type PipelineCheckState struct {
+	Name   string `json:"name"`
+	Column string `json:"column,omitempty"`
+	Status string `json:"status"`
 }
 
 type Metadata struct {
@@ -289,6 +301,9 @@ func (t *MetadataPushInstance) Blocking() bool {
 type TaskExecutionResult struct {
 	Instance TaskInstance
 	Error    error
+
+	StartedAt time.Time
+	Duration  time.Duration
 }
 
 type InstancesByType map[TaskInstanceType][]TaskInstance
@@ -337,6 +352,9 @@ type Scheduler struct {
 
 	runID          string
 	onStatusChange func(StatusChangeEvent)
+
+	// executions keeps the timing of the finished task instances for the run state.
+	executions map[TaskInstance]*TaskExecutionResult
 }
 
 type ConnectionDetailsGetter interface {
@@ -714,7 +732,7 @@ func NewScheduler(logger logger.Logger, p *pipeline.Pipeline, runID string) *Sch
 			instances = append(instances, testInstance)
 		}
 
-		if p.MetadataPush.HasAnyEnabled() {
+		if p.MetadataPush.IsEnabledFor(task.Type) {
 			instances = append(instances, &MetadataPushInstance{
 				AssetInstance: &AssetInstance{
 					ID:         uuid.New().String(),
@@ -949,6 +967,12 @@ func (s *Scheduler) Tick(result *TaskExecutionResult) bool {
 	if result.Error != nil {
 		s.markTaskInstanceFailedWithDownstream(result.Instance)
 	}
+	if !result.StartedAt.IsZero() {
+		if s.executions == nil {
+			s.executions = make(map[TaskInstance]*TaskExecutionResult)
+		}
+		s.executions[result.Instance] = result
+	}
 
 	// Run has already closed WorkQueue (cancellation); don't schedule more.
 	if s.stopped {
@@ -1156,13 +1180,41 @@ func (s *Scheduler) SavePipelineState(fs afero.Fs, cmd []string, param *RunConfi
 	}
 
 	state := make([]*PipelineAssetState, 0, len(dict))
+	stateByName := make(map[string]*PipelineAssetState, len(dict))
 	for key, status := range dict {
 		result := GetStatusForTask(status)
-		state = append(state, &PipelineAssetState{
+		assetState := &PipelineAssetState{
 			Name:   key,
 			Status: result.String(),
-		})
+		}
+		state = append(state, assetState)
+		stateByName[key] = assetState
+	}
+
+	s.taskScheduleLock.Lock()
+	for _, task := range s.taskInstances {
+		assetState := stateByName[task.GetAsset().Name]
+		switch t := task.(type) {
+		case *AssetInstance:
+			if execution, ok := s.executions[task]; ok {
+				startedAt := execution.StartedAt
+				assetState.StartedAt = &startedAt
+				assetState.DurationMs = execution.Duration.Milliseconds()
+			}
+		case *ColumnCheckInstance:
+			assetState.Checks = append(assetState.Checks, &PipelineCheckState{
+				Name:   t.Check.Name,
+				Column: t.Column.Name,
+				Status: t.GetStatus().String(),
+			})
+		case *CustomCheckInstance:
+			assetState.Checks = append(assetState.Checks, &PipelineCheckState{
+				Name:   t.Check.Name,
+				Status: t.GetStatus().String(),
+			})
+		}
 	}
+	s.taskScheduleLock.Unlock()
 
 	pipelineState := &PipelineState{
 		Cmdline:    cmd,
@@ -1554,6 +1554,56 @@ func TestScheduler_SavePipelineStateBackfill(t *testing.T) {
 	})
 }
 
+func TestScheduler_SavePipelineStateExecutions(t *testing.T) {
+	t.Parallel()
+
+	foundPipeline := &pipeline.Pipeline{
+		Name: "test",
+		Assets: []*pipeline.Asset{
+			{
+				Name: "task1",
+				Type: "bq.sql",
+				Columns: []pipeline.Column{
+					{Name: "id", Checks: []pipeline.ColumnCheck{{Name: "not_null"}}},
+				},
+				CustomChecks: []pipeline.CustomCheck{{Name: "row count", Query: "SELECT 1"}},
+			},
+		},
+	}
+
+	fs := afero.NewMemMapFs()
+	s := NewScheduler(zap.NewNop().Sugar(), foundPipeline, "run_a")
+
+	startedAt := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	for _, instance := range s.GetTaskInstances() {
+		switch instance.GetType() {
+		case TaskInstanceTypeMain:
+			s.Tick(&TaskExecutionResult{Instance: instance, StartedAt: startedAt, Duration: 1500 * time.Millisecond})
+		case TaskInstanceTypeColumnCheck:
+			s.Tick(&TaskExecutionResult{Instance: instance, StartedAt: startedAt, Duration: time.Second})
+		case TaskInstanceTypeCustomCheck:
+			s.Tick(&TaskExecutionResult{Instance: instance, Error: errors.New("check failed"), StartedAt: startedAt, Duration: time.Second})
+		default:
+		}
+	}
+
+	require.NoError(t, s.SavePipelineState(fs, []string{"bruin", "run"}, &RunConfig{}, "", 0, "run_a", "logs/runs"))
+
+	state, err := ReadState(fs, "logs/runs")
+	require.NoError(t, err)
+	require.Len(t, state.State, 1)
+
+	assetState := state.State[0]
+	assert.Equal(t, Failed.String(), assetState.Status)
+	require.NotNil(t, assetState.StartedAt)
+	assert.True(t, startedAt.Equal(*assetState.StartedAt))
+	assert.Equal(t, int64(1500), assetState.DurationMs)
+	assert.Equal(t, []*PipelineCheckState{
+		{Name: "not_null", Column: "id", Status: Succeeded.String()},
+		{Name: "row count", Status: Failed.String()},
+	}, assetState.Checks)
+}
+
 func TestScheduler_MarkAssetWithCustomChecks(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,122 @@
+package seed
+
+import (
+	"context"
+	"encoding/csv"
+	"io"
+	"net/http"
+	"os"
+
+	"github.com/pkg/errors"
+)
+
+// Rows streams the typed rows of a seed file. It implements pgx.CopyFromSource so that
+// it can be handed to COPY as is.
+type Rows struct {
+	file   *os.File
+	reader *csv.Reader
+	table  *Table
+	path   string
+	line   int
+	values []any
+	err    error
+}
+
+// Open opens the CSV file at path for reading the rows of table, the header is skipped.
+func Open(path string, table *Table) (*Rows, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to open seed file %s", path)
+	}
+
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = len(table.Columns)
+	reader.ReuseRecord = true
+	if _, err := reader.Read(); err != nil {
+		file.Close()
+		return nil, errors.Wrapf(err, "failed to read the header of seed file %s", path)
+	}
+
+	return &Rows{file: file, reader: reader, table: table, path: path, line: 1}, nil
+}
+
+// Next reads the next row, it returns false at the end of the file or on the first
+// invalid row; Err tells the two apart.
+func (r *Rows) Next() bool {
+	if r.err != nil {
+		return false
+	}
+
+	record, err := r.reader.Read()
+	if errors.Is(err, io.EOF) {
+		return false
+	}
+	r.line++
+	if err != nil {
+		r.err = errors.Wrapf(err, "failed to read seed file %s", r.path)
+		return false
+	}
+
+	values := make([]any, len(record))
+	for i, field := range record {
+		col := &r.table.Columns[i]
+		value, err := parseValue(col, field)
+		if err != nil {
+			r.err = errors.Errorf("seed file %s, line %d: value '%s' of column '%s' is not a valid %s: %v", r.path, r.line, field, col.Name, col.Kind, err)
+			return false
+		}
+		values[i] = value
+	}
+	r.values = values
+
+	return true
+}
+
+// Values returns the values of the current row, NULLs are nil.
+func (r *Rows) Values() ([]any, error) {
+	return r.values, nil
+}
+
+func (r *Rows) Err() error {
+	return r.err
+}
+
+func (r *Rows) Close() error {
+	return r.file.Close()
+}
+
+// Download fetches the seed file at url into a temporary file, the returned cleanup
+// function removes it.
+func Download(ctx context.Context, url string) (string, func(), error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", nil, errors.Wrapf(err, "failed to create the request for seed file %s", url)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", nil, errors.Wrapf(err, "failed to download seed file %s", url)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", nil, errors.Errorf("failed to download seed file %s: %s", url, resp.Status)
+	}
+
+	file, err := os.CreateTemp("", "bruin-seed-*.csv")
+	if err != nil {
+		return "", nil, errors.Wrap(err, "failed to create a temporary file for the seed")
+	}
+	cleanup := func() { _ = os.Remove(file.Name()) }
+
+	_, err = io.Copy(file, resp.Body)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		cleanup()
+		return "", nil, errors.Wrapf(err, "failed to download seed file %s", url)
+	}
+
+	return file.Name(), cleanup, nil
+}
@@ -0,0 +1,166 @@
+// Package seed loads CSV seed files into databases without going through ingestr.
+//
+// The package reads, types and validates the file; the destinations implement Loader
+// and only have to create the table and bulk-load the typed rows.
+package seed
+
+import (
+	"context"
+	"encoding/csv"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/bruin-data/bruin/pkg/helpers"
+	"github.com/pkg/errors"
+)
+
+// ErrUnsupported is returned by a Loader that cannot load the seed natively, e.g. a
+// Postgres client that talks to Redshift. It must be returned before the destination
+// is touched so that the caller can fall back to ingestr.
+var ErrUnsupported = errors.New("native seed loading is not supported for this connection")
+
+// Loader is implemented by the connections that can load seeds natively. LoadSeed
+// replaces the table with the rows, a failure must leave the existing table intact.
+type Loader interface {
+	LoadSeed(ctx context.Context, table *Table, rows *Rows) error
+}
+
+type Kind string
+
+const (
+	KindText      Kind = "text"
+	KindInteger   Kind = "integer"
+	KindFloat     Kind = "float"
+	KindDecimal   Kind = "decimal"
+	KindBoolean   Kind = "boolean"
+	KindDate      Kind = "date"
+	KindTimestamp Kind = "timestamp"
+)
+
+type Column struct {
+	Name string
+	Kind Kind
+
+	// Precision and Scale are only set for decimals with a declared size, the
+	// destinations fall back to their own default otherwise.
+	Precision int
+	Scale     int
+
+	// TimeZone marks timestamps that carry a time zone.
+	TimeZone bool
+
+	NotNull    bool
+	PrimaryKey bool
+}
+
+// Table is the typed description of a seed file, the columns are in the order of the
+// file header.
+type Table struct {
+	Name    string
+	Columns []Column
+}
+
+// ColumnNames returns the names of the columns in the order of the file header.
+func (t *Table) ColumnNames() []string {
+	names := make([]string, len(t.Columns))
+	for i, col := range t.Columns {
+		names[i] = col.Name
+	}
+	return names
+}
+
+// Describe reads the header of the CSV file at path and returns its table. declared
+// holds the columns defined in the asset, keyed by their normalized name; the rest of
+// the columns are typed by inferring their kind from the values in the file.
+func Describe(path, tableName string, declared map[string]Column, normalize func(string) string) (*Table, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to open seed file %s", path)
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, errors.Errorf("seed file %s has no header row", path)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read the header of seed file %s", path)
+	}
+
+	table := &Table{Name: tableName, Columns: make([]Column, len(header))}
+	seen := make(map[string]bool, len(header))
+	var toInfer []int
+	for i, name := range header {
+		name = strings.TrimSpace(name)
+		if normalize != nil {
+			name = normalize(name)
+		}
+		if name == "" {
+			return nil, errors.Errorf("seed file %s has an empty column name at position %d", path, i+1)
+		}
+		if seen[name] {
+			return nil, errors.Errorf("seed file %s has the column '%s' more than once", path, name)
+		}
+		seen[name] = true
+
+		col, ok := declared[name]
+		if !ok || col.Kind == "" {
+			toInfer = append(toInfer, i)
+		}
+		col.Name = name
+		table.Columns[i] = col
+	}
+
+	for name := range declared {
+		if !seen[name] {
+			return nil, errors.Errorf("column '%s' is declared in the asset but the seed file %s does not have it", name, path)
+		}
+	}
+
+	if len(toInfer) == 0 {
+		return table, nil
+	}
+
+	inferrers := make([]inferrer, len(toInfer))
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read seed file %s", path)
+		}
+
+		for i, idx := range toInfer {
+			inferrers[i].observe(record[idx])
+		}
+	}
+
+	for i, idx := range toInfer {
+		table.Columns[idx].Kind, table.Columns[idx].TimeZone = inferrers[i].result()
+	}
+
+	return table, nil
+}
+
+// ColumnDefinitions returns the column list of a CREATE TABLE statement, columnType
+// returns the destination type of a column including its NOT NULL constraint.
+func (t *Table) ColumnDefinitions(quote func(string) string, columnType func(Column) string) string {
+	definitions := make([]string, len(t.Columns))
+	for i, col := range t.Columns {
+		definitions[i] = quote(col.Name) + " " + columnType(col)
+	}
+	return strings.Join(definitions, ",\n")
+}
+
+// StagingTableName returns a unique table name in the schema of the given table, the
+// destinations without transactional DDL load the rows there before swapping it in.
+func StagingTableName(name string) string {
+	prefix := ""
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		prefix = name[:i+1]
+	}
+	return prefix + "__bruin_seed_" + helpers.PrefixGenerator()
+}
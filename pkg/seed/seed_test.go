@@ -0,0 +1,190 @@
+package seed
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeSeed(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "seed.csv")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestDescribe(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		content  string
+		declared map[string]Column
+		want     []Column
+		wantErr  string
+	}{
+		{
+			name: "kinds are inferred from the values",
+			content: "id,price,is_active,zip,signup_date,created_at,seen_at,notes,empty\n" +
+				"1,9.99,true,01234,2024-01-01,2024-01-01 10:00:00,2024-01-01T10:00:00Z,hello,\n" +
+				"2,10,FALSE,12345,2024-01-02,2024-01-02,2024-01-02T10:00:00+02:00,,\n",
+			want: []Column{
+				{Name: "id", Kind: KindInteger},
+				{Name: "price", Kind: KindFloat},
+				{Name: "is_active", Kind: KindBoolean},
+				{Name: "zip", Kind: KindText},
+				{Name: "signup_date", Kind: KindDate},
+				{Name: "created_at", Kind: KindTimestamp},
+				{Name: "seen_at", Kind: KindTimestamp, TimeZone: true},
+				{Name: "notes", Kind: KindText},
+				{Name: "empty", Kind: KindText},
+			},
+		},
+		{
+			name:    "header is normalized",
+			content: "Customer Id,Name\n1,a\n",
+			want: []Column{
+				{Name: "customer id", Kind: KindInteger},
+				{Name: "name", Kind: KindText},
+			},
+		},
+		{
+			name:    "declared kinds win over the inferred ones",
+			content: "id,amount\n1,10\n",
+			declared: map[string]Column{
+				"id":     {Kind: KindText, NotNull: true},
+				"amount": {Kind: KindDecimal, Precision: 10, Scale: 2},
+			},
+			want: []Column{
+				{Name: "id", Kind: KindText, NotNull: true},
+				{Name: "amount", Kind: KindDecimal, Precision: 10, Scale: 2},
+			},
+		},
+		{
+			name:     "declared columns without a kind are inferred",
+			content:  "id\n1\n",
+			declared: map[string]Column{"id": {PrimaryKey: true}},
+			want:     []Column{{Name: "id", Kind: KindInteger, PrimaryKey: true}},
+		},
+		{
+			name:     "declared columns must be in the file",
+			content:  "id\n1\n",
+			declared: map[string]Column{"name": {Kind: KindText}},
+			wantErr:  "column 'name' is declared in the asset but the seed file",
+		},
+		{
+			name:    "duplicate columns are rejected",
+			content: "id,ID\n1,2\n",
+			wantErr: "has the column 'id' more than once",
+		},
+		{
+			name:    "empty files are rejected",
+			content: "",
+			wantErr: "has no header row",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			table, err := Describe(writeSeed(t, tt.content), "seeds.table", tt.declared, strings.ToLower)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, "seeds.table", table.Name)
+			assert.Equal(t, tt.want, table.Columns)
+		})
+	}
+}
+
+func TestRows(t *testing.T) {
+	t.Parallel()
+
+	table := &Table{Columns: []Column{
+		{Name: "id", Kind: KindInteger, NotNull: true},
+		{Name: "price", Kind: KindFloat},
+		{Name: "amount", Kind: KindDecimal},
+		{Name: "is_active", Kind: KindBoolean},
+		{Name: "day", Kind: KindDate},
+		{Name: "created_at", Kind: KindTimestamp},
+		{Name: "name", Kind: KindText},
+	}}
+
+	tests := []struct {
+		name    string
+		content string
+		want    [][]any
+		wantErr string
+	}{
+		{
+			name:    "values are typed and empty fields are null",
+			content: "1, 9.5 ,10.25,yes,2024-01-31,2024-01-31 10:00:00.5,\" padded \"\n2,,,,,,\n",
+			want: [][]any{
+				{int64(1), 9.5, "10.25", true, time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 31, 10, 0, 0, 500000000, time.UTC), " padded "},
+				{int64(2), nil, nil, nil, nil, nil, nil},
+			},
+		},
+		{
+			name:    "invalid values report the line and column",
+			content: "1,1,1,true,2024-01-01,2024-01-01,a\n2,abc,1,true,2024-01-01,2024-01-01,b\n",
+			wantErr: "line 3: value 'abc' of column 'price' is not a valid float",
+		},
+		{
+			name:    "not nullable columns reject empty values",
+			content: ",1,1,true,2024-01-01,2024-01-01,a\n",
+			wantErr: "line 2: value '' of column 'id' is not a valid integer: the column is not nullable",
+		},
+		{
+			name:    "decimals must be numbers",
+			content: "1,1,1/3,true,2024-01-01,2024-01-01,a\n",
+			wantErr: "value '1/3' of column 'amount' is not a valid decimal",
+		},
+		{
+			name:    "rows must have every column",
+			content: "1,1\n",
+			wantErr: "wrong number of fields",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			rows, err := Open(writeSeed(t, strings.Join(table.ColumnNames(), ",")+"\n"+tt.content), table)
+			require.NoError(t, err)
+			defer rows.Close()
+
+			var got [][]any
+			for rows.Next() {
+				values, err := rows.Values()
+				require.NoError(t, err)
+				got = append(got, values)
+			}
+
+			if tt.wantErr != "" {
+				require.ErrorContains(t, rows.Err(), tt.wantErr)
+				return
+			}
+
+			require.NoError(t, rows.Err())
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestStagingTableName(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "__bruin_seed_abcefghi", StagingTableName("countries"))
+	assert.Equal(t, "seeds.__bruin_seed_abcefghi", StagingTableName("seeds.countries"))
+}
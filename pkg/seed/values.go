@@ -0,0 +1,157 @@
+package seed
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+var numberPattern = regexp.MustCompile(`^[+-]?(\d+\.?\d*|\.\d+)([eE][+-]?\d+)?$`)
+
+const dateLayout = "2006-01-02"
+
+var timestampLayouts = []struct {
+	layout   string
+	timeZone bool
+}{
+	{layout: time.RFC3339, timeZone: true},
+	{layout: "2006-01-02 15:04:05Z07:00", timeZone: true},
+	{layout: "2006-01-02 15:04:05 Z07:00", timeZone: true},
+	{layout: "2006-01-02T15:04:05"},
+	{layout: "2006-01-02 15:04:05"},
+	{layout: "2006-01-02T15:04"},
+	{layout: "2006-01-02 15:04"},
+	{layout: dateLayout},
+}
+
+// parseTimestamp parses the timestamp formats the seed files use in practice, fractional
+// seconds are accepted after the seconds of every layout. Timestamps without a zone are
+// read as UTC.
+func parseTimestamp(value string) (t time.Time, timeZone bool, err error) {
+	for _, l := range timestampLayouts {
+		if t, err := time.Parse(l.layout, value); err == nil {
+			return t, l.timeZone, nil
+		}
+	}
+
+	return time.Time{}, false, errors.New("unsupported timestamp format")
+}
+
+func parseBoolean(value string) (bool, error) {
+	switch strings.ToLower(value) {
+	case "true", "t", "yes", "y", "1":
+		return true, nil
+	case "false", "f", "no", "n", "0":
+		return false, nil
+	}
+
+	return false, errors.New("not a boolean")
+}
+
+// parseValue converts a CSV field to the Go value of the column's kind, empty fields are
+// NULL regardless of the kind.
+func parseValue(col *Column, value string) (any, error) {
+	if col.Kind != KindText {
+		value = strings.TrimSpace(value)
+	}
+	if value == "" {
+		if col.NotNull {
+			return nil, errors.New("the column is not nullable but the value is empty")
+		}
+		return nil, nil
+	}
+
+	switch col.Kind {
+	case KindText:
+		return value, nil
+	case KindInteger:
+		return strconv.ParseInt(value, 10, 64)
+	case KindFloat:
+		return strconv.ParseFloat(value, 64)
+	case KindDecimal:
+		if !numberPattern.MatchString(value) {
+			return nil, errors.New("not a decimal number")
+		}
+		return value, nil
+	case KindBoolean:
+		return parseBoolean(value)
+	case KindDate:
+		return time.Parse(dateLayout, value)
+	case KindTimestamp:
+		t, _, err := parseTimestamp(value)
+		return t, err
+	}
+
+	return nil, errors.Errorf("unsupported column kind '%s'", col.Kind)
+}
+
+// inferrer narrows down the kind of a column while its values are observed, every kind
+// starts as a candidate and is dropped by the first value it cannot represent.
+type inferrer struct {
+	seen         bool
+	leadingZero  bool
+	notInteger   bool
+	notFloat     bool
+	notBoolean   bool
+	notDate      bool
+	notTimestamp bool
+	hasTimeZone  bool
+}
+
+func (i *inferrer) observe(value string) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return
+	}
+	i.seen = true
+
+	// Leading zeros are significant in codes such as zip codes, "01234" keeps the column
+	// as text instead of becoming a number.
+	digits := strings.TrimLeft(value, "+-")
+	if len(digits) > 1 && digits[0] == '0' && digits[1] != '.' {
+		i.leadingZero = true
+	}
+
+	if !i.notInteger {
+		_, err := strconv.ParseInt(value, 10, 64)
+		i.notInteger = err != nil
+	}
+	if !i.notFloat {
+		i.notFloat = !numberPattern.MatchString(value)
+	}
+	if !i.notBoolean {
+		lower := strings.ToLower(value)
+		i.notBoolean = lower != "true" && lower != "false"
+	}
+	if !i.notDate {
+		_, err := time.Parse(dateLayout, value)
+		i.notDate = err != nil
+	}
+	if !i.notTimestamp {
+		_, timeZone, err := parseTimestamp(value)
+		i.notTimestamp = err != nil
+		i.hasTimeZone = i.hasTimeZone || timeZone
+	}
+}
+
+func (i *inferrer) result() (Kind, bool) {
+	switch {
+	case !i.seen:
+		return KindText, false
+	case !i.notInteger && !i.leadingZero:
+		return KindInteger, false
+	case !i.notFloat && !i.leadingZero:
+		return KindFloat, false
+	case !i.notBoolean:
+		return KindBoolean, false
+	case !i.notDate:
+		return KindDate, false
+	case !i.notTimestamp:
+		return KindTimestamp, i.hasTimeZone
+	}
+
+	return KindText, false
+}
@@ -0,0 +1,112 @@
+package snowflake
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/bruin-data/bruin/pkg/ansisql"
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/bruin-data/bruin/pkg/query"
+	"github.com/pkg/errors"
+)
+
+// ConstraintDialect migrates the constraints of Snowflake tables. Primary and foreign keys are
+// informational in Snowflake, they are created as NOT ENFORCED the same way the DDL strategy
+// does, only NOT NULL is enforced.
+var ConstraintDialect = ansisql.ConstraintDialect{
+	CurrentConstraints: currentConstraints,
+	AddPrimaryKey: func(asset *pipeline.Asset, columns []string) string {
+		quoted := make([]string, 0, len(columns))
+		for _, column := range columns {
+			quoted = append(quoted, quoteIdentifier(column))
+		}
+		return fmt.Sprintf("ALTER TABLE %s ADD PRIMARY KEY (%s) NOT ENFORCED;", quoteIdentifier(asset.Name), strings.Join(quoted, ", "))
+	},
+	DropPrimaryKey: func(asset *pipeline.Asset, name string) string {
+		return fmt.Sprintf("ALTER TABLE %s DROP PRIMARY KEY;", quoteIdentifier(asset.Name))
+	},
+	SetNotNull: func(asset *pipeline.Asset, column string) string {
+		return fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s SET NOT NULL;", quoteIdentifier(asset.Name), quoteIdentifier(column))
+	},
+	DropNotNull: func(asset *pipeline.Asset, column string) string {
+		return fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s DROP NOT NULL;", quoteIdentifier(asset.Name), quoteIdentifier(column))
+	},
+	AddForeignKey: func(asset *pipeline.Asset, key ansisql.ForeignKey) string {
+		return fmt.Sprintf(
+			"ALTER TABLE %s ADD FOREIGN KEY (%s) REFERENCES %s (%s) NOT ENFORCED;",
+			quoteIdentifier(asset.Name), quoteIdentifier(key.Column), quoteIdentifier(key.Table), quoteIdentifier(key.RefColumn),
+		)
+	},
+	DropForeignKey: func(asset *pipeline.Asset, key ansisql.ForeignKey) string {
+		return fmt.Sprintf("ALTER TABLE %s DROP FOREIGN KEY (%s);", quoteIdentifier(asset.Name), quoteIdentifier(key.Column))
+	},
+}
+
+// quoteIdentifier quotes only the reserved or non-standard parts of an identifier, quoting an
+// ordinary name would make it case-sensitive and stop it from matching the upper-cased name
+// Snowflake stores.
+func quoteIdentifier(identifier string) string {
+	return ansisql.QuoteIdentifierWithDoubleQuotesWhenNeeded(identifier)
+}
+
+// currentConstraints reads the keys of the table with SHOW PRIMARY KEYS and SHOW IMPORTED KEYS,
+// and the not-null columns from information_schema.
+func currentConstraints(ctx context.Context, conn ansisql.MetadataClient, asset *pipeline.Asset) (*ansisql.TableConstraints, error) {
+	constraints := &ansisql.TableConstraints{}
+
+	// SHOW PRIMARY KEYS returns created_on, database_name, schema_name, table_name, column_name,
+	// key_sequence, constraint_name, ...
+	primaryKeys, err := conn.Select(ctx, &query.Query{Query: "SHOW PRIMARY KEYS IN TABLE " + quoteIdentifier(asset.Name)})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get the primary key")
+	}
+	for _, row := range primaryKeys {
+		if len(row) < 7 {
+			continue
+		}
+		constraints.PrimaryKey = append(constraints.PrimaryKey, fmt.Sprint(row[4]))
+		constraints.PrimaryKeyName = fmt.Sprint(row[6])
+	}
+
+	// SHOW IMPORTED KEYS returns created_on, pk_database_name, pk_schema_name, pk_table_name,
+	// pk_column_name, fk_database_name, fk_schema_name, fk_table_name, fk_column_name,
+	// key_sequence, update_rule, delete_rule, fk_name, ...
+	foreignKeys, err := conn.Select(ctx, &query.Query{Query: "SHOW IMPORTED KEYS IN TABLE " + quoteIdentifier(asset.Name)})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get the foreign keys")
+	}
+	for _, row := range foreignKeys {
+		if len(row) < 13 {
+			continue
+		}
+		constraints.ForeignKeys = append(constraints.ForeignKeys, ansisql.ForeignKey{
+			Name:      fmt.Sprint(row[12]),
+			Column:    fmt.Sprint(row[8]),
+			Table:     fmt.Sprintf("%s.%s.%s", row[1], row[2], row[3]),
+			RefColumn: fmt.Sprint(row[4]),
+		})
+	}
+
+	informationSchema := "information_schema"
+	if parts := strings.Split(asset.Name, "."); len(parts) == 3 {
+		informationSchema = parts[0] + ".information_schema"
+	}
+	schema, table := ansisql.SplitTableName(asset.Name, "PUBLIC")
+	notNull, err := conn.Select(ctx, &query.Query{Query: fmt.Sprintf(
+		"SELECT column_name FROM %s.columns WHERE table_schema = %s AND table_name = %s AND is_nullable = 'NO' ORDER BY ordinal_position",
+		informationSchema,
+		ansisql.QuoteLiteral(strings.ToUpper(schema)),
+		ansisql.QuoteLiteral(strings.ToUpper(table)),
+	)})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get the not-null columns")
+	}
+	for _, row := range notNull {
+		if len(row) > 0 {
+			constraints.NotNull = append(constraints.NotNull, fmt.Sprint(row[0]))
+		}
+	}
+
+	return constraints, nil
+}
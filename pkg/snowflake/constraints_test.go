@@ -0,0 +1,66 @@
+package snowflake
+
+import (
+	"testing"
+
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/bruin-data/bruin/pkg/query"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConstraintDialect_RenderMigration(t *testing.T) {
+	t.Parallel()
+
+	notNull := false
+	asset := &pipeline.Asset{
+		Name: "analytics.sales.orders",
+		Columns: []pipeline.Column{
+			{Name: "id", PrimaryKey: true, Nullable: pipeline.DefaultTrueBool{Value: &notNull}},
+			{Name: "customer_id", Nullable: pipeline.DefaultTrueBool{Value: &notNull}, ForeignKey: &pipeline.ColumnReference{Table: "sales.customers", Column: "id"}},
+			{Name: "product_id", ForeignKey: &pipeline.ColumnReference{Table: "sales.products", Column: "id"}},
+		},
+	}
+
+	conn := new(mockQuerierWithResult)
+	conn.On("Select", mock.Anything, &query.Query{Query: "SHOW PRIMARY KEYS IN TABLE analytics.sales.orders"}).Return([][]interface{}{
+		{"2024-01-01", "ANALYTICS", "SALES", "ORDERS", "ID", 1, "SYS_CONSTRAINT_1"},
+	}, nil)
+	conn.On("Select", mock.Anything, &query.Query{Query: "SHOW IMPORTED KEYS IN TABLE analytics.sales.orders"}).Return([][]interface{}{
+		{"2024-01-01", "ANALYTICS", "SALES", "CUSTOMERS", "ID", "ANALYTICS", "SALES", "ORDERS", "CUSTOMER_ID", 1, "NO ACTION", "NO ACTION", "SYS_CONSTRAINT_2"},
+	}, nil)
+	conn.On("Select", mock.Anything, &query.Query{Query: "SELECT column_name FROM analytics.information_schema.columns WHERE table_schema = 'SALES' AND table_name = 'ORDERS' AND is_nullable = 'NO' ORDER BY ordinal_position"}).Return([][]interface{}{
+		{"ID"},
+	}, nil)
+
+	queries, err := ConstraintDialect.RenderMigration(t.Context(), conn, asset)
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"ALTER TABLE analytics.sales.orders ALTER COLUMN customer_id SET NOT NULL;",
+		"ALTER TABLE analytics.sales.orders ADD FOREIGN KEY (product_id) REFERENCES sales.products (id) NOT ENFORCED;",
+	}, queries)
+}
+
+func TestConstraintDialect_RenderMigration_QuotesIdentifiers(t *testing.T) {
+	t.Parallel()
+
+	asset := &pipeline.Asset{
+		Name: "analytics.sales.order",
+		Columns: []pipeline.Column{
+			{Name: "group", PrimaryKey: true},
+			{Name: "customer id", ForeignKey: &pipeline.ColumnReference{Table: "sales.customer-accounts", Column: "id"}},
+		},
+	}
+
+	conn := new(mockQuerierWithResult)
+	conn.On("Select", mock.Anything, &query.Query{Query: `SHOW PRIMARY KEYS IN TABLE analytics.sales."order"`}).Return([][]interface{}{}, nil)
+	conn.On("Select", mock.Anything, &query.Query{Query: `SHOW IMPORTED KEYS IN TABLE analytics.sales."order"`}).Return([][]interface{}{}, nil)
+	conn.On("Select", mock.Anything, &query.Query{Query: "SELECT column_name FROM analytics.information_schema.columns WHERE table_schema = 'SALES' AND table_name = 'ORDER' AND is_nullable = 'NO' ORDER BY ordinal_position"}).Return([][]interface{}{}, nil)
+
+	queries, err := ConstraintDialect.RenderMigration(t.Context(), conn, asset)
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		`ALTER TABLE analytics.sales."order" ADD PRIMARY KEY ("group") NOT ENFORCED;`,
+		`ALTER TABLE analytics.sales."order" ADD FOREIGN KEY ("customer id") REFERENCES sales."customer-accounts" (id) NOT ENFORCED;`,
+	}, queries)
+}
@@ -584,6 +584,11 @@ func (db *DB) RecreateTableOnMaterializationTypeMismatch(ctx context.Context, as
 	return nil
 }
 
+const (
+	ownerTag = "BRUIN_OWNER"
+	tagsTag  = "BRUIN_TAGS"
+)
+
 func (db *DB) PushColumnDescriptions(ctx context.Context, asset *pipeline.Asset) error {
 	tableComponents := strings.Split(asset.Name, ".")
 	var databaseName string
@@ -602,7 +607,7 @@ func (db *DB) PushColumnDescriptions(ctx context.Context, asset *pipeline.Asset)
 		return nil
 	}
 
-	if asset.Description == "" && len(asset.Columns) == 0 {
+	if asset.Description == "" && len(asset.Columns) == 0 && asset.Owner == "" && len(asset.Tags) == 0 {
 		return errors.New("no metadata to push: table and columns have no descriptions")
 	}
 
@@ -625,13 +630,13 @@ func (db *DB) PushColumnDescriptions(ctx context.Context, asset *pipeline.Asset)
 		if row[1] != nil {
 			comment = row[1].(string)
 		}
-		existingComments[columnName] = comment
+		existingComments[strings.ToUpper(columnName)] = comment
 	}
 
 	// Find columns that need updates
 	var updateQueries []string
 	for _, col := range asset.Columns {
-		if col.Description != "" && existingComments[col.Name] != col.Description {
+		if col.Description != "" && existingComments[strings.ToUpper(col.Name)] != col.Description {
 			query := fmt.Sprintf(
 				`ALTER TABLE %s.%s.%s MODIFY COLUMN %s COMMENT '%s'`,
 				databaseName, schemaName, tableName, col.Name, escapeSQLString(col.Description),
@@ -647,15 +652,76 @@ func (db *DB) PushColumnDescriptions(ctx context.Context, asset *pipeline.Asset)
 	}
 
 	if asset.Description != "" {
-		updateTableQuery := fmt.Sprintf(
-			`COMMENT ON TABLE %s.%s.%s IS '%s'`,
-			databaseName, schemaName, tableName, escapeSQLString(asset.Description),
-		)
-		if err := db.RunQueryWithoutResult(ctx, &query.Query{Query: updateTableQuery}); err != nil {
-			return errors.Wrap(err, "failed to update table description")
+		rows, err := db.Select(ctx, &query.Query{Query: fmt.Sprintf(
+			`SELECT COMMENT FROM %s.INFORMATION_SCHEMA.TABLES WHERE TABLE_SCHEMA = '%s' AND TABLE_NAME = '%s'`,
+			databaseName, schemaName, tableName,
+		)})
+		if err != nil {
+			return errors.Wrapf(err, "failed to query table metadata for %s.%s", schemaName, tableName)
+		}
+
+		if len(rows) == 0 || len(rows[0]) == 0 || rows[0][0] == nil || rows[0][0].(string) != asset.Description {
+			updateTableQuery := fmt.Sprintf(
+				`COMMENT ON TABLE %s.%s.%s IS '%s'`,
+				databaseName, schemaName, tableName, escapeSQLString(asset.Description),
+			)
+			if err := db.RunQueryWithoutResult(ctx, &query.Query{Query: updateTableQuery}); err != nil {
+				return errors.Wrap(err, "failed to update table description")
+			}
 		}
 	}
 
+	return db.pushTags(ctx, databaseName, schemaName, tableName, asset)
+}
+
+// pushTags sets the owner and the tags of the asset as the `bruin_owner` and `bruin_tags`
+// Snowflake tags of the table, the tags are created in the table's schema if they don't exist.
+func (db *DB) pushTags(ctx context.Context, databaseName, schemaName, tableName string, asset *pipeline.Asset) error {
+	desired := make(map[string]string)
+	if asset.Owner != "" {
+		desired[ownerTag] = asset.Owner
+	}
+	if len(asset.Tags) > 0 {
+		desired[tagsTag] = strings.Join(asset.Tags, ",")
+	}
+	if len(desired) == 0 {
+		return nil
+	}
+
+	qualifiedTable := fmt.Sprintf("%s.%s.%s", databaseName, schemaName, tableName)
+	rows, err := db.Select(ctx, &query.Query{Query: fmt.Sprintf(
+		`SELECT TAG_NAME, TAG_VALUE FROM TABLE(%s.INFORMATION_SCHEMA.TAG_REFERENCES('%s', 'table'))`,
+		databaseName, qualifiedTable,
+	)})
+	if err != nil {
+		return errors.Wrapf(err, "failed to query the tags of %s", qualifiedTable)
+	}
+
+	current := make(map[string]string)
+	for _, row := range rows {
+		if len(row) >= 2 && row[0] != nil && row[1] != nil {
+			current[strings.ToUpper(row[0].(string))] = row[1].(string)
+		}
+	}
+
+	var updateQueries, setValues []string
+	for _, tag := range []string{ownerTag, tagsTag} {
+		value, ok := desired[tag]
+		if !ok || current[tag] == value {
+			continue
+		}
+		qualifiedTag := fmt.Sprintf("%s.%s.%s", databaseName, schemaName, tag)
+		updateQueries = append(updateQueries, fmt.Sprintf(`CREATE TAG IF NOT EXISTS %s`, qualifiedTag))
+		setValues = append(setValues, fmt.Sprintf(`%s = '%s'`, qualifiedTag, escapeSQLString(value)))
+	}
+	if len(setValues) == 0 {
+		return nil
+	}
+
+	updateQueries = append(updateQueries, fmt.Sprintf(`ALTER TABLE %s SET TAG %s`, qualifiedTable, strings.Join(setValues, ", ")))
+	if err := db.RunQueryWithoutResult(ctx, &query.Query{Query: strings.Join(updateQueries, "; ")}); err != nil {
+		return errors.Wrap(err, "failed to update table tags")
+	}
 	return nil
 }
 
@@ -1041,11 +1041,58 @@ func TestDB_PushColumnDescriptions(t *testing.T) {
                      WHERE TABLE_SCHEMA = 'TEST_SCHEMA' AND TABLE_NAME = 'TEST_TABLE'`,
 				).WillReturnRows(sqlmock.NewRows(nil)) // No columns exist
 
+				mock.ExpectQuery(
+					`SELECT COMMENT FROM MYDB.INFORMATION_SCHEMA.TABLES WHERE TABLE_SCHEMA = 'TEST_SCHEMA' AND TABLE_NAME = 'TEST_TABLE'`,
+				).WillReturnRows(sqlmock.NewRows([]string{"COMMENT"}).AddRow("Old description"))
+
 				// Simulate updating table description
 				mock.ExpectQuery(`COMMENT ON TABLE MYDB.TEST_SCHEMA.TEST_TABLE IS 'Table description'`).
 					WillReturnRows(sqlmock.NewRows(nil))
 			},
 		},
+		{
+			name: "unchanged metadata is not pushed",
+			asset: &pipeline.Asset{
+				Name:        "test_schema.test_table",
+				Description: "Table description",
+				Columns: []pipeline.Column{
+					{Name: "col1", Description: "Description 1"},
+				},
+			},
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(
+					`SELECT COLUMN_NAME, COMMENT FROM MYDB.INFORMATION_SCHEMA.COLUMNS WHERE TABLE_SCHEMA = 'TEST_SCHEMA' AND TABLE_NAME = 'TEST_TABLE'`,
+				).WillReturnRows(sqlmock.NewRows([]string{"COLUMN_NAME", "COMMENT"}).
+					AddRow("COL1", "Description 1"))
+
+				mock.ExpectQuery(
+					`SELECT COMMENT FROM MYDB.INFORMATION_SCHEMA.TABLES WHERE TABLE_SCHEMA = 'TEST_SCHEMA' AND TABLE_NAME = 'TEST_TABLE'`,
+				).WillReturnRows(sqlmock.NewRows([]string{"COMMENT"}).AddRow("Table description"))
+			},
+		},
+		{
+			name: "owner and tags are pushed as snowflake tags",
+			asset: &pipeline.Asset{
+				Name:  "test_schema.test_table",
+				Owner: "jane@example.com",
+				Tags:  []string{"finance", "daily"},
+			},
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(
+					`SELECT COLUMN_NAME, COMMENT FROM MYDB.INFORMATION_SCHEMA.COLUMNS WHERE TABLE_SCHEMA = 'TEST_SCHEMA' AND TABLE_NAME = 'TEST_TABLE'`,
+				).WillReturnRows(sqlmock.NewRows(nil))
+
+				mock.ExpectQuery(
+					`SELECT TAG_NAME, TAG_VALUE FROM TABLE(MYDB.INFORMATION_SCHEMA.TAG_REFERENCES('MYDB.TEST_SCHEMA.TEST_TABLE', 'table'))`,
+				).WillReturnRows(sqlmock.NewRows([]string{"TAG_NAME", "TAG_VALUE"}).
+					AddRow("BRUIN_OWNER", "jane@example.com").
+					AddRow("BRUIN_TAGS", "finance"))
+
+				mock.ExpectQuery(
+					`CREATE TAG IF NOT EXISTS MYDB.TEST_SCHEMA.BRUIN_TAGS; ALTER TABLE MYDB.TEST_SCHEMA.TEST_TABLE SET TAG MYDB.TEST_SCHEMA.BRUIN_TAGS = 'finance,daily'`,
+				).WillReturnRows(sqlmock.NewRows(nil))
+			},
+		},
 		{
 			name: "error during querying existing metadata",
 			asset: &pipeline.Asset{
@@ -1101,6 +1148,9 @@ func TestDB_PushColumnDescriptions(t *testing.T) {
                      FROM MYDB.INFORMATION_SCHEMA.COLUMNS 
                      WHERE TABLE_SCHEMA = 'TEST_SCHEMA' AND TABLE_NAME = 'TEST_TABLE'`,
 				).WillReturnRows(sqlmock.NewRows(nil)) // No columns exist
+				mock.ExpectQuery(
+					`SELECT COMMENT FROM MYDB.INFORMATION_SCHEMA.TABLES WHERE TABLE_SCHEMA = 'TEST_SCHEMA' AND TABLE_NAME = 'TEST_TABLE'`,
+				).WillReturnRows(sqlmock.NewRows([]string{"COMMENT"}).AddRow(nil))
 				mock.ExpectQuery(`COMMENT ON TABLE MYDB.TEST_SCHEMA.TEST_TABLE IS 'Table description'`).
 					WillReturnError(errors.New("update error"))
 			},
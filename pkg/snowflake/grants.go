@@ -0,0 +1,55 @@
+package snowflake
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/bruin-data/bruin/pkg/ansisql"
+	"github.com/bruin-data/bruin/pkg/pipeline"
+)
+
+// GrantDialect applies the grants of Snowflake assets. Grantees are roles unless they are
+// prefixed with another grantee type, e.g. `database role analytics.readers`.
+var GrantDialect = ansisql.GrantDialect{
+	CurrentGrantsQuery: func(asset *pipeline.Asset) string {
+		return fmt.Sprintf("SHOW GRANTS ON %s %s", grantObjectType(asset), asset.Name)
+	},
+	// SHOW GRANTS returns created_on, privilege, granted_on, name, granted_to, grantee_name, ...
+	PrivilegeColumn: 1,
+	GranteeColumn:   5,
+	GrantStatement: func(asset *pipeline.Asset, privilege, grantee string) string {
+		return fmt.Sprintf("GRANT %s ON %s %s TO %s;", privilege, grantObjectType(asset), asset.Name, granteeWithType(grantee))
+	},
+	// SHOW GRANTS lists the privileges ALL PRIVILEGES stands for one by one, the ones that depend
+	// on the account, e.g. EVOLVE SCHEMA, are not required.
+	AllPrivileges: func(asset *pipeline.Asset) []string {
+		if asset.Materialization.Type == pipeline.MaterializationTypeView {
+			return []string{"SELECT", "REFERENCES"}
+		}
+		return []string{"SELECT", "INSERT", "UPDATE", "DELETE", "TRUNCATE", "REFERENCES"}
+	},
+}
+
+func grantObjectType(asset *pipeline.Asset) string {
+	if asset.Materialization.Type == pipeline.MaterializationTypeView {
+		return "VIEW"
+	}
+	return "TABLE"
+}
+
+func granteeWithType(grantee string) string {
+	lower := strings.ToLower(grantee)
+	for _, prefix := range []string{"role ", "database role ", "application role ", "share "} {
+		if strings.HasPrefix(lower, prefix) {
+			return grantee
+		}
+	}
+	return "ROLE " + grantee
+}
+
+// ApplyGrants applies the grants of an asset whose table was loaded without running SQL through
+// the operator, e.g. a materialized Python asset.
+func (db *DB) ApplyGrants(ctx context.Context, asset *pipeline.Asset) error {
+	return GrantDialect.ApplyGrants(ctx, db, asset)
+}
@@ -0,0 +1,32 @@
+package snowflake
+
+import (
+	"testing"
+
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGrantDialect(t *testing.T) {
+	t.Parallel()
+
+	asset := &pipeline.Asset{
+		Name:            "analytics.public.orders",
+		Materialization: pipeline.Materialization{Type: pipeline.MaterializationTypeView},
+		Grants: pipeline.Grants{
+			"select": {"role_bi", "database role analytics.readers"},
+		},
+	}
+
+	require.Equal(t, "SHOW GRANTS ON VIEW analytics.public.orders", GrantDialect.CurrentGrantsQuery(asset))
+
+	queries, err := GrantDialect.RenderGrants(asset)
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"GRANT SELECT ON VIEW analytics.public.orders TO ROLE role_bi;",
+		"GRANT SELECT ON VIEW analytics.public.orders TO database role analytics.readers;",
+	}, queries)
+
+	require.Equal(t, []string{"SELECT", "REFERENCES"}, GrantDialect.AllPrivileges(asset))
+	require.Contains(t, GrantDialect.AllPrivileges(&pipeline.Asset{}), "TRUNCATE")
+}
@@ -184,12 +184,15 @@ func buildDDLQuery(asset *pipeline.Asset, query string) (string, error) {
 		if col.Default != "" {
 			def += " DEFAULT " + col.Default
 		}
+		if col.IsNotNull() {
+			def += " NOT NULL"
+		}
 		if col.PrimaryKey {
 			primaryKeys = append(primaryKeys, col.Name)
 		}
-		if col.ForeignKey != nil && col.ForeignKey.Table != "" && col.ForeignKey.Column != "" {
+		if col.HasForeignKey() {
 			foreignKeys = append(foreignKeys, fmt.Sprintf(
-				"foreign key (%s) references %s (%s)",
+				"foreign key (%s) references %s (%s) not enforced",
 				col.Name, col.ForeignKey.Table, col.ForeignKey.Column,
 			))
 		}
@@ -205,7 +208,7 @@ func buildDDLQuery(asset *pipeline.Asset, query string) (string, error) {
 	}
 	primaryKeyClause := ""
 	if len(primaryKeys) > 0 {
-		primaryKeyClause = fmt.Sprintf(",\nprimary key (%s)", strings.Join(primaryKeys, ", "))
+		primaryKeyClause = fmt.Sprintf(",\nprimary key (%s) not enforced", strings.Join(primaryKeys, ", "))
 	}
 	foreignKeyClause := ""
 	if len(foreignKeys) > 0 {
@@ -377,7 +377,7 @@ func TestMaterializer_Render(t *testing.T) {
 			want: "CREATE TABLE IF NOT EXISTS my_primary_key_table \\(\n" +
 				"id INT64,\n" +
 				"category STRING COMMENT 'Category of the item',\n" +
-				"primary key \\(id\\)\n" +
+				"primary key \\(id\\) not enforced\n" +
 				"\\)",
 		},
 		{
@@ -396,7 +396,7 @@ func TestMaterializer_Render(t *testing.T) {
 			want: "CREATE TABLE IF NOT EXISTS my_composite_primary_key_table \\(\n" +
 				"id INT64,\n" +
 				"category STRING COMMENT 'Category of the item',\n" +
-				"primary key \\(id, category\\)\n" +
+				"primary key \\(id, category\\) not enforced\n" +
 				"\\)",
 		},
 		{
@@ -413,7 +413,21 @@ func TestMaterializer_Render(t *testing.T) {
 					{Name: "customer_id", Type: "INT64", ForeignKey: &pipeline.ColumnReference{Table: "customers", Column: "id"}},
 				},
 			},
-			want: `CREATE TABLE IF NOT EXISTS orders \(\s*amount NUMBER\(10, 2\) DEFAULT 0,\s*name STRING COLLATE 'en_US',\s*customer_id INT64,\s*foreign key \(customer_id\) references customers \(id\)\s*\)`,
+			want: `CREATE TABLE IF NOT EXISTS orders \(\s*amount NUMBER\(10, 2\) DEFAULT 0,\s*name STRING COLLATE 'en_US',\s*customer_id INT64,\s*foreign key \(customer_id\) references customers \(id\) not enforced\s*\)`,
+		},
+		{
+			name: "table with a not null foreign key",
+			task: &pipeline.Asset{
+				Name: "orders",
+				Materialization: pipeline.Materialization{
+					Type:     pipeline.MaterializationTypeTable,
+					Strategy: pipeline.MaterializationStrategyDDL,
+				},
+				Columns: []pipeline.Column{
+					{Name: "customer_id", Type: "INT64", Nullable: pipeline.DefaultTrueBool{Value: boolp(false)}, ForeignKey: &pipeline.ColumnReference{Table: "customers", Column: "id"}},
+				},
+			},
+			want: `CREATE TABLE IF NOT EXISTS orders \(\s*customer_id INT64 NOT NULL,\s*foreign key \(customer_id\) references customers \(id\) not enforced\s*\)`,
 		},
 	}
 	for _, tt := range tests {
@@ -1058,3 +1072,5 @@ func TestBuildSCD2QueryByTime(t *testing.T) {
 }
 
 func intp(i int) *int { return &i }
+
+func boolp(value bool) *bool { return &value }
@@ -0,0 +1,130 @@
+package snowflake
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/bruin-data/bruin/pkg/ansisql"
+	"github.com/bruin-data/bruin/pkg/executor"
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/bruin-data/bruin/pkg/query"
+	"github.com/pkg/errors"
+)
+
+// ApplyPolicies attaches the masking policies of the asset's columns and its row access policy to
+// the materialized table. Masking policies are set with FORCE so that a policy that is already
+// attached to a column is replaced in place, the row access policy is only swapped when the
+// table has a different one.
+func ApplyPolicies(ctx context.Context, conn SfClient, asset *pipeline.Asset) error {
+	if !asset.HasPolicies() || asset.Materialization.Type == pipeline.MaterializationTypeNone {
+		return nil
+	}
+
+	queries := maskingPolicyQueries(asset)
+	if asset.RowAccessPolicy != nil {
+		current, err := currentRowAccessPolicies(ctx, conn, asset)
+		if err != nil {
+			return err
+		}
+
+		q, err := rowAccessPolicyQuery(asset, current)
+		if err != nil {
+			return err
+		}
+		if q != "" {
+			queries = append(queries, q)
+		}
+	}
+
+	writer := ctx.Value(executor.KeyPrinter)
+	for _, q := range queries {
+		ansisql.LogQueryIfVerbose(ctx, writer, q)
+		if err := conn.RunQueryWithoutResult(ctx, &query.Query{Query: q}); err != nil {
+			return errors.Wrapf(err, "failed to apply the policies on '%s'", asset.Name)
+		}
+	}
+
+	return nil
+}
+
+func maskingPolicyQueries(asset *pipeline.Asset) []string {
+	queries := make([]string, 0)
+	for _, column := range asset.Columns {
+		policy := strings.TrimSpace(column.MaskingPolicy)
+		if policy == "" {
+			continue
+		}
+		queries = append(queries, fmt.Sprintf(
+			"ALTER %s %s MODIFY COLUMN %s SET MASKING POLICY %s FORCE;",
+			grantObjectType(asset), asset.Name, column.Name, policy,
+		))
+	}
+	return queries
+}
+
+func currentRowAccessPolicies(ctx context.Context, conn SfClient, asset *pipeline.Asset) ([]string, error) {
+	informationSchema := "information_schema"
+	if parts := strings.Split(asset.Name, "."); len(parts) == 3 {
+		informationSchema = parts[0] + ".information_schema"
+	}
+
+	rows, err := conn.Select(ctx, &query.Query{Query: fmt.Sprintf(
+		"SELECT policy_db, policy_schema, policy_name FROM TABLE(%s.policy_references(ref_entity_name => %s, ref_entity_domain => %s)) WHERE policy_kind = 'ROW_ACCESS_POLICY'",
+		informationSchema,
+		ansisql.QuoteLiteral(asset.Name),
+		ansisql.QuoteLiteral(strings.ToLower(grantObjectType(asset))),
+	)})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get the row access policies on '%s'", asset.Name)
+	}
+
+	policies := make([]string, 0, len(rows))
+	for _, row := range rows {
+		parts := make([]string, 0, len(row))
+		for _, value := range row {
+			if value != nil && fmt.Sprint(value) != "" {
+				parts = append(parts, fmt.Sprint(value))
+			}
+		}
+		policies = append(policies, strings.Join(parts, "."))
+	}
+	return policies, nil
+}
+
+func rowAccessPolicyQuery(asset *pipeline.Asset, current []string) (string, error) {
+	policy := asset.RowAccessPolicy
+	if strings.TrimSpace(policy.Name) == "" {
+		return "", errors.New("row_access_policy.name is required for Snowflake assets, it must be an existing row access policy")
+	}
+	if len(policy.On) == 0 {
+		return "", errors.New("row_access_policy.on is required for Snowflake assets, it must list the columns passed to the policy")
+	}
+
+	var drop []string
+	for _, existing := range current {
+		if samePolicy(existing, policy.Name) {
+			return "", nil
+		}
+		drop = append(drop, "DROP ROW ACCESS POLICY "+existing)
+	}
+
+	add := fmt.Sprintf("ADD ROW ACCESS POLICY %s ON (%s)", policy.Name, strings.Join(policy.On, ", "))
+	return fmt.Sprintf("ALTER %s %s %s;", grantObjectType(asset), asset.Name, strings.Join(append(drop, add), ", ")), nil
+}
+
+// samePolicy compares a fully qualified policy name with the one in the asset definition, which
+// may be qualified only partially.
+func samePolicy(qualified, defined string) bool {
+	qualifiedParts := strings.Split(strings.ToLower(qualified), ".")
+	definedParts := strings.Split(strings.ToLower(strings.Trim(defined, `"`)), ".")
+	if len(definedParts) > len(qualifiedParts) {
+		return false
+	}
+	for i := 1; i <= len(definedParts); i++ {
+		if strings.Trim(qualifiedParts[len(qualifiedParts)-i], `"`) != strings.Trim(definedParts[len(definedParts)-i], `"`) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,70 @@
+package snowflake
+
+import (
+	"testing"
+
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaskingPolicyQueries(t *testing.T) {
+	t.Parallel()
+
+	asset := &pipeline.Asset{
+		Name:            "analytics.public.customers",
+		Materialization: pipeline.Materialization{Type: pipeline.MaterializationTypeTable},
+		Columns: []pipeline.Column{
+			{Name: "id"},
+			{Name: "email", MaskingPolicy: "governance.policies.email_mask"},
+		},
+	}
+
+	require.Equal(t, []string{
+		"ALTER TABLE analytics.public.customers MODIFY COLUMN email SET MASKING POLICY governance.policies.email_mask FORCE;",
+	}, maskingPolicyQueries(asset))
+}
+
+func TestRowAccessPolicyQuery(t *testing.T) {
+	t.Parallel()
+
+	asset := &pipeline.Asset{
+		Name:            "analytics.public.orders",
+		Materialization: pipeline.Materialization{Type: pipeline.MaterializationTypeTable},
+		RowAccessPolicy: &pipeline.RowAccessPolicy{Name: "governance.policies.region_access", On: []string{"region", "country"}},
+	}
+
+	tests := []struct {
+		name    string
+		current []string
+		want    string
+	}{
+		{
+			name: "policy is added when the table has none",
+			want: "ALTER TABLE analytics.public.orders ADD ROW ACCESS POLICY governance.policies.region_access ON (region, country);",
+		},
+		{
+			name:    "nothing is done when the policy is already attached",
+			current: []string{"GOVERNANCE.POLICIES.REGION_ACCESS"},
+		},
+		{
+			name:    "a different policy is swapped in the same statement",
+			current: []string{"GOVERNANCE.POLICIES.OLD_ACCESS"},
+			want:    "ALTER TABLE analytics.public.orders DROP ROW ACCESS POLICY GOVERNANCE.POLICIES.OLD_ACCESS, ADD ROW ACCESS POLICY governance.policies.region_access ON (region, country);",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := rowAccessPolicyQuery(asset, tt.current)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+
+	_, err := rowAccessPolicyQuery(&pipeline.Asset{
+		Name:            "analytics.public.orders",
+		RowAccessPolicy: &pipeline.RowAccessPolicy{Name: "region_access"},
+	}, nil)
+	require.Error(t, err)
+}
@@ -26,6 +26,7 @@ func NewMaterializer(fullRefresh bool) *pipeline.Materializer {
 	tableMaterializers[pipeline.MaterializationStrategyTimeInterval] = quoteIncrementalKey(
 		tableMaterializers[pipeline.MaterializationStrategyTimeInterval],
 	)
+	tableMaterializers[pipeline.MaterializationStrategyInsertOverwrite] = buildInsertOverwriteQuery
 	tableMaterializers[pipeline.MaterializationStrategyMerge] = buildMergeQuery
 	tableMaterializers[pipeline.MaterializationStrategyDDL] = buildDDLQuery
 	tableMaterializers[pipeline.MaterializationStrategySCD2ByColumn] = buildSCD2ByColumnQuery
@@ -119,6 +120,25 @@ func buildDDLQuery(asset *pipeline.Asset, _ string) (string, error) {
 	return strings.Join(lines, "\n"), nil
 }
 
+// buildInsertOverwriteQuery overwrites the partitions that appear in the query result and leaves
+// the rest of the table untouched. The overwrite mode is reset afterwards so that it does not leak
+// into the other statements running on the same session.
+func buildInsertOverwriteQuery(asset *pipeline.Asset, query string) (string, error) {
+	if asset.Materialization.PartitionBy == "" {
+		return "", errors.New("materialization strategy insert_overwrite requires the `partition_by` field to be set")
+	}
+	if asset.Materialization.TimeGranularity != "" {
+		return "", errors.New("materialization strategy insert_overwrite does not support `time_granularity` on Spark, the partitions are taken from the query result")
+	}
+
+	return strings.Join([]string{
+		"SET spark.sql.sources.partitionOverwriteMode = dynamic;",
+		fmt.Sprintf("INSERT OVERWRITE TABLE %s", quoteIdentifier(asset.Name)),
+		strings.TrimSpace(strings.TrimSuffix(query, ";")) + ";",
+		"RESET spark.sql.sources.partitionOverwriteMode;",
+	}, "\n"), nil
+}
+
 func buildMergeQuery(asset *pipeline.Asset, query string) (string, error) {
 	if len(asset.Columns) == 0 {
 		return "", fmt.Errorf("materialization strategy %s requires the `columns` field to be set", asset.Materialization.Strategy)
@@ -125,6 +125,34 @@ func TestMaterializerCreateReplaceLayout(t *testing.T) {
 	)
 }
 
+func TestMaterializerInsertOverwrite(t *testing.T) {
+	t.Parallel()
+
+	asset := &pipeline.Asset{
+		Name: "catalog.analytics.events",
+		Materialization: pipeline.Materialization{
+			Type:        pipeline.MaterializationTypeTable,
+			Strategy:    pipeline.MaterializationStrategyInsertOverwrite,
+			PartitionBy: "days(event_at)",
+		},
+	}
+
+	actual, err := NewMaterializer(false).Render(asset, "SELECT * FROM incoming_events;")
+	require.NoError(t, err)
+	require.Equal(
+		t,
+		"SET spark.sql.sources.partitionOverwriteMode = dynamic;\n"+
+			"INSERT OVERWRITE TABLE `catalog`.`analytics`.`events`\n"+
+			"SELECT * FROM incoming_events;\n"+
+			"RESET spark.sql.sources.partitionOverwriteMode;",
+		actual,
+	)
+
+	asset.Materialization.TimeGranularity = pipeline.MaterializationTimeGranularityDate
+	_, err = NewMaterializer(false).Render(asset, "SELECT * FROM incoming_events;")
+	require.ErrorContains(t, err, "does not support `time_granularity`")
+}
+
 func TestMaterializerDDLLayout(t *testing.T) {
 	t.Parallel()
 
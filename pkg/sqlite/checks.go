@@ -0,0 +1,100 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/bruin-data/bruin/pkg/ansisql"
+	"github.com/bruin-data/bruin/pkg/config"
+	"github.com/bruin-data/bruin/pkg/query"
+	"github.com/bruin-data/bruin/pkg/scheduler"
+	"github.com/pkg/errors"
+	"modernc.org/sqlite"
+)
+
+// SQLite parses the REGEXP operator but leaves the function behind it to the application, `x REGEXP y`
+// calls `regexp(y, x)`.
+func init() {
+	sqlite.MustRegisterDeterministicScalarFunction("regexp", 2, func(_ *sqlite.FunctionContext, args []driver.Value) (driver.Value, error) {
+		if args[0] == nil || args[1] == nil {
+			return nil, nil
+		}
+
+		re, err := regexp.Compile(fmt.Sprint(args[0]))
+		if err != nil {
+			return nil, err
+		}
+
+		value := args[1]
+		if b, ok := value.([]byte); ok {
+			value = string(b)
+		}
+
+		return re.MatchString(fmt.Sprint(value)), nil
+	})
+}
+
+type AcceptedValuesCheck struct {
+	conn config.ConnectionGetter
+}
+
+func (c *AcceptedValuesCheck) Check(ctx context.Context, ti *scheduler.ColumnCheckInstance) error {
+	if ti.Check.Value.StringArray == nil && ti.Check.Value.IntArray == nil {
+		return errors.Errorf("unexpected value for accepted_values check, expected an array but received %T", ti.Check.Value)
+	}
+
+	if ti.Check.Value.StringArray != nil && len(*ti.Check.Value.StringArray) == 0 {
+		return errors.Errorf("no values provided for accepted_values check")
+	}
+
+	if ti.Check.Value.IntArray != nil && len(*ti.Check.Value.IntArray) == 0 {
+		return errors.Errorf("no values provided for accepted_values check")
+	}
+
+	var values []string
+	if ti.Check.Value.StringArray != nil {
+		for _, v := range *ti.Check.Value.StringArray {
+			values = append(values, strings.ReplaceAll(v, "'", "''"))
+		}
+	} else {
+		for _, v := range *ti.Check.Value.IntArray {
+			values = append(values, strconv.Itoa(v))
+		}
+	}
+
+	queryString := fmt.Sprintf(
+		"SELECT COUNT(*) FROM %s WHERE CAST(%s AS TEXT) NOT IN ('%s')",
+		ti.GetAsset().Name,
+		ti.Column.Name,
+		strings.Join(values, "','"),
+	)
+
+	return ansisql.NewCountableQueryCheck(c.conn, 0, &query.Query{Query: queryString}, "accepted_values", func(count int64) error {
+		return errors.Errorf("column '%s' has %d rows that are not in the accepted values", ti.Column.Name, count)
+	}).Check(ctx, ti)
+}
+
+type PatternCheck struct {
+	conn config.ConnectionGetter
+}
+
+func (c *PatternCheck) Check(ctx context.Context, ti *scheduler.ColumnCheckInstance) error {
+	if ti.Check.Value.String == nil {
+		return errors.Errorf("unexpected value %s for pattern check, expected a string", ti.Check.Value.ToString())
+	}
+
+	queryString := fmt.Sprintf(
+		"SELECT COUNT(*) FROM %s WHERE %s NOT REGEXP '%s'",
+		ti.GetAsset().Name,
+		ti.Column.Name,
+		strings.ReplaceAll(*ti.Check.Value.String, "'", "''"),
+	)
+
+	return ansisql.NewCountableQueryCheck(c.conn, 0, &query.Query{Query: queryString}, "pattern", func(count int64) error {
+		return errors.Errorf("column %s has %d values that do not satisfy the pattern %s", ti.Column.Name, count, *ti.Check.Value.String)
+	}).Check(ctx, ti)
+}
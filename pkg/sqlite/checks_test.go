@@ -0,0 +1,57 @@
+package sqlite
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/bruin-data/bruin/pkg/query"
+	"github.com/bruin-data/bruin/pkg/scheduler"
+	"github.com/stretchr/testify/require"
+)
+
+type connections map[string]any
+
+func (c connections) GetConnection(name string) any {
+	return c[name]
+}
+
+func TestColumnChecks(t *testing.T) {
+	t.Parallel()
+
+	client, err := NewClient(Config{Path: filepath.Join(t.TempDir(), "checks.db")})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = client.Close() })
+
+	require.NoError(t, client.RunQueryWithoutResult(t.Context(), &query.Query{
+		Query: "CREATE TABLE users AS SELECT 'a@example.com' AS email, 'active' AS status UNION ALL SELECT 'not-an-email', 'deleted'",
+	}))
+
+	conns := connections{"sqlite-default": client}
+	p := &pipeline.Pipeline{DefaultConnections: map[string]string{"sqlite": "sqlite-default"}}
+	asset := &pipeline.Asset{Name: "users", Type: pipeline.AssetTypeSQLiteQuery}
+
+	instance := func(column string, value pipeline.ColumnCheckValue) *scheduler.ColumnCheckInstance {
+		return &scheduler.ColumnCheckInstance{
+			AssetInstance: &scheduler.AssetInstance{Asset: asset, Pipeline: p},
+			Column:        &pipeline.Column{Name: column},
+			Check:         &pipeline.ColumnCheck{Value: value},
+		}
+	}
+
+	emailPattern := `^[^@]+@[^@]+\.[a-z]+$`
+	anyPattern := ".*"
+	require.EqualError(t,
+		(&PatternCheck{conn: conns}).Check(t.Context(), instance("email", pipeline.ColumnCheckValue{String: &emailPattern})),
+		`column email has 1 values that do not satisfy the pattern ^[^@]+@[^@]+\.[a-z]+$`,
+	)
+	require.NoError(t, (&PatternCheck{conn: conns}).Check(t.Context(), instance("email", pipeline.ColumnCheckValue{String: &anyPattern})))
+
+	accepted := []string{"active", "deleted"}
+	onlyActive := []string{"active"}
+	require.NoError(t, (&AcceptedValuesCheck{conn: conns}).Check(t.Context(), instance("status", pipeline.ColumnCheckValue{StringArray: &accepted})))
+	require.EqualError(t,
+		(&AcceptedValuesCheck{conn: conns}).Check(t.Context(), instance("status", pipeline.ColumnCheckValue{StringArray: &onlyActive})),
+		"column 'status' has 1 rows that are not in the accepted values",
+	)
+}
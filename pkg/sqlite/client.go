@@ -1,7 +1,20 @@
 package sqlite
 
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/bruin-data/bruin/pkg/query"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
 type Client struct {
 	config Config
+	conn   *sqlx.DB
+	mutex  sync.Mutex
 }
 
 func (c *Client) GetIngestrURI() (string, error) {
@@ -9,5 +22,163 @@ func (c *Client) GetIngestrURI() (string, error) {
 }
 
 func NewClient(c Config) (*Client, error) {
-	return &Client{c}, nil
+	// Don't open the database here, the file is only created once a query runs.
+	return &Client{config: c}, nil
+}
+
+func (c *Client) initializeDB(ctx context.Context) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.conn != nil {
+		return nil
+	}
+
+	conn, err := sqlx.ConnectContext(ctx, "sqlite", c.config.ToDBConnectionURI())
+	if err != nil {
+		return errors.Wrapf(err, "failed to open sqlite database '%s'", c.config.Path)
+	}
+
+	// SQLite allows a single writer, a single connection keeps the concurrent assets from
+	// failing with "database is locked" and keeps temporary tables visible across queries.
+	conn.SetMaxOpenConns(1)
+
+	c.conn = conn
+	return nil
+}
+
+// RunQueryWithoutResult runs the query, which can be a script of several statements. When a
+// statement of a `BEGIN TRANSACTION ... COMMIT` script fails, the transaction is rolled back on the
+// same connection so that the shared connection is not left inside it.
+func (c *Client) RunQueryWithoutResult(ctx context.Context, query *query.Query) error {
+	if err := c.initializeDB(ctx); err != nil {
+		return err
+	}
+
+	conn, err := c.conn.Connx(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to get a connection")
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, query.String()); err != nil {
+		// fails with "no transaction is active" when the script did not start one
+		_, _ = conn.ExecContext(context.WithoutCancel(ctx), "ROLLBACK")
+		return errors.Wrap(err, "failed to execute query")
+	}
+
+	return nil
+}
+
+func (c *Client) Select(ctx context.Context, query *query.Query) ([][]interface{}, error) {
+	result, err := c.SelectWithSchema(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Rows, nil
+}
+
+func (c *Client) SelectWithSchema(ctx context.Context, queryObj *query.Query) (*query.QueryResult, error) {
+	if err := c.initializeDB(ctx); err != nil {
+		return nil, err
+	}
+
+	rows, err := c.conn.QueryContext(ctx, queryObj.String())
+	if err != nil {
+		return nil, errors.New(strings.ReplaceAll(err.Error(), "\n", "  -  "))
+	}
+	defer rows.Close()
+
+	cols, err := rows.Columns()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to retrieve column names")
+	}
+
+	columnTypes, err := rows.ColumnTypes()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to retrieve column types")
+	}
+
+	result := &query.QueryResult{
+		Columns:     cols,
+		ColumnTypes: make([]string, len(columnTypes)),
+		Rows:        [][]interface{}{},
+	}
+	for i, columnType := range columnTypes {
+		result.ColumnTypes[i] = columnType.DatabaseTypeName()
+	}
+
+	for rows.Next() {
+		row := make([]interface{}, len(cols))
+		columnPointers := make([]interface{}, len(cols))
+		for i := range row {
+			columnPointers[i] = &row[i]
+		}
+
+		if err := rows.Scan(columnPointers...); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+
+		for i, v := range row {
+			if b, ok := v.([]byte); ok {
+				row[i] = string(b)
+			}
+		}
+
+		result.Rows = append(result.Rows, row)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error occurred during row iteration: %w", err)
+	}
+
+	return result, nil
+}
+
+func (c *Client) Ping(ctx context.Context) error {
+	if err := c.RunQueryWithoutResult(ctx, &query.Query{Query: "SELECT 1"}); err != nil {
+		return errors.Wrap(err, "failed to run test query on SQLite connection")
+	}
+
+	return nil
+}
+
+// GetTables returns the tables and views of the given schema, which is `main` for the database file
+// itself or the name of an attached database.
+func (c *Client) GetTables(ctx context.Context, schemaName string) ([]string, error) {
+	if schemaName == "" {
+		schemaName = "main"
+	}
+
+	q := fmt.Sprintf(
+		"SELECT name FROM %s.sqlite_master WHERE type IN ('table', 'view') AND name NOT LIKE 'sqlite_%%' ORDER BY name",
+		schemaName,
+	)
+	rows, err := c.Select(ctx, &query.Query{Query: q})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list the tables of schema '%s'", schemaName)
+	}
+
+	tables := make([]string, 0, len(rows))
+	for _, row := range rows {
+		if len(row) > 0 {
+			tables = append(tables, fmt.Sprint(row[0]))
+		}
+	}
+
+	return tables, nil
+}
+
+func (c *Client) Close() error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.conn == nil {
+		return nil
+	}
+
+	err := c.conn.Close()
+	c.conn = nil
+	return err
 }
@@ -0,0 +1,43 @@
+package sqlite
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/bruin-data/bruin/pkg/query"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_RunQueryWithoutResult_RollsBackFailedScripts(t *testing.T) {
+	t.Parallel()
+
+	client, err := NewClient(Config{Path: filepath.Join(t.TempDir(), "rollback.db")})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = client.Close() })
+
+	ctx := t.Context()
+	require.NoError(t, client.RunQueryWithoutResult(ctx, &query.Query{Query: "CREATE TABLE orders AS SELECT 1 AS id"}))
+
+	asset := &pipeline.Asset{Name: "orders"}
+
+	// the table is dropped before the failing statement, within the transaction
+	failing, err := buildCreateReplaceQuery(asset, "SELECT id FROM missing_table")
+	require.NoError(t, err)
+	err = client.RunQueryWithoutResult(ctx, &query.Query{Query: failing})
+	require.ErrorContains(t, err, "missing_table")
+
+	rows, err := client.Select(ctx, &query.Query{Query: "SELECT id FROM orders"})
+	require.NoError(t, err)
+	assert.Equal(t, [][]interface{}{{int64(1)}}, rows)
+
+	// the connection is not left inside the failed transaction
+	succeeding, err := buildCreateReplaceQuery(asset, "SELECT 2 AS id")
+	require.NoError(t, err)
+	require.NoError(t, client.RunQueryWithoutResult(ctx, &query.Query{Query: succeeding}))
+
+	rows, err = client.Select(ctx, &query.Query{Query: "SELECT id FROM orders"})
+	require.NoError(t, err)
+	assert.Equal(t, [][]interface{}{{int64(2)}}, rows)
+}
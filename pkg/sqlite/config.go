@@ -7,3 +7,9 @@ type Config struct {
 func (c *Config) GetIngestrURI() string {
 	return "sqlite:///" + c.Path
 }
+
+// ToDBConnectionURI returns the data source name for the Go driver. Foreign keys are enforced
+// and writers wait for the lock instead of failing right away when another process holds it.
+func (c *Config) ToDBConnectionURI() string {
+	return "file:" + c.Path + "?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)"
+}
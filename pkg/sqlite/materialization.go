@@ -0,0 +1,236 @@
+package sqlite
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/bruin-data/bruin/pkg/ansisql"
+	"github.com/bruin-data/bruin/pkg/helpers"
+	"github.com/bruin-data/bruin/pkg/pipeline"
+)
+
+func NewMaterializer(fullRefresh bool) *pipeline.Materializer {
+	return &pipeline.Materializer{
+		MaterializationMap: matMap,
+		FullRefresh:        fullRefresh,
+	}
+}
+
+var matMap = pipeline.AssetMaterializationMap{
+	pipeline.MaterializationTypeView: {
+		pipeline.MaterializationStrategyNone:          viewMaterializer,
+		pipeline.MaterializationStrategyAppend:        errorMaterializer,
+		pipeline.MaterializationStrategyCreateReplace: errorMaterializer,
+		pipeline.MaterializationStrategyDeleteInsert:  errorMaterializer,
+		pipeline.MaterializationStrategyMerge:         errorMaterializer,
+		pipeline.MaterializationStrategyDDL:           errorMaterializer,
+	},
+	pipeline.MaterializationTypeTable: {
+		pipeline.MaterializationStrategyNone:           buildCreateReplaceQuery,
+		pipeline.MaterializationStrategyAppend:         buildAppendQuery,
+		pipeline.MaterializationStrategyCreateReplace:  buildCreateReplaceQuery,
+		pipeline.MaterializationStrategyDeleteInsert:   buildIncrementalQuery,
+		pipeline.MaterializationStrategyTruncateInsert: buildTruncateInsertQuery,
+		pipeline.MaterializationStrategyMerge:          buildMergeQuery,
+		pipeline.MaterializationStrategyTimeInterval:   buildTimeIntervalQuery,
+		pipeline.MaterializationStrategyDDL:            buildDDLQuery,
+	},
+}
+
+func errorMaterializer(asset *pipeline.Asset, _ string) (string, error) {
+	return "", fmt.Errorf(
+		"materialization strategy %s is not supported for materialization type %s and asset type %s",
+		asset.Materialization.Strategy,
+		asset.Materialization.Type,
+		asset.Type,
+	)
+}
+
+func trimQuery(query string) string {
+	return strings.TrimSuffix(strings.TrimSpace(query), ";")
+}
+
+// SQLite has no `CREATE OR REPLACE`, the existing objects are dropped first.
+func viewMaterializer(asset *pipeline.Asset, query string) (string, error) {
+	return fmt.Sprintf("DROP VIEW IF EXISTS %s;\nCREATE VIEW %s AS\n%s;", asset.Name, asset.Name, trimQuery(query)), nil
+}
+
+func buildCreateReplaceQuery(asset *pipeline.Asset, query string) (string, error) {
+	queries := []string{
+		"BEGIN TRANSACTION",
+		"DROP TABLE IF EXISTS " + asset.Name,
+		fmt.Sprintf("CREATE TABLE %s AS\n%s", asset.Name, trimQuery(query)),
+		"COMMIT",
+	}
+
+	return strings.Join(queries, ";\n") + ";", nil
+}
+
+func buildAppendQuery(asset *pipeline.Asset, query string) (string, error) {
+	return fmt.Sprintf("INSERT INTO %s %s;", asset.Name, trimQuery(query)), nil
+}
+
+func buildIncrementalQuery(asset *pipeline.Asset, query string) (string, error) {
+	mat := asset.Materialization
+	if mat.IncrementalKey == "" {
+		return "", fmt.Errorf("materialization strategy %s requires the `incremental_key` field to be set", mat.Strategy)
+	}
+
+	tempTableName := "__bruin_tmp_" + helpers.PrefixGenerator()
+
+	queries := []string{
+		"BEGIN TRANSACTION",
+		fmt.Sprintf("CREATE TEMP TABLE %s AS %s", tempTableName, trimQuery(query)),
+		fmt.Sprintf("DELETE FROM %s WHERE %s IN (SELECT DISTINCT %s FROM %s)", asset.Name, mat.IncrementalKey, mat.IncrementalKey, tempTableName),
+		fmt.Sprintf("INSERT INTO %s SELECT * FROM %s", asset.Name, tempTableName),
+		"DROP TABLE " + tempTableName,
+		"COMMIT",
+	}
+
+	return strings.Join(queries, ";\n") + ";", nil
+}
+
+// SQLite has no TRUNCATE, an unqualified DELETE is optimized into one.
+func buildTruncateInsertQuery(asset *pipeline.Asset, query string) (string, error) {
+	queries := []string{
+		"BEGIN TRANSACTION",
+		"DELETE FROM " + asset.Name,
+		fmt.Sprintf("INSERT INTO %s %s", asset.Name, trimQuery(query)),
+		"COMMIT",
+	}
+
+	return strings.Join(queries, ";\n") + ";", nil
+}
+
+func buildTimeIntervalQuery(asset *pipeline.Asset, query string) (string, error) {
+	if asset.Materialization.IncrementalKey == "" {
+		return "", errors.New("incremental_key is required for time_interval strategy")
+	}
+
+	if asset.Materialization.TimeGranularity == "" {
+		return "", errors.New("time_granularity is required for time_interval strategy")
+	}
+
+	if asset.Materialization.TimeGranularity != pipeline.MaterializationTimeGranularityTimestamp &&
+		asset.Materialization.TimeGranularity != pipeline.MaterializationTimeGranularityDate {
+		return "", errors.New("time_granularity must be either 'date' or 'timestamp'")
+	}
+
+	startVar := "{{start_timestamp}}"
+	endVar := "{{end_timestamp}}"
+	if asset.Materialization.TimeGranularity == pipeline.MaterializationTimeGranularityDate {
+		startVar = "{{start_date}}"
+		endVar = "{{end_date}}"
+	}
+
+	queries := []string{
+		"BEGIN TRANSACTION",
+		fmt.Sprintf("DELETE FROM %s WHERE %s BETWEEN '%s' AND '%s'", asset.Name, asset.Materialization.IncrementalKey, startVar, endVar),
+		fmt.Sprintf("INSERT INTO %s %s", asset.Name, trimQuery(query)),
+		"COMMIT",
+	}
+
+	return strings.Join(queries, ";\n") + ";", nil
+}
+
+// buildMergeQuery updates the matching rows through `UPDATE ... FROM` and inserts the rest, which
+// unlike `INSERT ... ON CONFLICT` does not require a unique index on the primary key columns.
+func buildMergeQuery(asset *pipeline.Asset, query string) (string, error) {
+	if len(asset.Columns) == 0 {
+		return "", fmt.Errorf("materialization strategy %s requires the `columns` field to be set", asset.Materialization.Strategy)
+	}
+
+	primaryKeys := asset.ColumnNamesWithPrimaryKey()
+	if len(primaryKeys) == 0 {
+		return "", fmt.Errorf("materialization strategy %s requires the `primary_key` field to be set on at least one column", asset.Materialization.Strategy)
+	}
+
+	columnNames := asset.ColumnNames()
+	selectColumns := make([]string, 0, len(columnNames))
+	for _, col := range columnNames {
+		selectColumns = append(selectColumns, "source."+col)
+	}
+
+	tempTableName := "__bruin_merge_tmp_" + helpers.PrefixGenerator()
+	onClause := strings.Join(ansisql.AddIncrementalPredicate([]string{buildJoinConditions(primaryKeys, "target", "source")}, asset.Materialization.IncrementalPredicate), " AND ")
+
+	queries := []string{
+		"BEGIN TRANSACTION",
+		fmt.Sprintf("CREATE TEMP TABLE %s AS\n%s", tempTableName, trimQuery(query)),
+	}
+
+	if mergeColumns := ansisql.GetColumnsWithMergeLogic(asset); len(mergeColumns) > 0 {
+		assignments := make([]string, 0, len(mergeColumns))
+		for _, col := range mergeColumns {
+			expr := "source." + col.Name
+			if col.MergeSQL != "" {
+				expr = col.MergeSQL
+			}
+			assignments = append(assignments, fmt.Sprintf("%s = %s", col.Name, expr))
+		}
+
+		queries = append(queries, fmt.Sprintf(
+			"UPDATE %s AS target SET %s\nFROM %s AS source\nWHERE %s",
+			asset.Name,
+			strings.Join(assignments, ", "),
+			tempTableName,
+			onClause,
+		))
+	}
+
+	queries = append(queries,
+		fmt.Sprintf(
+			"INSERT INTO %s (%s)\nSELECT %s\nFROM %s AS source\nWHERE NOT EXISTS (SELECT 1 FROM %s AS target WHERE %s)",
+			asset.Name,
+			strings.Join(columnNames, ", "),
+			strings.Join(selectColumns, ", "),
+			tempTableName,
+			asset.Name,
+			onClause,
+		),
+		"DROP TABLE "+tempTableName,
+		"COMMIT",
+	)
+
+	return strings.Join(queries, ";\n") + ";", nil
+}
+
+func buildJoinConditions(keys []string, leftAlias, rightAlias string) string {
+	conditions := make([]string, len(keys))
+	for i, key := range keys {
+		conditions[i] = fmt.Sprintf("%s.%s = %s.%s", leftAlias, key, rightAlias, key)
+	}
+	return strings.Join(conditions, " AND ")
+}
+
+func buildDDLQuery(asset *pipeline.Asset, _ string) (string, error) {
+	if len(asset.Columns) == 0 {
+		return "", errors.New("DDL strategy requires `columns` to be specified")
+	}
+
+	columnDefs := make([]string, 0, len(asset.Columns))
+	primaryKeys := make([]string, 0)
+	for _, col := range asset.Columns {
+		if col.PrimaryKey {
+			primaryKeys = append(primaryKeys, col.Name)
+		}
+
+		definition := strings.TrimSpace(fmt.Sprintf("%s %s", col.Name, col.Type))
+		if col.Nullable.Value != nil && !*col.Nullable.Value {
+			definition += " NOT NULL"
+		}
+
+		columnDefs = append(columnDefs, definition)
+	}
+
+	if len(primaryKeys) > 0 {
+		columnDefs = append(columnDefs, fmt.Sprintf("PRIMARY KEY (%s)", strings.Join(primaryKeys, ", ")))
+	}
+
+	return fmt.Sprintf(
+		"CREATE TABLE IF NOT EXISTS %s (\n%s\n);",
+		asset.Name,
+		strings.Join(columnDefs, ",\n"),
+	), nil
+}
@@ -0,0 +1,255 @@
+package sqlite
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/bruin-data/bruin/pkg/query"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaterializer_Render(t *testing.T) {
+	t.Parallel()
+
+	falsePtr := func() *bool {
+		v := false
+		return &v
+	}()
+
+	tests := []struct {
+		name        string
+		asset       *pipeline.Asset
+		query       string
+		fullRefresh bool
+		want        string
+		wantErr     string
+	}{
+		{
+			name: "returns raw query when materialization disabled",
+			asset: &pipeline.Asset{
+				Materialization: pipeline.Materialization{Type: pipeline.MaterializationTypeNone},
+			},
+			query: "SELECT 1",
+			want:  "SELECT 1",
+		},
+		{
+			name: "view is dropped and recreated",
+			asset: &pipeline.Asset{
+				Name:            "daily_orders",
+				Materialization: pipeline.Materialization{Type: pipeline.MaterializationTypeView},
+			},
+			query: "SELECT 1;",
+			want:  "DROP VIEW IF EXISTS daily_orders;\nCREATE VIEW daily_orders AS\nSELECT 1;",
+		},
+		{
+			name: "table defaults to create replace",
+			asset: &pipeline.Asset{
+				Name:            "orders",
+				Materialization: pipeline.Materialization{Type: pipeline.MaterializationTypeTable},
+			},
+			query: "SELECT * FROM source",
+			want: "BEGIN TRANSACTION;\n" +
+				"DROP TABLE IF EXISTS orders;\n" +
+				"CREATE TABLE orders AS\nSELECT * FROM source;\n" +
+				"COMMIT;",
+		},
+		{
+			name: "full refresh overrides strategy",
+			asset: &pipeline.Asset{
+				Name: "orders",
+				Materialization: pipeline.Materialization{
+					Type:     pipeline.MaterializationTypeTable,
+					Strategy: pipeline.MaterializationStrategyAppend,
+				},
+			},
+			query:       "SELECT * FROM source",
+			fullRefresh: true,
+			want: "BEGIN TRANSACTION;\n" +
+				"DROP TABLE IF EXISTS orders;\n" +
+				"CREATE TABLE orders AS\nSELECT * FROM source;\n" +
+				"COMMIT;",
+		},
+		{
+			name: "append emits insert",
+			asset: &pipeline.Asset{
+				Name: "orders",
+				Materialization: pipeline.Materialization{
+					Type:     pipeline.MaterializationTypeTable,
+					Strategy: pipeline.MaterializationStrategyAppend,
+				},
+			},
+			query: "SELECT * FROM source;",
+			want:  "INSERT INTO orders SELECT * FROM source;",
+		},
+		{
+			name: "truncate insert deletes all rows",
+			asset: &pipeline.Asset{
+				Name: "orders",
+				Materialization: pipeline.Materialization{
+					Type:     pipeline.MaterializationTypeTable,
+					Strategy: pipeline.MaterializationStrategyTruncateInsert,
+				},
+			},
+			query: "SELECT * FROM source",
+			want: "BEGIN TRANSACTION;\n" +
+				"DELETE FROM orders;\n" +
+				"INSERT INTO orders SELECT * FROM source;\n" +
+				"COMMIT;",
+		},
+		{
+			name: "time interval deletes the interval",
+			asset: &pipeline.Asset{
+				Name: "orders",
+				Materialization: pipeline.Materialization{
+					Type:            pipeline.MaterializationTypeTable,
+					Strategy:        pipeline.MaterializationStrategyTimeInterval,
+					IncrementalKey:  "dt",
+					TimeGranularity: pipeline.MaterializationTimeGranularityDate,
+				},
+			},
+			query: "SELECT * FROM source",
+			want: "BEGIN TRANSACTION;\n" +
+				"DELETE FROM orders WHERE dt BETWEEN '{{start_date}}' AND '{{end_date}}';\n" +
+				"INSERT INTO orders SELECT * FROM source;\n" +
+				"COMMIT;",
+		},
+		{
+			name: "ddl creates the table from the columns",
+			asset: &pipeline.Asset{
+				Name: "orders",
+				Materialization: pipeline.Materialization{
+					Type:     pipeline.MaterializationTypeTable,
+					Strategy: pipeline.MaterializationStrategyDDL,
+				},
+				Columns: []pipeline.Column{
+					{Name: "id", Type: "INTEGER", PrimaryKey: true},
+					{Name: "status", Type: "TEXT", Nullable: pipeline.DefaultTrueBool{Value: falsePtr}},
+				},
+			},
+			want: "CREATE TABLE IF NOT EXISTS orders (\nid INTEGER,\nstatus TEXT NOT NULL,\nPRIMARY KEY (id)\n);",
+		},
+		{
+			name: "delete insert requires an incremental key",
+			asset: &pipeline.Asset{
+				Name: "orders",
+				Materialization: pipeline.Materialization{
+					Type:     pipeline.MaterializationTypeTable,
+					Strategy: pipeline.MaterializationStrategyDeleteInsert,
+				},
+			},
+			wantErr: "materialization strategy delete+insert requires the `incremental_key` field to be set",
+		},
+		{
+			name: "merge requires a primary key",
+			asset: &pipeline.Asset{
+				Name: "orders",
+				Materialization: pipeline.Materialization{
+					Type:     pipeline.MaterializationTypeTable,
+					Strategy: pipeline.MaterializationStrategyMerge,
+				},
+				Columns: []pipeline.Column{{Name: "id"}},
+			},
+			wantErr: "materialization strategy merge requires the `primary_key` field to be set on at least one column",
+		},
+		{
+			name: "scd2 is not supported",
+			asset: &pipeline.Asset{
+				Name: "orders",
+				Materialization: pipeline.Materialization{
+					Type:     pipeline.MaterializationTypeTable,
+					Strategy: pipeline.MaterializationStrategySCD2ByColumn,
+				},
+			},
+			wantErr: "unsupported materialization type - strategy combination",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := NewMaterializer(tt.fullRefresh).Render(tt.asset, tt.query)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestMaterializer_RunsOnSQLite(t *testing.T) {
+	t.Parallel()
+
+	orders := func(strategy pipeline.MaterializationStrategy) *pipeline.Asset {
+		return &pipeline.Asset{
+			Name: "orders",
+			Materialization: pipeline.Materialization{
+				Type:           pipeline.MaterializationTypeTable,
+				Strategy:       strategy,
+				IncrementalKey: "dt",
+			},
+			Columns: []pipeline.Column{
+				{Name: "id", PrimaryKey: true},
+				{Name: "dt"},
+				{Name: "amount", UpdateOnMerge: true},
+			},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		strategy pipeline.MaterializationStrategy
+		want     [][]interface{}
+	}{
+		{
+			name:     "create replace",
+			strategy: pipeline.MaterializationStrategyCreateReplace,
+			want:     [][]interface{}{{int64(2), "2024-01-02", int64(25)}, {int64(3), "2024-01-02", int64(30)}},
+		},
+		{
+			name:     "append",
+			strategy: pipeline.MaterializationStrategyAppend,
+			want: [][]interface{}{
+				{int64(1), "2024-01-01", int64(10)},
+				{int64(2), "2024-01-02", int64(20)},
+				{int64(2), "2024-01-02", int64(25)},
+				{int64(3), "2024-01-02", int64(30)},
+			},
+		},
+		{
+			name:     "delete insert",
+			strategy: pipeline.MaterializationStrategyDeleteInsert,
+			want:     [][]interface{}{{int64(1), "2024-01-01", int64(10)}, {int64(2), "2024-01-02", int64(25)}, {int64(3), "2024-01-02", int64(30)}},
+		},
+		{
+			name:     "merge",
+			strategy: pipeline.MaterializationStrategyMerge,
+			want:     [][]interface{}{{int64(1), "2024-01-01", int64(10)}, {int64(2), "2024-01-02", int64(25)}, {int64(3), "2024-01-02", int64(30)}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			client, err := NewClient(Config{Path: filepath.Join(t.TempDir(), "test.db")})
+			require.NoError(t, err)
+			t.Cleanup(func() { _ = client.Close() })
+
+			require.NoError(t, client.RunQueryWithoutResult(t.Context(), &query.Query{Query: "CREATE TABLE orders AS SELECT 1 AS id, '2024-01-01' AS dt, 10 AS amount UNION ALL SELECT 2, '2024-01-02', 20"}))
+
+			rendered, err := NewMaterializer(false).Render(orders(tt.strategy), "SELECT 2 AS id, '2024-01-02' AS dt, 25 AS amount UNION ALL SELECT 3, '2024-01-02', 30")
+			require.NoError(t, err)
+			require.NoError(t, client.RunQueryWithoutResult(t.Context(), &query.Query{Query: rendered}))
+
+			rows, err := client.Select(t.Context(), &query.Query{Query: "SELECT id, dt, amount FROM orders ORDER BY id, amount"})
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, rows)
+		})
+	}
+}
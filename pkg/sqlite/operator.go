@@ -0,0 +1,120 @@
+package sqlite
+
+import (
+	"context"
+
+	"github.com/bruin-data/bruin/pkg/ansisql"
+	"github.com/bruin-data/bruin/pkg/config"
+	"github.com/bruin-data/bruin/pkg/executor"
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/bruin-data/bruin/pkg/query"
+	"github.com/bruin-data/bruin/pkg/scheduler"
+	"github.com/pkg/errors"
+)
+
+type materializer interface {
+	Render(task *pipeline.Asset, query string) (string, error)
+	LogIfFullRefreshAndDDL(writer interface{}, asset *pipeline.Asset) error
+}
+
+type SQLiteClient interface {
+	RunQueryWithoutResult(ctx context.Context, query *query.Query) error
+	Select(ctx context.Context, query *query.Query) ([][]interface{}, error)
+	SelectWithSchema(ctx context.Context, queryObj *query.Query) (*query.QueryResult, error)
+	Ping(ctx context.Context) error
+}
+
+type BasicOperator struct {
+	connection   config.ConnectionGetter
+	extractor    query.QueryExtractor
+	materializer materializer
+}
+
+func NewBasicOperator(conn config.ConnectionGetter, extractor query.QueryExtractor, materializer materializer) *BasicOperator {
+	return &BasicOperator{
+		connection:   conn,
+		extractor:    extractor,
+		materializer: materializer,
+	}
+}
+
+func (o BasicOperator) Run(ctx context.Context, ti scheduler.TaskInstance) error {
+	return o.RunTask(ctx, ti.GetPipeline(), ti.GetAsset())
+}
+
+func (o BasicOperator) RunTask(ctx context.Context, p *pipeline.Pipeline, asset *pipeline.Asset) error {
+	extractor, err := o.extractor.CloneForAsset(ctx, p, asset)
+	if err != nil {
+		return errors.Wrapf(err, "failed to clone extractor for asset %s", asset.Name)
+	}
+
+	queries, err := extractor.ExtractQueriesFromString(asset.ExecutableFile.Content)
+	if err != nil {
+		return errors.Wrap(err, "cannot extract queries from the task file")
+	}
+
+	if len(queries) == 0 {
+		return nil
+	}
+
+	if len(queries) > 1 && asset.Materialization.Type != pipeline.MaterializationTypeNone {
+		return errors.New("SQLite operator can only handle a single query when materialization is enabled")
+	}
+
+	q := queries[0]
+	materialized, err := o.materializer.Render(asset, q.String())
+	if err != nil {
+		return err
+	}
+
+	writer := ctx.Value(executor.KeyPrinter)
+	if err := o.materializer.LogIfFullRefreshAndDDL(writer, asset); err != nil {
+		return err
+	}
+
+	q.Query = materialized
+
+	if asset.Materialization.Strategy == pipeline.MaterializationStrategyTimeInterval {
+		renderedQueries, err := extractor.ExtractQueriesFromString(materialized)
+		if err != nil {
+			return errors.Wrap(err, "cannot re-extract rendered query for time_interval strategy")
+		}
+		if len(renderedQueries) == 0 {
+			return errors.New("rendered queries unexpectedly empty")
+		}
+		q.Query = renderedQueries[0].Query
+	}
+
+	connName, err := p.GetConnectionNameForAsset(asset)
+	if err != nil {
+		return err
+	}
+
+	rawConn := o.connection.GetConnection(connName)
+	if rawConn == nil {
+		return config.NewConnectionNotFoundError(ctx, "", connName)
+	}
+
+	conn, ok := rawConn.(SQLiteClient)
+	if !ok {
+		return errors.Errorf("connection '%s' is not a sqlite connection", connName)
+	}
+
+	ansisql.LogQueryIfVerbose(ctx, writer, q.Query)
+	return conn.RunQueryWithoutResult(ctx, q)
+}
+
+func NewColumnCheckOperator(manager config.ConnectionGetter) *ansisql.ColumnCheckOperator {
+	return ansisql.NewColumnCheckOperator(map[string]ansisql.CheckRunner{
+		"not_null":        ansisql.NewNotNullCheck(manager),
+		"unique":          ansisql.NewUniqueCheck(manager),
+		"relationships":   ansisql.NewRelationshipsCheck(manager, ansisql.QuoteIdentifierWithDoubleQuotes),
+		"positive":        ansisql.NewPositiveCheck(manager),
+		"non_negative":    ansisql.NewNonNegativeCheck(manager),
+		"negative":        ansisql.NewNegativeCheck(manager),
+		"min":             ansisql.NewMinCheck(manager),
+		"max":             ansisql.NewMaxCheck(manager),
+		"accepted_values": &AcceptedValuesCheck{conn: manager},
+		"pattern":         &PatternCheck{conn: manager},
+	})
+}
@@ -0,0 +1,100 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bruin-data/bruin/pkg/ansisql"
+	"github.com/bruin-data/bruin/pkg/seed"
+	"github.com/pkg/errors"
+)
+
+// LoadSeed replaces the table with the seed rows in a single transaction, the rows are
+// inserted through a prepared statement.
+func (c *Client) LoadSeed(ctx context.Context, table *seed.Table, rows *seed.Rows) error {
+	if err := c.initializeDB(ctx); err != nil {
+		return err
+	}
+
+	tx, err := c.conn.BeginTxx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to start a transaction")
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	tableName := ansisql.QuoteIdentifierWithDoubleQuotes(table.Name)
+	if _, err := tx.ExecContext(ctx, "DROP TABLE IF EXISTS "+tableName); err != nil {
+		return errors.Wrapf(err, "failed to drop table %s", table.Name)
+	}
+
+	createQuery := fmt.Sprintf("CREATE TABLE %s (\n%s\n)", tableName, table.ColumnDefinitions(ansisql.QuoteIdentifierWithDoubleQuotes, seedColumnType))
+	if _, err := tx.ExecContext(ctx, createQuery); err != nil {
+		return errors.Wrapf(err, "failed to create table %s", table.Name)
+	}
+
+	columns := make([]string, len(table.Columns))
+	for i, name := range table.ColumnNames() {
+		columns[i] = ansisql.QuoteIdentifierWithDoubleQuotes(name)
+	}
+	insertQuery := fmt.Sprintf(
+		"INSERT INTO %s (%s) VALUES (%s)",
+		tableName,
+		strings.Join(columns, ", "),
+		strings.TrimSuffix(strings.Repeat("?, ", len(columns)), ", "),
+	)
+	stmt, err := tx.PreparexContext(ctx, insertQuery)
+	if err != nil {
+		return errors.Wrap(err, "failed to prepare the insert statement")
+	}
+	defer stmt.Close()
+
+	for rows.Next() {
+		values, _ := rows.Values()
+		for i, v := range values {
+			// Keep dates and timestamps in the text format SQLite's date functions read.
+			if t, ok := v.(time.Time); ok {
+				if table.Columns[i].Kind == seed.KindDate {
+					values[i] = t.Format("2006-01-02")
+				} else {
+					values[i] = t.UTC().Format("2006-01-02 15:04:05.999999")
+				}
+			}
+		}
+
+		if _, err := stmt.ExecContext(ctx, values...); err != nil {
+			return errors.Wrapf(err, "failed to insert into table %s", table.Name)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	return errors.Wrap(tx.Commit(), "failed to commit the seed")
+}
+
+func seedColumnType(col seed.Column) string {
+	var typ string
+	switch col.Kind {
+	case seed.KindInteger:
+		typ = "INTEGER"
+	case seed.KindFloat:
+		typ = "REAL"
+	case seed.KindDecimal:
+		typ = "NUMERIC"
+	case seed.KindBoolean:
+		typ = "BOOLEAN"
+	case seed.KindDate:
+		typ = "DATE"
+	case seed.KindTimestamp:
+		typ = "TIMESTAMP"
+	default:
+		typ = "TEXT"
+	}
+
+	if col.NotNull {
+		typ += " NOT NULL"
+	}
+	return typ
+}
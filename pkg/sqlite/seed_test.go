@@ -0,0 +1,53 @@
+package sqlite
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bruin-data/bruin/pkg/query"
+	"github.com/bruin-data/bruin/pkg/seed"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_LoadSeed(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	client, err := NewClient(Config{Path: filepath.Join(dir, "seed.db")})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = client.Close() })
+
+	require.NoError(t, client.RunQueryWithoutResult(t.Context(), &query.Query{Query: "CREATE TABLE countries AS SELECT 'old' AS name"}))
+
+	load := func(content string, declared map[string]seed.Column) error {
+		path := filepath.Join(dir, "countries.csv")
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+		table, err := seed.Describe(path, "countries", declared, nil)
+		require.NoError(t, err)
+
+		rows, err := seed.Open(path, table)
+		require.NoError(t, err)
+		defer rows.Close()
+
+		return client.LoadSeed(t.Context(), table, rows)
+	}
+
+	require.NoError(t, load("id,name,joined_on,created_at,active\n1,Turkey,2024-01-31,2024-01-31T10:00:00+02:00,true\n2,,2024-02-01,2024-02-01 00:00:00,false\n", nil))
+
+	rows, err := client.Select(t.Context(), &query.Query{Query: "SELECT id, name, CAST(joined_on AS TEXT), CAST(created_at AS TEXT), active FROM countries ORDER BY id"})
+	require.NoError(t, err)
+	assert.Equal(t, [][]interface{}{
+		{int64(1), "Turkey", "2024-01-31", "2024-01-31 08:00:00", int64(1)},
+		{int64(2), nil, "2024-02-01", "2024-02-01 00:00:00", int64(0)},
+	}, rows)
+
+	// A row that fails validation rolls the whole load back.
+	require.ErrorContains(t, load("id,name,joined_on,created_at,active\n1,Turkey,2024-01-31,2024-01-31,maybe\n", map[string]seed.Column{"active": {Kind: seed.KindBoolean}}), "value 'maybe' of column 'active' is not a valid boolean")
+
+	rows, err = client.Select(t.Context(), &query.Query{Query: "SELECT COUNT(*) FROM countries"})
+	require.NoError(t, err)
+	assert.Equal(t, [][]interface{}{{int64(2)}}, rows)
+}
@@ -360,10 +360,12 @@ var assetTypeDialectMap = map[pipeline.AssetType]string{
 	pipeline.AssetTypeSynapseQuery:      "tsql",
 	pipeline.AssetTypeDuckDBQuery:       "duckdb",
 	pipeline.AssetTypeMotherduckQuery:   "duckdb",
+	pipeline.AssetTypeIcebergQuery:      "duckdb",
 	pipeline.AssetTypeOracleQuery:       "oracle",
 	pipeline.AssetTypeFabricQuery:       "fabric",
 	pipeline.AssetTypeFabricQueryLegacy: "fabric",
 	pipeline.AssetTypeVerticaQuery:      "postgres",
+	pipeline.AssetTypeSQLiteQuery:       "sqlite",
 }
 
 func AssetTypeToDialect(assetType pipeline.AssetType) (string, error) {
@@ -402,6 +404,7 @@ var connectionTypeDialectMap = map[string]string{
 	"oracle":                "oracle",
 	"fabric":                "fabric",
 	"vertica":               "postgres",
+	"sqlite":                "sqlite",
 }
 
 // ConnectionTypeToDialect maps a connection type identifier (e.g. "clickhouse")
@@ -477,6 +480,153 @@ func (s *SQLParser) PrependCTEs(sql string, dialect string, ctes []CTE) (string,
 	})
 }
 
+// Transpiled is a query, and the column types of its asset, translated to another dialect.
+// Unsupported lists the constructs that could not be translated faithfully and need a manual look.
+type Transpiled struct {
+	Query       string   `json:"query"`
+	ColumnTypes []string `json:"column_types"`
+	Unsupported []string `json:"unsupported"`
+}
+
+// Transpile translates a query and a list of column types from one dialect to another. The
+// statements of the query are pretty-printed in the target dialect, the column types are
+// returned in the same order they are given.
+func (s *SQLParser) Transpile(sql, sourceDialect, targetDialect string, columnTypes []string) (*Transpiled, error) {
+	err := s.Start()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to start sql parser")
+	}
+
+	if columnTypes == nil {
+		columnTypes = []string{}
+	}
+	command := parserCommand{
+		Command: "transpile",
+		Contents: map[string]interface{}{
+			"query":        sql,
+			"read":         sourceDialect,
+			"write":        targetDialect,
+			"column_types": columnTypes,
+		},
+	}
+
+	responsePayload, err := s.sendCommand(&command)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to send command")
+	}
+
+	var resp struct {
+		Transpiled
+		Error string `json:"error"`
+	}
+	err = json.Unmarshal([]byte(responsePayload), &resp)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal response")
+	}
+
+	if resp.Error != "" {
+		return nil, errors.New(resp.Error)
+	}
+
+	return &resp.Transpiled, nil
+}
+
+// sendFindCommand runs one of the parser commands that find patterns in a query and decodes the
+// response into the given value. It is the shared body for the checks the SQL policy rules use.
+func (s *SQLParser) sendFindCommand(command, sql, dialect string, result interface{}) error {
+	if err := s.Start(); err != nil {
+		return errors.Wrap(err, "failed to start sql parser")
+	}
+
+	responsePayload, err := s.sendCommand(&parserCommand{
+		Command: command,
+		Contents: map[string]interface{}{
+			"query":   sql,
+			"dialect": dialect,
+		},
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to send command")
+	}
+
+	var resp struct {
+		Error string `json:"error"`
+	}
+	if err := json.Unmarshal([]byte(responsePayload), &resp); err != nil {
+		return errors.Wrap(err, "failed to unmarshal response")
+	}
+	if resp.Error != "" {
+		return errors.New(resp.Error)
+	}
+
+	return errors.Wrap(json.Unmarshal([]byte(responsePayload), result), "failed to unmarshal response")
+}
+
+// SelectsStar reports whether the final projection of the query selects `*` or `table.*`.
+// Projections that list the excluded, replaced or renamed columns are not reported.
+func (s *SQLParser) SelectsStar(sql, dialect string) (bool, error) {
+	var resp struct {
+		SelectStar bool `json:"select_star"`
+	}
+	if err := s.sendFindCommand("find-select-star", sql, dialect, &resp); err != nil {
+		return false, err
+	}
+	return resp.SelectStar, nil
+}
+
+// UnconditionedJoins returns the tables the query joins with a comma or without a join condition.
+// Explicit CROSS JOINs and the joins with UNNEST, LATERAL and table functions are not reported.
+func (s *SQLParser) UnconditionedJoins(sql, dialect string) ([]string, error) {
+	var resp struct {
+		Joins []string `json:"joins"`
+	}
+	if err := s.sendFindCommand("find-unconditioned-joins", sql, dialect, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Joins, nil
+}
+
+// UnboundedOrderings describes the orderings of a query that are not limited.
+type UnboundedOrderings struct {
+	// Final is true when the final query is ordered without a limit.
+	Final bool `json:"final"`
+	// CTEs lists the CTEs that order their rows without a limit.
+	CTEs []string `json:"ctes"`
+}
+
+// UnboundedOrderings finds the ORDER BY clauses of the query that have no LIMIT.
+func (s *SQLParser) UnboundedOrderings(sql, dialect string) (*UnboundedOrderings, error) {
+	var resp UnboundedOrderings
+	if err := s.sendFindCommand("find-unbounded-orderings", sql, dialect, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// WrappedFilterColumns returns the columns the WHERE clauses of the query wrap in a function, a
+// cast or a conversion, e.g. `DATE(created_at) = '2024-01-01'`.
+func (s *SQLParser) WrappedFilterColumns(sql, dialect string) ([]string, error) {
+	var resp struct {
+		Columns []string `json:"columns"`
+	}
+	if err := s.sendFindCommand("find-wrapped-filter-columns", sql, dialect, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Columns, nil
+}
+
+// UnfilteredDML returns the kind, DELETE or UPDATE, of each statement of the query that modifies
+// rows without a WHERE clause.
+func (s *SQLParser) UnfilteredDML(sql, dialect string) ([]string, error) {
+	var resp struct {
+		Statements []string `json:"statements"`
+	}
+	if err := s.sendFindCommand("find-unfiltered-dml", sql, dialect, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Statements, nil
+}
+
 func (s *SQLParser) IsSingleSelectQuery(sql string, dialect string) (bool, error) {
 	err := s.Start()
 	if err != nil {
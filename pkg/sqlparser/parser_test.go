@@ -1408,6 +1408,30 @@ func TestSqlParser_FreezeTime(t *testing.T) { //nolint
 	})
 }
 
+func TestSqlParser_Transpile(t *testing.T) { //nolint
+	parser := sharedSQLParser
+
+	t.Run("the query and the column types are translated", func(t *testing.T) {
+		got, err := parser.Transpile("SELECT GETDATE() AS now, NVL(a, 0) AS a FROM raw.orders", "redshift", "snowflake", []string{"varchar(256)", "int4"})
+		require.NoError(t, err)
+		require.Equal(t, "SELECT\n  CURRENT_TIMESTAMP() AS now,\n  COALESCE(a, 0) AS a\nFROM raw.orders", got.Query)
+		require.Equal(t, []string{"VARCHAR(256)", "INT"}, got.ColumnTypes)
+		require.Empty(t, got.Unsupported)
+	})
+
+	t.Run("unknown functions are reported", func(t *testing.T) {
+		got, err := parser.Transpile("SELECT my_udf(x) FROM t", "postgres", "bigquery", nil)
+		require.NoError(t, err)
+		require.Contains(t, got.Query, "my_udf(x)")
+		require.Equal(t, []string{"function my_udf is not known to the parser and was kept as it is"}, got.Unsupported)
+	})
+
+	t.Run("an unknown target dialect is an error", func(t *testing.T) {
+		_, err := parser.Transpile("SELECT 1", "postgres", "not-a-dialect", nil)
+		require.Error(t, err)
+	})
+}
+
 func TestSqlParser_RenameTablesClearsStaleCatalog(t *testing.T) { //nolint
 	parser := sharedSQLParser
 
@@ -2398,3 +2422,46 @@ LIMIT 10
 		})
 	}
 }
+
+func TestSqlParser_FindPatterns(t *testing.T) { //nolint
+	parser := sharedSQLParser
+
+	t.Run("select star in the final projection", func(t *testing.T) {
+		got, err := parser.SelectsStar("WITH base AS (SELECT * FROM raw.orders) SELECT b.* FROM base b", "bigquery")
+		require.NoError(t, err)
+		require.True(t, got)
+
+		got, err = parser.SelectsStar("SELECT * EXCEPT (secret) FROM raw.orders", "bigquery")
+		require.NoError(t, err)
+		require.False(t, got)
+	})
+
+	t.Run("comma joins are found in dialects that parse them as cross joins", func(t *testing.T) {
+		got, err := parser.UnconditionedJoins("SELECT 1 FROM a, b CROSS JOIN c, UNNEST(items) AS item", "bigquery")
+		require.NoError(t, err)
+		require.Equal(t, []string{"b"}, got)
+	})
+
+	t.Run("orderings without a limit", func(t *testing.T) {
+		got, err := parser.UnboundedOrderings("WITH latest AS (SELECT id FROM t ORDER BY id) SELECT id FROM latest ORDER BY id LIMIT 5", "snowflake")
+		require.NoError(t, err)
+		require.Equal(t, &UnboundedOrderings{Final: false, CTEs: []string{"latest"}}, got)
+	})
+
+	t.Run("filtered columns wrapped in a cast", func(t *testing.T) {
+		got, err := parser.WrappedFilterColumns("SELECT id FROM raw.orders WHERE created_at::date = '2024-01-01' AND id > 5", "postgres")
+		require.NoError(t, err)
+		require.Equal(t, []string{"created_at"}, got)
+	})
+
+	t.Run("dml without a where clause", func(t *testing.T) {
+		got, err := parser.UnfilteredDML("DELETE FROM t; UPDATE t SET a = 1 WHERE id = 2", "duckdb")
+		require.NoError(t, err)
+		require.Equal(t, []string{"DELETE"}, got)
+	})
+
+	t.Run("unparsable queries are an error", func(t *testing.T) {
+		_, err := parser.UnfilteredDML("DELETE FROM (", "duckdb")
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,89 @@
+package transpile
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	jinjaRegion       = regexp.MustCompile(`(?s)\{\{.*?\}\}|\{%.*?%\}|\{#.*?#\}`)
+	jinjaPlaceholders = regexp.MustCompile(`/\*\s*__bruin_jinja_(\d+)__\s*\*/|__bruin_jinja_(\d+)__`)
+	jinjaTagName      = regexp.MustCompile(`^\{%[-+]?\s*(\w+)`)
+)
+
+// jinjaStatementTags are the tags that do not enclose any SQL, they only need to stay in front of
+// the expressions that use them. The other tags, e.g. `if` and `for`, decide which parts of the
+// query are rendered, and the parser attaches comments to the nearest expression rather than
+// keeping them where they were, so the SQL they enclose could end up outside of them.
+var jinjaStatementTags = map[string]bool{
+	"set":    true,
+	"import": true,
+	"from":   true,
+	"do":     true,
+}
+
+// maskedQuery is a query whose Jinja regions are replaced with placeholders the SQL parser can
+// carry through a translation: expressions become identifiers, which keeps them valid wherever a
+// value, a table name or part of a string is expected, and statement tags and comments become SQL
+// comments.
+type maskedQuery struct {
+	Query   string
+	Regions []string
+}
+
+// maskJinja fails for the queries with block tags, since their SQL cannot be carried through the
+// translation reliably.
+func maskJinja(query string) (*maskedQuery, error) {
+	for _, region := range jinjaRegion.FindAllString(query, -1) {
+		tag := jinjaTagName.FindStringSubmatch(region)
+		if tag == nil {
+			continue
+		}
+		// a `set` without a value is a block that assigns the content up to `endset`
+		if !jinjaStatementTags[tag[1]] || (tag[1] == "set" && !strings.Contains(region, "=")) {
+			return nil, errors.Errorf("the Jinja tag '%s' cannot be translated, queries with Jinja blocks need to be translated manually", region)
+		}
+	}
+
+	masked := &maskedQuery{Regions: make([]string, 0)}
+	masked.Query = jinjaRegion.ReplaceAllStringFunc(query, func(region string) string {
+		index := len(masked.Regions)
+		masked.Regions = append(masked.Regions, region)
+		if region[1] == '{' {
+			return fmt.Sprintf("__bruin_jinja_%d__", index)
+		}
+		return fmt.Sprintf("/* __bruin_jinja_%d__ */", index)
+	})
+	return masked, nil
+}
+
+// unmask puts the Jinja regions back into the translated query. The placeholders must all still be
+// there, in their original order, otherwise the templating logic may have been moved around by
+// the translation and the result cannot be trusted.
+func (m *maskedQuery) unmask(translated string) (string, error) {
+	next := 0
+	var err error
+	result := jinjaPlaceholders.ReplaceAllStringFunc(translated, func(placeholder string) string {
+		groups := jinjaPlaceholders.FindStringSubmatch(placeholder)
+		index, _ := strconv.Atoi(groups[1] + groups[2])
+		if index != next || index >= len(m.Regions) {
+			if err == nil {
+				err = errors.New("the Jinja regions were reordered during the translation")
+			}
+			return placeholder
+		}
+		next++
+		return m.Regions[index]
+	})
+	if err != nil {
+		return "", err
+	}
+	if next != len(m.Regions) {
+		return "", errors.Errorf("%d Jinja regions were lost during the translation", len(m.Regions)-next)
+	}
+	return result, nil
+}
@@ -0,0 +1,166 @@
+package transpile
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/bruin-data/bruin/pkg/sqlparser"
+	"github.com/pkg/errors"
+)
+
+type sqlTranspiler interface {
+	Transpile(sql, sourceDialect, targetDialect string, columnTypes []string) (*sqlparser.Transpiled, error)
+}
+
+// Result describes the translation of a single asset to another platform.
+type Result struct {
+	Asset       string             `json:"asset"`
+	Path        string             `json:"path"`
+	From        pipeline.AssetType `json:"from"`
+	To          pipeline.AssetType `json:"to"`
+	Unsupported []string           `json:"unsupported"`
+}
+
+// TargetAssetType resolves the `--to` value of the migration, which is either the prefix of the
+// SQL asset types of the platform, e.g. `sf`, or the full asset type, e.g. `sf.sql`.
+func TargetAssetType(target string) (pipeline.AssetType, error) {
+	assetType := pipeline.AssetType(target)
+	if !strings.Contains(target, ".") {
+		assetType = pipeline.AssetType(target + ".sql")
+	}
+	if _, err := sqlparser.AssetTypeToDialect(assetType); err != nil {
+		return "", errors.Errorf("'%s' is not a SQL platform that assets can be translated to", target)
+	}
+	return assetType, nil
+}
+
+// IsTranslatable reports whether the asset is a SQL asset whose dialect the parser knows.
+func IsTranslatable(asset *pipeline.Asset) bool {
+	_, err := sqlparser.AssetTypeToDialect(asset.Type)
+	return err == nil
+}
+
+// MigrateAsset translates the query, the hooks, the custom checks and the column types of the
+// asset to the dialect of the target asset type and changes the type of the asset. The asset is
+// only modified when all of its SQL could be translated, constructs that were translated on a
+// best-effort basis are listed in the result.
+func MigrateAsset(parser sqlTranspiler, asset *pipeline.Asset, target pipeline.AssetType) (*Result, error) {
+	sourceDialect, err := sqlparser.AssetTypeToDialect(asset.Type)
+	if err != nil {
+		return nil, errors.Errorf("asset type '%s' cannot be translated", asset.Type)
+	}
+	targetDialect, err := sqlparser.AssetTypeToDialect(target)
+	if err != nil {
+		return nil, errors.Errorf("asset type '%s' cannot be translated to", target)
+	}
+
+	result := &Result{
+		Asset:       asset.Name,
+		Path:        asset.ExecutableFile.Path,
+		From:        asset.Type,
+		To:          target,
+		Unsupported: make([]string, 0),
+	}
+	report := func(where string, messages []string) {
+		for _, message := range messages {
+			result.Unsupported = append(result.Unsupported, fmt.Sprintf("%s: %s", where, message))
+		}
+	}
+
+	translate := func(where, query string, columnTypes []string) (*sqlparser.Transpiled, error) {
+		masked, err := maskJinja(query)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to translate the %s", where)
+		}
+		translated, err := parser.Transpile(masked.Query, sourceDialect, targetDialect, columnTypes)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to translate the %s", where)
+		}
+		translated.Query, err = masked.unmask(translated.Query)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to translate the %s", where)
+		}
+		if strings.HasSuffix(strings.TrimSpace(query), ";") && translated.Query != "" {
+			translated.Query += ";"
+		}
+		report(where, translated.Unsupported)
+		return translated, nil
+	}
+
+	columnTypes := make([]string, 0, len(asset.Columns))
+	typedColumns := make([]int, 0, len(asset.Columns))
+	for i, column := range asset.Columns {
+		if column.Type == "" {
+			continue
+		}
+		columnTypes = append(columnTypes, column.Type)
+		typedColumns = append(typedColumns, i)
+	}
+
+	body, err := translate("query", asset.ExecutableFile.Content, columnTypes)
+	if err != nil {
+		return nil, err
+	}
+	if len(body.ColumnTypes) != len(typedColumns) {
+		return nil, errors.New("failed to translate the column types")
+	}
+
+	preHooks, err := translateHooks(translate, "pre hook", asset.Hooks.Pre)
+	if err != nil {
+		return nil, err
+	}
+	postHooks, err := translateHooks(translate, "post hook", asset.Hooks.Post)
+	if err != nil {
+		return nil, err
+	}
+
+	customChecks := make([]string, len(asset.CustomChecks))
+	for i, check := range asset.CustomChecks {
+		translated, err := translate(fmt.Sprintf("custom check '%s'", check.Name), check.Query, nil)
+		if err != nil {
+			return nil, err
+		}
+		customChecks[i] = translated.Query
+	}
+
+	if asset.Connection != "" {
+		result.Unsupported = append(result.Unsupported, fmt.Sprintf("connection: the asset uses the connection '%s', it needs to be changed to a %s connection", asset.Connection, targetDialect))
+	}
+
+	asset.Type = target
+	asset.ExecutableFile.Content = withTrailingNewline(body.Query, asset.ExecutableFile.Content)
+	for i, index := range typedColumns {
+		asset.Columns[index].Type = body.ColumnTypes[i]
+	}
+	for i := range asset.Hooks.Pre {
+		asset.Hooks.Pre[i].Query = preHooks[i]
+	}
+	for i := range asset.Hooks.Post {
+		asset.Hooks.Post[i].Query = postHooks[i]
+	}
+	for i := range asset.CustomChecks {
+		asset.CustomChecks[i].Query = customChecks[i]
+	}
+
+	return result, nil
+}
+
+func translateHooks(translate func(string, string, []string) (*sqlparser.Transpiled, error), where string, hooks []pipeline.Hook) ([]string, error) {
+	queries := make([]string, len(hooks))
+	for i, hook := range hooks {
+		translated, err := translate(where, hook.Query, nil)
+		if err != nil {
+			return nil, err
+		}
+		queries[i] = translated.Query
+	}
+	return queries, nil
+}
+
+func withTrailingNewline(query, original string) string {
+	if strings.HasSuffix(original, "\n") && !strings.HasSuffix(query, "\n") {
+		return query + "\n"
+	}
+	return query
+}
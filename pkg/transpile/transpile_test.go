@@ -0,0 +1,212 @@
+package transpile
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/bruin-data/bruin/pkg/sqlparser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeTranspiler mimics the parser with a few string replacements, it reports the functions it
+// does not know and drops the SQL comments when asked to.
+type fakeTranspiler struct {
+	dropComments bool
+	queries      []string
+}
+
+func (f *fakeTranspiler) Transpile(sql, sourceDialect, targetDialect string, columnTypes []string) (*sqlparser.Transpiled, error) {
+	f.queries = append(f.queries, sql)
+
+	result := &sqlparser.Transpiled{
+		Query:       strings.TrimSuffix(strings.TrimSpace(strings.ReplaceAll(sql, "GETDATE()", "CURRENT_TIMESTAMP()")), ";"),
+		ColumnTypes: make([]string, len(columnTypes)),
+		Unsupported: make([]string, 0),
+	}
+	if f.dropComments {
+		result.Query = strings.ReplaceAll(result.Query, "/* __bruin_jinja_2__ */", "")
+	}
+	if strings.Contains(sql, "my_udf") {
+		result.Unsupported = append(result.Unsupported, "function my_udf is not known to the parser and was kept as it is")
+	}
+	for i, columnType := range columnTypes {
+		result.ColumnTypes[i] = strings.ToUpper(strings.ReplaceAll(columnType, "int4", "int"))
+	}
+	return result, nil
+}
+
+func TestTargetAssetType(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		target  string
+		want    pipeline.AssetType
+		wantErr bool
+	}{
+		{target: "sf", want: pipeline.AssetTypeSnowflakeQuery},
+		{target: "bq.sql", want: pipeline.AssetTypeBigqueryQuery},
+		{target: "python", wantErr: true},
+		{target: "sf.seed", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.target, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := TargetAssetType(tt.target)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestMaskJinja(t *testing.T) {
+	t.Parallel()
+
+	query := "{% set days = 7 %}SELECT * FROM {{ ref('orders') }} WHERE dt > '{{ start_date }}' {# recent only #}"
+	masked, err := maskJinja(query)
+	require.NoError(t, err)
+
+	assert.Equal(t, "/* __bruin_jinja_0__ */SELECT * FROM __bruin_jinja_1__ WHERE dt > '__bruin_jinja_2__' /* __bruin_jinja_3__ */", masked.Query)
+
+	unmasked, err := masked.unmask("/* __bruin_jinja_0__ */\nSELECT\n  *\nFROM __bruin_jinja_1__\nWHERE\n  dt > '__bruin_jinja_2__' /*__bruin_jinja_3__*/")
+	require.NoError(t, err)
+	assert.Equal(t, "{% set days = 7 %}\nSELECT\n  *\nFROM {{ ref('orders') }}\nWHERE\n  dt > '{{ start_date }}' {# recent only #}", unmasked)
+
+	_, err = masked.unmask("SELECT * FROM __bruin_jinja_1__ /* __bruin_jinja_0__ */ WHERE '__bruin_jinja_2__' /* __bruin_jinja_3__ */")
+	require.Error(t, err)
+
+	_, err = masked.unmask("SELECT * FROM __bruin_jinja_0__")
+	require.Error(t, err)
+}
+
+func TestMaskJinja_BlockTags(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		query   string
+		wantErr string
+	}{
+		{
+			name:    "if blocks are refused",
+			query:   "SELECT a, {% if with_b %}b, {% endif %}c FROM t",
+			wantErr: "the Jinja tag '{% if with_b %}' cannot be translated, queries with Jinja blocks need to be translated manually",
+		},
+		{
+			name:    "for loops are refused",
+			query:   "SELECT {%- for col in cols %} {{ col }},{% endfor %} 1 FROM t",
+			wantErr: "the Jinja tag '{%- for col in cols %}' cannot be translated, queries with Jinja blocks need to be translated manually",
+		},
+		{
+			name:    "set blocks are refused",
+			query:   "{% set cols %}a, b{% endset %}SELECT {{ cols }} FROM t",
+			wantErr: "the Jinja tag '{% set cols %}' cannot be translated, queries with Jinja blocks need to be translated manually",
+		},
+		{
+			name:  "statement tags are allowed",
+			query: "{% from 'macros.sql' import cents %}{% set days = 7 %}SELECT {{ cents('amount') }} FROM t",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := maskJinja(tt.query)
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestMigrateAsset(t *testing.T) {
+	t.Parallel()
+
+	newAsset := func() *pipeline.Asset {
+		return &pipeline.Asset{
+			Name: "sales.orders",
+			Type: pipeline.AssetTypeRedshiftQuery,
+			ExecutableFile: pipeline.ExecutableFile{
+				Path:    "assets/orders.sql",
+				Content: "{% set source = ref('raw.orders') %}SELECT GETDATE() AS loaded_at, my_udf(id) AS id FROM {{ source }} {# all rows #};\n",
+			},
+			Columns: []pipeline.Column{
+				{Name: "loaded_at", Type: "timestamp"},
+				{Name: "id"},
+				{Name: "amount", Type: "int4"},
+			},
+			Hooks: pipeline.Hooks{
+				Post: []pipeline.Hook{{Query: "DELETE FROM sales.orders_staging WHERE loaded_at < GETDATE()"}},
+			},
+			CustomChecks: []pipeline.CustomCheck{
+				{Name: "recent", Query: "SELECT count(*) FROM sales.orders WHERE loaded_at > GETDATE()"},
+			},
+			Connection: "redshift-prod",
+		}
+	}
+
+	t.Run("the asset is translated", func(t *testing.T) {
+		t.Parallel()
+
+		asset := newAsset()
+		parser := &fakeTranspiler{}
+		result, err := MigrateAsset(parser, asset, pipeline.AssetTypeSnowflakeQuery)
+		require.NoError(t, err)
+
+		assert.Equal(t, "/* __bruin_jinja_0__ */SELECT GETDATE() AS loaded_at, my_udf(id) AS id FROM __bruin_jinja_1__ /* __bruin_jinja_2__ */;\n", parser.queries[0])
+		assert.Equal(t, pipeline.AssetTypeSnowflakeQuery, asset.Type)
+		assert.Equal(t, "{% set source = ref('raw.orders') %}SELECT CURRENT_TIMESTAMP() AS loaded_at, my_udf(id) AS id FROM {{ source }} {# all rows #};\n", asset.ExecutableFile.Content)
+		assert.Equal(t, "TIMESTAMP", asset.Columns[0].Type)
+		assert.Empty(t, asset.Columns[1].Type)
+		assert.Equal(t, "INT", asset.Columns[2].Type)
+		assert.Equal(t, "DELETE FROM sales.orders_staging WHERE loaded_at < CURRENT_TIMESTAMP()", asset.Hooks.Post[0].Query)
+		assert.Equal(t, "SELECT count(*) FROM sales.orders WHERE loaded_at > CURRENT_TIMESTAMP()", asset.CustomChecks[0].Query)
+
+		assert.Equal(t, &Result{
+			Asset: "sales.orders",
+			Path:  "assets/orders.sql",
+			From:  pipeline.AssetTypeRedshiftQuery,
+			To:    pipeline.AssetTypeSnowflakeQuery,
+			Unsupported: []string{
+				"query: function my_udf is not known to the parser and was kept as it is",
+				"connection: the asset uses the connection 'redshift-prod', it needs to be changed to a snowflake connection",
+			},
+		}, result)
+	})
+
+	t.Run("the asset is left untouched when the Jinja regions are lost", func(t *testing.T) {
+		t.Parallel()
+
+		asset := newAsset()
+		_, err := MigrateAsset(&fakeTranspiler{dropComments: true}, asset, pipeline.AssetTypeSnowflakeQuery)
+		require.Error(t, err)
+		assert.Equal(t, newAsset(), asset)
+	})
+
+	t.Run("the asset is left untouched when it has Jinja blocks", func(t *testing.T) {
+		t.Parallel()
+
+		asset := newAsset()
+		asset.ExecutableFile.Content = "SELECT id FROM {{ ref('raw.orders') }} {% if is_full_refresh %}WHERE id > 0{% endif %}"
+		parser := &fakeTranspiler{}
+		_, err := MigrateAsset(parser, asset, pipeline.AssetTypeSnowflakeQuery)
+		require.ErrorContains(t, err, "failed to translate the query: the Jinja tag '{% if is_full_refresh %}' cannot be translated")
+		assert.Empty(t, parser.queries)
+		assert.Equal(t, pipeline.AssetTypeRedshiftQuery, asset.Type)
+	})
+
+	t.Run("non-SQL assets cannot be translated", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := MigrateAsset(&fakeTranspiler{}, &pipeline.Asset{Type: pipeline.AssetTypePython}, pipeline.AssetTypeSnowflakeQuery)
+		require.Error(t, err)
+	})
+}
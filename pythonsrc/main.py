@@ -8,11 +8,17 @@ from parser.main import (
     add_ctes,
     add_limit,
     extract_select,
+    find_select_star,
+    find_unbounded_orderings,
+    find_unconditioned_joins,
+    find_unfiltered_dml,
+    find_wrapped_filter_columns,
     freeze_time,
     get_column_lineage,
     get_tables,
     is_single_select_query,
     select_cte,
+    transpile,
 )
 
 home = str(Path.home())
@@ -84,6 +90,32 @@ def main():
                 result = freeze_time(
                     c["query"], c.get("dialect"), c.get("execution_time")
                 )
+            elif cmd["command"] == "transpile":
+                logging.info("got transpile command")
+                c = cmd["contents"]
+                result = transpile(
+                    c["query"], c.get("read"), c.get("write"), c.get("column_types")
+                )
+            elif cmd["command"] == "find-select-star":
+                logging.info("got find-select-star command")
+                c = cmd["contents"]
+                result = find_select_star(c["query"], c.get("dialect"))
+            elif cmd["command"] == "find-unconditioned-joins":
+                logging.info("got find-unconditioned-joins command")
+                c = cmd["contents"]
+                result = find_unconditioned_joins(c["query"], c.get("dialect"))
+            elif cmd["command"] == "find-unbounded-orderings":
+                logging.info("got find-unbounded-orderings command")
+                c = cmd["contents"]
+                result = find_unbounded_orderings(c["query"], c.get("dialect"))
+            elif cmd["command"] == "find-wrapped-filter-columns":
+                logging.info("got find-wrapped-filter-columns command")
+                c = cmd["contents"]
+                result = find_wrapped_filter_columns(c["query"], c.get("dialect"))
+            elif cmd["command"] == "find-unfiltered-dml":
+                logging.info("got find-unfiltered-dml command")
+                c = cmd["contents"]
+                result = find_unfiltered_dml(c["query"], c.get("dialect"))
             elif cmd["command"] == "exit":
                 logging.info("got exit command amx")
                 break
@@ -2,9 +2,12 @@ import logging
 from dataclasses import dataclass
 
 from sqlglot import exp, lineage, parse, parse_one
+from sqlglot.dialects.dialect import Dialect
+from sqlglot.errors import ErrorLevel
 from sqlglot.lineage import Node
 from sqlglot.optimizer import optimize
 from sqlglot.optimizer.scope import build_scope, find_all_in_scope
+from sqlglot.tokens import TokenType
 
 
 def normalize_sqlglot_dialect(dialect: str | None) -> str | None:
@@ -817,3 +820,298 @@ def add_ctes(query: str, dialect: str = None, ctes: list = None) -> dict:
         return {"error": str(e)}
 
     return {"query": parsed.sql(dialect=dialect or None)}
+
+
+def transpile(
+    query: str, read: str = None, write: str = None, column_types: list = None
+) -> dict:
+    """Translate a query and a list of column types from one dialect to another.
+
+    Constructs the target dialect cannot express are rendered as closely as
+    sqlglot can and reported under "unsupported", as are the functions sqlglot
+    does not know, since they are copied as they are and may not exist in the
+    target. Returns {"query": <sql>, "column_types": [...], "unsupported": [...]}
+    or {"error": msg}.
+    """
+    read = normalize_sqlglot_dialect(read)
+    write = normalize_sqlglot_dialect(write)
+
+    try:
+        target = Dialect.get_or_raise(write)
+    except Exception as e:
+        return {"error": str(e)}
+
+    unsupported = []
+
+    def report(message):
+        if message not in unsupported:
+            unsupported.append(message)
+
+    statements = []
+    if query and query.strip():
+        try:
+            parsed = parse(query, dialect=read or None)
+        except Exception as e:
+            return {"error": str(e)}
+
+        for expression in parsed:
+            if expression is None:
+                continue
+            for node in expression.find_all(exp.Anonymous):
+                report(
+                    f"function {node.name} is not known to the parser and was kept as it is"
+                )
+            generator = target.generator(
+                unsupported_level=ErrorLevel.IGNORE, pretty=True
+            )
+            statements.append(generator.generate(expression))
+            for message in generator.unsupported_messages:
+                report(message)
+
+    types = []
+    for column_type in column_types or []:
+        try:
+            types.append(
+                exp.DataType.build(column_type, dialect=read or None).sql(
+                    dialect=write
+                )
+            )
+        except Exception:
+            types.append(column_type)
+            report(f"column type {column_type} could not be translated")
+
+    return {
+        "query": ";\n\n".join(statements),
+        "column_types": types,
+        "unsupported": unsupported,
+    }
+
+
+def _parse_statements(query: str, dialect: str = None) -> list:
+    dialect = normalize_sqlglot_dialect(dialect)
+    return [
+        expression
+        for expression in parse(query, dialect=dialect or None)
+        if expression is not None
+    ]
+
+
+def _query_of(statement):
+    """Return the query of a statement, e.g. the SELECT of a CREATE TABLE AS."""
+    while isinstance(statement, exp.Subquery):
+        statement = statement.this
+    if isinstance(statement, exp.Query):
+        return statement
+    expression = statement.args.get("expression")
+    if isinstance(expression, (exp.Query, exp.Subquery)):
+        return _query_of(expression)
+    return None
+
+
+def _is_unbounded(query) -> bool:
+    return bool(query.args.get("order")) and not (
+        query.args.get("limit") or query.args.get("fetch")
+    )
+
+
+def _selects_star(query) -> bool:
+    if isinstance(query, exp.SetOperation):
+        return _selects_star(_query_of(query.left)) or _selects_star(
+            _query_of(query.right)
+        )
+    if not isinstance(query, exp.Select):
+        return False
+    for projection in query.expressions:
+        star = projection
+        if isinstance(projection, exp.Column) and isinstance(
+            projection.this, exp.Star
+        ):
+            star = projection.this
+        if not isinstance(star, exp.Star):
+            continue
+        if any(star.args.get(arg) for arg in ("except_", "replace", "rename")):
+            continue
+        return True
+    return False
+
+
+def find_select_star(query: str, dialect: str = None) -> dict:
+    """Report whether the final projection of the query selects `*` or `table.*`.
+
+    Projections that list the excluded, replaced or renamed columns are allowed.
+    Returns {"select_star": bool} or {"error": msg}.
+    """
+    try:
+        statements = _parse_statements(query, dialect)
+    except Exception as e:
+        return {"error": str(e)}
+
+    if not statements:
+        return {"select_star": False}
+    final = _query_of(statements[-1])
+    return {"select_star": final is not None and _selects_star(final)}
+
+
+def _start_of(node):
+    positions = [
+        child.meta["start"] for child in node.walk() if "start" in child.meta
+    ]
+    return min(positions) if positions else None
+
+
+def _follows_comma(node, tokens) -> bool:
+    """Report whether the joined node is preceded by a comma in the query.
+
+    Dialects where all joins have the same precedence, e.g. BigQuery, parse
+    comma joins as CROSS JOINs, so the tokens are the only place the comma
+    is kept.
+    """
+    start = _start_of(node)
+    if start is None:
+        return False
+    index = next(
+        (i for i, token in enumerate(tokens) if token.end >= start), len(tokens)
+    )
+    for token in reversed(tokens[:index]):
+        if token.token_type == TokenType.COMMA:
+            return True
+        if token.token_type not in (
+            TokenType.L_PAREN,
+            TokenType.SELECT,
+            TokenType.DISTINCT,
+            TokenType.WITH,
+            TokenType.ALL,
+        ):
+            return False
+    return False
+
+
+def _is_table_function(node, aliases) -> bool:
+    if isinstance(node, (exp.Unnest, exp.Lateral, exp.TableFromRows)):
+        return True
+    if isinstance(node, exp.Table):
+        if isinstance(node.this, exp.Func):
+            return True
+        # BigQuery flattens the arrays of a table with `FROM t, t.items`
+        if node.db and not node.catalog and node.db.lower() in aliases:
+            return True
+    return False
+
+
+def find_unconditioned_joins(query: str, dialect: str = None) -> dict:
+    """Find the tables the query joins with a comma or without a join condition.
+
+    Explicit CROSS JOINs and NATURAL JOINs are not reported, nor are the
+    joins with UNNEST, LATERAL and table functions, which are the idiomatic
+    way to flatten arrays. Returns {"joins": [names]} or {"error": msg}.
+    """
+    dialect = normalize_sqlglot_dialect(dialect)
+    try:
+        statements = _parse_statements(query, dialect)
+        tokens = Dialect.get_or_raise(dialect or None).tokenize(query)
+    except Exception as e:
+        return {"error": str(e)}
+
+    joins = []
+    for statement in statements:
+        for select in statement.find_all(exp.Select):
+            from_ = select.args.get("from_")
+            aliases = set()
+            if from_ is not None:
+                aliases.add(from_.this.alias_or_name.lower())
+            for join in select.args.get("joins") or []:
+                item = join.this
+                unconditioned = (
+                    not join.args.get("on")
+                    and not join.args.get("using")
+                    and join.text("method").upper() != "NATURAL"
+                    and not _is_table_function(item, aliases)
+                    and (join.kind != "CROSS" or _follows_comma(item, tokens))
+                )
+                aliases.add(item.alias_or_name.lower())
+                if not unconditioned:
+                    continue
+                name = item.alias
+                if isinstance(item, exp.Table):
+                    name = exp.table_name(item)
+                if name and name not in joins:
+                    joins.append(name)
+
+    return {"joins": joins}
+
+
+def find_unbounded_orderings(query: str, dialect: str = None) -> dict:
+    """Find the orderings of the query that are not limited.
+
+    Returns {"final": bool, "ctes": [names]}, where "final" reports the
+    ordering of the final query and "ctes" lists the CTEs that sort their
+    rows without limiting them, or {"error": msg}.
+    """
+    try:
+        statements = _parse_statements(query, dialect)
+    except Exception as e:
+        return {"error": str(e)}
+
+    ctes = []
+    for statement in statements:
+        for cte in statement.find_all(exp.CTE):
+            if _is_unbounded(cte.this) and cte.alias not in ctes:
+                ctes.append(cte.alias)
+
+    final = _query_of(statements[-1]) if statements else None
+    return {"final": final is not None and _is_unbounded(final), "ctes": ctes}
+
+
+def find_wrapped_filter_columns(query: str, dialect: str = None) -> dict:
+    """Find the columns that are filtered through a function, a cast or a conversion.
+
+    Such filters prevent the platform from pruning partitions on the column,
+    e.g. `WHERE DATE(created_at) = '2024-01-01'`. Returns {"columns": [names]}
+    or {"error": msg}.
+    """
+    try:
+        statements = _parse_statements(query, dialect)
+    except Exception as e:
+        return {"error": str(e)}
+
+    columns = []
+    for statement in statements:
+        for where in statement.find_all(exp.Where):
+            for column in where.find_all(exp.Column):
+                # subqueries of the filter have their own WHERE clauses
+                if column.find_ancestor(exp.Query, exp.Where) is not where:
+                    continue
+                node = column.parent
+                while node is not where:
+                    # AND, OR and the comparisons are functions to sqlglot as well
+                    wraps = isinstance(
+                        node, (exp.Func, exp.AtTimeZone, exp.Collate)
+                    ) and not isinstance(node, (exp.Connector, exp.Predicate))
+                    if wraps:
+                        if column.name not in columns:
+                            columns.append(column.name)
+                        break
+                    node = node.parent
+
+    return {"columns": columns}
+
+
+def find_unfiltered_dml(query: str, dialect: str = None) -> dict:
+    """Find the DELETE and UPDATE statements of the query that have no WHERE clause.
+
+    Returns {"statements": ["DELETE" | "UPDATE", ...]}, one entry per
+    statement, or {"error": msg}.
+    """
+    try:
+        statements = _parse_statements(query, dialect)
+    except Exception as e:
+        return {"error": str(e)}
+
+    return {
+        "statements": [
+            statement.key.upper()
+            for statement in statements
+            if isinstance(statement, (exp.Delete, exp.Update))
+            and not statement.args.get("where")
+        ]
+    }
@@ -2590,3 +2590,197 @@ def test_get_column_lineage_case_insensitive_schema():
     assert result["columns"][0]["upstream"] == [
         {"column": "name", "table": "raw.Teams"}
     ]
+
+
+def test_transpile():
+    """Test that queries and column types are translated to the target dialect."""
+    from .main import transpile
+
+    result = transpile(
+        "SELECT GETDATE() AS now, NVL(a, 0) AS a FROM raw.orders; SELECT 1",
+        "redshift",
+        "snowflake",
+        ["varchar(256)", "int4"],
+    )
+    assert result["query"] == (
+        "SELECT\n  CURRENT_TIMESTAMP() AS now,\n  COALESCE(a, 0) AS a\nFROM raw.orders"
+        ";\n\nSELECT\n  1"
+    )
+    assert result["column_types"] == ["VARCHAR(256)", "INT"]
+    assert result["unsupported"] == []
+
+
+def test_transpile_reports_unsupported():
+    """Test that unknown functions and types are kept as they are and reported."""
+    from .main import transpile
+
+    result = transpile(
+        "SELECT my_udf(x) FROM t", "postgres", "bigquery", ["not a type"]
+    )
+    assert result["query"] == "SELECT\n  my_udf(x)\nFROM t"
+    assert result["column_types"] == ["not a type"]
+    assert result["unsupported"] == [
+        "function my_udf is not known to the parser and was kept as it is",
+        "column type not a type could not be translated",
+    ]
+
+    assert "error" in transpile("SELECT 1", "postgres", "not-a-dialect")
+
+
+@pytest.mark.parametrize(
+    "query,dialect,expected",
+    [
+        (
+            "WITH base AS (SELECT * FROM raw.orders) SELECT b.* FROM base b",
+            "bigquery",
+            True,
+        ),
+        (
+            "WITH base AS (SELECT * FROM raw.orders) SELECT id, count(*) FROM base GROUP BY id",
+            "bigquery",
+            False,
+        ),
+        ("SELECT * EXCEPT (secret) FROM raw.orders", "bigquery", False),
+        ("SELECT * EXCLUDE (secret) FROM raw.orders", "snowflake", False),
+        ("SELECT id FROM a UNION ALL SELECT * FROM b", "postgres", True),
+        ("CREATE TABLE x AS SELECT * FROM b", "postgres", True),
+        ("SELECT id # SELECT * FROM raw.orders\nFROM raw.orders", "bigquery", False),
+        (
+            "SELECT id, $body$ SELECT * FROM x $body$ AS note FROM raw.orders",
+            "postgres",
+            False,
+        ),
+    ],
+)
+def test_find_select_star(query, dialect, expected):
+    from .main import find_select_star
+
+    assert find_select_star(query, dialect) == {"select_star": expected}
+
+
+@pytest.mark.parametrize(
+    "query,dialect,expected",
+    [
+        (
+            "SELECT o.id FROM raw.orders o, raw.customers c WHERE o.customer_id = c.id",
+            "bigquery",
+            ["raw.customers"],
+        ),
+        (
+            "SELECT o.id FROM raw.orders o, raw.customers c WHERE o.customer_id = c.id",
+            "postgres",
+            ["raw.customers"],
+        ),
+        ("SELECT 1 FROM a JOIN b", "postgres", ["b"]),
+        ("SELECT 1 FROM a, (SELECT DISTINCT x FROM y) s", "bigquery", ["s"]),
+        (
+            "SELECT 1 FROM a CROSS JOIN b NATURAL JOIN c JOIN d USING (id)",
+            "bigquery",
+            [],
+        ),
+        (
+            "SELECT item FROM raw.orders, UNNEST(items) AS item JOIN raw.c ON a = b",
+            "bigquery",
+            [],
+        ),
+        ("SELECT item FROM raw.orders o, o.items AS item", "bigquery", []),
+        (
+            "SELECT f.value FROM a, LATERAL FLATTEN(input => a.x) f, TABLE(FLATTEN(a.y)) g",
+            "snowflake",
+            [],
+        ),
+        ("SELECT g FROM a, generate_series(1, 3) g", "postgres", []),
+    ],
+)
+def test_find_unconditioned_joins(query, dialect, expected):
+    from .main import find_unconditioned_joins
+
+    assert find_unconditioned_joins(query, dialect) == {"joins": expected}
+
+
+@pytest.mark.parametrize(
+    "query,dialect,expected",
+    [
+        (
+            "WITH latest AS (SELECT id FROM raw.orders ORDER BY id), top AS (SELECT id FROM raw.orders ORDER BY id LIMIT 10) SELECT id FROM latest ORDER BY id",
+            "bigquery",
+            {"final": True, "ctes": ["latest"]},
+        ),
+        (
+            "WITH ranked AS (SELECT id, ROW_NUMBER() OVER (ORDER BY ts) AS rn FROM raw.orders) SELECT id FROM ranked ORDER BY id LIMIT 5",
+            "bigquery",
+            {"final": False, "ctes": []},
+        ),
+        ("SELECT TOP 5 id FROM t ORDER BY id", "tsql", {"final": False, "ctes": []}),
+        (
+            "SELECT id FROM t ORDER BY id FETCH FIRST 5 ROWS ONLY",
+            "postgres",
+            {"final": False, "ctes": []},
+        ),
+    ],
+)
+def test_find_unbounded_orderings(query, dialect, expected):
+    from .main import find_unbounded_orderings
+
+    assert find_unbounded_orderings(query, dialect) == expected
+
+
+@pytest.mark.parametrize(
+    "query,dialect,expected",
+    [
+        (
+            "SELECT id FROM raw.orders WHERE DATE(created_at) = '2024-01-01'",
+            "bigquery",
+            ["created_at"],
+        ),
+        (
+            "SELECT id FROM raw.orders WHERE created_at::date = '2024-01-01'",
+            "postgres",
+            ["created_at"],
+        ),
+        (
+            "SELECT id FROM raw.orders WHERE created_at AT TIME ZONE 'UTC' >= '2024-01-01'",
+            "snowflake",
+            ["created_at"],
+        ),
+        (
+            "SELECT id FROM raw.orders WHERE CONVERT(date, [created_at]) = '2024-01-01'",
+            "tsql",
+            ["created_at"],
+        ),
+        (
+            "SELECT id FROM raw.orders WHERE id IN (SELECT id FROM raw.other WHERE DATE(created_at) = '2024-01-01')",
+            "bigquery",
+            ["created_at"],
+        ),
+        (
+            "SELECT DATE(created_at) AS day FROM raw.orders WHERE created_at >= TIMESTAMP('2024-01-01') AND id IN (SELECT MAX(created_at) FROM raw.other)",
+            "bigquery",
+            [],
+        ),
+    ],
+)
+def test_find_wrapped_filter_columns(query, dialect, expected):
+    from .main import find_wrapped_filter_columns
+
+    assert find_wrapped_filter_columns(query, dialect) == {"columns": expected}
+
+
+def test_find_unfiltered_dml():
+    from .main import find_unfiltered_dml
+
+    result = find_unfiltered_dml(
+        "DELETE FROM sales.orders_staging; "
+        "UPDATE sales.orders SET status = 'done' WHERE id = 1; "
+        "UPDATE sales.orders SET status = 'done'",
+        "bigquery",
+    )
+    assert result == {"statements": ["DELETE", "UPDATE"]}
+
+    result = find_unfiltered_dml(
+        "DELETE FROM sales.orders_staging WHERE loaded_at < CURRENT_DATE(); "
+        "TRUNCATE TABLE sales.tmp",
+        "bigquery",
+    )
+    assert result == {"statements": []}
+    assert "error" in find_unfiltered_dml("DELETE FROM (", "bigquery")